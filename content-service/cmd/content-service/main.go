@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log/slog"
 	"os"
 	"os/signal"
@@ -9,7 +10,6 @@ import (
 
 	"github.com/ocenb/music-go/content-service/internal/app"
 	"github.com/ocenb/music-go/content-service/internal/clients/cloudinaryclient"
-	"github.com/ocenb/music-go/content-service/internal/clients/notificationclient"
 	"github.com/ocenb/music-go/content-service/internal/clients/searchclient"
 	"github.com/ocenb/music-go/content-service/internal/clients/userclient"
 	"github.com/ocenb/music-go/content-service/internal/config"
@@ -77,32 +77,30 @@ func main() {
 		}
 	}()
 
-	notificationClient, err := notificationclient.NewNotificationClient(cfg.KafkaBrokers)
-	if err != nil {
-		log.Error("Failed to create notification client", utils.ErrLog(err))
-		os.Exit(1)
-	}
-	defer func() {
-		log.Info("Closing notification client")
-		err := notificationClient.Close()
-		if err != nil {
-			log.Error("Failed to close notification client", utils.ErrLog(err))
-		}
-	}()
-
 	log.Info("Initializing HTTP server", slog.Int("port", cfg.Port))
-	httpApp := app.New(postgres, cfg, log, cloudinary, searchServiceClient, userServiceClient, notificationClient)
+	httpApp := app.New(postgres, cfg, log, cloudinary, searchServiceClient, userServiceClient)
 
 	go func() {
 		httpApp.Run()
 	}()
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go httpApp.RunFanOut(ctx)
+	go httpApp.RunSmartPlaylistsRefresh(ctx)
+	go httpApp.RunPlaylistRefresh(ctx)
+	go httpApp.RunSearchSignalsPush(ctx)
+	go httpApp.RunOutboxRelay(ctx)
+	go httpApp.RunOutboxCleanup(ctx)
+
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, syscall.SIGTERM, syscall.SIGINT)
 	<-stop
 
 	shutdownStart := time.Now()
 
+	cancel()
 	httpApp.Stop()
 
 	log.Info("Service shutdown complete",
@@ -16,46 +16,92 @@ import (
 	"github.com/ocenb/music-go/content-service/internal/clients/userclient"
 	"github.com/ocenb/music-go/content-service/internal/config"
 	"github.com/ocenb/music-go/content-service/internal/modules/all"
+	"github.com/ocenb/music-go/content-service/internal/modules/feed"
+	"github.com/ocenb/music-go/content-service/internal/modules/feed/fanout"
 	"github.com/ocenb/music-go/content-service/internal/modules/file"
 	"github.com/ocenb/music-go/content-service/internal/modules/history"
 	"github.com/ocenb/music-go/content-service/internal/modules/playlist"
+	"github.com/ocenb/music-go/content-service/internal/modules/playlist/collaborators"
+	"github.com/ocenb/music-go/content-service/internal/modules/playlist/folders"
 	"github.com/ocenb/music-go/content-service/internal/modules/playlist/playlisttracks"
+	"github.com/ocenb/music-go/content-service/internal/modules/playlist/revisions"
+	"github.com/ocenb/music-go/content-service/internal/modules/playlist/transfer"
+	"github.com/ocenb/music-go/content-service/internal/modules/repost"
 	"github.com/ocenb/music-go/content-service/internal/modules/search"
 	"github.com/ocenb/music-go/content-service/internal/modules/track"
+	"github.com/ocenb/music-go/content-service/internal/utils"
+	"github.com/ocenb/music-go/shared/outbox"
+	"github.com/ocenb/music-go/shared/searchindex"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 type App struct {
-	server *http.Server
-	log    *slog.Logger
+	server                 *http.Server
+	log                    *slog.Logger
+	fanOutService          fanout.FanOutServiceInterface
+	playlistTracksService  playlisttracks.PlaylistTracksServiceInterface
+	smartPlaylistsInterval time.Duration
+	coverRefreshInterval   time.Duration
+	searchService          search.SearchServiceInterface
+	searchSignalsInterval  time.Duration
+	outboxRelay            outbox.RelayInterface
 }
 
 func New(postgres *sql.DB, cfg *config.Config, log *slog.Logger, cloudinary cloudinaryclient.CloudinaryClientInterface,
 	searchServiceClient *searchclient.SearchServiceClient, userServiceClient *userclient.UserServiceClient,
-	notificationClient notificationclient.NotificationClientInterface,
 ) *App {
+	outboxRepo := outbox.NewRepo(postgres, utils.GetTxFromContext)
+	outboxRelay := outbox.NewRelay(outbox.Config{
+		Brokers:       cfg.KafkaBrokers,
+		BatchSize:     cfg.OutboxBatchSize,
+		RelayInterval: cfg.OutboxRelayInterval,
+		Retention:     cfg.OutboxRetention,
+	}, log, outboxRepo)
+	searchIndexClient := searchindex.NewSearchIndexClient(outboxRepo)
+	notificationClient := notificationclient.NewNotificationClient(outboxRepo)
 	fileService := file.NewFileService(
 		cloudinary,
 		log,
 		cfg,
 	)
-	trackRepo := track.NewTrackRepo(postgres, log)
-	trackService := track.NewTrackService(log, trackRepo, fileService, searchServiceClient, notificationClient)
-	trackHandler := track.NewTrackHandler(trackService)
+	fanOutRepo := fanout.NewFanOutRepo(postgres, log)
+	fanOutService := fanout.NewFanOutService(cfg, log, fanOutRepo, userServiceClient)
 	playlistRepo := playlist.NewPlaylistRepo(postgres, log)
-	playlistService := playlist.NewPlaylistService(log, playlistRepo, fileService)
+	playlistService := playlist.NewPlaylistService(log, playlistRepo, fileService, fanOutService, searchIndexClient)
 	playlistHandler := playlist.NewPlaylistHandler(playlistService)
+	trackRepo := track.NewTrackRepo(postgres, log)
+	trackService := track.NewTrackService(log, trackRepo, fileService, searchIndexClient, notificationClient, fanOutService, playlistService)
+	trackHandler := track.NewTrackHandler(trackService)
 	playlistTracksRepo := playlisttracks.NewPlaylistTracksRepo(postgres, log)
-	playlistTracksService := playlisttracks.NewPlaylistTracksService(log, playlistTracksRepo, playlistRepo, trackRepo)
+	playlistTracksService := playlisttracks.NewPlaylistTracksService(log, playlistTracksRepo, playlistRepo, playlistService, trackRepo, userServiceClient)
 	playlistTracksHandler := playlisttracks.NewHandlers(playlistTracksService)
+	collaboratorsRepo := collaborators.NewCollaboratorsRepo(postgres, log)
+	collaboratorsService := collaborators.NewCollaboratorsService(log, collaboratorsRepo, playlistRepo, userServiceClient)
+	collaboratorsHandler := collaborators.NewHandlers(collaboratorsService)
+	foldersRepo := folders.NewFoldersRepo(postgres, log)
+	foldersService := folders.NewFoldersService(log, foldersRepo, playlistRepo)
+	foldersHandler := folders.NewHandlers(foldersService)
+	revisionsRepo := revisions.NewRevisionsRepo(postgres, log)
+	revisionsService := revisions.NewRevisionsService(log, revisionsRepo, playlistRepo, playlistTracksService)
+	revisionsHandler := revisions.NewHandlers(revisionsService)
+	transferService := transfer.NewTransferService(log, playlistRepo, playlistService, playlistTracksService, trackRepo, fileService, searchServiceClient)
+	transferHandler := transfer.NewHandlers(transferService)
 	historyRepo := history.NewHistoryRepo(postgres, log)
 	historyService := history.NewHistoryService(log, historyRepo, trackService)
 	historyHandler := history.NewHistoryHandler(historyService)
+	repostRepo := repost.NewRepostRepo(postgres, log)
+	repostService := repost.NewRepostService(log, repostRepo, trackRepo, playlistRepo)
+	repostHandler := repost.NewRepostHandler(repostService)
+	feedRepo := feed.NewFeedRepo(postgres, log)
+	feedService := feed.NewFeedService(cfg, log, feedRepo, repostService, userServiceClient)
+	feedHandler := feed.NewFeedHandler(feedService)
 	allRepo := all.NewAllRepo(postgres, log)
 	allService := all.NewAllService(log, allRepo, fileService)
 	allHandler := all.NewAllHandler(allService)
-	searchHandler := search.NewSearchHandler(searchServiceClient)
+	searchRepo := search.NewSearchRepo(postgres, log)
+	searchService := search.NewSearchService(cfg, log, searchRepo, searchServiceClient, searchIndexClient, userServiceClient, trackRepo, playlistRepo)
+	searchHandler := search.NewSearchHandler(searchServiceClient, searchService)
 
 	if cfg.Environment == "prod" {
 		gin.SetMode(gin.ReleaseMode)
@@ -68,13 +114,21 @@ func New(postgres *sql.DB, cfg *config.Config, log *slog.Logger, cloudinary clou
 	api := router.Group("/api/content")
 	apiWithoutAuth := router.Group("/api/content")
 	api.Use(authMiddleware(userServiceClient))
+	apiWithForwardedAuth := api.Group("")
+	apiWithForwardedAuth.Use(forwardAuthMiddleware())
 
-	trackHandler.RegisterHandlers(api)
-	playlistHandler.RegisterHandlers(api)
-	playlistTracksHandler.RegisterHandlers(api)
+	trackHandler.RegisterHandlers(apiWithForwardedAuth)
+	playlistHandler.RegisterHandlers(apiWithForwardedAuth)
+	playlistTracksHandler.RegisterHandlers(apiWithForwardedAuth)
+	collaboratorsHandler.RegisterHandlers(api)
+	foldersHandler.RegisterHandlers(api)
+	revisionsHandler.RegisterHandlers(api)
+	transferHandler.RegisterHandlers(apiWithForwardedAuth)
 	historyHandler.RegisterHandlers(api)
+	repostHandler.RegisterHandlers(api)
+	feedHandler.RegisterHandlers(apiWithForwardedAuth)
 	allHandler.RegisterHandlers(apiWithoutAuth)
-	searchHandler.RegisterHandlers(api)
+	searchHandler.RegisterHandlers(apiWithForwardedAuth)
 
 	server := &http.Server{
 		Addr:    fmt.Sprintf(":%d", cfg.Port),
@@ -82,8 +136,15 @@ func New(postgres *sql.DB, cfg *config.Config, log *slog.Logger, cloudinary clou
 	}
 
 	return &App{
-		server: server,
-		log:    log,
+		server:                 server,
+		log:                    log,
+		fanOutService:          fanOutService,
+		playlistTracksService:  playlistTracksService,
+		smartPlaylistsInterval: cfg.SmartPlaylistsInterval,
+		coverRefreshInterval:   cfg.CoverRefreshInterval,
+		searchService:          searchService,
+		searchSignalsInterval:  cfg.SearchSignalsInterval,
+		outboxRelay:            outboxRelay,
 	}
 }
 
@@ -94,6 +155,76 @@ func (a *App) Run() {
 	}
 }
 
+func (a *App) RunFanOut(ctx context.Context) {
+	a.log.Info("Feed fan-out worker started")
+	a.fanOutService.Run(ctx)
+}
+
+func (a *App) RunSmartPlaylistsRefresh(ctx context.Context) {
+	a.log.Info("Smart playlists refresh scheduled", "interval", a.smartPlaylistsInterval)
+	ticker := time.NewTicker(a.smartPlaylistsInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			refreshCtx, cancel := context.WithTimeout(ctx, a.smartPlaylistsInterval)
+			if err := a.playlistTracksService.RefreshSmartPlaylists(refreshCtx); err != nil {
+				a.log.Error("Failed to refresh smart playlists", "error", err)
+			}
+			cancel()
+		}
+	}
+}
+
+func (a *App) RunPlaylistRefresh(ctx context.Context) {
+	a.log.Info("Playlist refresh worker scheduled", "interval", a.coverRefreshInterval)
+	ticker := time.NewTicker(a.coverRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := a.playlistTracksService.RefreshPending(ctx); err != nil {
+				a.log.Error("Failed to refresh playlists", "error", err)
+			}
+		}
+	}
+}
+
+func (a *App) RunSearchSignalsPush(ctx context.Context) {
+	a.log.Info("Search signals push scheduled", "interval", a.searchSignalsInterval)
+	ticker := time.NewTicker(a.searchSignalsInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			pushCtx, cancel := context.WithTimeout(ctx, a.searchSignalsInterval)
+			if err := a.searchService.PushSignals(pushCtx); err != nil {
+				a.log.Error("Failed to push search signals", "error", err)
+			} else {
+				a.log.Info("Successfully pushed search signals")
+			}
+			cancel()
+		}
+	}
+}
+
+func (a *App) RunOutboxRelay(ctx context.Context) {
+	a.outboxRelay.RunRelay(ctx)
+}
+
+func (a *App) RunOutboxCleanup(ctx context.Context) {
+	a.outboxRelay.RunCleanup(ctx)
+}
+
 func (a *App) Stop() {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -101,6 +232,10 @@ func (a *App) Stop() {
 	if err := a.server.Shutdown(ctx); err != nil {
 		a.log.Error("Error stopping HTTP server", "error", err)
 	}
+
+	if err := a.outboxRelay.Close(); err != nil {
+		a.log.Error("Error closing outbox writer", "error", err)
+	}
 }
 
 func loggerMiddleware(log *slog.Logger) gin.HandlerFunc {
@@ -147,3 +282,16 @@ func authMiddleware(userServiceClient *userclient.UserServiceClient) gin.Handler
 		c.Next()
 	}
 }
+
+// forwardAuthMiddleware passes the caller's token on to downstream gRPC
+// services, for routes whose handlers call them on the user's behalf.
+func forwardAuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		outMD := metadata.New(map[string]string{
+			"authorization": c.GetHeader("Authorization"),
+		})
+		c.Request = c.Request.WithContext(metadata.NewOutgoingContext(c.Request.Context(), outMD))
+
+		c.Next()
+	}
+}
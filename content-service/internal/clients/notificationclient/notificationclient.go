@@ -5,7 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 
-	"github.com/segmentio/kafka-go"
+	"github.com/ocenb/music-go/shared/outbox"
 )
 
 const (
@@ -13,12 +13,11 @@ const (
 )
 
 type NotificationClientInterface interface {
-	SendEmailNotification(email, msg string) error
-	Close() error
+	SendEmailNotification(ctx context.Context, email, msg string) error
 }
 
 type NotificationClient struct {
-	writer *kafka.Writer
+	outboxRepo outbox.RepoInterface
 }
 
 type EmailNotification struct {
@@ -26,20 +25,13 @@ type EmailNotification struct {
 	Msg   string `json:"msg"`
 }
 
-func NewNotificationClient(brokers []string) (NotificationClientInterface, error) {
-	writer := &kafka.Writer{
-		Addr:         kafka.TCP(brokers...),
-		Topic:        notificationEmailTopic,
-		RequiredAcks: kafka.RequireAll,
-		Async:        false,
-	}
-
+func NewNotificationClient(outboxRepo outbox.RepoInterface) NotificationClientInterface {
 	return &NotificationClient{
-		writer: writer,
-	}, nil
+		outboxRepo: outboxRepo,
+	}
 }
 
-func (s *NotificationClient) SendEmailNotification(email, msg string) error {
+func (s *NotificationClient) SendEmailNotification(ctx context.Context, email, msg string) error {
 	emailNotification := EmailNotification{
 		Email: email,
 		Msg:   msg,
@@ -50,18 +42,10 @@ func (s *NotificationClient) SendEmailNotification(email, msg string) error {
 		return fmt.Errorf("failed to marshal email notification: %w", err)
 	}
 
-	err = s.writer.WriteMessages(context.Background(),
-		kafka.Message{
-			Value: payload,
-		},
-	)
+	err = s.outboxRepo.Add(ctx, notificationEmailTopic, email, payload)
 	if err != nil {
-		return fmt.Errorf("failed to send message: %w", err)
+		return fmt.Errorf("failed to add email notification to outbox: %w", err)
 	}
 
 	return nil
 }
-
-func (s *NotificationClient) Close() error {
-	return s.writer.Close()
-}
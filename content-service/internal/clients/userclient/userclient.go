@@ -1,6 +1,7 @@
 package userclient
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"time"
@@ -44,3 +45,28 @@ func New(cfg *config.Config) (*UserServiceClient, error) {
 		Conn:   conn,
 	}, nil
 }
+
+const followingPageSize = 1000
+
+// GetAllFollowing walks every page of GetFollowing for the user.
+func (c *UserServiceClient) GetAllFollowing(ctx context.Context, userID int64) ([]*userservice.UserPublicModel, error) {
+	var users []*userservice.UserPublicModel
+	var lastID int64
+
+	for {
+		res, err := c.Client.GetFollowing(ctx, &userservice.GetFollowingRequest{
+			UserId: userID,
+			Take:   followingPageSize,
+			LastId: lastID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(users, res.Users...)
+		if len(res.Users) < followingPageSize {
+			return users, nil
+		}
+		lastID = res.Users[len(res.Users)-1].Id
+	}
+}
@@ -11,33 +11,43 @@ import (
 )
 
 type Config struct {
-	LogLevel             int           `yaml:"log_level" env-default:"0"`
-	LogHandler           string        `yaml:"log_handler" env-default:"text"`
-	DBMaxOpenConns       int           `yaml:"db_max_open_conns" env-default:"10"`
-	DBMaxIdleConns       int           `yaml:"db_max_idle_conns" env-default:"5"`
-	DBConnMaxLifetime    time.Duration `yaml:"db_conn_max_lifetime" env-default:"1h"`
-	ImageFileLimit       int64         `yaml:"image_file_limit" env-default:"10485760"`
-	AudioFileLimit       int64         `yaml:"audio_file_limit" env-default:"52428800"`
-	Environment          string        `env:"ENVIRONMENT" env-required:"true"`
-	DBHost               string        `env:"POSTGRES_HOST" env-required:"true"`
-	DBPort               string        `env:"POSTGRES_PORT" env-required:"true"`
-	DBUser               string        `env:"POSTGRES_USER" env-required:"true"`
-	DBPassword           string        `env:"POSTGRES_PASSWORD" env-required:"true"`
-	DBName               string        `env:"POSTGRES_DB" env-required:"true"`
-	DBSSLMode            string        `env:"POSTGRES_SSL_MODE" env-default:"disable"`
-	DatabaseUrl          string
-	RedisHost            string `env:"REDIS_HOST" env-required:"true"`
-	RedisPort            string `env:"REDIS_PORT" env-required:"true"`
-	RedisPassword        string `env:"REDIS_PASSWORD" env-required:"true"`
-	RedisUrl             string
-	Domain               string   `env:"DOMAIN" env-required:"true"`
-	Port                 int      `env:"PORT" env-required:"true"`
-	CloudinaryCloudName  string   `env:"CLOUDINARY_CLOUD_NAME" env-required:"true"`
-	CloudinaryApiKey     string   `env:"CLOUDINARY_API_KEY" env-required:"true"`
-	CloudinaryApiSecret  string   `env:"CLOUDINARY_API_SECRET" env-required:"true"`
-	SearchServiceAddress string   `env:"SEARCH_SERVICE_ADDRESS" env-required:"true"`
-	UserServiceAddress   string   `env:"USER_SERVICE_ADDRESS" env-required:"true"`
-	KafkaBrokers         []string `env:"KAFKA_BROKERS" env-required:"true"`
+	LogLevel               int           `yaml:"log_level" env-default:"0"`
+	LogHandler             string        `yaml:"log_handler" env-default:"text"`
+	DBMaxOpenConns         int           `yaml:"db_max_open_conns" env-default:"10"`
+	DBMaxIdleConns         int           `yaml:"db_max_idle_conns" env-default:"5"`
+	DBConnMaxLifetime      time.Duration `yaml:"db_conn_max_lifetime" env-default:"1h"`
+	ImageFileLimit         int64         `yaml:"image_file_limit" env-default:"10485760"`
+	AudioFileLimit         int64         `yaml:"audio_file_limit" env-default:"52428800"`
+	FeedFanOutThreshold    int           `yaml:"feed_fan_out_threshold" env-default:"1000"`
+	FeedFanOutQueueSize    int           `yaml:"feed_fan_out_queue_size" env-default:"1000"`
+	FeedBackfillSize       int           `yaml:"feed_backfill_size" env-default:"20"`
+	SmartPlaylistsInterval time.Duration `yaml:"smart_playlists_interval" env-default:"1h"`
+	CoverRefreshInterval   time.Duration `yaml:"cover_refresh_interval" env-default:"5s"`
+	SearchSignalsInterval  time.Duration `yaml:"search_signals_interval" env-default:"15m"`
+	SearchSignalsBatchSize int           `yaml:"search_signals_batch_size" env-default:"500"`
+	OutboxRelayInterval    time.Duration `yaml:"outbox_relay_interval" env-default:"1s"`
+	OutboxBatchSize        int           `yaml:"outbox_batch_size" env-default:"100"`
+	OutboxRetention        time.Duration `yaml:"outbox_retention" env-default:"168h"`
+	Environment            string        `env:"ENVIRONMENT" env-required:"true"`
+	DBHost                 string        `env:"POSTGRES_HOST" env-required:"true"`
+	DBPort                 string        `env:"POSTGRES_PORT" env-required:"true"`
+	DBUser                 string        `env:"POSTGRES_USER" env-required:"true"`
+	DBPassword             string        `env:"POSTGRES_PASSWORD" env-required:"true"`
+	DBName                 string        `env:"POSTGRES_DB" env-required:"true"`
+	DBSSLMode              string        `env:"POSTGRES_SSL_MODE" env-default:"disable"`
+	DatabaseUrl            string
+	RedisHost              string `env:"REDIS_HOST" env-required:"true"`
+	RedisPort              string `env:"REDIS_PORT" env-required:"true"`
+	RedisPassword          string `env:"REDIS_PASSWORD" env-required:"true"`
+	RedisUrl               string
+	Domain                 string   `env:"DOMAIN" env-required:"true"`
+	Port                   int      `env:"PORT" env-required:"true"`
+	CloudinaryCloudName    string   `env:"CLOUDINARY_CLOUD_NAME" env-required:"true"`
+	CloudinaryApiKey       string   `env:"CLOUDINARY_API_KEY" env-required:"true"`
+	CloudinaryApiSecret    string   `env:"CLOUDINARY_API_SECRET" env-required:"true"`
+	SearchServiceAddress   string   `env:"SEARCH_SERVICE_ADDRESS" env-required:"true"`
+	UserServiceAddress     string   `env:"USER_SERVICE_ADDRESS" env-required:"true"`
+	KafkaBrokers           []string `env:"KAFKA_BROKERS" env-required:"true"`
 }
 
 func MustLoad() *Config {
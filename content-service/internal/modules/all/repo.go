@@ -87,7 +87,7 @@ func (r *AllRepo) DeleteAll(ctx context.Context, userID int64) ([]string, []stri
 	}
 
 	var playlistImages []string
-	rows, err = tx.QueryContext(ctx, "SELECT image FROM playlists WHERE user_id = $1", userID)
+	rows, err = tx.QueryContext(ctx, "SELECT image FROM playlists WHERE user_id = $1 AND image != 'default'", userID)
 	if err != nil {
 		r.log.Error("Failed to get playlist images", "error", err, "user_id", userID)
 		return nil, nil, nil, err
@@ -129,6 +129,48 @@ func (r *AllRepo) DeleteAll(ctx context.Context, userID int64) ([]string, []stri
 		return nil, nil, nil, err
 	}
 
+	_, err = tx.ExecContext(ctx, "DELETE FROM feed_items WHERE user_id = $1 OR actor_id = $1", userID)
+	if err != nil {
+		r.log.Error("Failed to delete feed items", "error", err, "user_id", userID)
+		return nil, nil, nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM track_reposts WHERE user_id = $1", userID)
+	if err != nil {
+		r.log.Error("Failed to delete track reposts", "error", err, "user_id", userID)
+		return nil, nil, nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM playlist_reposts WHERE user_id = $1", userID)
+	if err != nil {
+		r.log.Error("Failed to delete playlist reposts", "error", err, "user_id", userID)
+		return nil, nil, nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM playlist_collaborators WHERE user_id = $1", userID)
+	if err != nil {
+		r.log.Error("Failed to delete playlist collaborators", "error", err, "user_id", userID)
+		return nil, nil, nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM playlist_invites WHERE inviter_id = $1 OR invitee_id = $1", userID)
+	if err != nil {
+		r.log.Error("Failed to delete playlist invites", "error", err, "user_id", userID)
+		return nil, nil, nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM playlist_folder_items WHERE user_id = $1", userID)
+	if err != nil {
+		r.log.Error("Failed to delete playlist folder items", "error", err, "user_id", userID)
+		return nil, nil, nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM playlist_folders WHERE user_id = $1", userID)
+	if err != nil {
+		r.log.Error("Failed to delete playlist folders", "error", err, "user_id", userID)
+		return nil, nil, nil, err
+	}
+
 	_, err = tx.ExecContext(ctx, "DELETE FROM playlists WHERE user_id = $1", userID)
 	if err != nil {
 		r.log.Error("Failed to delete playlists", "error", err, "user_id", userID)
@@ -0,0 +1,19 @@
+package fanout
+
+import "time"
+
+type ItemType string
+
+const (
+	TrackItem    ItemType = "track"
+	PlaylistItem ItemType = "playlist"
+)
+
+type FeedItemModel struct {
+	UserID    int64     `json:"userId"`
+	ActorID   int64     `json:"actorId"`
+	ItemType  ItemType  `json:"itemType"`
+	ItemID    int64     `json:"itemId"`
+	IsRepost  bool      `json:"isRepost"`
+	CreatedAt time.Time `json:"createdAt"`
+}
@@ -0,0 +1,79 @@
+package fanout
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type FanOutRepoInterface interface {
+	Add(ctx context.Context, followerIDs []int64, actorID int64, itemType ItemType, itemID int64, isRepost bool, createdAt time.Time) error
+	Remove(ctx context.Context, actorID int64, itemType ItemType, itemID int64, isRepost bool) error
+	RemoveItem(ctx context.Context, itemType ItemType, itemID int64) error
+}
+
+type FanOutRepo struct {
+	postgres *sql.DB
+	log      *slog.Logger
+}
+
+func NewFanOutRepo(postgres *sql.DB, log *slog.Logger) FanOutRepoInterface {
+	return &FanOutRepo{postgres: postgres, log: log}
+}
+
+// Add delivers the item only while its source still exists: the track, the
+// public playlist or the repost. The source row is locked for the insert, so
+// a retract either waits for the insert and removes what it delivered, or
+// removes the source first and nothing is delivered.
+func (r *FanOutRepo) Add(ctx context.Context, followerIDs []int64, actorID int64, itemType ItemType, itemID int64, isRepost bool, createdAt time.Time) error {
+	query := `
+		INSERT INTO feed_items (user_id, actor_id, item_type, item_id, is_repost, created_at)
+		SELECT follower_id, $2, $3, $4, $5, $6
+		FROM unnest($1::int[]) AS follower_id
+		WHERE EXISTS (` + sourceQuery(itemType, isRepost) + `)
+		ON CONFLICT (user_id, actor_id, item_type, item_id, is_repost) DO NOTHING
+	`
+
+	_, err := r.postgres.ExecContext(ctx, query, pq.Array(followerIDs), actorID, itemType, itemID, isRepost, createdAt)
+	return err
+}
+
+func sourceQuery(itemType ItemType, isRepost bool) string {
+	switch {
+	case itemType == TrackItem && isRepost:
+		return `SELECT 1 FROM track_reposts WHERE user_id = $2 AND track_id = $4 FOR SHARE`
+	case itemType == TrackItem:
+		return `SELECT 1 FROM tracks WHERE user_id = $2 AND id = $4 FOR SHARE`
+	case isRepost:
+		return `
+			SELECT 1 FROM playlist_reposts pr
+			JOIN playlists p ON p.id = pr.playlist_id
+			WHERE pr.user_id = $2 AND pr.playlist_id = $4 AND p.visibility = 'public'
+			FOR SHARE`
+	default:
+		return `SELECT 1 FROM playlists WHERE user_id = $2 AND id = $4 AND visibility = 'public' FOR SHARE`
+	}
+}
+
+func (r *FanOutRepo) Remove(ctx context.Context, actorID int64, itemType ItemType, itemID int64, isRepost bool) error {
+	query := `
+		DELETE FROM feed_items
+		WHERE actor_id = $1 AND item_type = $2 AND item_id = $3 AND is_repost = $4
+	`
+
+	_, err := r.postgres.ExecContext(ctx, query, actorID, itemType, itemID, isRepost)
+	return err
+}
+
+func (r *FanOutRepo) RemoveItem(ctx context.Context, itemType ItemType, itemID int64) error {
+	query := `
+		DELETE FROM feed_items
+		WHERE item_type = $1 AND item_id = $2
+	`
+
+	_, err := r.postgres.ExecContext(ctx, query, itemType, itemID)
+	return err
+}
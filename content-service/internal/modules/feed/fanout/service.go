@@ -0,0 +1,117 @@
+package fanout
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/ocenb/music-go/content-service/internal/clients/userclient"
+	"github.com/ocenb/music-go/content-service/internal/config"
+	"github.com/ocenb/music-protos/gen/userservice"
+)
+
+const publishTimeout = 30 * time.Second
+
+type FanOutServiceInterface interface {
+	Publish(ctx context.Context, actorID int64, itemType ItemType, itemID int64, isRepost bool, createdAt time.Time)
+	Run(ctx context.Context)
+	Retract(ctx context.Context, actorID int64, itemType ItemType, itemID int64, isRepost bool) error
+	RetractItem(ctx context.Context, itemType ItemType, itemID int64) error
+}
+
+type FanOutService struct {
+	cfg        *config.Config
+	log        *slog.Logger
+	fanOutRepo FanOutRepoInterface
+	userClient *userclient.UserServiceClient
+	jobs       chan publishJob
+}
+
+type publishJob struct {
+	actorID   int64
+	itemType  ItemType
+	itemID    int64
+	isRepost  bool
+	createdAt time.Time
+}
+
+func NewFanOutService(cfg *config.Config, log *slog.Logger, fanOutRepo FanOutRepoInterface, userClient *userclient.UserServiceClient) FanOutServiceInterface {
+	return &FanOutService{
+		cfg:        cfg,
+		log:        log,
+		fanOutRepo: fanOutRepo,
+		userClient: userClient,
+		jobs:       make(chan publishJob, cfg.FeedFanOutQueueSize),
+	}
+}
+
+// Publish queues the item for delivery to the actor's followers. Queued jobs
+// run under the context of Run, never under the caller's one, which may be
+// reused once the request is over; when the queue is full the item is
+// delivered inline instead.
+func (s *FanOutService) Publish(ctx context.Context, actorID int64, itemType ItemType, itemID int64, isRepost bool, createdAt time.Time) {
+	job := publishJob{
+		actorID:   actorID,
+		itemType:  itemType,
+		itemID:    itemID,
+		isRepost:  isRepost,
+		createdAt: createdAt,
+	}
+
+	select {
+	case s.jobs <- job:
+	default:
+		s.log.Warn("Fan-out queue is full, publishing inline", "item_type", itemType, "item_id", itemID)
+		s.handle(ctx, job)
+	}
+}
+
+func (s *FanOutService) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job := <-s.jobs:
+			s.handle(ctx, job)
+		}
+	}
+}
+
+func (s *FanOutService) handle(ctx context.Context, job publishJob) {
+	ctx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+
+	err := s.publish(ctx, job.actorID, job.itemType, job.itemID, job.isRepost, job.createdAt)
+	if err != nil {
+		s.log.Error("Failed to fan out item", "error", err, "item_type", job.itemType, "item_id", job.itemID, "is_repost", job.isRepost)
+	}
+}
+
+func (s *FanOutService) publish(ctx context.Context, actorID int64, itemType ItemType, itemID int64, isRepost bool, createdAt time.Time) error {
+	res, err := s.userClient.Client.GetFollowers(ctx, &userservice.GetFollowersRequest{
+		UserId: actorID,
+		Take:   int64(s.cfg.FeedFanOutThreshold),
+	})
+	if err != nil {
+		return err
+	}
+
+	if res.FollowersCount > int64(s.cfg.FeedFanOutThreshold) {
+		s.log.Debug("Skipping fan-out on write", "actor_id", actorID, "followers_count", res.FollowersCount)
+		return nil
+	}
+
+	if len(res.UserIds) == 0 {
+		return nil
+	}
+
+	return s.fanOutRepo.Add(ctx, res.UserIds, actorID, itemType, itemID, isRepost, createdAt)
+}
+
+func (s *FanOutService) Retract(ctx context.Context, actorID int64, itemType ItemType, itemID int64, isRepost bool) error {
+	return s.fanOutRepo.Remove(ctx, actorID, itemType, itemID, isRepost)
+}
+
+func (s *FanOutService) RetractItem(ctx context.Context, itemType ItemType, itemID int64) error {
+	return s.fanOutRepo.RemoveItem(ctx, itemType, itemID)
+}
@@ -0,0 +1,60 @@
+package feed
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ocenb/music-go/content-service/internal/modules/feed/fanout"
+	"github.com/ocenb/music-go/content-service/internal/modules/repost"
+	"github.com/ocenb/music-go/content-service/internal/utils"
+)
+
+type FeedHandlerInterface interface {
+	getMany(c *gin.Context)
+	RegisterHandlers(router *gin.RouterGroup)
+}
+
+type FeedHandler struct {
+	feedService FeedServiceInterface
+}
+
+func NewFeedHandler(feedService FeedServiceInterface) FeedHandlerInterface {
+	return &FeedHandler{
+		feedService: feedService,
+	}
+}
+
+func (h *FeedHandler) getMany(c *gin.Context) {
+	var params GetManyForm
+	if err := c.ShouldBindQuery(&params); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.InternalError(c, err)
+		return
+	}
+
+	feed, err := h.feedService.GetMany(c.Request.Context(), user.Id, params.Take, feedCursor(params))
+	if err != nil {
+		utils.InternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, feed)
+}
+
+func (h *FeedHandler) RegisterHandlers(router *gin.RouterGroup) {
+	feedRouter := router.Group("/feed")
+	feedRouter.GET("", h.getMany)
+}
+
+func feedCursor(params GetManyForm) FeedCursorModel {
+	return FeedCursorModel{
+		StreamCursorModel: repost.StreamCursorModel{Date: params.LastDate, Type: fanout.ItemType(params.LastType), ItemID: params.LastID},
+		IsRepost:          params.LastIsRepost,
+		ActorID:           params.LastActorID,
+	}
+}
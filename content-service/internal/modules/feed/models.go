@@ -0,0 +1,12 @@
+package feed
+
+import "github.com/ocenb/music-go/content-service/internal/modules/repost"
+
+// FeedCursorModel points after the last entry of a feed page. A feed merges
+// several actors and the same item can be both posted and reposted, so the
+// repost flag and the actor are part of the cursor too.
+type FeedCursorModel struct {
+	repost.StreamCursorModel
+	IsRepost bool
+	ActorID  int64
+}
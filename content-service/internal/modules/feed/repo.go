@@ -0,0 +1,125 @@
+package feed
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/lib/pq"
+	"github.com/ocenb/music-go/content-service/internal/modules/repost"
+)
+
+type FeedRepoInterface interface {
+	GetMany(ctx context.Context, userID int64, followingIDs, pullIDs []int64, take int, cursor FeedCursorModel) ([]*repost.StreamEntryModel, error)
+	Backfill(ctx context.Context, userID int64, actorIDs []int64, perActor int) error
+}
+
+type FeedRepo struct {
+	postgres *sql.DB
+	log      *slog.Logger
+}
+
+func NewFeedRepo(postgres *sql.DB, log *slog.Logger) FeedRepoInterface {
+	return &FeedRepo{postgres: postgres, log: log}
+}
+
+func (r *FeedRepo) GetMany(ctx context.Context, userID int64, followingIDs, pullIDs []int64, take int, cursor FeedCursorModel) ([]*repost.StreamEntryModel, error) {
+	query := `
+		SELECT actor_id, item_type, item_id, is_repost, item_date FROM (
+			SELECT actor_id, item_type, item_id, is_repost, created_at as item_date
+			FROM feed_items
+			WHERE user_id = $1 AND actor_id = ANY($2)
+			UNION
+			SELECT user_id as actor_id, 'track' as item_type, id as item_id, false as is_repost, created_at as item_date
+			FROM tracks
+			WHERE user_id = ANY($3)
+			UNION
+			SELECT user_id as actor_id, 'playlist' as item_type, id as item_id, false as is_repost, created_at as item_date
+			FROM playlists
+			WHERE user_id = ANY($3) AND visibility = 'public'
+			UNION
+			SELECT user_id as actor_id, 'track' as item_type, track_id as item_id, true as is_repost, reposted_at as item_date
+			FROM track_reposts
+			WHERE user_id = ANY($3)
+			UNION
+			SELECT pr.user_id as actor_id, 'playlist' as item_type, pr.playlist_id as item_id, true as is_repost, pr.reposted_at as item_date
+			FROM playlist_reposts pr
+			JOIN playlists p ON p.id = pr.playlist_id
+			WHERE pr.user_id = ANY($3) AND p.visibility = 'public'
+		) feed
+		WHERE $4::timestamp IS NULL OR (item_date, item_type, item_id, is_repost, actor_id) < ($4, $5, $6, $7, $8)
+		ORDER BY item_date DESC, item_type DESC, item_id DESC, is_repost DESC, actor_id DESC
+		LIMIT $9
+	`
+
+	before := sql.NullTime{Time: cursor.Date, Valid: !cursor.Date.IsZero()}
+
+	rows, err := r.postgres.QueryContext(ctx, query, userID, pq.Array(followingIDs), pq.Array(pullIDs), before, string(cursor.Type), cursor.ItemID, cursor.IsRepost, cursor.ActorID, take)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			r.log.Error("Failed to close rows", "error", err)
+		}
+	}()
+
+	var entries []*repost.StreamEntryModel
+
+	for rows.Next() {
+		var entry repost.StreamEntryModel
+
+		err := rows.Scan(&entry.ActorID, &entry.Type, &entry.ItemID, &entry.IsRepost, &entry.Date)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, &entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// Backfill copies the latest items of actors the user has not been backfilled
+// from yet into their feed, so following someone shows their past activity.
+func (r *FeedRepo) Backfill(ctx context.Context, userID int64, actorIDs []int64, perActor int) error {
+	query := `
+		WITH sources AS (
+			INSERT INTO feed_sources (user_id, actor_id)
+			SELECT $1, actor_id FROM unnest($2::int[]) AS actor_id
+			ON CONFLICT (user_id, actor_id) DO NOTHING
+			RETURNING actor_id
+		)
+		INSERT INTO feed_items (user_id, actor_id, item_type, item_id, is_repost, created_at)
+		SELECT $1, actor_id, item_type, item_id, is_repost, item_date FROM (
+			SELECT items.*, ROW_NUMBER() OVER (PARTITION BY actor_id ORDER BY item_date DESC) as rn FROM (
+				SELECT user_id as actor_id, 'track' as item_type, id as item_id, false as is_repost, created_at as item_date
+				FROM tracks
+				WHERE user_id IN (SELECT actor_id FROM sources)
+				UNION ALL
+				SELECT user_id as actor_id, 'playlist' as item_type, id as item_id, false as is_repost, created_at as item_date
+				FROM playlists
+				WHERE user_id IN (SELECT actor_id FROM sources) AND visibility = 'public'
+				UNION ALL
+				SELECT user_id as actor_id, 'track' as item_type, track_id as item_id, true as is_repost, reposted_at as item_date
+				FROM track_reposts
+				WHERE user_id IN (SELECT actor_id FROM sources)
+				UNION ALL
+				SELECT pr.user_id as actor_id, 'playlist' as item_type, pr.playlist_id as item_id, true as is_repost, pr.reposted_at as item_date
+				FROM playlist_reposts pr
+				JOIN playlists p ON p.id = pr.playlist_id
+				WHERE pr.user_id IN (SELECT actor_id FROM sources) AND p.visibility = 'public'
+			) items
+		) ranked
+		WHERE rn <= $3
+		ON CONFLICT (user_id, actor_id, item_type, item_id, is_repost) DO NOTHING
+	`
+
+	_, err := r.postgres.ExecContext(ctx, query, userID, pq.Array(actorIDs), perActor)
+	return err
+}
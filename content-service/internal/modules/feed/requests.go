@@ -0,0 +1,12 @@
+package feed
+
+import "time"
+
+type GetManyForm struct {
+	Take         int       `form:"take" binding:"omitempty,min=1"`
+	LastDate     time.Time `form:"lastDate" binding:"omitempty"`
+	LastType     string    `form:"lastType" binding:"required_with=LastDate,omitempty,oneof=track playlist"`
+	LastID       int64     `form:"lastId" binding:"omitempty,min=1"`
+	LastIsRepost bool      `form:"lastIsRepost"`
+	LastActorID  int64     `form:"lastActorId" binding:"required_with=LastDate,omitempty,min=1"`
+}
@@ -0,0 +1,67 @@
+package feed
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ocenb/music-go/content-service/internal/clients/userclient"
+	"github.com/ocenb/music-go/content-service/internal/config"
+	"github.com/ocenb/music-go/content-service/internal/modules/repost"
+)
+
+type FeedServiceInterface interface {
+	GetMany(ctx context.Context, currentUserID int64, take int, cursor FeedCursorModel) ([]*repost.StreamItemModel, error)
+}
+
+type FeedService struct {
+	cfg           *config.Config
+	log           *slog.Logger
+	feedRepo      FeedRepoInterface
+	repostService repost.RepostServiceInterface
+	userClient    *userclient.UserServiceClient
+}
+
+func NewFeedService(cfg *config.Config, log *slog.Logger, feedRepo FeedRepoInterface, repostService repost.RepostServiceInterface, userClient *userclient.UserServiceClient) FeedServiceInterface {
+	return &FeedService{
+		cfg:           cfg,
+		log:           log,
+		feedRepo:      feedRepo,
+		repostService: repostService,
+		userClient:    userClient,
+	}
+}
+
+func (s *FeedService) GetMany(ctx context.Context, currentUserID int64, take int, cursor FeedCursorModel) ([]*repost.StreamItemModel, error) {
+	following, err := s.userClient.GetAllFollowing(ctx, currentUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(following) == 0 {
+		return []*repost.StreamItemModel{}, nil
+	}
+
+	followingIDs := make([]int64, 0, len(following))
+	var pullIDs, pushIDs []int64
+	for _, user := range following {
+		followingIDs = append(followingIDs, user.Id)
+		if user.FollowersCount > int64(s.cfg.FeedFanOutThreshold) {
+			pullIDs = append(pullIDs, user.Id)
+		} else {
+			pushIDs = append(pushIDs, user.Id)
+		}
+	}
+
+	if len(pushIDs) > 0 && cursor.Date.IsZero() {
+		if err := s.feedRepo.Backfill(ctx, currentUserID, pushIDs, s.cfg.FeedBackfillSize); err != nil {
+			s.log.Error("Failed to backfill feed", "error", err, "user_id", currentUserID)
+		}
+	}
+
+	entries, err := s.feedRepo.GetMany(ctx, currentUserID, followingIDs, pullIDs, take, cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repostService.Hydrate(ctx, currentUserID, entries)
+}
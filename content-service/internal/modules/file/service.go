@@ -4,14 +4,18 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"image"
+	"image/draw"
 	"image/jpeg"
 	"io"
 	"log/slog"
 	"mime/multipart"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/nfnt/resize"
@@ -23,9 +27,11 @@ import (
 type FileCategory string
 
 const (
-	AudioCategory  FileCategory = "audio"
-	ImagesCategory FileCategory = "images"
-	tempDir                     = "/app/temp"
+	AudioCategory   FileCategory = "audio"
+	ImagesCategory  FileCategory = "images"
+	tempDir                      = "/app/temp"
+	DefaultImage                 = "default"
+	downloadTimeout              = 10 * time.Second
 )
 
 type AudioResult struct {
@@ -36,13 +42,17 @@ type AudioResult struct {
 type FileServiceInterface interface {
 	SaveAudio(ctx context.Context, file *multipart.FileHeader) (*AudioResult, error)
 	SaveImage(ctx context.Context, file *multipart.FileHeader) (string, error)
+	SaveMosaic(ctx context.Context, imageNames []string) (string, error)
 	DeleteFile(ctx context.Context, fileName string, category FileCategory) error
+	GetAudioURL(fileName string) string
+	GetImageURL(fileName string) string
 }
 
 type FileService struct {
 	cloudinary cloudinaryclient.CloudinaryClientInterface
 	log        *slog.Logger
 	cfg        *config.Config
+	httpClient *http.Client
 }
 
 func NewFileService(
@@ -54,6 +64,7 @@ func NewFileService(
 		cloudinary: cloudinary,
 		log:        log,
 		cfg:        cfg,
+		httpClient: &http.Client{Timeout: downloadTimeout},
 	}
 }
 
@@ -199,6 +210,71 @@ func (s *FileService) SaveImage(ctx context.Context, file *multipart.FileHeader)
 	return fileName, nil
 }
 
+func (s *FileService) SaveMosaic(ctx context.Context, imageNames []string) (string, error) {
+	if len(imageNames) == 0 {
+		return "", fmt.Errorf("no images for mosaic")
+	}
+	if len(imageNames) < 4 {
+		imageNames = imageNames[:1]
+	} else {
+		imageNames = imageNames[:4]
+	}
+
+	mosaic := image.NewRGBA(image.Rect(0, 0, 250, 250))
+	tileSize := 250
+	if len(imageNames) == 4 {
+		tileSize = 125
+	}
+
+	for i, imageName := range imageNames {
+		tile, err := s.downloadImage(ctx, s.GetImageURL(imageName))
+		if err != nil {
+			return "", fmt.Errorf("failed to download image %s: %w", imageName, err)
+		}
+
+		resized := resize.Resize(uint(tileSize), uint(tileSize), tile, resize.Lanczos3)
+		offset := image.Pt((i%2)*tileSize, (i/2)*tileSize)
+		draw.Draw(mosaic, resized.Bounds().Add(offset), resized, resized.Bounds().Min, draw.Src)
+	}
+
+	fileName := uuid.New().String()
+	fileName250 := fmt.Sprintf("%s_250x250", fileName)
+	fileName50 := fmt.Sprintf("%s_50x50", fileName)
+
+	filePath250 := filepath.Join(tempDir, fmt.Sprintf("%s.jpg", fileName250))
+	filePath50 := filepath.Join(tempDir, fmt.Sprintf("%s.jpg", fileName50))
+
+	if err := s.writeImage(mosaic, filePath250, 250, 250); err != nil {
+		return "", fmt.Errorf("failed to process 250x250 image: %w", err)
+	}
+	defer func() {
+		err := os.Remove(filePath250)
+		if err != nil {
+			s.log.Error("Failed to remove file", "error", err)
+		}
+	}()
+
+	if err := s.writeImage(mosaic, filePath50, 50, 50); err != nil {
+		return "", fmt.Errorf("failed to process 50x50 image: %w", err)
+	}
+	defer func() {
+		err := os.Remove(filePath50)
+		if err != nil {
+			s.log.Error("Failed to remove file", "error", err)
+		}
+	}()
+
+	if err := s.cloudinary.Upload(ctx, filePath250, fileName250, "image", "images"); err != nil {
+		return "", fmt.Errorf("failed to upload 250x250 image: %w", err)
+	}
+
+	if err := s.cloudinary.Upload(ctx, filePath50, fileName50, "image", "images"); err != nil {
+		return "", fmt.Errorf("failed to upload 50x50 image: %w", err)
+	}
+
+	return fileName, nil
+}
+
 func (s *FileService) DeleteFile(ctx context.Context, fileName string, category FileCategory) error {
 	if category == ImagesCategory {
 		s.log.Info("Deleting 250x250 image", "fileName", fileName)
@@ -218,6 +294,17 @@ func (s *FileService) DeleteFile(ctx context.Context, fileName string, category
 	return nil
 }
 
+func (s *FileService) GetAudioURL(fileName string) string {
+	return fmt.Sprintf("https://res.cloudinary.com/%s/video/upload/audio/%s.webm", s.cfg.CloudinaryCloudName, fileName)
+}
+
+func (s *FileService) GetImageURL(fileName string) string {
+	if fileName == DefaultImage {
+		return ""
+	}
+	return fmt.Sprintf("https://res.cloudinary.com/%s/image/upload/images/%s_250x250.jpg", s.cfg.CloudinaryCloudName, fileName)
+}
+
 func (s *FileService) saveMultipartFile(file *multipart.FileHeader, dst string) error {
 	src, err := file.Open()
 	if err != nil {
@@ -263,6 +350,56 @@ func (s *FileService) resize(input io.Reader, output io.Writer, width, height in
 	return nil
 }
 
+func (s *FileService) downloadImage(ctx context.Context, url string) (image.Image, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err := resp.Body.Close()
+		if err != nil {
+			s.log.Error("Failed to close response body", "error", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	img, err := jpeg.Decode(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	return img, nil
+}
+
+func (s *FileService) writeImage(img image.Image, filePath string, width, height int) error {
+	out, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer func() {
+		err := out.Close()
+		if err != nil {
+			s.log.Error("Failed to close file", "error", err)
+		}
+	}()
+
+	resized := resize.Resize(uint(width), uint(height), img, resize.Lanczos3)
+
+	if err := jpeg.Encode(out, resized, &jpeg.Options{Quality: 90}); err != nil {
+		return fmt.Errorf("failed to encode image: %w", err)
+	}
+
+	return nil
+}
+
 func (s *FileService) convertAudioToWebm(inputPath, outputPath string) error {
 	err := ffmpeg.Input(inputPath).
 		Output(outputPath, ffmpeg.KwArgs{
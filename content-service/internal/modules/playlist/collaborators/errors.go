@@ -0,0 +1,16 @@
+package collaborators
+
+import "errors"
+
+var (
+	ErrPlaylistNotFound      = errors.New("playlist not found")
+	ErrPermissionDenied      = errors.New("permission denied")
+	ErrCollaboratorNotFound  = errors.New("collaborator not found")
+	ErrAlreadyCollaborator   = errors.New("user is already a collaborator")
+	ErrAlreadyInvited        = errors.New("user is already invited")
+	ErrCannotInviteYourself  = errors.New("cannot invite yourself")
+	ErrInviteNotFound        = errors.New("invite not found")
+	ErrInviteExpired         = errors.New("invite has expired")
+	ErrCannotChangeOwnerRole = errors.New("cannot change owner role")
+	ErrUserNotFound          = errors.New("user not found")
+)
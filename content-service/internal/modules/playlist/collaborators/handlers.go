@@ -0,0 +1,403 @@
+package collaborators
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ocenb/music-go/content-service/internal/modules/playlist"
+	"github.com/ocenb/music-go/content-service/internal/utils"
+)
+
+type CollaboratorsHandlersInterface interface {
+	GetMany(c *gin.Context)
+	UpdateRole(c *gin.Context)
+	Remove(c *gin.Context)
+	Invite(c *gin.Context)
+	CreateInviteLink(c *gin.Context)
+	GetManyPlaylistInvites(c *gin.Context)
+	RevokeInvite(c *gin.Context)
+	GetManyMyInvites(c *gin.Context)
+	AcceptInvite(c *gin.Context)
+	DeclineInvite(c *gin.Context)
+	AcceptInviteLink(c *gin.Context)
+	RegisterHandlers(router *gin.RouterGroup)
+}
+
+type CollaboratorsHandlers struct {
+	collaboratorsService CollaboratorsServiceInterface
+}
+
+func NewHandlers(collaboratorsService CollaboratorsServiceInterface) CollaboratorsHandlersInterface {
+	return &CollaboratorsHandlers{
+		collaboratorsService: collaboratorsService,
+	}
+}
+
+func (h *CollaboratorsHandlers) GetMany(c *gin.Context) {
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.UnauthenticatedError(c, err)
+		return
+	}
+
+	var playlistReq PlaylistUri
+	if err := c.ShouldBindUri(&playlistReq); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	collaborators, err := h.collaboratorsService.GetMany(c, user.Id, playlistReq.PlaylistID)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrPlaylistNotFound):
+			utils.NotFoundError(c, err)
+		case errors.Is(err, ErrPermissionDenied):
+			utils.PermissionDeniedError(c, err)
+		default:
+			utils.InternalError(c, err)
+		}
+
+		return
+	}
+
+	c.JSON(http.StatusOK, collaborators)
+}
+
+func (h *CollaboratorsHandlers) UpdateRole(c *gin.Context) {
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.UnauthenticatedError(c, err)
+		return
+	}
+
+	var collaboratorReq CollaboratorUri
+	if err := c.ShouldBindUri(&collaboratorReq); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	var updateRoleReq UpdateRoleJSON
+	if err := c.ShouldBindJSON(&updateRoleReq); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	err = h.collaboratorsService.UpdateRole(c, user.Id, collaboratorReq.PlaylistID, collaboratorReq.UserID, playlist.Role(updateRoleReq.Role))
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrPlaylistNotFound):
+			utils.NotFoundError(c, err)
+		case errors.Is(err, ErrCollaboratorNotFound):
+			utils.NotFoundError(c, err)
+		case errors.Is(err, ErrPermissionDenied):
+			utils.PermissionDeniedError(c, err)
+		case errors.Is(err, ErrCannotChangeOwnerRole):
+			utils.BadRequestError(c, err)
+		default:
+			utils.InternalError(c, err)
+		}
+
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *CollaboratorsHandlers) Remove(c *gin.Context) {
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.UnauthenticatedError(c, err)
+		return
+	}
+
+	var collaboratorReq CollaboratorUri
+	if err := c.ShouldBindUri(&collaboratorReq); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	err = h.collaboratorsService.Remove(c, user.Id, collaboratorReq.PlaylistID, collaboratorReq.UserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrPlaylistNotFound):
+			utils.NotFoundError(c, err)
+		case errors.Is(err, ErrCollaboratorNotFound):
+			utils.NotFoundError(c, err)
+		case errors.Is(err, ErrPermissionDenied):
+			utils.PermissionDeniedError(c, err)
+		default:
+			utils.InternalError(c, err)
+		}
+
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *CollaboratorsHandlers) Invite(c *gin.Context) {
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.UnauthenticatedError(c, err)
+		return
+	}
+
+	var playlistReq PlaylistUri
+	if err := c.ShouldBindUri(&playlistReq); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	var inviteReq InviteJSON
+	if err := c.ShouldBindJSON(&inviteReq); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	invite, err := h.collaboratorsService.Invite(c, user.Id, playlistReq.PlaylistID, inviteReq.UserID, playlist.Role(inviteReq.Role))
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrPlaylistNotFound):
+			utils.NotFoundError(c, err)
+		case errors.Is(err, ErrUserNotFound):
+			utils.NotFoundError(c, err)
+		case errors.Is(err, ErrPermissionDenied):
+			utils.PermissionDeniedError(c, err)
+		case errors.Is(err, ErrCannotInviteYourself):
+			utils.BadRequestError(c, err)
+		case errors.Is(err, ErrAlreadyCollaborator):
+			utils.BadRequestError(c, err)
+		case errors.Is(err, ErrAlreadyInvited):
+			utils.BadRequestError(c, err)
+		default:
+			utils.InternalError(c, err)
+		}
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, invite)
+}
+
+func (h *CollaboratorsHandlers) CreateInviteLink(c *gin.Context) {
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.UnauthenticatedError(c, err)
+		return
+	}
+
+	var playlistReq PlaylistUri
+	if err := c.ShouldBindUri(&playlistReq); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	var inviteLinkReq InviteLinkJSON
+	if err := c.ShouldBindJSON(&inviteLinkReq); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	expiresIn := time.Duration(inviteLinkReq.ExpiresInHours) * time.Hour
+
+	invite, err := h.collaboratorsService.CreateInviteLink(c, user.Id, playlistReq.PlaylistID, playlist.Role(inviteLinkReq.Role), expiresIn)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrPlaylistNotFound):
+			utils.NotFoundError(c, err)
+		case errors.Is(err, ErrPermissionDenied):
+			utils.PermissionDeniedError(c, err)
+		default:
+			utils.InternalError(c, err)
+		}
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, invite)
+}
+
+func (h *CollaboratorsHandlers) GetManyPlaylistInvites(c *gin.Context) {
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.UnauthenticatedError(c, err)
+		return
+	}
+
+	var playlistReq PlaylistUri
+	if err := c.ShouldBindUri(&playlistReq); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	invites, err := h.collaboratorsService.GetManyPlaylistInvites(c, user.Id, playlistReq.PlaylistID)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrPlaylistNotFound):
+			utils.NotFoundError(c, err)
+		case errors.Is(err, ErrPermissionDenied):
+			utils.PermissionDeniedError(c, err)
+		default:
+			utils.InternalError(c, err)
+		}
+
+		return
+	}
+
+	c.JSON(http.StatusOK, invites)
+}
+
+func (h *CollaboratorsHandlers) RevokeInvite(c *gin.Context) {
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.UnauthenticatedError(c, err)
+		return
+	}
+
+	var playlistInviteReq PlaylistInviteUri
+	if err := c.ShouldBindUri(&playlistInviteReq); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	err = h.collaboratorsService.RevokeInvite(c, user.Id, playlistInviteReq.PlaylistID, playlistInviteReq.InviteID)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrPlaylistNotFound):
+			utils.NotFoundError(c, err)
+		case errors.Is(err, ErrInviteNotFound):
+			utils.NotFoundError(c, err)
+		case errors.Is(err, ErrPermissionDenied):
+			utils.PermissionDeniedError(c, err)
+		default:
+			utils.InternalError(c, err)
+		}
+
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *CollaboratorsHandlers) GetManyMyInvites(c *gin.Context) {
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.UnauthenticatedError(c, err)
+		return
+	}
+
+	invites, err := h.collaboratorsService.GetManyMyInvites(c, user.Id)
+	if err != nil {
+		utils.InternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, invites)
+}
+
+func (h *CollaboratorsHandlers) AcceptInvite(c *gin.Context) {
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.UnauthenticatedError(c, err)
+		return
+	}
+
+	var inviteReq InviteUri
+	if err := c.ShouldBindUri(&inviteReq); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	collaborator, err := h.collaboratorsService.AcceptInvite(c, user.Id, inviteReq.InviteID)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrInviteNotFound):
+			utils.NotFoundError(c, err)
+		default:
+			utils.InternalError(c, err)
+		}
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, collaborator)
+}
+
+func (h *CollaboratorsHandlers) DeclineInvite(c *gin.Context) {
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.UnauthenticatedError(c, err)
+		return
+	}
+
+	var inviteReq InviteUri
+	if err := c.ShouldBindUri(&inviteReq); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	err = h.collaboratorsService.DeclineInvite(c, user.Id, inviteReq.InviteID)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrInviteNotFound):
+			utils.NotFoundError(c, err)
+		default:
+			utils.InternalError(c, err)
+		}
+
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *CollaboratorsHandlers) AcceptInviteLink(c *gin.Context) {
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.UnauthenticatedError(c, err)
+		return
+	}
+
+	var inviteLinkReq InviteLinkUri
+	if err := c.ShouldBindUri(&inviteLinkReq); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	collaborator, err := h.collaboratorsService.AcceptInviteLink(c, user.Id, inviteLinkReq.Token)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrInviteNotFound):
+			utils.NotFoundError(c, err)
+		case errors.Is(err, ErrPlaylistNotFound):
+			utils.NotFoundError(c, err)
+		case errors.Is(err, ErrInviteExpired):
+			utils.BadRequestError(c, err)
+		case errors.Is(err, ErrAlreadyCollaborator):
+			utils.BadRequestError(c, err)
+		default:
+			utils.InternalError(c, err)
+		}
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, collaborator)
+}
+
+func (h *CollaboratorsHandlers) RegisterHandlers(router *gin.RouterGroup) {
+	collaboratorsRouter := router.Group("/playlist-collaborators")
+	collaboratorsRouter.GET("/invites", h.GetManyMyInvites)
+	collaboratorsRouter.POST("/invites/:inviteId/accept", h.AcceptInvite)
+	collaboratorsRouter.DELETE("/invites/:inviteId", h.DeclineInvite)
+	collaboratorsRouter.POST("/invite-links/:token/accept", h.AcceptInviteLink)
+	collaboratorsRouter.GET("/:playlistId", h.GetMany)
+	collaboratorsRouter.PUT("/:playlistId/users/:userId/role", h.UpdateRole)
+	collaboratorsRouter.DELETE("/:playlistId/users/:userId", h.Remove)
+	collaboratorsRouter.POST("/:playlistId/invites", h.Invite)
+	collaboratorsRouter.POST("/:playlistId/invite-links", h.CreateInviteLink)
+	collaboratorsRouter.GET("/:playlistId/invites", h.GetManyPlaylistInvites)
+	collaboratorsRouter.DELETE("/:playlistId/invites/:inviteId", h.RevokeInvite)
+}
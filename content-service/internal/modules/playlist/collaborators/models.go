@@ -0,0 +1,25 @@
+package collaborators
+
+import (
+	"time"
+
+	"github.com/ocenb/music-go/content-service/internal/modules/playlist"
+)
+
+type CollaboratorModel struct {
+	PlaylistID int64         `json:"playlistId"`
+	UserID     int64         `json:"userId"`
+	Role       playlist.Role `json:"role"`
+	AddedAt    time.Time     `json:"addedAt"`
+}
+
+type InviteModel struct {
+	ID         int64         `json:"id"`
+	PlaylistID int64         `json:"playlistId"`
+	InviterID  int64         `json:"inviterId"`
+	InviteeID  *int64        `json:"inviteeId,omitempty"`
+	Role       playlist.Role `json:"role"`
+	Token      string        `json:"token,omitempty"`
+	CreatedAt  time.Time     `json:"createdAt"`
+	ExpiresAt  *time.Time    `json:"expiresAt,omitempty"`
+}
@@ -0,0 +1,324 @@
+package collaborators
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/ocenb/music-go/content-service/internal/modules/playlist"
+	"github.com/ocenb/music-go/content-service/internal/utils"
+)
+
+type CollaboratorsRepoInterface interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+	GetMany(ctx context.Context, playlistID int64) ([]*CollaboratorModel, error)
+	GetOne(ctx context.Context, playlistID, userID int64) (*CollaboratorModel, error)
+	Add(ctx context.Context, playlistID, userID int64, role playlist.Role) (*CollaboratorModel, error)
+	UpdateRole(ctx context.Context, playlistID, userID int64, role playlist.Role) error
+	Remove(ctx context.Context, playlistID, userID int64) error
+	CreateInvite(ctx context.Context, playlistID, inviterID int64, inviteeID *int64, role playlist.Role, token string, expiresAt *time.Time) (*InviteModel, error)
+	GetInviteByID(ctx context.Context, inviteID int64) (*InviteModel, error)
+	GetInviteByToken(ctx context.Context, token string) (*InviteModel, error)
+	GetManyInvitesForPlaylist(ctx context.Context, playlistID int64) ([]*InviteModel, error)
+	GetManyInvitesForUser(ctx context.Context, userID int64) ([]*InviteModel, error)
+	DeleteInvite(ctx context.Context, inviteID int64) error
+}
+
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type CollaboratorsRepo struct {
+	postgres *sql.DB
+	log      *slog.Logger
+}
+
+func NewCollaboratorsRepo(postgres *sql.DB, log *slog.Logger) CollaboratorsRepoInterface {
+	return &CollaboratorsRepo{postgres: postgres, log: log}
+}
+
+func (r *CollaboratorsRepo) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	return r.postgres.BeginTx(ctx, opts)
+}
+
+func (r *CollaboratorsRepo) db(ctx context.Context) querier {
+	if tx, hasTx := utils.GetTxFromContext(ctx); hasTx {
+		return tx
+	}
+	return r.postgres
+}
+
+func (r *CollaboratorsRepo) GetMany(ctx context.Context, playlistID int64) ([]*CollaboratorModel, error) {
+	query := `
+		SELECT playlist_id, user_id, role, added_at
+		FROM playlist_collaborators
+		WHERE playlist_id = $1
+		ORDER BY added_at ASC
+	`
+
+	rows, err := r.db(ctx).QueryContext(ctx, query, playlistID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			r.log.Error("Failed to close rows", "error", err)
+		}
+	}()
+
+	collaborators := []*CollaboratorModel{}
+	for rows.Next() {
+		var model CollaboratorModel
+		if err := rows.Scan(&model.PlaylistID, &model.UserID, &model.Role, &model.AddedAt); err != nil {
+			return nil, err
+		}
+		collaborators = append(collaborators, &model)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return collaborators, nil
+}
+
+func (r *CollaboratorsRepo) GetOne(ctx context.Context, playlistID, userID int64) (*CollaboratorModel, error) {
+	query := `
+		SELECT playlist_id, user_id, role, added_at
+		FROM playlist_collaborators
+		WHERE playlist_id = $1 AND user_id = $2
+	`
+
+	var model CollaboratorModel
+	err := r.db(ctx).QueryRowContext(ctx, query, playlistID, userID).Scan(
+		&model.PlaylistID,
+		&model.UserID,
+		&model.Role,
+		&model.AddedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model, nil
+}
+
+func (r *CollaboratorsRepo) Add(ctx context.Context, playlistID, userID int64, role playlist.Role) (*CollaboratorModel, error) {
+	query := `
+		INSERT INTO playlist_collaborators (playlist_id, user_id, role, added_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (playlist_id, user_id) DO UPDATE SET role = EXCLUDED.role
+		RETURNING playlist_id, user_id, role, added_at
+	`
+
+	var model CollaboratorModel
+	err := r.db(ctx).QueryRowContext(ctx, query, playlistID, userID, role, time.Now()).Scan(
+		&model.PlaylistID,
+		&model.UserID,
+		&model.Role,
+		&model.AddedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model, nil
+}
+
+func (r *CollaboratorsRepo) UpdateRole(ctx context.Context, playlistID, userID int64, role playlist.Role) error {
+	query := `
+		UPDATE playlist_collaborators
+		SET role = $3
+		WHERE playlist_id = $1 AND user_id = $2
+	`
+
+	result, err := r.db(ctx).ExecContext(ctx, query, playlistID, userID, role)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *CollaboratorsRepo) Remove(ctx context.Context, playlistID, userID int64) error {
+	query := `
+		DELETE FROM playlist_collaborators
+		WHERE playlist_id = $1 AND user_id = $2
+	`
+
+	result, err := r.db(ctx).ExecContext(ctx, query, playlistID, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *CollaboratorsRepo) CreateInvite(ctx context.Context, playlistID, inviterID int64, inviteeID *int64, role playlist.Role, token string, expiresAt *time.Time) (*InviteModel, error) {
+	query := `
+		INSERT INTO playlist_invites (playlist_id, inviter_id, invitee_id, role, token, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7)
+		RETURNING id, playlist_id, inviter_id, invitee_id, role, token, created_at, expires_at
+	`
+
+	var inviteeArg sql.NullInt64
+	if inviteeID != nil {
+		inviteeArg = sql.NullInt64{Int64: *inviteeID, Valid: true}
+	}
+	var expiresArg sql.NullTime
+	if expiresAt != nil {
+		expiresArg = sql.NullTime{Time: *expiresAt, Valid: true}
+	}
+
+	row := r.db(ctx).QueryRowContext(ctx, query, playlistID, inviterID, inviteeArg, role, token, time.Now(), expiresArg)
+
+	return scanInvite(row)
+}
+
+func (r *CollaboratorsRepo) GetInviteByID(ctx context.Context, inviteID int64) (*InviteModel, error) {
+	query := `
+		SELECT id, playlist_id, inviter_id, invitee_id, role, token, created_at, expires_at
+		FROM playlist_invites
+		WHERE id = $1
+	`
+
+	return scanInvite(r.db(ctx).QueryRowContext(ctx, query, inviteID))
+}
+
+func (r *CollaboratorsRepo) GetInviteByToken(ctx context.Context, token string) (*InviteModel, error) {
+	query := `
+		SELECT id, playlist_id, inviter_id, invitee_id, role, token, created_at, expires_at
+		FROM playlist_invites
+		WHERE token = $1
+		FOR SHARE
+	`
+
+	return scanInvite(r.db(ctx).QueryRowContext(ctx, query, token))
+}
+
+func (r *CollaboratorsRepo) GetManyInvitesForPlaylist(ctx context.Context, playlistID int64) ([]*InviteModel, error) {
+	query := `
+		SELECT id, playlist_id, inviter_id, invitee_id, role, token, created_at, expires_at
+		FROM playlist_invites
+		WHERE playlist_id = $1
+		ORDER BY created_at DESC
+	`
+
+	return r.getManyInvites(ctx, query, playlistID)
+}
+
+func (r *CollaboratorsRepo) GetManyInvitesForUser(ctx context.Context, userID int64) ([]*InviteModel, error) {
+	query := `
+		SELECT id, playlist_id, inviter_id, invitee_id, role, token, created_at, expires_at
+		FROM playlist_invites
+		WHERE invitee_id = $1
+		ORDER BY created_at DESC
+	`
+
+	return r.getManyInvites(ctx, query, userID)
+}
+
+func (r *CollaboratorsRepo) DeleteInvite(ctx context.Context, inviteID int64) error {
+	query := `
+		DELETE FROM playlist_invites
+		WHERE id = $1
+	`
+
+	result, err := r.db(ctx).ExecContext(ctx, query, inviteID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *CollaboratorsRepo) getManyInvites(ctx context.Context, query string, args ...any) ([]*InviteModel, error) {
+	rows, err := r.db(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			r.log.Error("Failed to close rows", "error", err)
+		}
+	}()
+
+	invites := []*InviteModel{}
+	for rows.Next() {
+		invite, err := scanInvite(rows)
+		if err != nil {
+			return nil, err
+		}
+		invites = append(invites, invite)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return invites, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanInvite(row rowScanner) (*InviteModel, error) {
+	var model InviteModel
+	var inviteeID sql.NullInt64
+	var token sql.NullString
+	var expiresAt sql.NullTime
+
+	err := row.Scan(
+		&model.ID,
+		&model.PlaylistID,
+		&model.InviterID,
+		&inviteeID,
+		&model.Role,
+		&token,
+		&model.CreatedAt,
+		&expiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if inviteeID.Valid {
+		model.InviteeID = &inviteeID.Int64
+	}
+	model.Token = token.String
+	if expiresAt.Valid {
+		model.ExpiresAt = &expiresAt.Time
+	}
+
+	return &model, nil
+}
@@ -0,0 +1,37 @@
+package collaborators
+
+type PlaylistUri struct {
+	PlaylistID int64 `uri:"playlistId" binding:"required"`
+}
+
+type CollaboratorUri struct {
+	PlaylistID int64 `uri:"playlistId" binding:"required"`
+	UserID     int64 `uri:"userId" binding:"required"`
+}
+
+type PlaylistInviteUri struct {
+	PlaylistID int64 `uri:"playlistId" binding:"required"`
+	InviteID   int64 `uri:"inviteId" binding:"required"`
+}
+
+type InviteUri struct {
+	InviteID int64 `uri:"inviteId" binding:"required"`
+}
+
+type InviteLinkUri struct {
+	Token string `uri:"token" binding:"required"`
+}
+
+type InviteJSON struct {
+	UserID int64  `json:"userId" binding:"required,min=1"`
+	Role   string `json:"role" binding:"required,oneof=editor viewer"`
+}
+
+type InviteLinkJSON struct {
+	Role           string `json:"role" binding:"required,oneof=editor viewer"`
+	ExpiresInHours int    `json:"expiresInHours" binding:"omitempty,min=1"`
+}
+
+type UpdateRoleJSON struct {
+	Role string `json:"role" binding:"required,oneof=editor viewer"`
+}
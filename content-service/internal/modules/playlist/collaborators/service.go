@@ -0,0 +1,312 @@
+package collaborators
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/ocenb/music-go/content-service/internal/clients/userclient"
+	"github.com/ocenb/music-go/content-service/internal/modules/playlist"
+	"github.com/ocenb/music-go/content-service/internal/storage"
+	"github.com/ocenb/music-go/content-service/internal/utils"
+	"github.com/ocenb/music-protos/gen/userservice"
+)
+
+type CollaboratorsServiceInterface interface {
+	GetMany(ctx context.Context, currentUserID, playlistID int64) ([]*CollaboratorModel, error)
+	UpdateRole(ctx context.Context, currentUserID, playlistID, userID int64, role playlist.Role) error
+	Remove(ctx context.Context, currentUserID, playlistID, userID int64) error
+	Invite(ctx context.Context, currentUserID, playlistID, userID int64, role playlist.Role) (*InviteModel, error)
+	CreateInviteLink(ctx context.Context, currentUserID, playlistID int64, role playlist.Role, expiresIn time.Duration) (*InviteModel, error)
+	GetManyPlaylistInvites(ctx context.Context, currentUserID, playlistID int64) ([]*InviteModel, error)
+	RevokeInvite(ctx context.Context, currentUserID, playlistID, inviteID int64) error
+	GetManyMyInvites(ctx context.Context, currentUserID int64) ([]*InviteModel, error)
+	AcceptInvite(ctx context.Context, currentUserID, inviteID int64) (*CollaboratorModel, error)
+	DeclineInvite(ctx context.Context, currentUserID, inviteID int64) error
+	AcceptInviteLink(ctx context.Context, currentUserID int64, token string) (*CollaboratorModel, error)
+}
+
+type CollaboratorsService struct {
+	log               *slog.Logger
+	collaboratorsRepo CollaboratorsRepoInterface
+	playlistRepo      playlist.PlaylistRepoInterface
+	userClient        *userclient.UserServiceClient
+}
+
+func NewCollaboratorsService(
+	log *slog.Logger,
+	collaboratorsRepo CollaboratorsRepoInterface,
+	playlistRepo playlist.PlaylistRepoInterface,
+	userClient *userclient.UserServiceClient,
+) CollaboratorsServiceInterface {
+	return &CollaboratorsService{
+		log:               log,
+		collaboratorsRepo: collaboratorsRepo,
+		playlistRepo:      playlistRepo,
+		userClient:        userClient,
+	}
+}
+
+func (s *CollaboratorsService) GetMany(ctx context.Context, currentUserID, playlistID int64) ([]*CollaboratorModel, error) {
+	role, err := s.getRole(ctx, currentUserID, playlistID)
+	if err != nil {
+		return nil, err
+	}
+	if !role.CanView() {
+		return nil, ErrPermissionDenied
+	}
+
+	return s.collaboratorsRepo.GetMany(ctx, playlistID)
+}
+
+func (s *CollaboratorsService) UpdateRole(ctx context.Context, currentUserID, playlistID, userID int64, role playlist.Role) error {
+	if err := s.checkOwner(ctx, currentUserID, playlistID); err != nil {
+		return err
+	}
+	if userID == currentUserID {
+		return ErrCannotChangeOwnerRole
+	}
+
+	err := s.collaboratorsRepo.UpdateRole(ctx, playlistID, userID, role)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrCollaboratorNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *CollaboratorsService) Remove(ctx context.Context, currentUserID, playlistID, userID int64) error {
+	if userID != currentUserID {
+		if err := s.checkOwner(ctx, currentUserID, playlistID); err != nil {
+			return err
+		}
+	}
+
+	err := s.collaboratorsRepo.Remove(ctx, playlistID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrCollaboratorNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *CollaboratorsService) Invite(ctx context.Context, currentUserID, playlistID, userID int64, role playlist.Role) (*InviteModel, error) {
+	if err := s.checkOwner(ctx, currentUserID, playlistID); err != nil {
+		return nil, err
+	}
+	if userID == currentUserID {
+		return nil, ErrCannotInviteYourself
+	}
+
+	res, err := s.userClient.Client.GetUsersByIds(ctx, &userservice.GetUsersByIdsRequest{
+		UserIds: []int64{userID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Users) == 0 {
+		return nil, ErrUserNotFound
+	}
+
+	_, err = s.collaboratorsRepo.GetOne(ctx, playlistID, userID)
+	if err == nil {
+		return nil, ErrAlreadyCollaborator
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	invites, err := s.collaboratorsRepo.GetManyInvitesForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, invite := range invites {
+		if invite.PlaylistID == playlistID {
+			return nil, ErrAlreadyInvited
+		}
+	}
+
+	return s.collaboratorsRepo.CreateInvite(ctx, playlistID, currentUserID, &userID, role, "", nil)
+}
+
+func (s *CollaboratorsService) CreateInviteLink(ctx context.Context, currentUserID, playlistID int64, role playlist.Role, expiresIn time.Duration) (*InviteModel, error) {
+	if err := s.checkOwner(ctx, currentUserID, playlistID); err != nil {
+		return nil, err
+	}
+
+	token, err := utils.GenerateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	var expiresAt *time.Time
+	if expiresIn > 0 {
+		t := time.Now().Add(expiresIn)
+		expiresAt = &t
+	}
+
+	return s.collaboratorsRepo.CreateInvite(ctx, playlistID, currentUserID, nil, role, token, expiresAt)
+}
+
+func (s *CollaboratorsService) GetManyPlaylistInvites(ctx context.Context, currentUserID, playlistID int64) ([]*InviteModel, error) {
+	if err := s.checkOwner(ctx, currentUserID, playlistID); err != nil {
+		return nil, err
+	}
+
+	return s.collaboratorsRepo.GetManyInvitesForPlaylist(ctx, playlistID)
+}
+
+func (s *CollaboratorsService) RevokeInvite(ctx context.Context, currentUserID, playlistID, inviteID int64) error {
+	if err := s.checkOwner(ctx, currentUserID, playlistID); err != nil {
+		return err
+	}
+
+	invite, err := s.getInvite(ctx, inviteID)
+	if err != nil {
+		return err
+	}
+	if invite.PlaylistID != playlistID {
+		return ErrInviteNotFound
+	}
+
+	return s.deleteInvite(ctx, inviteID)
+}
+
+func (s *CollaboratorsService) GetManyMyInvites(ctx context.Context, currentUserID int64) ([]*InviteModel, error) {
+	return s.collaboratorsRepo.GetManyInvitesForUser(ctx, currentUserID)
+}
+
+func (s *CollaboratorsService) AcceptInvite(ctx context.Context, currentUserID, inviteID int64) (*CollaboratorModel, error) {
+	invite, err := s.getUserInvite(ctx, currentUserID, inviteID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The invite is consumed first, so of concurrent accepts only one gets
+	// past the delete and a failed accept leaves it in place.
+	var collaborator *CollaboratorModel
+	err = storage.WithTransaction(ctx, s.collaboratorsRepo, func(txCtx context.Context) error {
+		if err := s.deleteInvite(txCtx, inviteID); err != nil {
+			return err
+		}
+
+		var err error
+		collaborator, err = s.collaboratorsRepo.Add(txCtx, invite.PlaylistID, currentUserID, invite.Role)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return collaborator, nil
+}
+
+func (s *CollaboratorsService) DeclineInvite(ctx context.Context, currentUserID, inviteID int64) error {
+	if _, err := s.getUserInvite(ctx, currentUserID, inviteID); err != nil {
+		return err
+	}
+
+	return s.deleteInvite(ctx, inviteID)
+}
+
+// AcceptInviteLink keeps the link locked while the collaborator is added, so
+// a link revoked in the meantime cannot be accepted anymore.
+func (s *CollaboratorsService) AcceptInviteLink(ctx context.Context, currentUserID int64, token string) (*CollaboratorModel, error) {
+	var collaborator *CollaboratorModel
+	err := storage.WithTransaction(ctx, s.collaboratorsRepo, func(txCtx context.Context) error {
+		invite, err := s.collaboratorsRepo.GetInviteByToken(txCtx, token)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrInviteNotFound
+			}
+			return err
+		}
+		if invite.ExpiresAt != nil && invite.ExpiresAt.Before(time.Now()) {
+			return ErrInviteExpired
+		}
+
+		role, err := s.getRole(txCtx, currentUserID, invite.PlaylistID)
+		if err != nil {
+			return err
+		}
+		if role != playlist.RoleNone {
+			return ErrAlreadyCollaborator
+		}
+
+		collaborator, err = s.collaboratorsRepo.Add(txCtx, invite.PlaylistID, currentUserID, invite.Role)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return collaborator, nil
+}
+
+func (s *CollaboratorsService) getRole(ctx context.Context, userID, playlistID int64) (playlist.Role, error) {
+	role, err := s.playlistRepo.GetRole(ctx, userID, playlistID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return playlist.RoleNone, ErrPlaylistNotFound
+		}
+		return playlist.RoleNone, err
+	}
+
+	return role, nil
+}
+
+func (s *CollaboratorsService) checkOwner(ctx context.Context, userID, playlistID int64) error {
+	role, err := s.getRole(ctx, userID, playlistID)
+	if err != nil {
+		return err
+	}
+	if role != playlist.RoleOwner {
+		return ErrPermissionDenied
+	}
+
+	return nil
+}
+
+func (s *CollaboratorsService) getInvite(ctx context.Context, inviteID int64) (*InviteModel, error) {
+	invite, err := s.collaboratorsRepo.GetInviteByID(ctx, inviteID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInviteNotFound
+		}
+		return nil, err
+	}
+
+	return invite, nil
+}
+
+func (s *CollaboratorsService) getUserInvite(ctx context.Context, userID, inviteID int64) (*InviteModel, error) {
+	invite, err := s.getInvite(ctx, inviteID)
+	if err != nil {
+		return nil, err
+	}
+	if invite.InviteeID == nil || *invite.InviteeID != userID {
+		return nil, ErrInviteNotFound
+	}
+
+	return invite, nil
+}
+
+func (s *CollaboratorsService) deleteInvite(ctx context.Context, inviteID int64) error {
+	err := s.collaboratorsRepo.DeleteInvite(ctx, inviteID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrInviteNotFound
+		}
+		return err
+	}
+
+	return nil
+}
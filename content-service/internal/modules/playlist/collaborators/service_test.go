@@ -0,0 +1,355 @@
+package collaborators
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ocenb/music-go/content-service/internal/clients/userclient"
+	"github.com/ocenb/music-go/content-service/internal/modules/playlist"
+	"github.com/ocenb/music-protos/gen/userservice"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+const (
+	ownerID    = int64(1)
+	editorID   = int64(2)
+	viewerID   = int64(3)
+	strangerID = int64(4)
+	missingID  = int64(99)
+	playlistID = int64(10)
+)
+
+// txConn only supports transactions, so storage.WithTransaction can run
+// without a database.
+type txConn struct{}
+
+func (txConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("queries are not supported")
+}
+
+func (txConn) Close() error {
+	return nil
+}
+
+func (txConn) Begin() (driver.Tx, error) {
+	return txConn{}, nil
+}
+
+func (txConn) Commit() error {
+	return nil
+}
+
+func (txConn) Rollback() error {
+	return nil
+}
+
+type txConnector struct{}
+
+func (txConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	return txConn{}, nil
+}
+
+func (txConnector) Driver() driver.Driver {
+	return nil
+}
+
+// memoryCollaboratorsRepo keeps the collaborators and invites of one
+// playlist owned by ownerID.
+type memoryCollaboratorsRepo struct {
+	postgres      *sql.DB
+	collaborators map[int64]playlist.Role
+	invites       []*InviteModel
+}
+
+func newMemoryCollaboratorsRepo(t *testing.T) *memoryCollaboratorsRepo {
+	t.Helper()
+
+	postgres := sql.OpenDB(txConnector{})
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	return &memoryCollaboratorsRepo{
+		postgres: postgres,
+		collaborators: map[int64]playlist.Role{
+			editorID: playlist.RoleEditor,
+			viewerID: playlist.RoleViewer,
+		},
+	}
+}
+
+func (r *memoryCollaboratorsRepo) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	return r.postgres.BeginTx(ctx, opts)
+}
+
+func (r *memoryCollaboratorsRepo) GetMany(ctx context.Context, playlistID int64) ([]*CollaboratorModel, error) {
+	collaborators := []*CollaboratorModel{}
+	for userID, role := range r.collaborators {
+		collaborators = append(collaborators, &CollaboratorModel{PlaylistID: playlistID, UserID: userID, Role: role})
+	}
+	return collaborators, nil
+}
+
+func (r *memoryCollaboratorsRepo) GetOne(ctx context.Context, playlistID, userID int64) (*CollaboratorModel, error) {
+	role, ok := r.collaborators[userID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return &CollaboratorModel{PlaylistID: playlistID, UserID: userID, Role: role}, nil
+}
+
+func (r *memoryCollaboratorsRepo) Add(ctx context.Context, playlistID, userID int64, role playlist.Role) (*CollaboratorModel, error) {
+	r.collaborators[userID] = role
+	return &CollaboratorModel{PlaylistID: playlistID, UserID: userID, Role: role, AddedAt: time.Now()}, nil
+}
+
+func (r *memoryCollaboratorsRepo) UpdateRole(ctx context.Context, playlistID, userID int64, role playlist.Role) error {
+	if _, ok := r.collaborators[userID]; !ok {
+		return sql.ErrNoRows
+	}
+	r.collaborators[userID] = role
+	return nil
+}
+
+func (r *memoryCollaboratorsRepo) Remove(ctx context.Context, playlistID, userID int64) error {
+	if _, ok := r.collaborators[userID]; !ok {
+		return sql.ErrNoRows
+	}
+	delete(r.collaborators, userID)
+	return nil
+}
+
+func (r *memoryCollaboratorsRepo) CreateInvite(ctx context.Context, playlistID, inviterID int64, inviteeID *int64, role playlist.Role, token string, expiresAt *time.Time) (*InviteModel, error) {
+	invite := &InviteModel{
+		ID:         int64(len(r.invites) + 1),
+		PlaylistID: playlistID,
+		InviterID:  inviterID,
+		InviteeID:  inviteeID,
+		Role:       role,
+		Token:      token,
+		CreatedAt:  time.Now(),
+		ExpiresAt:  expiresAt,
+	}
+	r.invites = append(r.invites, invite)
+	return invite, nil
+}
+
+func (r *memoryCollaboratorsRepo) GetInviteByID(ctx context.Context, inviteID int64) (*InviteModel, error) {
+	for _, invite := range r.invites {
+		if invite.ID == inviteID {
+			return invite, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (r *memoryCollaboratorsRepo) GetInviteByToken(ctx context.Context, token string) (*InviteModel, error) {
+	for _, invite := range r.invites {
+		if invite.Token != "" && invite.Token == token {
+			return invite, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (r *memoryCollaboratorsRepo) GetManyInvitesForPlaylist(ctx context.Context, playlistID int64) ([]*InviteModel, error) {
+	return r.invites, nil
+}
+
+func (r *memoryCollaboratorsRepo) GetManyInvitesForUser(ctx context.Context, userID int64) ([]*InviteModel, error) {
+	invites := []*InviteModel{}
+	for _, invite := range r.invites {
+		if invite.InviteeID != nil && *invite.InviteeID == userID {
+			invites = append(invites, invite)
+		}
+	}
+	return invites, nil
+}
+
+func (r *memoryCollaboratorsRepo) DeleteInvite(ctx context.Context, inviteID int64) error {
+	for i, invite := range r.invites {
+		if invite.ID == inviteID {
+			r.invites = slices.Delete(r.invites, i, i+1)
+			return nil
+		}
+	}
+	return sql.ErrNoRows
+}
+
+type memoryPlaylistRepo struct {
+	playlist.PlaylistRepoInterface
+	collaborators *memoryCollaboratorsRepo
+}
+
+func (r *memoryPlaylistRepo) GetRole(ctx context.Context, userID, id int64) (playlist.Role, error) {
+	if id != playlistID {
+		return playlist.RoleNone, sql.ErrNoRows
+	}
+	if userID == ownerID {
+		return playlist.RoleOwner, nil
+	}
+	return r.collaborators.collaborators[userID], nil
+}
+
+type fakeUserServiceClient struct {
+	userservice.UserServiceClient
+}
+
+func (c *fakeUserServiceClient) GetUsersByIds(ctx context.Context, in *userservice.GetUsersByIdsRequest, opts ...grpc.CallOption) (*userservice.GetUsersByIdsResponse, error) {
+	users := []*userservice.UserPublicModel{}
+	for _, id := range in.UserIds {
+		if id != missingID {
+			users = append(users, &userservice.UserPublicModel{Id: id})
+		}
+	}
+	return &userservice.GetUsersByIdsResponse{Users: users}, nil
+}
+
+func newTestService(t *testing.T) (CollaboratorsServiceInterface, *memoryCollaboratorsRepo) {
+	t.Helper()
+
+	repo := newMemoryCollaboratorsRepo(t)
+	service := NewCollaboratorsService(
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		repo,
+		&memoryPlaylistRepo{collaborators: repo},
+		&userclient.UserServiceClient{Client: &fakeUserServiceClient{}},
+	)
+
+	return service, repo
+}
+
+func TestRolePermissions(t *testing.T) {
+	tests := []struct {
+		role    playlist.Role
+		canView bool
+		canEdit bool
+	}{
+		{role: playlist.RoleOwner, canView: true, canEdit: true},
+		{role: playlist.RoleEditor, canView: true, canEdit: true},
+		{role: playlist.RoleViewer, canView: true, canEdit: false},
+		{role: playlist.RoleNone, canView: false, canEdit: false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.canView, tt.role.CanView(), "%q can view", tt.role)
+		assert.Equal(t, tt.canEdit, tt.role.CanEdit(), "%q can edit", tt.role)
+	}
+}
+
+func TestOnlyOwnerManagesCollaborators(t *testing.T) {
+	ctx := context.Background()
+	service, _ := newTestService(t)
+
+	for _, userID := range []int64{editorID, viewerID, strangerID} {
+		_, err := service.Invite(ctx, userID, playlistID, strangerID, playlist.RoleViewer)
+		assert.ErrorIs(t, err, ErrPermissionDenied)
+
+		err = service.UpdateRole(ctx, userID, playlistID, viewerID, playlist.RoleEditor)
+		assert.ErrorIs(t, err, ErrPermissionDenied)
+
+		_, err = service.CreateInviteLink(ctx, userID, playlistID, playlist.RoleViewer, time.Hour)
+		assert.ErrorIs(t, err, ErrPermissionDenied)
+	}
+
+	err := service.UpdateRole(ctx, ownerID, playlistID, ownerID, playlist.RoleViewer)
+	assert.ErrorIs(t, err, ErrCannotChangeOwnerRole)
+
+	require.NoError(t, service.UpdateRole(ctx, ownerID, playlistID, viewerID, playlist.RoleEditor))
+}
+
+func TestGetManyRequiresViewAccess(t *testing.T) {
+	ctx := context.Background()
+	service, _ := newTestService(t)
+
+	collaborators, err := service.GetMany(ctx, viewerID, playlistID)
+	require.NoError(t, err)
+	assert.Len(t, collaborators, 2)
+
+	_, err = service.GetMany(ctx, strangerID, playlistID)
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+
+	_, err = service.GetMany(ctx, ownerID, playlistID+1)
+	assert.ErrorIs(t, err, ErrPlaylistNotFound)
+}
+
+func TestRemoveCollaborator(t *testing.T) {
+	ctx := context.Background()
+	service, repo := newTestService(t)
+
+	err := service.Remove(ctx, editorID, playlistID, viewerID)
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+
+	require.NoError(t, service.Remove(ctx, viewerID, playlistID, viewerID), "collaborators can leave")
+	require.NoError(t, service.Remove(ctx, ownerID, playlistID, editorID))
+	assert.Empty(t, repo.collaborators)
+
+	err = service.Remove(ctx, ownerID, playlistID, strangerID)
+	assert.ErrorIs(t, err, ErrCollaboratorNotFound)
+}
+
+func TestInvite(t *testing.T) {
+	ctx := context.Background()
+	service, repo := newTestService(t)
+
+	_, err := service.Invite(ctx, ownerID, playlistID, ownerID, playlist.RoleEditor)
+	assert.ErrorIs(t, err, ErrCannotInviteYourself)
+
+	_, err = service.Invite(ctx, ownerID, playlistID, missingID, playlist.RoleEditor)
+	assert.ErrorIs(t, err, ErrUserNotFound)
+
+	_, err = service.Invite(ctx, ownerID, playlistID, viewerID, playlist.RoleEditor)
+	assert.ErrorIs(t, err, ErrAlreadyCollaborator)
+
+	invite, err := service.Invite(ctx, ownerID, playlistID, strangerID, playlist.RoleEditor)
+	require.NoError(t, err)
+
+	_, err = service.Invite(ctx, ownerID, playlistID, strangerID, playlist.RoleViewer)
+	assert.ErrorIs(t, err, ErrAlreadyInvited)
+
+	_, err = service.AcceptInvite(ctx, viewerID, invite.ID)
+	assert.ErrorIs(t, err, ErrInviteNotFound, "only the invitee accepts")
+
+	collaborator, err := service.AcceptInvite(ctx, strangerID, invite.ID)
+	require.NoError(t, err)
+	assert.Equal(t, playlist.RoleEditor, collaborator.Role)
+	assert.Equal(t, playlist.RoleEditor, repo.collaborators[strangerID])
+	assert.Empty(t, repo.invites, "the invite is consumed")
+
+	_, err = service.AcceptInvite(ctx, strangerID, invite.ID)
+	assert.ErrorIs(t, err, ErrInviteNotFound)
+}
+
+func TestAcceptInviteLink(t *testing.T) {
+	ctx := context.Background()
+	service, repo := newTestService(t)
+
+	link, err := service.CreateInviteLink(ctx, ownerID, playlistID, playlist.RoleViewer, time.Hour)
+	require.NoError(t, err)
+	require.NotEmpty(t, link.Token)
+
+	_, err = service.AcceptInviteLink(ctx, editorID, link.Token)
+	assert.ErrorIs(t, err, ErrAlreadyCollaborator)
+
+	_, err = service.AcceptInviteLink(ctx, strangerID, "unknown")
+	assert.ErrorIs(t, err, ErrInviteNotFound)
+
+	collaborator, err := service.AcceptInviteLink(ctx, strangerID, link.Token)
+	require.NoError(t, err)
+	assert.Equal(t, playlist.RoleViewer, collaborator.Role)
+	assert.Len(t, repo.invites, 1, "links can be used more than once")
+
+	expired := time.Now().Add(-time.Minute)
+	link.ExpiresAt = &expired
+	_, err = service.AcceptInviteLink(ctx, missingID, link.Token)
+	assert.ErrorIs(t, err, ErrInviteExpired)
+}
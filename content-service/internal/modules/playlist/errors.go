@@ -7,13 +7,18 @@ import (
 )
 
 var (
-	ErrPlaylistNotFound      = errors.New("playlist not found")
-	ErrPlaylistAlreadyExists = errors.New("playlist with this title already exists")
-	ErrChangeableIDExists    = errors.New("playlist with this changeableID already exists")
-	ErrPermissionDenied      = errors.New("permission denied")
-	ErrPlaylistIsYours       = errors.New("playlist is yours")
-	ErrPlaylistAlreadySaved  = errors.New("playlist is already saved")
-	ErrPlaylistIsNotSaved    = errors.New("playlist is not saved")
+	ErrPlaylistNotFound        = errors.New("playlist not found")
+	ErrPlaylistAlreadyExists   = errors.New("playlist with this title already exists")
+	ErrChangeableIDExists      = errors.New("playlist with this changeableID already exists")
+	ErrPermissionDenied        = errors.New("permission denied")
+	ErrPlaylistIsYours         = errors.New("playlist is yours")
+	ErrPlaylistAlreadySaved    = errors.New("playlist is already saved")
+	ErrPlaylistIsNotSaved      = errors.New("playlist is not saved")
+	ErrPlaylistAlreadyReposted = errors.New("playlist is already reposted")
+	ErrPlaylistIsNotReposted   = errors.New("playlist is not reposted")
+	ErrPlaylistIsNotPublic     = errors.New("playlist is not public")
+	ErrPlaylistIsNotSmart      = errors.New("playlist is not smart")
+	ErrInvalidRules            = errors.New("invalid playlist rules")
 )
 
 var BadRequestErrors = []error{
@@ -22,4 +27,8 @@ var BadRequestErrors = []error{
 	file.ErrInvalidImageFormat,
 	ErrPlaylistIsYours,
 	ErrPlaylistAlreadySaved,
+	ErrPlaylistAlreadyReposted,
+	ErrPlaylistIsNotPublic,
+	ErrPlaylistIsNotSmart,
+	ErrInvalidRules,
 }
@@ -0,0 +1,11 @@
+package folders
+
+import "errors"
+
+var (
+	ErrFolderNotFound       = errors.New("folder not found")
+	ErrParentFolderNotFound = errors.New("parent folder not found")
+	ErrPlaylistNotFound     = errors.New("playlist not found")
+	ErrPlaylistNotInLibrary = errors.New("playlist is not in your library")
+	ErrCannotMoveIntoItself = errors.New("folder cannot be moved into itself or its subfolder")
+)
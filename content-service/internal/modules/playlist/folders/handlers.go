@@ -0,0 +1,222 @@
+package folders
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ocenb/music-go/content-service/internal/utils"
+)
+
+type FoldersHandlersInterface interface {
+	GetLibrary(c *gin.Context)
+	Create(c *gin.Context)
+	ChangeTitle(c *gin.Context)
+	Move(c *gin.Context)
+	Delete(c *gin.Context)
+	PlacePlaylist(c *gin.Context)
+	RegisterHandlers(router *gin.RouterGroup)
+}
+
+type FoldersHandlers struct {
+	foldersService FoldersServiceInterface
+}
+
+func NewHandlers(foldersService FoldersServiceInterface) FoldersHandlersInterface {
+	return &FoldersHandlers{
+		foldersService: foldersService,
+	}
+}
+
+func (h *FoldersHandlers) GetLibrary(c *gin.Context) {
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.UnauthenticatedError(c, err)
+		return
+	}
+
+	library, err := h.foldersService.GetLibrary(c, user.Id)
+	if err != nil {
+		utils.InternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, library)
+}
+
+func (h *FoldersHandlers) Create(c *gin.Context) {
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.UnauthenticatedError(c, err)
+		return
+	}
+
+	var createReq CreateJSON
+	if err := c.ShouldBindJSON(&createReq); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	folder, err := h.foldersService.Create(c, user.Id, createReq.ParentID, createReq.Title, createReq.Position)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrParentFolderNotFound):
+			utils.NotFoundError(c, err)
+		default:
+			utils.InternalError(c, err)
+		}
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, folder)
+}
+
+func (h *FoldersHandlers) ChangeTitle(c *gin.Context) {
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.UnauthenticatedError(c, err)
+		return
+	}
+
+	var folderReq FolderUri
+	if err := c.ShouldBindUri(&folderReq); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	var changeTitleReq ChangeTitleJSON
+	if err := c.ShouldBindJSON(&changeTitleReq); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	err = h.foldersService.ChangeTitle(c, user.Id, folderReq.FolderID, changeTitleReq.Title)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrFolderNotFound):
+			utils.NotFoundError(c, err)
+		default:
+			utils.InternalError(c, err)
+		}
+
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *FoldersHandlers) Move(c *gin.Context) {
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.UnauthenticatedError(c, err)
+		return
+	}
+
+	var folderReq FolderUri
+	if err := c.ShouldBindUri(&folderReq); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	var moveReq MoveJSON
+	if err := c.ShouldBindJSON(&moveReq); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	err = h.foldersService.Move(c, user.Id, folderReq.FolderID, moveReq.ParentID, moveReq.Position)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrFolderNotFound):
+			utils.NotFoundError(c, err)
+		case errors.Is(err, ErrParentFolderNotFound):
+			utils.NotFoundError(c, err)
+		case errors.Is(err, ErrCannotMoveIntoItself):
+			utils.BadRequestError(c, err)
+		default:
+			utils.InternalError(c, err)
+		}
+
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *FoldersHandlers) Delete(c *gin.Context) {
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.UnauthenticatedError(c, err)
+		return
+	}
+
+	var folderReq FolderUri
+	if err := c.ShouldBindUri(&folderReq); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	err = h.foldersService.Delete(c, user.Id, folderReq.FolderID)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrFolderNotFound):
+			utils.NotFoundError(c, err)
+		default:
+			utils.InternalError(c, err)
+		}
+
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *FoldersHandlers) PlacePlaylist(c *gin.Context) {
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.UnauthenticatedError(c, err)
+		return
+	}
+
+	var playlistReq PlaylistUri
+	if err := c.ShouldBindUri(&playlistReq); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	var placeReq PlaceJSON
+	if err := c.ShouldBindJSON(&placeReq); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	err = h.foldersService.PlacePlaylist(c, user.Id, playlistReq.PlaylistID, placeReq.FolderID, placeReq.Position)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrPlaylistNotFound):
+			utils.NotFoundError(c, err)
+		case errors.Is(err, ErrFolderNotFound):
+			utils.NotFoundError(c, err)
+		case errors.Is(err, ErrPlaylistNotInLibrary):
+			utils.BadRequestError(c, err)
+		default:
+			utils.InternalError(c, err)
+		}
+
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *FoldersHandlers) RegisterHandlers(router *gin.RouterGroup) {
+	router.GET("/library", h.GetLibrary)
+
+	foldersRouter := router.Group("/playlist-folders")
+	foldersRouter.POST("", h.Create)
+	foldersRouter.PATCH("/:folderId/title", h.ChangeTitle)
+	foldersRouter.PUT("/:folderId/position", h.Move)
+	foldersRouter.DELETE("/:folderId", h.Delete)
+	foldersRouter.PUT("/playlists/:playlistId", h.PlacePlaylist)
+}
@@ -0,0 +1,36 @@
+package folders
+
+import (
+	"time"
+
+	"github.com/ocenb/music-go/content-service/internal/modules/playlist"
+)
+
+type FolderModel struct {
+	ID        int64     `json:"id"`
+	UserID    int64     `json:"userId"`
+	ParentID  *int64    `json:"parentId"`
+	Title     string    `json:"title"`
+	Position  int       `json:"position"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type FolderItemModel struct {
+	UserID     int64     `json:"userId"`
+	PlaylistID int64     `json:"playlistId"`
+	FolderID   int64     `json:"folderId"`
+	Position   int       `json:"position"`
+	AddedAt    time.Time `json:"addedAt"`
+}
+
+type LibraryFolderModel struct {
+	FolderModel
+	Folders   []*LibraryFolderModel              `json:"folders"`
+	Playlists []*playlist.PlaylistWithSavedModel `json:"playlists"`
+}
+
+type LibraryModel struct {
+	Folders   []*LibraryFolderModel              `json:"folders"`
+	Playlists []*playlist.PlaylistWithSavedModel `json:"playlists"`
+}
@@ -0,0 +1,294 @@
+package folders
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/ocenb/music-go/content-service/internal/utils"
+)
+
+type FoldersRepoInterface interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+	GetMany(ctx context.Context, userID int64) ([]*FolderModel, error)
+	GetOne(ctx context.Context, userID, folderID int64) (*FolderModel, error)
+	Create(ctx context.Context, userID int64, parentID *int64, title string, position int) (*FolderModel, error)
+	ChangeTitle(ctx context.Context, folderID int64, title string) error
+	UpdatePosition(ctx context.Context, folderID int64, parentID *int64, position int) error
+	Delete(ctx context.Context, folderID int64) error
+	CountFolders(ctx context.Context, userID int64, parentID *int64, excludeFolderID int64) (int, error)
+	ShiftFolders(ctx context.Context, userID int64, parentID *int64, from, delta int) error
+	MoveChildFolders(ctx context.Context, folderID int64, parentID *int64, offset int) error
+	GetItems(ctx context.Context, userID int64) ([]*FolderItemModel, error)
+	GetItem(ctx context.Context, userID, playlistID int64) (*FolderItemModel, error)
+	SetItem(ctx context.Context, userID, playlistID, folderID int64, position int) error
+	RemoveItem(ctx context.Context, userID, playlistID int64) error
+	CountItems(ctx context.Context, userID, folderID, excludePlaylistID int64) (int, error)
+	ShiftItems(ctx context.Context, userID, folderID int64, from, delta int) error
+	MoveChildItems(ctx context.Context, folderID, parentID int64, offset int) error
+}
+
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type FoldersRepo struct {
+	postgres *sql.DB
+	log      *slog.Logger
+}
+
+func NewFoldersRepo(postgres *sql.DB, log *slog.Logger) FoldersRepoInterface {
+	return &FoldersRepo{postgres: postgres, log: log}
+}
+
+func (r *FoldersRepo) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	return r.postgres.BeginTx(ctx, opts)
+}
+
+func (r *FoldersRepo) db(ctx context.Context) querier {
+	if tx, hasTx := utils.GetTxFromContext(ctx); hasTx {
+		return tx
+	}
+	return r.postgres
+}
+
+func (r *FoldersRepo) GetMany(ctx context.Context, userID int64) ([]*FolderModel, error) {
+	query := `
+		SELECT id, user_id, parent_id, title, position, created_at, updated_at
+		FROM playlist_folders
+		WHERE user_id = $1
+		ORDER BY parent_id NULLS FIRST, position ASC
+	`
+
+	rows, err := r.db(ctx).QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			r.log.Error("Failed to close rows", "error", err)
+		}
+	}()
+
+	folders := []*FolderModel{}
+	for rows.Next() {
+		folder, err := scanFolder(rows)
+		if err != nil {
+			return nil, err
+		}
+		folders = append(folders, folder)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return folders, nil
+}
+
+func (r *FoldersRepo) GetOne(ctx context.Context, userID, folderID int64) (*FolderModel, error) {
+	query := `
+		SELECT id, user_id, parent_id, title, position, created_at, updated_at
+		FROM playlist_folders
+		WHERE id = $1 AND user_id = $2
+	`
+
+	return scanFolder(r.db(ctx).QueryRowContext(ctx, query, folderID, userID))
+}
+
+func (r *FoldersRepo) Create(ctx context.Context, userID int64, parentID *int64, title string, position int) (*FolderModel, error) {
+	query := `
+		INSERT INTO playlist_folders (user_id, parent_id, title, position)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, user_id, parent_id, title, position, created_at, updated_at
+	`
+
+	return scanFolder(r.db(ctx).QueryRowContext(ctx, query, userID, parentID, title, position))
+}
+
+func (r *FoldersRepo) ChangeTitle(ctx context.Context, folderID int64, title string) error {
+	query := `
+		UPDATE playlist_folders
+		SET title = $1
+		WHERE id = $2
+	`
+
+	_, err := r.db(ctx).ExecContext(ctx, query, title, folderID)
+	return err
+}
+
+func (r *FoldersRepo) UpdatePosition(ctx context.Context, folderID int64, parentID *int64, position int) error {
+	query := `
+		UPDATE playlist_folders
+		SET parent_id = $1, position = $2
+		WHERE id = $3
+	`
+
+	_, err := r.db(ctx).ExecContext(ctx, query, parentID, position, folderID)
+	return err
+}
+
+func (r *FoldersRepo) Delete(ctx context.Context, folderID int64) error {
+	query := `DELETE FROM playlist_folders WHERE id = $1`
+
+	_, err := r.db(ctx).ExecContext(ctx, query, folderID)
+	return err
+}
+
+func (r *FoldersRepo) CountFolders(ctx context.Context, userID int64, parentID *int64, excludeFolderID int64) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM playlist_folders
+		WHERE user_id = $1 AND parent_id IS NOT DISTINCT FROM $2 AND id <> $3
+	`
+
+	var count int
+	err := r.db(ctx).QueryRowContext(ctx, query, userID, parentID, excludeFolderID).Scan(&count)
+	return count, err
+}
+
+func (r *FoldersRepo) ShiftFolders(ctx context.Context, userID int64, parentID *int64, from, delta int) error {
+	query := `
+		UPDATE playlist_folders
+		SET position = position + $1
+		WHERE user_id = $2 AND parent_id IS NOT DISTINCT FROM $3 AND position >= $4
+	`
+
+	_, err := r.db(ctx).ExecContext(ctx, query, delta, userID, parentID, from)
+	return err
+}
+
+func (r *FoldersRepo) MoveChildFolders(ctx context.Context, folderID int64, parentID *int64, offset int) error {
+	query := `
+		UPDATE playlist_folders
+		SET parent_id = $1, position = position + $2
+		WHERE parent_id = $3
+	`
+
+	_, err := r.db(ctx).ExecContext(ctx, query, parentID, offset, folderID)
+	return err
+}
+
+func (r *FoldersRepo) GetItems(ctx context.Context, userID int64) ([]*FolderItemModel, error) {
+	query := `
+		SELECT user_id, playlist_id, folder_id, position, added_at
+		FROM playlist_folder_items
+		WHERE user_id = $1
+		ORDER BY folder_id, position ASC
+	`
+
+	rows, err := r.db(ctx).QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			r.log.Error("Failed to close rows", "error", err)
+		}
+	}()
+
+	items := []*FolderItemModel{}
+	for rows.Next() {
+		var item FolderItemModel
+		if err := rows.Scan(&item.UserID, &item.PlaylistID, &item.FolderID, &item.Position, &item.AddedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, &item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (r *FoldersRepo) GetItem(ctx context.Context, userID, playlistID int64) (*FolderItemModel, error) {
+	query := `
+		SELECT user_id, playlist_id, folder_id, position, added_at
+		FROM playlist_folder_items
+		WHERE user_id = $1 AND playlist_id = $2
+	`
+
+	var item FolderItemModel
+	err := r.db(ctx).QueryRowContext(ctx, query, userID, playlistID).Scan(&item.UserID, &item.PlaylistID, &item.FolderID, &item.Position, &item.AddedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+func (r *FoldersRepo) SetItem(ctx context.Context, userID, playlistID, folderID int64, position int) error {
+	query := `
+		INSERT INTO playlist_folder_items (user_id, playlist_id, folder_id, position)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, playlist_id) DO UPDATE SET folder_id = EXCLUDED.folder_id, position = EXCLUDED.position
+	`
+
+	_, err := r.db(ctx).ExecContext(ctx, query, userID, playlistID, folderID, position)
+	return err
+}
+
+func (r *FoldersRepo) RemoveItem(ctx context.Context, userID, playlistID int64) error {
+	query := `DELETE FROM playlist_folder_items WHERE user_id = $1 AND playlist_id = $2`
+
+	_, err := r.db(ctx).ExecContext(ctx, query, userID, playlistID)
+	return err
+}
+
+func (r *FoldersRepo) CountItems(ctx context.Context, userID, folderID, excludePlaylistID int64) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM playlist_folder_items
+		WHERE user_id = $1 AND folder_id = $2 AND playlist_id <> $3
+	`
+
+	var count int
+	err := r.db(ctx).QueryRowContext(ctx, query, userID, folderID, excludePlaylistID).Scan(&count)
+	return count, err
+}
+
+func (r *FoldersRepo) ShiftItems(ctx context.Context, userID, folderID int64, from, delta int) error {
+	query := `
+		UPDATE playlist_folder_items
+		SET position = position + $1
+		WHERE user_id = $2 AND folder_id = $3 AND position >= $4
+	`
+
+	_, err := r.db(ctx).ExecContext(ctx, query, delta, userID, folderID, from)
+	return err
+}
+
+func (r *FoldersRepo) MoveChildItems(ctx context.Context, folderID, parentID int64, offset int) error {
+	query := `
+		UPDATE playlist_folder_items
+		SET folder_id = $1, position = position + $2
+		WHERE folder_id = $3
+	`
+
+	_, err := r.db(ctx).ExecContext(ctx, query, parentID, offset, folderID)
+	return err
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanFolder(row rowScanner) (*FolderModel, error) {
+	var folder FolderModel
+	var parentID sql.NullInt64
+
+	err := row.Scan(&folder.ID, &folder.UserID, &parentID, &folder.Title, &folder.Position, &folder.CreatedAt, &folder.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if parentID.Valid {
+		folder.ParentID = &parentID.Int64
+	}
+
+	return &folder, nil
+}
@@ -0,0 +1,29 @@
+package folders
+
+type FolderUri struct {
+	FolderID int64 `uri:"folderId" binding:"required"`
+}
+
+type PlaylistUri struct {
+	PlaylistID int64 `uri:"playlistId" binding:"required"`
+}
+
+type CreateJSON struct {
+	Title    string `json:"title" binding:"required,min=1,max=50"`
+	ParentID *int64 `json:"parentId" binding:"omitempty,min=1"`
+	Position int    `json:"position" binding:"omitempty,min=1"`
+}
+
+type ChangeTitleJSON struct {
+	Title string `json:"title" binding:"required,min=1,max=50"`
+}
+
+type MoveJSON struct {
+	ParentID *int64 `json:"parentId" binding:"omitempty,min=1"`
+	Position int    `json:"position" binding:"omitempty,min=1"`
+}
+
+type PlaceJSON struct {
+	FolderID *int64 `json:"folderId" binding:"omitempty,min=1"`
+	Position int    `json:"position" binding:"omitempty,min=1"`
+}
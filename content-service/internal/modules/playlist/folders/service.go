@@ -0,0 +1,313 @@
+package folders
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+
+	"github.com/ocenb/music-go/content-service/internal/modules/playlist"
+	"github.com/ocenb/music-go/content-service/internal/storage"
+)
+
+const maxLibraryPlaylists = 10000
+
+type FoldersServiceInterface interface {
+	GetLibrary(ctx context.Context, userID int64) (*LibraryModel, error)
+	Create(ctx context.Context, userID int64, parentID *int64, title string, position int) (*FolderModel, error)
+	ChangeTitle(ctx context.Context, userID, folderID int64, title string) error
+	Move(ctx context.Context, userID, folderID int64, parentID *int64, position int) error
+	Delete(ctx context.Context, userID, folderID int64) error
+	PlacePlaylist(ctx context.Context, userID, playlistID int64, folderID *int64, position int) error
+}
+
+type FoldersService struct {
+	log          *slog.Logger
+	foldersRepo  FoldersRepoInterface
+	playlistRepo playlist.PlaylistRepoInterface
+}
+
+func NewFoldersService(
+	log *slog.Logger,
+	foldersRepo FoldersRepoInterface,
+	playlistRepo playlist.PlaylistRepoInterface,
+) FoldersServiceInterface {
+	return &FoldersService{
+		log:          log,
+		foldersRepo:  foldersRepo,
+		playlistRepo: playlistRepo,
+	}
+}
+
+func (s *FoldersService) GetLibrary(ctx context.Context, userID int64) (*LibraryModel, error) {
+	folders, err := s.foldersRepo.GetMany(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.foldersRepo.GetItems(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	playlists, err := s.playlistRepo.GetManyWithSaved(ctx, userID, maxLibraryPlaylists, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildLibrary(folders, items, playlists), nil
+}
+
+func (s *FoldersService) Create(ctx context.Context, userID int64, parentID *int64, title string, position int) (*FolderModel, error) {
+	var folder *FolderModel
+
+	err := storage.WithTransaction(ctx, s.foldersRepo, func(txCtx context.Context) error {
+		if parentID != nil {
+			if _, err := s.getFolder(txCtx, userID, *parentID); err != nil {
+				if errors.Is(err, ErrFolderNotFound) {
+					return ErrParentFolderNotFound
+				}
+				return err
+			}
+		}
+
+		count, err := s.foldersRepo.CountFolders(txCtx, userID, parentID, 0)
+		if err != nil {
+			return err
+		}
+		position = clampPosition(position, count)
+
+		if err := s.foldersRepo.ShiftFolders(txCtx, userID, parentID, position, 1); err != nil {
+			return err
+		}
+
+		folder, err = s.foldersRepo.Create(txCtx, userID, parentID, title, position)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return folder, nil
+}
+
+func (s *FoldersService) ChangeTitle(ctx context.Context, userID, folderID int64, title string) error {
+	if _, err := s.getFolder(ctx, userID, folderID); err != nil {
+		return err
+	}
+
+	return s.foldersRepo.ChangeTitle(ctx, folderID, title)
+}
+
+func (s *FoldersService) Move(ctx context.Context, userID, folderID int64, parentID *int64, position int) error {
+	return storage.WithTransaction(ctx, s.foldersRepo, func(txCtx context.Context) error {
+		folder, err := s.getFolder(txCtx, userID, folderID)
+		if err != nil {
+			return err
+		}
+
+		if parentID != nil {
+			folders, err := s.foldersRepo.GetMany(txCtx, userID)
+			if err != nil {
+				return err
+			}
+			if !containsFolder(folders, *parentID) {
+				return ErrParentFolderNotFound
+			}
+			if isDescendant(folders, *parentID, folderID) {
+				return ErrCannotMoveIntoItself
+			}
+		}
+
+		if err := s.foldersRepo.ShiftFolders(txCtx, userID, folder.ParentID, folder.Position+1, -1); err != nil {
+			return err
+		}
+
+		count, err := s.foldersRepo.CountFolders(txCtx, userID, parentID, folderID)
+		if err != nil {
+			return err
+		}
+		position = clampPosition(position, count)
+
+		if err := s.foldersRepo.ShiftFolders(txCtx, userID, parentID, position, 1); err != nil {
+			return err
+		}
+
+		return s.foldersRepo.UpdatePosition(txCtx, folderID, parentID, position)
+	})
+}
+
+func (s *FoldersService) Delete(ctx context.Context, userID, folderID int64) error {
+	return storage.WithTransaction(ctx, s.foldersRepo, func(txCtx context.Context) error {
+		folder, err := s.getFolder(txCtx, userID, folderID)
+		if err != nil {
+			return err
+		}
+
+		siblingsCount, err := s.foldersRepo.CountFolders(txCtx, userID, folder.ParentID, folderID)
+		if err != nil {
+			return err
+		}
+		if err := s.foldersRepo.ShiftFolders(txCtx, userID, folder.ParentID, folder.Position+1, -1); err != nil {
+			return err
+		}
+		if err := s.foldersRepo.MoveChildFolders(txCtx, folderID, folder.ParentID, siblingsCount); err != nil {
+			return err
+		}
+
+		if folder.ParentID != nil {
+			itemsCount, err := s.foldersRepo.CountItems(txCtx, userID, *folder.ParentID, 0)
+			if err != nil {
+				return err
+			}
+			if err := s.foldersRepo.MoveChildItems(txCtx, folderID, *folder.ParentID, itemsCount); err != nil {
+				return err
+			}
+		}
+
+		return s.foldersRepo.Delete(txCtx, folderID)
+	})
+}
+
+func (s *FoldersService) PlacePlaylist(ctx context.Context, userID, playlistID int64, folderID *int64, position int) error {
+	existingPlaylist, err := s.playlistRepo.GetByID(ctx, playlistID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrPlaylistNotFound
+		}
+		return err
+	}
+	if existingPlaylist.UserID != userID && !existingPlaylist.IsSaved {
+		return ErrPlaylistNotInLibrary
+	}
+
+	return storage.WithTransaction(ctx, s.foldersRepo, func(txCtx context.Context) error {
+		if folderID != nil {
+			if _, err := s.getFolder(txCtx, userID, *folderID); err != nil {
+				return err
+			}
+		}
+
+		item, err := s.foldersRepo.GetItem(txCtx, userID, playlistID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		if item != nil {
+			if err := s.foldersRepo.ShiftItems(txCtx, userID, item.FolderID, item.Position+1, -1); err != nil {
+				return err
+			}
+		}
+
+		if folderID == nil {
+			return s.foldersRepo.RemoveItem(txCtx, userID, playlistID)
+		}
+
+		count, err := s.foldersRepo.CountItems(txCtx, userID, *folderID, playlistID)
+		if err != nil {
+			return err
+		}
+		position = clampPosition(position, count)
+
+		if err := s.foldersRepo.ShiftItems(txCtx, userID, *folderID, position, 1); err != nil {
+			return err
+		}
+
+		return s.foldersRepo.SetItem(txCtx, userID, playlistID, *folderID, position)
+	})
+}
+
+func (s *FoldersService) getFolder(ctx context.Context, userID, folderID int64) (*FolderModel, error) {
+	folder, err := s.foldersRepo.GetOne(ctx, userID, folderID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrFolderNotFound
+		}
+		return nil, err
+	}
+
+	return folder, nil
+}
+
+func buildLibrary(folders []*FolderModel, items []*FolderItemModel, playlists []*playlist.PlaylistWithSavedModel) *LibraryModel {
+	library := &LibraryModel{
+		Folders:   []*LibraryFolderModel{},
+		Playlists: []*playlist.PlaylistWithSavedModel{},
+	}
+
+	nodes := make(map[int64]*LibraryFolderModel, len(folders))
+	for _, folder := range folders {
+		nodes[folder.ID] = &LibraryFolderModel{
+			FolderModel: *folder,
+			Folders:     []*LibraryFolderModel{},
+			Playlists:   []*playlist.PlaylistWithSavedModel{},
+		}
+	}
+
+	for _, folder := range folders {
+		node := nodes[folder.ID]
+		if folder.ParentID == nil {
+			library.Folders = append(library.Folders, node)
+			continue
+		}
+		if parent, ok := nodes[*folder.ParentID]; ok {
+			parent.Folders = append(parent.Folders, node)
+		}
+	}
+
+	playlistsByID := make(map[int64]*playlist.PlaylistWithSavedModel, len(playlists))
+	for _, p := range playlists {
+		playlistsByID[p.ID] = p
+	}
+
+	placed := make(map[int64]struct{}, len(items))
+	for _, item := range items {
+		p, ok := playlistsByID[item.PlaylistID]
+		if !ok {
+			continue
+		}
+		node, ok := nodes[item.FolderID]
+		if !ok {
+			continue
+		}
+		node.Playlists = append(node.Playlists, p)
+		placed[item.PlaylistID] = struct{}{}
+	}
+
+	for _, p := range playlists {
+		if _, ok := placed[p.ID]; !ok {
+			library.Playlists = append(library.Playlists, p)
+		}
+	}
+
+	return library
+}
+
+func containsFolder(folders []*FolderModel, folderID int64) bool {
+	for _, folder := range folders {
+		if folder.ID == folderID {
+			return true
+		}
+	}
+	return false
+}
+
+func isDescendant(folders []*FolderModel, folderID, ancestorID int64) bool {
+	parents := make(map[int64]*int64, len(folders))
+	for _, folder := range folders {
+		parents[folder.ID] = folder.ParentID
+	}
+
+	for current := &folderID; current != nil; current = parents[*current] {
+		if *current == ancestorID {
+			return true
+		}
+	}
+	return false
+}
+
+func clampPosition(position, count int) int {
+	if position <= 0 || position > count+1 {
+		return count + 1
+	}
+	return position
+}
@@ -0,0 +1,264 @@
+package folders
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"log/slog"
+	"sort"
+	"testing"
+
+	"github.com/ocenb/music-go/content-service/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryFoldersRepo keeps folders and items in memory so the position
+// bookkeeping of the service can be checked without a database.
+type memoryFoldersRepo struct {
+	folders map[int64]*FolderModel
+	items   map[int64]*FolderItemModel
+}
+
+func newMemoryFoldersRepo() *memoryFoldersRepo {
+	return &memoryFoldersRepo{
+		folders: map[int64]*FolderModel{},
+		items:   map[int64]*FolderItemModel{},
+	}
+}
+
+func (r *memoryFoldersRepo) add(id int64, parentID *int64, position int) {
+	r.folders[id] = &FolderModel{ID: id, UserID: 1, ParentID: parentID, Position: position}
+}
+
+func (r *memoryFoldersRepo) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	return nil, sql.ErrConnDone
+}
+
+func (r *memoryFoldersRepo) GetMany(ctx context.Context, userID int64) ([]*FolderModel, error) {
+	folders := []*FolderModel{}
+	for _, folder := range r.folders {
+		if folder.UserID == userID {
+			folders = append(folders, folder)
+		}
+	}
+	return folders, nil
+}
+
+func (r *memoryFoldersRepo) GetOne(ctx context.Context, userID, folderID int64) (*FolderModel, error) {
+	folder, ok := r.folders[folderID]
+	if !ok || folder.UserID != userID {
+		return nil, sql.ErrNoRows
+	}
+	copied := *folder
+	return &copied, nil
+}
+
+func (r *memoryFoldersRepo) Create(ctx context.Context, userID int64, parentID *int64, title string, position int) (*FolderModel, error) {
+	id := int64(len(r.folders) + 1)
+	r.folders[id] = &FolderModel{ID: id, UserID: userID, ParentID: parentID, Title: title, Position: position}
+	return r.folders[id], nil
+}
+
+func (r *memoryFoldersRepo) ChangeTitle(ctx context.Context, folderID int64, title string) error {
+	r.folders[folderID].Title = title
+	return nil
+}
+
+func (r *memoryFoldersRepo) UpdatePosition(ctx context.Context, folderID int64, parentID *int64, position int) error {
+	r.folders[folderID].ParentID = parentID
+	r.folders[folderID].Position = position
+	return nil
+}
+
+func (r *memoryFoldersRepo) Delete(ctx context.Context, folderID int64) error {
+	delete(r.folders, folderID)
+	for id, folder := range r.folders {
+		if folder.ParentID != nil && *folder.ParentID == folderID {
+			if err := r.Delete(ctx, id); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *memoryFoldersRepo) CountFolders(ctx context.Context, userID int64, parentID *int64, excludeFolderID int64) (int, error) {
+	count := 0
+	for _, folder := range r.folders {
+		if folder.UserID == userID && sameParent(folder.ParentID, parentID) && folder.ID != excludeFolderID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *memoryFoldersRepo) ShiftFolders(ctx context.Context, userID int64, parentID *int64, from, delta int) error {
+	for _, folder := range r.folders {
+		if folder.UserID == userID && sameParent(folder.ParentID, parentID) && folder.Position >= from {
+			folder.Position += delta
+		}
+	}
+	return nil
+}
+
+func (r *memoryFoldersRepo) MoveChildFolders(ctx context.Context, folderID int64, parentID *int64, offset int) error {
+	for _, folder := range r.folders {
+		if folder.ParentID != nil && *folder.ParentID == folderID {
+			folder.ParentID = parentID
+			folder.Position += offset
+		}
+	}
+	return nil
+}
+
+func (r *memoryFoldersRepo) GetItems(ctx context.Context, userID int64) ([]*FolderItemModel, error) {
+	items := []*FolderItemModel{}
+	for _, item := range r.items {
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (r *memoryFoldersRepo) GetItem(ctx context.Context, userID, playlistID int64) (*FolderItemModel, error) {
+	item, ok := r.items[playlistID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return item, nil
+}
+
+func (r *memoryFoldersRepo) SetItem(ctx context.Context, userID, playlistID, folderID int64, position int) error {
+	r.items[playlistID] = &FolderItemModel{UserID: userID, PlaylistID: playlistID, FolderID: folderID, Position: position}
+	return nil
+}
+
+func (r *memoryFoldersRepo) RemoveItem(ctx context.Context, userID, playlistID int64) error {
+	delete(r.items, playlistID)
+	return nil
+}
+
+func (r *memoryFoldersRepo) CountItems(ctx context.Context, userID, folderID, excludePlaylistID int64) (int, error) {
+	count := 0
+	for _, item := range r.items {
+		if item.FolderID == folderID && item.PlaylistID != excludePlaylistID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *memoryFoldersRepo) ShiftItems(ctx context.Context, userID, folderID int64, from, delta int) error {
+	for _, item := range r.items {
+		if item.FolderID == folderID && item.Position >= from {
+			item.Position += delta
+		}
+	}
+	return nil
+}
+
+func (r *memoryFoldersRepo) MoveChildItems(ctx context.Context, folderID, parentID int64, offset int) error {
+	for _, item := range r.items {
+		if item.FolderID == folderID {
+			item.FolderID = parentID
+			item.Position += offset
+		}
+	}
+	return nil
+}
+
+func sameParent(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func (r *memoryFoldersRepo) positions(parentID *int64) map[int64]int {
+	positions := map[int64]int{}
+	for _, folder := range r.folders {
+		if sameParent(folder.ParentID, parentID) {
+			positions[folder.ID] = folder.Position
+		}
+	}
+	return positions
+}
+
+func (r *memoryFoldersRepo) itemPositions(folderID int64) []int {
+	var positions []int
+	for _, item := range r.items {
+		if item.FolderID == folderID {
+			positions = append(positions, item.Position)
+		}
+	}
+	sort.Ints(positions)
+	return positions
+}
+
+// txContext marks the context as already inside a transaction, so the
+// service runs its callbacks directly against the in-memory repo.
+func txContext() context.Context {
+	return utils.SetTxToContext(context.Background(), &sql.Tx{})
+}
+
+func newTestService(repo FoldersRepoInterface) FoldersServiceInterface {
+	return NewFoldersService(slog.New(slog.NewTextHandler(io.Discard, nil)), repo, nil)
+}
+
+func TestDelete_RootFolderWithChildren(t *testing.T) {
+	const a, b, c, x, y = 1, 2, 3, 4, 5
+	bID := int64(b)
+
+	repo := newMemoryFoldersRepo()
+	repo.add(a, nil, 1)
+	repo.add(b, nil, 2)
+	repo.add(c, nil, 3)
+	repo.add(x, &bID, 1)
+	repo.add(y, &bID, 2)
+
+	err := newTestService(repo).Delete(txContext(), 1, b)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[int64]int{a: 1, c: 2, x: 3, y: 4}, repo.positions(nil))
+}
+
+func TestDelete_NestedFolderWithChildren(t *testing.T) {
+	const root, a, b, c, x = 1, 2, 3, 4, 5
+	rootID, bID := int64(root), int64(b)
+
+	repo := newMemoryFoldersRepo()
+	repo.add(root, nil, 1)
+	repo.add(a, &rootID, 1)
+	repo.add(b, &rootID, 2)
+	repo.add(c, &rootID, 3)
+	repo.add(x, &bID, 1)
+	require.NoError(t, repo.SetItem(context.Background(), 1, 10, root, 1))
+	require.NoError(t, repo.SetItem(context.Background(), 1, 11, b, 1))
+	require.NoError(t, repo.SetItem(context.Background(), 1, 12, b, 2))
+
+	err := newTestService(repo).Delete(txContext(), 1, b)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[int64]int{a: 1, c: 2, x: 3}, repo.positions(&rootID))
+	assert.Equal(t, []int{1, 2, 3}, repo.itemPositions(root))
+}
+
+func TestDelete_FolderWithoutChildren(t *testing.T) {
+	repo := newMemoryFoldersRepo()
+	repo.add(1, nil, 1)
+	repo.add(2, nil, 2)
+	repo.add(3, nil, 3)
+
+	err := newTestService(repo).Delete(txContext(), 1, 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[int64]int{2: 1, 3: 2}, repo.positions(nil))
+}
+
+func TestDelete_NotFound(t *testing.T) {
+	repo := newMemoryFoldersRepo()
+	repo.add(1, nil, 1)
+
+	err := newTestService(repo).Delete(txContext(), 1, 2)
+	assert.ErrorIs(t, err, ErrFolderNotFound)
+}
@@ -14,12 +14,20 @@ type PlaylistHandlerInterface interface {
 	getMany(c *gin.Context)
 	getManyWithSaved(c *gin.Context)
 	create(c *gin.Context)
+	createSmart(c *gin.Context)
 	changeTitle(c *gin.Context)
 	changeChangeableId(c *gin.Context)
 	changeImage(c *gin.Context)
+	resetImage(c *gin.Context)
 	delete(c *gin.Context)
 	savePlaylist(c *gin.Context)
 	removeFromSaved(c *gin.Context)
+	repost(c *gin.Context)
+	removeRepost(c *gin.Context)
+	changeVisibility(c *gin.Context)
+	changeRules(c *gin.Context)
+	createShareToken(c *gin.Context)
+	revokeShareToken(c *gin.Context)
 	RegisterHandlers(router *gin.RouterGroup)
 }
 
@@ -46,7 +54,7 @@ func (h *PlaylistHandler) getOne(c *gin.Context) {
 		return
 	}
 
-	playlist, err := h.playlistService.GetOne(c.Request.Context(), user.Id, params.Username, params.ChangeableID)
+	playlist, err := h.playlistService.GetOne(c.Request.Context(), user.Id, params.Username, params.ChangeableID, params.ShareToken)
 	if err != nil {
 		if errors.Is(err, ErrPlaylistNotFound) {
 			utils.NotFoundError(c, err)
@@ -261,6 +269,33 @@ func (h *PlaylistHandler) changeImage(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+func (h *PlaylistHandler) resetImage(c *gin.Context) {
+	var params ResetImageUri
+	if err := c.ShouldBindUri(&params); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.InternalError(c, err)
+		return
+	}
+
+	err = h.playlistService.ResetImage(c.Request.Context(), user.Id, params.PlaylistID)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrPermissionDenied):
+			utils.PermissionDeniedError(c, err)
+		default:
+			utils.InternalError(c, err)
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
 func (h *PlaylistHandler) delete(c *gin.Context) {
 	var params DeleteUri
 	if err := c.ShouldBindUri(&params); err != nil {
@@ -302,7 +337,13 @@ func (h *PlaylistHandler) savePlaylist(c *gin.Context) {
 		return
 	}
 
-	if err := h.playlistService.SavePlaylist(c.Request.Context(), user.Id, params.PlaylistID); err != nil {
+	var request SavePlaylistForm
+	if err := c.ShouldBindQuery(&request); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	if err := h.playlistService.SavePlaylist(c.Request.Context(), user.Id, params.PlaylistID, request.ShareToken); err != nil {
 		switch {
 		case errors.Is(err, ErrPlaylistNotFound):
 			utils.NotFoundError(c, err)
@@ -345,16 +386,242 @@ func (h *PlaylistHandler) removeFromSaved(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+func (h *PlaylistHandler) repost(c *gin.Context) {
+	var params GetByPlaylistIDUri
+	if err := c.ShouldBindUri(&params); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.InternalError(c, err)
+		return
+	}
+
+	if err := h.playlistService.Repost(c.Request.Context(), user.Id, params.PlaylistID); err != nil {
+		switch {
+		case errors.Is(err, ErrPlaylistNotFound):
+			utils.NotFoundError(c, err)
+		case errors.Is(err, ErrPlaylistIsYours), errors.Is(err, ErrPlaylistAlreadyReposted), errors.Is(err, ErrPlaylistIsNotPublic):
+			utils.BadRequestError(c, err)
+		default:
+			utils.InternalError(c, err)
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *PlaylistHandler) removeRepost(c *gin.Context) {
+	var params GetByPlaylistIDUri
+	if err := c.ShouldBindUri(&params); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.InternalError(c, err)
+		return
+	}
+
+	if err := h.playlistService.RemoveRepost(c.Request.Context(), user.Id, params.PlaylistID); err != nil {
+		switch {
+		case errors.Is(err, ErrPlaylistNotFound):
+			utils.NotFoundError(c, err)
+		case errors.Is(err, ErrPlaylistIsNotReposted):
+			utils.BadRequestError(c, err)
+		default:
+			utils.InternalError(c, err)
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *PlaylistHandler) createSmart(c *gin.Context) {
+	var request CreateSmartPlaylistJSON
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.InternalError(c, err)
+		return
+	}
+
+	playlist, err := h.playlistService.CreateSmart(
+		c.Request.Context(),
+		user.Id,
+		user.Username,
+		request.Title,
+		request.ChangeableID,
+		request.Rules,
+	)
+	if err != nil {
+		for _, badRequestError := range BadRequestErrors {
+			if errors.Is(err, badRequestError) {
+				utils.BadRequestError(c, err)
+				return
+			}
+		}
+		utils.InternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, playlist)
+}
+
+func (h *PlaylistHandler) changeRules(c *gin.Context) {
+	var params GetByPlaylistIDUri
+	if err := c.ShouldBindUri(&params); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	var request ChangeRulesJSON
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.InternalError(c, err)
+		return
+	}
+
+	if err := h.playlistService.ChangeRules(c.Request.Context(), user.Id, params.PlaylistID, request.Rules); err != nil {
+		switch {
+		case errors.Is(err, ErrPlaylistNotFound):
+			utils.NotFoundError(c, err)
+		case errors.Is(err, ErrPermissionDenied):
+			utils.PermissionDeniedError(c, err)
+		case errors.Is(err, ErrPlaylistIsNotSmart), errors.Is(err, ErrInvalidRules):
+			utils.BadRequestError(c, err)
+		default:
+			utils.InternalError(c, err)
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *PlaylistHandler) changeVisibility(c *gin.Context) {
+	var params GetByPlaylistIDUri
+	if err := c.ShouldBindUri(&params); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	var request ChangeVisibilityForm
+	if err := c.ShouldBind(&request); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.InternalError(c, err)
+		return
+	}
+
+	if err := h.playlistService.ChangeVisibility(c.Request.Context(), user.Id, params.PlaylistID, Visibility(request.Visibility)); err != nil {
+		switch {
+		case errors.Is(err, ErrPlaylistNotFound):
+			utils.NotFoundError(c, err)
+		case errors.Is(err, ErrPermissionDenied):
+			utils.PermissionDeniedError(c, err)
+		default:
+			utils.InternalError(c, err)
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *PlaylistHandler) createShareToken(c *gin.Context) {
+	var params GetByPlaylistIDUri
+	if err := c.ShouldBindUri(&params); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.InternalError(c, err)
+		return
+	}
+
+	shareToken, err := h.playlistService.CreateShareToken(c.Request.Context(), user.Id, params.PlaylistID)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrPlaylistNotFound):
+			utils.NotFoundError(c, err)
+		case errors.Is(err, ErrPermissionDenied):
+			utils.PermissionDeniedError(c, err)
+		default:
+			utils.InternalError(c, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, shareToken)
+}
+
+func (h *PlaylistHandler) revokeShareToken(c *gin.Context) {
+	var params GetByPlaylistIDUri
+	if err := c.ShouldBindUri(&params); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.InternalError(c, err)
+		return
+	}
+
+	if err := h.playlistService.RevokeShareToken(c.Request.Context(), user.Id, params.PlaylistID); err != nil {
+		switch {
+		case errors.Is(err, ErrPlaylistNotFound):
+			utils.NotFoundError(c, err)
+		case errors.Is(err, ErrPermissionDenied):
+			utils.PermissionDeniedError(c, err)
+		default:
+			utils.InternalError(c, err)
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
 func (h *PlaylistHandler) RegisterHandlers(router *gin.RouterGroup) {
 	playlistRouter := router.Group("/playlist")
 	playlistRouter.GET("/one", h.getOne)
 	playlistRouter.GET("", h.getMany)
 	playlistRouter.GET("/with-saved", h.getManyWithSaved)
 	playlistRouter.POST("", h.create)
+	playlistRouter.POST("/smart", h.createSmart)
 	playlistRouter.PATCH("/:playlistId/title", h.changeTitle)
 	playlistRouter.PATCH("/:playlistId/changeable-id", h.changeChangeableId)
 	playlistRouter.PATCH("/:playlistId/image", h.changeImage)
+	playlistRouter.DELETE("/:playlistId/image", h.resetImage)
 	playlistRouter.DELETE("/:playlistId", h.delete)
 	playlistRouter.POST("/:playlistId/save", h.savePlaylist)
 	playlistRouter.DELETE("/:playlistId/save", h.removeFromSaved)
+	playlistRouter.POST("/:playlistId/repost", h.repost)
+	playlistRouter.DELETE("/:playlistId/repost", h.removeRepost)
+	playlistRouter.PATCH("/:playlistId/visibility", h.changeVisibility)
+	playlistRouter.PUT("/:playlistId/rules", h.changeRules)
+	playlistRouter.POST("/:playlistId/share-token", h.createShareToken)
+	playlistRouter.DELETE("/:playlistId/share-token", h.revokeShareToken)
 }
@@ -2,21 +2,158 @@ package playlist
 
 import "time"
 
+type Role string
+
+const (
+	RoleNone   Role = ""
+	RoleOwner  Role = "owner"
+	RoleEditor Role = "editor"
+	RoleViewer Role = "viewer"
+)
+
+func (r Role) CanView() bool {
+	return r != RoleNone
+}
+
+func (r Role) CanEdit() bool {
+	return r == RoleOwner || r == RoleEditor
+}
+
+type Visibility string
+
+const (
+	VisibilityPublic   Visibility = "public"
+	VisibilityUnlisted Visibility = "unlisted"
+	VisibilityPrivate  Visibility = "private"
+)
+
+type RuleField string
+
+const (
+	RuleFieldLiked          RuleField = "liked"
+	RuleFieldLikedAt        RuleField = "likedAt"
+	RuleFieldFollowedArtist RuleField = "followedArtist"
+	RuleFieldArtist         RuleField = "artist"
+	RuleFieldTitle          RuleField = "title"
+	RuleFieldGenre          RuleField = "genre"
+	RuleFieldDuration       RuleField = "duration"
+	RuleFieldPlays          RuleField = "plays"
+	RuleFieldCreatedAt      RuleField = "createdAt"
+)
+
+type RuleOperator string
+
+const (
+	RuleOperatorEq       RuleOperator = "eq"
+	RuleOperatorNeq      RuleOperator = "neq"
+	RuleOperatorGt       RuleOperator = "gt"
+	RuleOperatorGte      RuleOperator = "gte"
+	RuleOperatorLt       RuleOperator = "lt"
+	RuleOperatorLte      RuleOperator = "lte"
+	RuleOperatorContains RuleOperator = "contains"
+	RuleOperatorInLast   RuleOperator = "inLast"
+)
+
+type RuleMatch string
+
+const (
+	RuleMatchAll RuleMatch = "all"
+	RuleMatchAny RuleMatch = "any"
+)
+
+type RuleSort string
+
+const (
+	RuleSortNewest        RuleSort = "newest"
+	RuleSortOldest        RuleSort = "oldest"
+	RuleSortMostPlayed    RuleSort = "mostPlayed"
+	RuleSortRecentlyLiked RuleSort = "recentlyLiked"
+	RuleSortTitle         RuleSort = "title"
+)
+
+type RuleModel struct {
+	Field    RuleField    `json:"field"`
+	Operator RuleOperator `json:"operator"`
+	Value    any          `json:"value"`
+}
+
+type RuleSetModel struct {
+	Match RuleMatch    `json:"match"`
+	Rules []*RuleModel `json:"rules"`
+	Sort  RuleSort     `json:"sort"`
+	Limit int          `json:"limit"`
+}
+
+type RevisionChange string
+
+const (
+	RevisionCreated             RevisionChange = "created"
+	RevisionTitleChanged        RevisionChange = "titleChanged"
+	RevisionChangeableIDChanged RevisionChange = "changeableIdChanged"
+	RevisionImageChanged        RevisionChange = "imageChanged"
+	RevisionVisibilityChanged   RevisionChange = "visibilityChanged"
+	RevisionRulesChanged        RevisionChange = "rulesChanged"
+	RevisionTrackAdded          RevisionChange = "trackAdded"
+	RevisionTrackMoved          RevisionChange = "trackMoved"
+	RevisionTrackRemoved        RevisionChange = "trackRemoved"
+	RevisionTracksAdded         RevisionChange = "tracksAdded"
+	RevisionTracksRemoved       RevisionChange = "tracksRemoved"
+	RevisionTracksBatch         RevisionChange = "tracksBatch"
+	RevisionDuplicatesRemoved   RevisionChange = "duplicatesRemoved"
+	RevisionTracksSorted        RevisionChange = "tracksSorted"
+	RevisionTracksReordered     RevisionChange = "tracksReordered"
+	RevisionRestored            RevisionChange = "restored"
+	RevisionUndone              RevisionChange = "undone"
+)
+
+// SnapshotModel is the playlist state captured by a revision. Image, ImageAuto
+// and Visibility are nil for revisions recorded before they were tracked.
+type SnapshotModel struct {
+	Title        string        `json:"title"`
+	ChangeableID string        `json:"changeableId"`
+	Image        *string       `json:"image,omitempty"`
+	ImageAuto    *bool         `json:"imageAuto,omitempty"`
+	Visibility   *Visibility   `json:"visibility,omitempty"`
+	Rules        *RuleSetModel `json:"rules,omitempty"`
+	TrackIDs     []int64       `json:"trackIds"`
+}
+
 type PlaylistModel struct {
-	ID           int64     `json:"id"`
-	ChangeableID string    `json:"changeableId"`
-	Title        string    `json:"title"`
-	Image        string    `json:"image"`
-	UserID       int64     `json:"userId"`
-	Username     string    `json:"username"`
-	CreatedAt    time.Time `json:"createdAt"`
-	UpdatedAt    time.Time `json:"updatedAt"`
+	ID            int64         `json:"id"`
+	ChangeableID  string        `json:"changeableId"`
+	Title         string        `json:"title"`
+	Image         string        `json:"image"`
+	RepostsCount  int64         `json:"repostsCount"`
+	TrackCount    int64         `json:"trackCount"`
+	TotalDuration int64         `json:"totalDuration"`
+	Visibility    Visibility    `json:"visibility"`
+	ShareToken    *string       `json:"shareToken,omitempty"`
+	Rules         *RuleSetModel `json:"rules,omitempty"`
+	UserID        int64         `json:"userId"`
+	Username      string        `json:"username"`
+	CreatedAt     time.Time     `json:"createdAt"`
+	UpdatedAt     time.Time     `json:"updatedAt"`
+}
+
+type RefreshRequestModel struct {
+	PlaylistID  int64
+	UserID      int64
+	Rules       *RuleSetModel
+	RequestedAt time.Time
+}
+
+type CoverModel struct {
+	Image   string
+	Auto    bool
+	Source  []string
+	IsSmart bool
 }
 
 type PlaylistWithSavedModel struct {
 	PlaylistModel
-	IsSaved bool       `json:"isSaved"`
-	SavedAt *time.Time `json:"savedAt,omitempty"`
+	IsSaved    bool       `json:"isSaved"`
+	SavedAt    *time.Time `json:"savedAt,omitempty"`
+	IsReposted bool       `json:"isReposted"`
 }
 
 type PlaylistTrackModel struct {
@@ -31,3 +168,7 @@ type UserSavedPlaylistModel struct {
 	PlaylistID int64     `json:"playlistId"`
 	AddedAt    time.Time `json:"addedAt"`
 }
+
+type ShareTokenModel struct {
+	ShareToken string `json:"shareToken"`
+}
@@ -9,9 +9,17 @@ var (
 	ErrTrackAlreadyInPlaylist = errors.New("track already in playlist")
 	ErrTrackNotInPlaylist     = errors.New("track is not in this playlist")
 	ErrPositionConflict       = errors.New("track already in this position")
+	ErrPositionRequired       = errors.New("position is required")
+	ErrInvalidOperation       = errors.New("invalid operation")
+	ErrOrderMismatch          = errors.New("order must contain every track of the playlist exactly once")
+	ErrSmartPlaylist          = errors.New("tracks of a smart playlist are defined by its rules")
 )
 
 var BadRequestErrors = []error{
 	ErrTrackAlreadyInPlaylist,
 	ErrPositionConflict,
+	ErrPositionRequired,
+	ErrInvalidOperation,
+	ErrOrderMismatch,
+	ErrSmartPlaylist,
 }
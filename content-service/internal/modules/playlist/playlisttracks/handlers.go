@@ -13,6 +13,12 @@ type PlaylistTracksHandlersInterface interface {
 	Add(c *gin.Context)
 	UpdatePosition(c *gin.Context)
 	Remove(c *gin.Context)
+	Batch(c *gin.Context)
+	AddMany(c *gin.Context)
+	RemoveMany(c *gin.Context)
+	RemoveDuplicates(c *gin.Context)
+	Sort(c *gin.Context)
+	ReplaceOrder(c *gin.Context)
 	RegisterHandlers(router *gin.RouterGroup)
 }
 
@@ -45,7 +51,7 @@ func (h *PlaylistTracksHandlers) GetMany(c *gin.Context) {
 		return
 	}
 
-	tracks, err := h.playlistTracksService.GetMany(c, user.Id, playlistReq.PlaylistID, getManyReq.Take)
+	tracks, err := h.playlistTracksService.GetMany(c, user.Id, playlistReq.PlaylistID, getManyReq.Take, getManyReq.ShareToken)
 	if err != nil {
 		switch {
 		case errors.Is(err, ErrPlaylistNotFound):
@@ -88,6 +94,8 @@ func (h *PlaylistTracksHandlers) Add(c *gin.Context) {
 			utils.NotFoundError(c, err)
 		case errors.Is(err, ErrPermissionDenied):
 			utils.PermissionDeniedError(c, err)
+		case errors.Is(err, ErrSmartPlaylist):
+			utils.BadRequestError(c, err)
 		case errors.Is(err, ErrTrackAlreadyInPlaylist):
 			utils.BadRequestError(c, err)
 		default:
@@ -128,6 +136,8 @@ func (h *PlaylistTracksHandlers) UpdatePosition(c *gin.Context) {
 			utils.NotFoundError(c, err)
 		case errors.Is(err, ErrPermissionDenied):
 			utils.PermissionDeniedError(c, err)
+		case errors.Is(err, ErrSmartPlaylist):
+			utils.BadRequestError(c, err)
 		case errors.Is(err, ErrPositionConflict):
 			utils.BadRequestError(c, err)
 		default:
@@ -162,6 +172,8 @@ func (h *PlaylistTracksHandlers) Remove(c *gin.Context) {
 			utils.NotFoundError(c, err)
 		case errors.Is(err, ErrPermissionDenied):
 			utils.PermissionDeniedError(c, err)
+		case errors.Is(err, ErrSmartPlaylist):
+			utils.BadRequestError(c, err)
 		default:
 			utils.InternalError(c, err)
 		}
@@ -171,10 +183,269 @@ func (h *PlaylistTracksHandlers) Remove(c *gin.Context) {
 	c.Status(http.StatusOK)
 }
 
+func (h *PlaylistTracksHandlers) Batch(c *gin.Context) {
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.UnauthenticatedError(c, err)
+		return
+	}
+
+	var playlistReq PlaylistUri
+	if err := c.ShouldBindUri(&playlistReq); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	var batchReq BatchJSON
+	if err := c.ShouldBindJSON(&batchReq); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	operations := make([]*BatchOperationModel, 0, len(batchReq.Operations))
+	for _, operation := range batchReq.Operations {
+		operations = append(operations, &BatchOperationModel{
+			Type:     BatchOperationType(operation.Type),
+			TrackID:  operation.TrackID,
+			Position: operation.Position,
+		})
+	}
+
+	tracks, err := h.playlistTracksService.Batch(c, user.Id, playlistReq.PlaylistID, operations)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrPlaylistNotFound):
+			utils.NotFoundError(c, err)
+		case errors.Is(err, ErrTrackNotFound):
+			utils.NotFoundError(c, err)
+		case errors.Is(err, ErrTrackNotInPlaylist):
+			utils.NotFoundError(c, err)
+		case errors.Is(err, ErrPermissionDenied):
+			utils.PermissionDeniedError(c, err)
+		case errors.Is(err, ErrSmartPlaylist):
+			utils.BadRequestError(c, err)
+		case errors.Is(err, ErrTrackAlreadyInPlaylist),
+			errors.Is(err, ErrPositionConflict),
+			errors.Is(err, ErrPositionRequired),
+			errors.Is(err, ErrInvalidOperation):
+			utils.BadRequestError(c, err)
+		default:
+			utils.InternalError(c, err)
+		}
+
+		return
+	}
+
+	c.JSON(http.StatusOK, tracks)
+}
+
+func (h *PlaylistTracksHandlers) AddMany(c *gin.Context) {
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.UnauthenticatedError(c, err)
+		return
+	}
+
+	var playlistReq PlaylistUri
+	if err := c.ShouldBindUri(&playlistReq); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	var addManyReq AddManyJSON
+	if err := c.ShouldBindJSON(&addManyReq); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	tracks, err := h.playlistTracksService.AddMany(c, user.Id, playlistReq.PlaylistID, addManyReq.TrackIDs, addManyReq.Position)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrPlaylistNotFound):
+			utils.NotFoundError(c, err)
+		case errors.Is(err, ErrTrackNotFound):
+			utils.NotFoundError(c, err)
+		case errors.Is(err, ErrPermissionDenied):
+			utils.PermissionDeniedError(c, err)
+		case errors.Is(err, ErrSmartPlaylist):
+			utils.BadRequestError(c, err)
+		case errors.Is(err, ErrTrackAlreadyInPlaylist):
+			utils.BadRequestError(c, err)
+		default:
+			utils.InternalError(c, err)
+		}
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, tracks)
+}
+
+func (h *PlaylistTracksHandlers) RemoveMany(c *gin.Context) {
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.UnauthenticatedError(c, err)
+		return
+	}
+
+	var playlistReq PlaylistUri
+	if err := c.ShouldBindUri(&playlistReq); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	var removeManyReq RemoveManyJSON
+	if err := c.ShouldBindJSON(&removeManyReq); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	tracks, err := h.playlistTracksService.RemoveMany(c, user.Id, playlistReq.PlaylistID, removeManyReq.TrackIDs)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrPlaylistNotFound):
+			utils.NotFoundError(c, err)
+		case errors.Is(err, ErrTrackNotInPlaylist):
+			utils.NotFoundError(c, err)
+		case errors.Is(err, ErrPermissionDenied):
+			utils.PermissionDeniedError(c, err)
+		case errors.Is(err, ErrSmartPlaylist):
+			utils.BadRequestError(c, err)
+		default:
+			utils.InternalError(c, err)
+		}
+
+		return
+	}
+
+	c.JSON(http.StatusOK, tracks)
+}
+
+func (h *PlaylistTracksHandlers) RemoveDuplicates(c *gin.Context) {
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.UnauthenticatedError(c, err)
+		return
+	}
+
+	var playlistReq PlaylistUri
+	if err := c.ShouldBindUri(&playlistReq); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	tracks, err := h.playlistTracksService.RemoveDuplicates(c, user.Id, playlistReq.PlaylistID)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrPlaylistNotFound):
+			utils.NotFoundError(c, err)
+		case errors.Is(err, ErrPermissionDenied):
+			utils.PermissionDeniedError(c, err)
+		case errors.Is(err, ErrSmartPlaylist):
+			utils.BadRequestError(c, err)
+		default:
+			utils.InternalError(c, err)
+		}
+
+		return
+	}
+
+	c.JSON(http.StatusOK, tracks)
+}
+
+func (h *PlaylistTracksHandlers) Sort(c *gin.Context) {
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.UnauthenticatedError(c, err)
+		return
+	}
+
+	var playlistReq PlaylistUri
+	if err := c.ShouldBindUri(&playlistReq); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	var sortReq SortJSON
+	if err := c.ShouldBindJSON(&sortReq); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	order := OrderAsc
+	if sortReq.Order != "" {
+		order = SortOrder(sortReq.Order)
+	}
+
+	tracks, err := h.playlistTracksService.Sort(c, user.Id, playlistReq.PlaylistID, SortField(sortReq.Sort), order)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrPlaylistNotFound):
+			utils.NotFoundError(c, err)
+		case errors.Is(err, ErrPermissionDenied):
+			utils.PermissionDeniedError(c, err)
+		case errors.Is(err, ErrSmartPlaylist):
+			utils.BadRequestError(c, err)
+		default:
+			utils.InternalError(c, err)
+		}
+
+		return
+	}
+
+	c.JSON(http.StatusOK, tracks)
+}
+
+func (h *PlaylistTracksHandlers) ReplaceOrder(c *gin.Context) {
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.UnauthenticatedError(c, err)
+		return
+	}
+
+	var playlistReq PlaylistUri
+	if err := c.ShouldBindUri(&playlistReq); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	var replaceOrderReq ReplaceOrderJSON
+	if err := c.ShouldBindJSON(&replaceOrderReq); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	tracks, err := h.playlistTracksService.ReplaceOrder(c, user.Id, playlistReq.PlaylistID, replaceOrderReq.TrackIDs)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrPlaylistNotFound):
+			utils.NotFoundError(c, err)
+		case errors.Is(err, ErrPermissionDenied):
+			utils.PermissionDeniedError(c, err)
+		case errors.Is(err, ErrSmartPlaylist):
+			utils.BadRequestError(c, err)
+		case errors.Is(err, ErrOrderMismatch):
+			utils.BadRequestError(c, err)
+		default:
+			utils.InternalError(c, err)
+		}
+
+		return
+	}
+
+	c.JSON(http.StatusOK, tracks)
+}
+
 func (h *PlaylistTracksHandlers) RegisterHandlers(router *gin.RouterGroup) {
 	playlistTracksRouter := router.Group("/playlist-tracks")
 	playlistTracksRouter.GET("/:playlistId", h.GetMany)
 	playlistTracksRouter.POST("/:playlistId/tracks/:trackId", h.Add)
 	playlistTracksRouter.PUT("/:playlistId/tracks/:trackId/position", h.UpdatePosition)
 	playlistTracksRouter.DELETE("/:playlistId/tracks/:trackId", h.Remove)
+	playlistTracksRouter.POST("/:playlistId/batch", h.Batch)
+	playlistTracksRouter.POST("/:playlistId/tracks", h.AddMany)
+	playlistTracksRouter.DELETE("/:playlistId/tracks", h.RemoveMany)
+	playlistTracksRouter.POST("/:playlistId/dedupe", h.RemoveDuplicates)
+	playlistTracksRouter.POST("/:playlistId/sort", h.Sort)
+	playlistTracksRouter.PUT("/:playlistId/order", h.ReplaceOrder)
 }
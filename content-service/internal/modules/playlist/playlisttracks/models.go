@@ -8,6 +8,8 @@ type PlaylistTrackModel struct {
 	PlaylistID int64     `json:"playlistId"`
 	TrackID    int64     `json:"trackId"`
 	Position   int       `json:"position"`
+	Rank       string    `json:"rank"`
+	AddedBy    int64     `json:"addedBy"`
 	AddedAt    time.Time `json:"addedAt"`
 }
 
@@ -15,9 +17,43 @@ type TrackInPlaylistModel struct {
 	PlaylistID     int64     `json:"playlistId"`
 	TrackID        int64     `json:"trackId"`
 	Position       int       `json:"position"`
+	Rank           string    `json:"rank"`
 	Title          string    `json:"title"`
 	Artist         string    `json:"artist"`
 	Duration       int       `json:"duration"`
+	Audio          string    `json:"audio"`
 	CoverImagePath string    `json:"coverImagePath"`
+	AddedBy        int64     `json:"addedBy"`
+	IsLiked        bool      `json:"isLiked"`
 	CreatedAt      time.Time `json:"createdAt"`
 }
+
+type BatchOperationType string
+
+const (
+	BatchAdd    BatchOperationType = "add"
+	BatchMove   BatchOperationType = "move"
+	BatchRemove BatchOperationType = "remove"
+)
+
+type BatchOperationModel struct {
+	Type     BatchOperationType
+	TrackID  int64
+	Position int
+}
+
+type SortField string
+
+const (
+	SortTitle    SortField = "title"
+	SortArtist   SortField = "artist"
+	SortDuration SortField = "duration"
+	SortAddedAt  SortField = "addedAt"
+)
+
+type SortOrder string
+
+const (
+	OrderAsc  SortOrder = "asc"
+	OrderDesc SortOrder = "desc"
+)
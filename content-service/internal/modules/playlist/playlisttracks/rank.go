@@ -0,0 +1,145 @@
+package playlisttracks
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	rankDigits  = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	initialRank = "V000000000V"
+)
+
+var errInvalidRankRange = errors.New("invalid rank range")
+
+func rankBetween(prev, next string) (string, error) {
+	if next != "" && prev >= next {
+		return "", errInvalidRankRange
+	}
+
+	switch {
+	case prev == "" && next == "":
+		return initialRank, nil
+	case next == "":
+		return rankAfter(prev), nil
+	case prev == "":
+		return rankBefore(next), nil
+	default:
+		return rankMidpoint(prev, next), nil
+	}
+}
+
+func ranksBetween(prev, next string, n int) ([]string, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+
+	if next == "" {
+		ranks := make([]string, 0, n)
+		for range n {
+			rank, err := rankBetween(prev, "")
+			if err != nil {
+				return nil, err
+			}
+			ranks = append(ranks, rank)
+			prev = rank
+		}
+		return ranks, nil
+	}
+
+	mid, err := rankBetween(prev, next)
+	if err != nil {
+		return nil, err
+	}
+
+	left, err := ranksBetween(prev, mid, n/2)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := ranksBetween(mid, next, n-n/2-1)
+	if err != nil {
+		return nil, err
+	}
+
+	ranks := make([]string, 0, n)
+	ranks = append(ranks, left...)
+	ranks = append(ranks, mid)
+	ranks = append(ranks, right...)
+
+	return ranks, nil
+}
+
+func rankAfter(prev string) string {
+	digits := []byte(prev)
+	for i := len(digits) - 1; i >= 0; i-- {
+		digit := strings.IndexByte(rankDigits, digits[i])
+		if digit < len(rankDigits)-1 {
+			digits[i] = rankDigits[digit+1]
+			if last := len(digits) - 1; digits[last] == rankDigits[0] {
+				digits[last] = rankDigits[1]
+			}
+			return string(digits)
+		}
+		digits[i] = rankDigits[0]
+	}
+
+	return prev + string(rankDigits[len(rankDigits)/2])
+}
+
+func rankBefore(next string) string {
+	digits := []byte(next)
+	for i := len(digits) - 1; i >= 0; i-- {
+		digit := strings.IndexByte(rankDigits, digits[i])
+		if digit > 1 || (digit == 1 && i < len(digits)-1) {
+			digits[i] = rankDigits[digit-1]
+			for j := i + 1; j < len(digits); j++ {
+				digits[j] = rankDigits[len(rankDigits)-1]
+			}
+			return string(digits)
+		}
+	}
+
+	return rankMidpoint("", next)
+}
+
+func rankMidpoint(prev, next string) string {
+	if next != "" {
+		n := 0
+		for n < len(next) && rankDigitAt(prev, n) == strings.IndexByte(rankDigits, next[n]) {
+			n++
+		}
+		if n > 0 {
+			return next[:n] + rankMidpoint(rankSuffix(prev, n), next[n:])
+		}
+	}
+
+	prevDigit := rankDigitAt(prev, 0)
+	nextDigit := len(rankDigits)
+	if next != "" {
+		nextDigit = strings.IndexByte(rankDigits, next[0])
+	}
+
+	if nextDigit-prevDigit > 1 {
+		return string(rankDigits[(prevDigit+nextDigit+1)/2])
+	}
+	if len(next) > 1 {
+		return next[:1]
+	}
+
+	return string(rankDigits[prevDigit]) + rankMidpoint(rankSuffix(prev, 1), "")
+}
+
+func rankDigitAt(rank string, i int) int {
+	if i >= len(rank) {
+		return 0
+	}
+	return strings.IndexByte(rankDigits, rank[i])
+}
+
+func rankSuffix(rank string, i int) string {
+	if i >= len(rank) {
+		return ""
+	}
+	return rank[i:]
+}
@@ -0,0 +1,144 @@
+package playlisttracks
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRankBetween(t *testing.T) {
+	tests := []struct {
+		name string
+		prev string
+		next string
+		want string
+	}{
+		{name: "empty playlist", prev: "", next: "", want: initialRank},
+		{name: "append increments last digit", prev: "V000000000V", next: "", want: "V000000000W"},
+		{name: "append carries over", prev: "Vz", next: "", want: "W1"},
+		{name: "append after max rank grows", prev: "zz", next: "", want: "zzV"},
+		{name: "prepend decrements last digit", prev: "", next: "V000000000V", want: "V000000000U"},
+		{name: "prepend avoids trailing zero digit", prev: "", next: "V1", want: "Uz"},
+		{name: "midpoint of distant ranks", prev: "A", next: "C", want: "B"},
+		{name: "midpoint of adjacent ranks extends", prev: "A", next: "B", want: "AV"},
+		{name: "midpoint keeps common prefix", prev: "V000000000V", next: "V000000000X", want: "V000000000W"},
+		{name: "midpoint below shorter next", prev: "A", next: "AB", want: "A6"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rankBetween(tt.prev, tt.next)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assertBetween(t, tt.prev, got, tt.next)
+		})
+	}
+}
+
+func TestRankBetween_InvalidRange(t *testing.T) {
+	tests := []struct {
+		name string
+		prev string
+		next string
+	}{
+		{name: "equal ranks", prev: "V", next: "V"},
+		{name: "reversed ranks", prev: "W", next: "V"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := rankBetween(tt.prev, tt.next)
+			assert.ErrorIs(t, err, errInvalidRankRange)
+		})
+	}
+}
+
+func TestRankBetween_RepeatedBisection(t *testing.T) {
+	prev, next := "V000000000V", "V000000000W"
+	for range 50 {
+		mid, err := rankBetween(prev, next)
+		require.NoError(t, err)
+		assertBetween(t, prev, mid, next)
+		next = mid
+	}
+}
+
+func TestRankBetween_RandomInserts(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for trial := range 100 {
+		var ranks []string
+		for range 200 {
+			pos := r.Intn(len(ranks) + 1)
+			switch trial % 3 {
+			case 0:
+				pos = 0
+			case 1:
+				pos = len(ranks)
+			}
+
+			prev, next := "", ""
+			if pos > 0 {
+				prev = ranks[pos-1]
+			}
+			if pos < len(ranks) {
+				next = ranks[pos]
+			}
+
+			rank, err := rankBetween(prev, next)
+			require.NoError(t, err)
+			assertBetween(t, prev, rank, next)
+
+			ranks = append(ranks[:pos], append([]string{rank}, ranks[pos:]...)...)
+		}
+		require.True(t, sort.StringsAreSorted(ranks))
+	}
+}
+
+func TestRanksBetween(t *testing.T) {
+	tests := []struct {
+		name string
+		prev string
+		next string
+		n    int
+	}{
+		{name: "none", prev: "A", next: "B", n: 0},
+		{name: "into empty playlist", prev: "", next: "", n: 5},
+		{name: "appended", prev: "V", next: "", n: 10},
+		{name: "prepended", prev: "", next: "V", n: 10},
+		{name: "between adjacent ranks", prev: "A", next: "B", n: 25},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranks, err := ranksBetween(tt.prev, tt.next, tt.n)
+			require.NoError(t, err)
+			require.Len(t, ranks, tt.n)
+
+			prev := tt.prev
+			for _, rank := range ranks {
+				assertBetween(t, prev, rank, tt.next)
+				prev = rank
+			}
+		})
+	}
+}
+
+func TestRanksBetween_InvalidRange(t *testing.T) {
+	_, err := ranksBetween("B", "A", 3)
+	assert.ErrorIs(t, err, errInvalidRankRange)
+}
+
+func assertBetween(t *testing.T, prev, rank, next string) {
+	t.Helper()
+	if prev != "" {
+		assert.Less(t, prev, rank)
+	}
+	if next != "" {
+		assert.Less(t, rank, next)
+	}
+	assert.NotEqual(t, rankDigits[0], rank[len(rank)-1], "rank %q must not end with the zero digit", rank)
+}
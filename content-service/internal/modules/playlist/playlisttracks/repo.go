@@ -3,23 +3,47 @@ package playlisttracks
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"log/slog"
 	"time"
 
+	"github.com/lib/pq"
+	"github.com/ocenb/music-go/content-service/internal/modules/playlist"
 	"github.com/ocenb/music-go/content-service/internal/modules/track"
+	"github.com/ocenb/music-go/content-service/internal/utils"
 )
 
 type PlaylistTracksRepoInterface interface {
 	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
 	GetMany(ctx context.Context, playlistID, currentUserID int64, take int) ([]*TrackInPlaylistModel, error)
-	Add(ctx context.Context, playlistID, trackID int64, position int) (*PlaylistTrackModel, error)
-	UpdatePosition(ctx context.Context, playlistID, trackID int64, position int) error
+	GetManyByRules(ctx context.Context, playlistID, ownerID, currentUserID int64, rules *playlist.RuleSetModel, followingIDs []int64, take int) ([]*TrackInPlaylistModel, error)
+	GetStatsByRules(ctx context.Context, ownerID int64, rules *playlist.RuleSetModel, followingIDs []int64) (int64, int64, error)
+	Add(ctx context.Context, playlistID, trackID, addedBy int64, rank string) (*PlaylistTrackModel, error)
+	UpdateRank(ctx context.Context, playlistID, trackID int64, rank string) error
 	Remove(ctx context.Context, playlistID, trackID int64) error
 	GetOne(ctx context.Context, playlistID, trackID int64) (*PlaylistTrackModel, error)
-	GetLastPosition(ctx context.Context, playlistID int64) (int, error)
-	IncrementPositions(ctx context.Context, playlistID int64, fromPosition int) error
-	DecrementPositions(ctx context.Context, playlistID int64, fromPosition int) error
-	MovePositions(ctx context.Context, playlistID int64, fromPosition, toPosition int) error
+	GetNeighborRanks(ctx context.Context, playlistID, excludeTrackID int64, position int) (string, string, error)
+	LockPlaylist(ctx context.Context, playlistID int64) error
+	GetTrackIDs(ctx context.Context, playlistID int64) ([]int64, error)
+	GetSortedTrackIDs(ctx context.Context, playlistID int64, sort SortField, order SortOrder) ([]int64, error)
+	GetDuplicateTrackIDs(ctx context.Context, playlistID int64) ([]int64, error)
+	AddMany(ctx context.Context, playlistID, addedBy int64, trackIDs []int64, ranks []string) error
+	RemoveMany(ctx context.Context, playlistID int64, trackIDs []int64) error
+	SetRanks(ctx context.Context, playlistID int64, trackIDs []int64, ranks []string) error
+}
+
+var sortColumns = map[SortField]string{
+	SortTitle:    "lower(t.title)",
+	SortArtist:   "lower(t.username)",
+	SortDuration: "t.duration",
+	SortAddedAt:  "pt.added_at",
+}
+
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
 }
 
 type PlaylistTracksRepo struct {
@@ -35,16 +59,23 @@ func (r *PlaylistTracksRepo) BeginTx(ctx context.Context, opts *sql.TxOptions) (
 	return r.postgres.BeginTx(ctx, opts)
 }
 
+func (r *PlaylistTracksRepo) db(ctx context.Context) querier {
+	if tx, hasTx := utils.GetTxFromContext(ctx); hasTx {
+		return tx
+	}
+	return r.postgres
+}
+
 func (r *PlaylistTracksRepo) GetMany(ctx context.Context, playlistID, currentUserID int64, take int) ([]*TrackInPlaylistModel, error) {
 	query := `
-		SELECT pt.track_id, pt.position, pt.added_at,
+		SELECT pt.track_id, ROW_NUMBER() OVER (ORDER BY pt.rank) as position, pt.rank, pt.added_by, pt.added_at,
 			t.id, t.user_id, t.username, t.title, t.changeable_id, t.audio, t.image, t.duration, t.plays, t.created_at, t.updated_at,
 			CASE WHEN ult.user_id IS NOT NULL THEN true ELSE false END as is_liked
 		FROM playlist_tracks pt
 		JOIN tracks t ON pt.track_id = t.id
 		LEFT JOIN user_liked_tracks ult ON ult.track_id = t.id AND ult.user_id = $1
 		WHERE pt.playlist_id = $2
-		ORDER BY pt.position ASC
+		ORDER BY pt.rank ASC
 	`
 
 	if take > 0 {
@@ -55,9 +86,9 @@ func (r *PlaylistTracksRepo) GetMany(ctx context.Context, playlistID, currentUse
 	var err error
 
 	if take > 0 {
-		rows, err = r.postgres.QueryContext(ctx, query, currentUserID, playlistID, take)
+		rows, err = r.db(ctx).QueryContext(ctx, query, currentUserID, playlistID, take)
 	} else {
-		rows, err = r.postgres.QueryContext(ctx, query, currentUserID, playlistID)
+		rows, err = r.db(ctx).QueryContext(ctx, query, currentUserID, playlistID)
 	}
 
 	if err != nil {
@@ -70,6 +101,80 @@ func (r *PlaylistTracksRepo) GetMany(ctx context.Context, playlistID, currentUse
 		}
 	}()
 
+	return scanTracksInPlaylist(rows, playlistID)
+}
+
+func (r *PlaylistTracksRepo) GetManyByRules(ctx context.Context, playlistID, ownerID, currentUserID int64, rules *playlist.RuleSetModel, followingIDs []int64, take int) ([]*TrackInPlaylistModel, error) {
+	conditions, orderBy, args := compileRules(rules, ownerID, followingIDs)
+
+	limit := rules.Limit
+	if limit <= 0 || limit > playlist.MaxSmartPlaylistLimit {
+		limit = playlist.MaxSmartPlaylistLimit
+	}
+	if take > 0 && take < limit {
+		limit = take
+	}
+	args = append(args, currentUserID, limit)
+
+	query := fmt.Sprintf(`
+		SELECT t.id, ROW_NUMBER() OVER (ORDER BY %[2]s) as position, '' as rank, $1::bigint as added_by, COALESCE(ol.added_at, t.created_at) as added_at,
+			t.id, t.user_id, t.username, t.title, t.changeable_id, t.audio, t.image, t.duration, t.plays, t.created_at, t.updated_at,
+			CASE WHEN ult.user_id IS NOT NULL THEN true ELSE false END as is_liked
+		FROM tracks t
+		LEFT JOIN user_liked_tracks ol ON ol.track_id = t.id AND ol.user_id = $1
+		LEFT JOIN user_liked_tracks ult ON ult.track_id = t.id AND ult.user_id = $%[3]d
+		WHERE %[1]s
+		ORDER BY %[2]s
+		LIMIT $%[4]d
+	`, conditions, orderBy, len(args)-1, len(args))
+
+	rows, err := r.db(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			r.log.Error("Failed to close rows", "error", err)
+		}
+	}()
+
+	return scanTracksInPlaylist(rows, playlistID)
+}
+
+// GetStatsByRules returns the track count and total duration a rule-based
+// playlist currently evaluates to.
+func (r *PlaylistTracksRepo) GetStatsByRules(ctx context.Context, ownerID int64, rules *playlist.RuleSetModel, followingIDs []int64) (int64, int64, error) {
+	conditions, orderBy, args := compileRules(rules, ownerID, followingIDs)
+
+	limit := rules.Limit
+	if limit <= 0 || limit > playlist.MaxSmartPlaylistLimit {
+		limit = playlist.MaxSmartPlaylistLimit
+	}
+	args = append(args, limit)
+
+	query := fmt.Sprintf(`
+		SELECT COUNT(*), COALESCE(SUM(matched.duration), 0)
+		FROM (
+			SELECT t.duration
+			FROM tracks t
+			LEFT JOIN user_liked_tracks ol ON ol.track_id = t.id AND ol.user_id = $1
+			WHERE %s
+			ORDER BY %s
+			LIMIT $%d
+		) matched
+	`, conditions, orderBy, len(args))
+
+	var trackCount, totalDuration int64
+	err := r.db(ctx).QueryRowContext(ctx, query, args...).Scan(&trackCount, &totalDuration)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return trackCount, totalDuration, nil
+}
+
+func scanTracksInPlaylist(rows *sql.Rows, playlistID int64) ([]*TrackInPlaylistModel, error) {
 	var tracks []*TrackInPlaylistModel
 
 	for rows.Next() {
@@ -80,6 +185,8 @@ func (r *PlaylistTracksRepo) GetMany(ctx context.Context, playlistID, currentUse
 		err := rows.Scan(
 			&trackInPlaylist.TrackID,
 			&trackInPlaylist.Position,
+			&trackInPlaylist.Rank,
+			&trackInPlaylist.AddedBy,
 			&addedAt,
 			&trackModel.ID,
 			&trackModel.UserID,
@@ -106,7 +213,9 @@ func (r *PlaylistTracksRepo) GetMany(ctx context.Context, playlistID, currentUse
 		trackInPlaylist.Title = trackModel.Title
 		trackInPlaylist.Artist = trackModel.Username
 		trackInPlaylist.Duration = int(trackModel.Duration)
+		trackInPlaylist.Audio = trackModel.Audio
 		trackInPlaylist.CoverImagePath = trackModel.Image
+		trackInPlaylist.IsLiked = trackModel.IsLiked
 		trackInPlaylist.CreatedAt = addedAt
 
 		tracks = append(tracks, &trackInPlaylist)
@@ -119,22 +228,30 @@ func (r *PlaylistTracksRepo) GetMany(ctx context.Context, playlistID, currentUse
 	return tracks, nil
 }
 
-func (r *PlaylistTracksRepo) Add(ctx context.Context, playlistID, trackID int64, position int) (*PlaylistTrackModel, error) {
+func (r *PlaylistTracksRepo) Add(ctx context.Context, playlistID, trackID, addedBy int64, rank string) (*PlaylistTrackModel, error) {
 	query := `
-		INSERT INTO playlist_tracks (playlist_id, track_id, position, added_at)
-		VALUES ($1, $2, $3, $4)
-		RETURNING playlist_id, track_id, position, added_at
+		WITH inserted AS (
+			INSERT INTO playlist_tracks (playlist_id, track_id, rank, added_by, added_at)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING playlist_id, track_id, rank, added_by, added_at
+		)
+		SELECT i.playlist_id, i.track_id,
+			(SELECT COUNT(*) FROM playlist_tracks pt WHERE pt.playlist_id = i.playlist_id AND pt.rank < i.rank) + 1 as position,
+			i.rank, i.added_by, i.added_at
+		FROM inserted i
 	`
 
 	var model PlaylistTrackModel
 	var addedAt time.Time
 
-	err := r.postgres.QueryRowContext(
-		ctx, query, playlistID, trackID, position, time.Now(),
+	err := r.db(ctx).QueryRowContext(
+		ctx, query, playlistID, trackID, rank, addedBy, time.Now(),
 	).Scan(
 		&model.PlaylistID,
 		&model.TrackID,
 		&model.Position,
+		&model.Rank,
+		&model.AddedBy,
 		&addedAt,
 	)
 
@@ -147,14 +264,14 @@ func (r *PlaylistTracksRepo) Add(ctx context.Context, playlistID, trackID int64,
 	return &model, nil
 }
 
-func (r *PlaylistTracksRepo) UpdatePosition(ctx context.Context, playlistID, trackID int64, position int) error {
+func (r *PlaylistTracksRepo) UpdateRank(ctx context.Context, playlistID, trackID int64, rank string) error {
 	query := `
 		UPDATE playlist_tracks
-		SET position = $1
+		SET rank = $1
 		WHERE playlist_id = $2 AND track_id = $3
 	`
 
-	_, err := r.postgres.ExecContext(ctx, query, position, playlistID, trackID)
+	_, err := r.db(ctx).ExecContext(ctx, query, rank, playlistID, trackID)
 	return err
 }
 
@@ -162,33 +279,42 @@ func (r *PlaylistTracksRepo) Remove(ctx context.Context, playlistID, trackID int
 	query := `
 		DELETE FROM playlist_tracks
 		WHERE playlist_id = $1 AND track_id = $2
-		RETURNING position
 	`
 
-	var position int
-	err := r.postgres.QueryRowContext(ctx, query, playlistID, trackID).Scan(&position)
+	result, err := r.db(ctx).ExecContext(ctx, query, playlistID, trackID)
 	if err != nil {
 		return err
 	}
 
-	err = r.DecrementPositions(ctx, playlistID, position)
-	return err
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
 }
 
 func (r *PlaylistTracksRepo) GetOne(ctx context.Context, playlistID, trackID int64) (*PlaylistTrackModel, error) {
 	query := `
-		SELECT playlist_id, track_id, position, added_at
-		FROM playlist_tracks
-		WHERE playlist_id = $1 AND track_id = $2
+		SELECT pt.playlist_id, pt.track_id,
+			(SELECT COUNT(*) FROM playlist_tracks o WHERE o.playlist_id = pt.playlist_id AND o.rank <= pt.rank) as position,
+			pt.rank, pt.added_by, pt.added_at
+		FROM playlist_tracks pt
+		WHERE pt.playlist_id = $1 AND pt.track_id = $2
 	`
 
 	var model PlaylistTrackModel
 	var addedAt time.Time
 
-	err := r.postgres.QueryRowContext(ctx, query, playlistID, trackID).Scan(
+	err := r.db(ctx).QueryRowContext(ctx, query, playlistID, trackID).Scan(
 		&model.PlaylistID,
 		&model.TrackID,
 		&model.Position,
+		&model.Rank,
+		&model.AddedBy,
 		&addedAt,
 	)
 
@@ -201,62 +327,182 @@ func (r *PlaylistTracksRepo) GetOne(ctx context.Context, playlistID, trackID int
 	return &model, nil
 }
 
-func (r *PlaylistTracksRepo) GetLastPosition(ctx context.Context, playlistID int64) (int, error) {
+func (r *PlaylistTracksRepo) GetNeighborRanks(ctx context.Context, playlistID, excludeTrackID int64, position int) (string, string, error) {
+	if position > 0 {
+		query := `
+			SELECT rank
+			FROM playlist_tracks
+			WHERE playlist_id = $1 AND track_id <> $2
+			ORDER BY rank ASC
+			OFFSET $3
+			LIMIT 2
+		`
+
+		offset := max(position-2, 0)
+		rows, err := r.db(ctx).QueryContext(ctx, query, playlistID, excludeTrackID, offset)
+		if err != nil {
+			return "", "", err
+		}
+		defer func() {
+			err := rows.Close()
+			if err != nil {
+				r.log.Error("Failed to close rows", "error", err)
+			}
+		}()
+
+		var ranks []string
+		for rows.Next() {
+			var rank string
+			if err := rows.Scan(&rank); err != nil {
+				return "", "", err
+			}
+			ranks = append(ranks, rank)
+		}
+
+		if err := rows.Err(); err != nil {
+			return "", "", err
+		}
+
+		switch {
+		case position == 1 && len(ranks) > 0:
+			return "", ranks[0], nil
+		case position > 1 && len(ranks) == 2:
+			return ranks[0], ranks[1], nil
+		case position > 1 && len(ranks) == 1:
+			return ranks[0], "", nil
+		}
+	}
+
+	query := `
+		SELECT rank
+		FROM playlist_tracks
+		WHERE playlist_id = $1 AND track_id <> $2
+		ORDER BY rank DESC
+		LIMIT 1
+	`
+
+	var lastRank string
+	err := r.db(ctx).QueryRowContext(ctx, query, playlistID, excludeTrackID).Scan(&lastRank)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return "", "", err
+	}
+
+	return lastRank, "", nil
+}
+
+func (r *PlaylistTracksRepo) LockPlaylist(ctx context.Context, playlistID int64) error {
 	query := `
-		SELECT COALESCE(MAX(position), 0)
+		SELECT id
+		FROM playlists
+		WHERE id = $1
+		FOR UPDATE
+	`
+
+	var id int64
+	return r.db(ctx).QueryRowContext(ctx, query, playlistID).Scan(&id)
+}
+
+func (r *PlaylistTracksRepo) GetTrackIDs(ctx context.Context, playlistID int64) ([]int64, error) {
+	query := `
+		SELECT track_id
 		FROM playlist_tracks
 		WHERE playlist_id = $1
+		ORDER BY rank ASC
 	`
 
-	var lastPosition int
-	err := r.postgres.QueryRowContext(ctx, query, playlistID).Scan(&lastPosition)
-	if err != nil {
-		return 0, err
+	return r.getTrackIDs(ctx, query, playlistID)
+}
+
+func (r *PlaylistTracksRepo) GetSortedTrackIDs(ctx context.Context, playlistID int64, sort SortField, order SortOrder) ([]int64, error) {
+	direction := "ASC"
+	if order == OrderDesc {
+		direction = "DESC"
 	}
 
-	return lastPosition, nil
+	query := fmt.Sprintf(`
+		SELECT pt.track_id
+		FROM playlist_tracks pt
+		JOIN tracks t ON pt.track_id = t.id
+		WHERE pt.playlist_id = $1
+		ORDER BY %s %s, pt.rank ASC
+	`, sortColumns[sort], direction)
+
+	return r.getTrackIDs(ctx, query, playlistID)
 }
 
-func (r *PlaylistTracksRepo) IncrementPositions(ctx context.Context, playlistID int64, fromPosition int) error {
+func (r *PlaylistTracksRepo) GetDuplicateTrackIDs(ctx context.Context, playlistID int64) ([]int64, error) {
 	query := `
-		UPDATE playlist_tracks
-		SET position = position + 1
-		WHERE playlist_id = $1 AND position >= $2
+		SELECT track_id
+		FROM (
+			SELECT pt.track_id,
+				ROW_NUMBER() OVER (PARTITION BY lower(t.title), lower(t.username) ORDER BY pt.rank) as occurrence
+			FROM playlist_tracks pt
+			JOIN tracks t ON pt.track_id = t.id
+			WHERE pt.playlist_id = $1
+		) ranked
+		WHERE occurrence > 1
+	`
+
+	return r.getTrackIDs(ctx, query, playlistID)
+}
+
+func (r *PlaylistTracksRepo) AddMany(ctx context.Context, playlistID, addedBy int64, trackIDs []int64, ranks []string) error {
+	query := `
+		INSERT INTO playlist_tracks (playlist_id, track_id, rank, added_by, added_at)
+		SELECT $1, track_id, rank, $2, $3
+		FROM unnest($4::int[], $5::text[]) AS input(track_id, rank)
 	`
 
-	_, err := r.postgres.ExecContext(ctx, query, playlistID, fromPosition)
+	_, err := r.db(ctx).ExecContext(ctx, query, playlistID, addedBy, time.Now(), pq.Array(trackIDs), pq.Array(ranks))
 	return err
 }
 
-func (r *PlaylistTracksRepo) DecrementPositions(ctx context.Context, playlistID int64, fromPosition int) error {
+func (r *PlaylistTracksRepo) RemoveMany(ctx context.Context, playlistID int64, trackIDs []int64) error {
 	query := `
-		UPDATE playlist_tracks
-		SET position = position - 1
-		WHERE playlist_id = $1 AND position > $2
+		DELETE FROM playlist_tracks
+		WHERE playlist_id = $1 AND track_id = ANY($2)
 	`
 
-	_, err := r.postgres.ExecContext(ctx, query, playlistID, fromPosition)
+	_, err := r.db(ctx).ExecContext(ctx, query, playlistID, pq.Array(trackIDs))
 	return err
 }
 
-func (r *PlaylistTracksRepo) MovePositions(ctx context.Context, playlistID int64, fromPosition, toPosition int) error {
-	if fromPosition < toPosition {
-		query := `
-			UPDATE playlist_tracks
-			SET position = position - 1
-			WHERE playlist_id = $1 AND position > $2 AND position <= $3
-		`
-		_, err := r.postgres.ExecContext(ctx, query, playlistID, fromPosition, toPosition)
-		return err
-	} else if fromPosition > toPosition {
-		query := `
-			UPDATE playlist_tracks
-			SET position = position + 1
-			WHERE playlist_id = $1 AND position >= $2 AND position < $3
-		`
-		_, err := r.postgres.ExecContext(ctx, query, playlistID, toPosition, fromPosition)
-		return err
+func (r *PlaylistTracksRepo) SetRanks(ctx context.Context, playlistID int64, trackIDs []int64, ranks []string) error {
+	query := `
+		UPDATE playlist_tracks pt
+		SET rank = input.rank
+		FROM unnest($2::int[], $3::text[]) AS input(track_id, rank)
+		WHERE pt.playlist_id = $1 AND pt.track_id = input.track_id
+	`
+
+	_, err := r.db(ctx).ExecContext(ctx, query, playlistID, pq.Array(trackIDs), pq.Array(ranks))
+	return err
+}
+
+func (r *PlaylistTracksRepo) getTrackIDs(ctx context.Context, query string, args ...any) ([]int64, error) {
+	rows, err := r.db(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			r.log.Error("Failed to close rows", "error", err)
+		}
+	}()
+
+	var trackIDs []int64
+	for rows.Next() {
+		var trackID int64
+		if err := rows.Scan(&trackID); err != nil {
+			return nil, err
+		}
+		trackIDs = append(trackIDs, trackID)
 	}
 
-	return nil
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return trackIDs, nil
 }
@@ -10,7 +10,8 @@ type PlaylistUri struct {
 }
 
 type GetManyForm struct {
-	Take int `form:"take" binding:"omitempty,min=1"`
+	Take       int    `form:"take" binding:"omitempty,min=1"`
+	ShareToken string `form:"shareToken"`
 }
 
 type AddTrackJSON struct {
@@ -20,3 +21,31 @@ type AddTrackJSON struct {
 type UpdatePositionJSON struct {
 	Position int `json:"position" binding:"required,min=1"`
 }
+
+type BatchJSON struct {
+	Operations []BatchOperationJSON `json:"operations" binding:"required,min=1,max=100,dive"`
+}
+
+type BatchOperationJSON struct {
+	Type     string `json:"type" binding:"required,oneof=add move remove"`
+	TrackID  int64  `json:"trackId" binding:"required,min=1"`
+	Position int    `json:"position" binding:"omitempty,min=1"`
+}
+
+type AddManyJSON struct {
+	TrackIDs []int64 `json:"trackIds" binding:"required,min=1,max=500,dive,min=1"`
+	Position int     `json:"position" binding:"omitempty,min=1"`
+}
+
+type RemoveManyJSON struct {
+	TrackIDs []int64 `json:"trackIds" binding:"required,min=1,max=500,dive,min=1"`
+}
+
+type SortJSON struct {
+	Sort  string `json:"sort" binding:"required,oneof=title artist duration addedAt"`
+	Order string `json:"order" binding:"omitempty,oneof=asc desc"`
+}
+
+type ReplaceOrderJSON struct {
+	TrackIDs []int64 `json:"trackIds" binding:"required,max=10000,dive,min=1"`
+}
@@ -0,0 +1,104 @@
+package playlisttracks
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/ocenb/music-go/content-service/internal/modules/playlist"
+)
+
+var ruleComparisons = map[playlist.RuleOperator]string{
+	playlist.RuleOperatorEq:  "=",
+	playlist.RuleOperatorNeq: "<>",
+	playlist.RuleOperatorGt:  ">",
+	playlist.RuleOperatorGte: ">=",
+	playlist.RuleOperatorLt:  "<",
+	playlist.RuleOperatorLte: "<=",
+}
+
+var ruleTextColumns = map[playlist.RuleField]string{
+	playlist.RuleFieldArtist: "lower(t.username)",
+	playlist.RuleFieldTitle:  "lower(t.title)",
+	playlist.RuleFieldGenre:  "lower(t.genre)",
+}
+
+var ruleNumberColumns = map[playlist.RuleField]string{
+	playlist.RuleFieldDuration: "t.duration",
+	playlist.RuleFieldPlays:    "t.plays",
+}
+
+var ruleSortColumns = map[playlist.RuleSort]string{
+	playlist.RuleSortNewest:        "t.created_at DESC, t.id DESC",
+	playlist.RuleSortOldest:        "t.created_at ASC, t.id ASC",
+	playlist.RuleSortMostPlayed:    "t.plays DESC, t.id DESC",
+	playlist.RuleSortRecentlyLiked: "ol.added_at DESC NULLS LAST, t.id DESC",
+	playlist.RuleSortTitle:         "lower(t.title) ASC, t.id ASC",
+}
+
+type ruleCompiler struct {
+	args []any
+}
+
+func compileRules(rules *playlist.RuleSetModel, ownerID int64, followingIDs []int64) (string, string, []any) {
+	compiler := &ruleCompiler{args: []any{ownerID}}
+
+	conditions := make([]string, 0, len(rules.Rules))
+	for _, rule := range rules.Rules {
+		conditions = append(conditions, compiler.condition(rule, followingIDs))
+	}
+
+	separator := " AND "
+	if rules.Match == playlist.RuleMatchAny {
+		separator = " OR "
+	}
+
+	orderBy, ok := ruleSortColumns[rules.Sort]
+	if !ok {
+		orderBy = ruleSortColumns[playlist.RuleSortNewest]
+	}
+
+	return strings.Join(conditions, separator), orderBy, compiler.args
+}
+
+func (c *ruleCompiler) arg(value any) string {
+	c.args = append(c.args, value)
+	return fmt.Sprintf("$%d", len(c.args))
+}
+
+func (c *ruleCompiler) condition(rule *playlist.RuleModel, followingIDs []int64) string {
+	switch rule.Field {
+	case playlist.RuleFieldLiked:
+		if value, _ := rule.Value.(bool); value {
+			return "ol.user_id IS NOT NULL"
+		}
+		return "ol.user_id IS NULL"
+	case playlist.RuleFieldFollowedArtist:
+		condition := fmt.Sprintf("t.user_id = ANY(%s)", c.arg(pq.Array(followingIDs)))
+		if value, _ := rule.Value.(bool); value {
+			return condition
+		}
+		return "NOT " + condition
+	case playlist.RuleFieldLikedAt:
+		return fmt.Sprintf("ol.added_at >= NOW() - make_interval(days => %s)", c.arg(numberValue(rule)))
+	case playlist.RuleFieldCreatedAt:
+		return fmt.Sprintf("t.created_at >= NOW() - make_interval(days => %s)", c.arg(numberValue(rule)))
+	}
+
+	if column, ok := ruleNumberColumns[rule.Field]; ok {
+		return fmt.Sprintf("%s %s %s", column, ruleComparisons[rule.Operator], c.arg(numberValue(rule)))
+	}
+
+	column := ruleTextColumns[rule.Field]
+	value, _ := rule.Value.(string)
+	placeholder := c.arg(strings.ToLower(value))
+	if rule.Operator == playlist.RuleOperatorContains {
+		return fmt.Sprintf("strpos(%s, %s) > 0", column, placeholder)
+	}
+	return fmt.Sprintf("%s %s %s", column, ruleComparisons[rule.Operator], placeholder)
+}
+
+func numberValue(rule *playlist.RuleModel) int64 {
+	value, _ := rule.Value.(float64)
+	return int64(value)
+}
@@ -0,0 +1,98 @@
+package playlisttracks
+
+import (
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/ocenb/music-go/content-service/internal/modules/playlist"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompileRules(t *testing.T) {
+	tests := []struct {
+		name       string
+		rules      *playlist.RuleSetModel
+		wantWhere  string
+		wantOrder  string
+		wantParams []any
+	}{
+		{
+			name: "all rules are joined with and",
+			rules: &playlist.RuleSetModel{
+				Match: playlist.RuleMatchAll,
+				Sort:  playlist.RuleSortMostPlayed,
+				Rules: []*playlist.RuleModel{
+					{Field: playlist.RuleFieldLiked, Operator: playlist.RuleOperatorEq, Value: true},
+					{Field: playlist.RuleFieldPlays, Operator: playlist.RuleOperatorGte, Value: float64(100)},
+				},
+			},
+			wantWhere:  "ol.user_id IS NOT NULL AND t.plays >= $2",
+			wantOrder:  "t.plays DESC, t.id DESC",
+			wantParams: []any{int64(1), int64(100)},
+		},
+		{
+			name: "any rules are joined with or",
+			rules: &playlist.RuleSetModel{
+				Match: playlist.RuleMatchAny,
+				Sort:  playlist.RuleSortTitle,
+				Rules: []*playlist.RuleModel{
+					{Field: playlist.RuleFieldLiked, Operator: playlist.RuleOperatorEq, Value: false},
+					{Field: playlist.RuleFieldDuration, Operator: playlist.RuleOperatorLt, Value: float64(180)},
+				},
+			},
+			wantWhere:  "ol.user_id IS NULL OR t.duration < $2",
+			wantOrder:  "lower(t.title) ASC, t.id ASC",
+			wantParams: []any{int64(1), int64(180)},
+		},
+		{
+			name: "text rules compare lowercased values",
+			rules: &playlist.RuleSetModel{
+				Match: playlist.RuleMatchAll,
+				Sort:  playlist.RuleSortOldest,
+				Rules: []*playlist.RuleModel{
+					{Field: playlist.RuleFieldGenre, Operator: playlist.RuleOperatorContains, Value: "Rock"},
+					{Field: playlist.RuleFieldArtist, Operator: playlist.RuleOperatorNeq, Value: "DJ"},
+				},
+			},
+			wantWhere:  "strpos(lower(t.genre), $2) > 0 AND lower(t.username) <> $3",
+			wantOrder:  "t.created_at ASC, t.id ASC",
+			wantParams: []any{int64(1), "rock", "dj"},
+		},
+		{
+			name: "date rules count days back from now",
+			rules: &playlist.RuleSetModel{
+				Match: playlist.RuleMatchAll,
+				Sort:  playlist.RuleSortRecentlyLiked,
+				Rules: []*playlist.RuleModel{
+					{Field: playlist.RuleFieldLikedAt, Operator: playlist.RuleOperatorInLast, Value: float64(30)},
+					{Field: playlist.RuleFieldCreatedAt, Operator: playlist.RuleOperatorInLast, Value: float64(7)},
+				},
+			},
+			wantWhere:  "ol.added_at >= NOW() - make_interval(days => $2) AND t.created_at >= NOW() - make_interval(days => $3)",
+			wantOrder:  "ol.added_at DESC NULLS LAST, t.id DESC",
+			wantParams: []any{int64(1), int64(30), int64(7)},
+		},
+		{
+			name: "followed artist rules use the following ids",
+			rules: &playlist.RuleSetModel{
+				Match: playlist.RuleMatchAny,
+				Rules: []*playlist.RuleModel{
+					{Field: playlist.RuleFieldFollowedArtist, Operator: playlist.RuleOperatorEq, Value: true},
+					{Field: playlist.RuleFieldFollowedArtist, Operator: playlist.RuleOperatorEq, Value: false},
+				},
+			},
+			wantWhere:  "t.user_id = ANY($2) OR NOT t.user_id = ANY($3)",
+			wantOrder:  "t.created_at DESC, t.id DESC",
+			wantParams: []any{int64(1), pq.Array([]int64{2, 3}), pq.Array([]int64{2, 3})},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			where, orderBy, params := compileRules(tt.rules, 1, []int64{2, 3})
+			assert.Equal(t, tt.wantWhere, where)
+			assert.Equal(t, tt.wantOrder, orderBy)
+			assert.Equal(t, tt.wantParams, params)
+		})
+	}
+}
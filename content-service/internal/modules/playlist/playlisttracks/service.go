@@ -5,48 +5,85 @@ import (
 	"database/sql"
 	"errors"
 	"log/slog"
+	"time"
 
+	"github.com/ocenb/music-go/content-service/internal/clients/userclient"
 	"github.com/ocenb/music-go/content-service/internal/modules/playlist"
 	"github.com/ocenb/music-go/content-service/internal/modules/track"
 	"github.com/ocenb/music-go/content-service/internal/storage"
 )
 
 type PlaylistTracksServiceInterface interface {
-	GetMany(ctx context.Context, currentUserID, playlistID int64, take int) ([]*TrackInPlaylistModel, error)
+	GetMany(ctx context.Context, currentUserID, playlistID int64, take int, shareToken string) ([]*TrackInPlaylistModel, error)
 	Add(ctx context.Context, userID, playlistID, trackID int64, position int) (*PlaylistTrackModel, error)
 	UpdatePosition(ctx context.Context, userID, playlistID, trackID int64, position int) error
 	Remove(ctx context.Context, userID, playlistID, trackID int64) error
+	Batch(ctx context.Context, userID, playlistID int64, operations []*BatchOperationModel) ([]*TrackInPlaylistModel, error)
+	AddMany(ctx context.Context, userID, playlistID int64, trackIDs []int64, position int) ([]*TrackInPlaylistModel, error)
+	RemoveMany(ctx context.Context, userID, playlistID int64, trackIDs []int64) ([]*TrackInPlaylistModel, error)
+	RemoveDuplicates(ctx context.Context, userID, playlistID int64) ([]*TrackInPlaylistModel, error)
+	Sort(ctx context.Context, userID, playlistID int64, sort SortField, order SortOrder) ([]*TrackInPlaylistModel, error)
+	ReplaceOrder(ctx context.Context, userID, playlistID int64, trackIDs []int64) ([]*TrackInPlaylistModel, error)
+	Restore(ctx context.Context, userID, playlistID int64, snapshot *playlist.SnapshotModel, change playlist.RevisionChange, details any) error
+	RefreshSmartPlaylists(ctx context.Context) error
+	RefreshPending(ctx context.Context) error
 }
 
+const (
+	smartPlaylistsPageSize = 100
+	refreshBatchSize       = 20
+	refreshRetryDelay      = time.Minute
+)
+
 type PlaylistTracksService struct {
 	log                *slog.Logger
 	playlistTracksRepo PlaylistTracksRepoInterface
 	playlistRepo       playlist.PlaylistRepoInterface
+	playlistService    playlist.PlaylistServiceInterface
 	trackRepo          track.TrackRepoInterface
+	userClient         *userclient.UserServiceClient
 }
 
 func NewPlaylistTracksService(
 	log *slog.Logger,
 	playlistTracksRepo PlaylistTracksRepoInterface,
 	playlistRepo playlist.PlaylistRepoInterface,
+	playlistService playlist.PlaylistServiceInterface,
 	trackRepo track.TrackRepoInterface,
+	userClient *userclient.UserServiceClient,
 ) PlaylistTracksServiceInterface {
 	return &PlaylistTracksService{
 		log:                log,
 		playlistTracksRepo: playlistTracksRepo,
 		playlistRepo:       playlistRepo,
+		playlistService:    playlistService,
 		trackRepo:          trackRepo,
+		userClient:         userClient,
 	}
 }
 
-func (s *PlaylistTracksService) GetMany(ctx context.Context, currentUserID, playlistID int64, take int) ([]*TrackInPlaylistModel, error) {
-	_, err := s.playlistRepo.GetByID(ctx, playlistID, currentUserID)
+func (s *PlaylistTracksService) GetMany(ctx context.Context, currentUserID, playlistID int64, take int, shareToken string) ([]*TrackInPlaylistModel, error) {
+	canView, err := s.playlistRepo.CanView(ctx, currentUserID, playlistID, shareToken)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrPlaylistNotFound
 		}
 		return nil, err
 	}
+	if !canView {
+		return nil, ErrPlaylistNotFound
+	}
+
+	existingPlaylist, err := s.playlistRepo.GetByID(ctx, playlistID, currentUserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPlaylistNotFound
+		}
+		return nil, err
+	}
+	if existingPlaylist.Rules != nil {
+		return s.getManyByRules(ctx, playlistID, existingPlaylist.UserID, currentUserID, existingPlaylist.Rules, take)
+	}
 
 	tracks, err := s.playlistTracksRepo.GetMany(ctx, playlistID, currentUserID, take)
 	if err != nil {
@@ -57,72 +94,525 @@ func (s *PlaylistTracksService) GetMany(ctx context.Context, currentUserID, play
 }
 
 func (s *PlaylistTracksService) Add(ctx context.Context, userID, playlistID, trackID int64, position int) (*PlaylistTrackModel, error) {
-	hasPermission, err := s.playlistRepo.CheckPermission(ctx, userID, playlistID)
-	if err != nil {
+	if err := s.checkEditPermission(ctx, userID, playlistID); err != nil {
 		return nil, err
 	}
-	if !hasPermission {
-		return nil, ErrPermissionDenied
+
+	if err := s.checkTrack(ctx, userID, trackID); err != nil {
+		return nil, err
 	}
 
-	_, err = s.trackRepo.GetByID(ctx, trackID, userID)
+	var playlistTrack *PlaylistTrackModel
+	err := s.withLockedPlaylist(ctx, playlistID, func(txCtx context.Context) error {
+		var err error
+		playlistTrack, err = s.add(txCtx, userID, playlistID, trackID, position)
+		if err != nil {
+			return err
+		}
+
+		return s.playlistRepo.RecordRevision(txCtx, playlistID, userID, playlist.RevisionTrackAdded, map[string]any{"trackId": trackID, "position": position})
+	})
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, ErrTrackNotFound
+		return nil, err
+	}
+
+	return playlistTrack, nil
+}
+
+func (s *PlaylistTracksService) UpdatePosition(ctx context.Context, userID, playlistID, trackID int64, position int) error {
+	if err := s.checkEditPermission(ctx, userID, playlistID); err != nil {
+		return err
+	}
+
+	return s.withLockedPlaylist(ctx, playlistID, func(txCtx context.Context) error {
+		if err := s.move(txCtx, playlistID, trackID, position); err != nil {
+			return err
 		}
+
+		return s.playlistRepo.RecordRevision(txCtx, playlistID, userID, playlist.RevisionTrackMoved, map[string]any{"trackId": trackID, "position": position})
+	})
+}
+
+func (s *PlaylistTracksService) Remove(ctx context.Context, userID, playlistID, trackID int64) error {
+	if err := s.checkEditPermission(ctx, userID, playlistID); err != nil {
+		return err
+	}
+
+	return s.withLockedPlaylist(ctx, playlistID, func(txCtx context.Context) error {
+		if err := s.remove(txCtx, playlistID, trackID); err != nil {
+			return err
+		}
+
+		return s.playlistRepo.RecordRevision(txCtx, playlistID, userID, playlist.RevisionTrackRemoved, map[string]any{"trackId": trackID})
+	})
+}
+
+func (s *PlaylistTracksService) Batch(ctx context.Context, userID, playlistID int64, operations []*BatchOperationModel) ([]*TrackInPlaylistModel, error) {
+	if err := s.checkEditPermission(ctx, userID, playlistID); err != nil {
 		return nil, err
 	}
 
-	trackInPlaylist, err := s.playlistTracksRepo.GetOne(ctx, playlistID, trackID)
-	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+	for _, operation := range operations {
+		switch operation.Type {
+		case BatchAdd:
+			if err := s.checkTrack(ctx, userID, operation.TrackID); err != nil {
+				return nil, err
+			}
+		case BatchMove:
+			if operation.Position <= 0 {
+				return nil, ErrPositionRequired
+			}
+		case BatchRemove:
+		default:
+			return nil, ErrInvalidOperation
+		}
+	}
+
+	err := s.withLockedPlaylist(ctx, playlistID, func(txCtx context.Context) error {
+		for _, operation := range operations {
+			var err error
+			switch operation.Type {
+			case BatchAdd:
+				_, err = s.add(txCtx, userID, playlistID, operation.TrackID, operation.Position)
+			case BatchMove:
+				err = s.move(txCtx, playlistID, operation.TrackID, operation.Position)
+			case BatchRemove:
+				err = s.remove(txCtx, playlistID, operation.TrackID)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		return s.playlistRepo.RecordRevision(txCtx, playlistID, userID, playlist.RevisionTracksBatch, map[string]any{"operations": operations})
+	})
+	if err != nil {
 		return nil, err
 	}
-	if trackInPlaylist != nil {
+
+	return s.playlistTracksRepo.GetMany(ctx, playlistID, userID, 0)
+}
+
+func (s *PlaylistTracksService) AddMany(ctx context.Context, userID, playlistID int64, trackIDs []int64, position int) ([]*TrackInPlaylistModel, error) {
+	if err := s.checkEditPermission(ctx, userID, playlistID); err != nil {
+		return nil, err
+	}
+
+	if hasDuplicates(trackIDs) {
 		return nil, ErrTrackAlreadyInPlaylist
 	}
 
-	lastPosition, err := s.playlistTracksRepo.GetLastPosition(ctx, playlistID)
+	tracks, err := s.trackRepo.GetManyByIDs(ctx, trackIDs, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(tracks) != len(trackIDs) {
+		return nil, ErrTrackNotFound
+	}
+
+	err = s.withLockedPlaylist(ctx, playlistID, func(txCtx context.Context) error {
+		currentTrackIDs, err := s.playlistTracksRepo.GetTrackIDs(txCtx, playlistID)
+		if err != nil {
+			return err
+		}
+
+		current := toSet(currentTrackIDs)
+		for _, trackID := range trackIDs {
+			if _, ok := current[trackID]; ok {
+				return ErrTrackAlreadyInPlaylist
+			}
+		}
+
+		prev, next, err := s.playlistTracksRepo.GetNeighborRanks(txCtx, playlistID, 0, position)
+		if err != nil {
+			return err
+		}
+
+		ranks, err := ranksBetween(prev, next, len(trackIDs))
+		if err != nil {
+			return err
+		}
+
+		if err := s.playlistTracksRepo.AddMany(txCtx, playlistID, userID, trackIDs, ranks); err != nil {
+			return err
+		}
+
+		return s.playlistRepo.RecordRevision(txCtx, playlistID, userID, playlist.RevisionTracksAdded, map[string]any{"trackIds": trackIDs, "position": position})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.playlistTracksRepo.GetMany(ctx, playlistID, userID, 0)
+}
+
+func (s *PlaylistTracksService) RemoveMany(ctx context.Context, userID, playlistID int64, trackIDs []int64) ([]*TrackInPlaylistModel, error) {
+	if err := s.checkEditPermission(ctx, userID, playlistID); err != nil {
+		return nil, err
+	}
+
+	err := s.withLockedPlaylist(ctx, playlistID, func(txCtx context.Context) error {
+		currentTrackIDs, err := s.playlistTracksRepo.GetTrackIDs(txCtx, playlistID)
+		if err != nil {
+			return err
+		}
+
+		current := toSet(currentTrackIDs)
+		for _, trackID := range trackIDs {
+			if _, ok := current[trackID]; !ok {
+				return ErrTrackNotInPlaylist
+			}
+		}
+
+		if err := s.playlistTracksRepo.RemoveMany(txCtx, playlistID, trackIDs); err != nil {
+			return err
+		}
+
+		return s.playlistRepo.RecordRevision(txCtx, playlistID, userID, playlist.RevisionTracksRemoved, map[string]any{"trackIds": trackIDs})
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	newPosition := lastPosition + 1
-	if position > 0 && position <= lastPosition+1 {
-		newPosition = position
+	return s.playlistTracksRepo.GetMany(ctx, playlistID, userID, 0)
+}
+
+func (s *PlaylistTracksService) RemoveDuplicates(ctx context.Context, userID, playlistID int64) ([]*TrackInPlaylistModel, error) {
+	if err := s.checkEditPermission(ctx, userID, playlistID); err != nil {
+		return nil, err
 	}
 
-	var playlistTrack *PlaylistTrackModel
-	err = storage.WithTransaction(ctx, s.playlistTracksRepo, func(txCtx context.Context) error {
-		if position > 0 && position <= lastPosition {
-			err = s.playlistTracksRepo.IncrementPositions(txCtx, playlistID, position)
+	err := s.withLockedPlaylist(ctx, playlistID, func(txCtx context.Context) error {
+		duplicateTrackIDs, err := s.playlistTracksRepo.GetDuplicateTrackIDs(txCtx, playlistID)
+		if err != nil {
+			return err
+		}
+		if len(duplicateTrackIDs) == 0 {
+			return nil
+		}
+
+		if err := s.playlistTracksRepo.RemoveMany(txCtx, playlistID, duplicateTrackIDs); err != nil {
+			return err
+		}
+
+		return s.playlistRepo.RecordRevision(txCtx, playlistID, userID, playlist.RevisionDuplicatesRemoved, map[string]any{"trackIds": duplicateTrackIDs})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.playlistTracksRepo.GetMany(ctx, playlistID, userID, 0)
+}
+
+func (s *PlaylistTracksService) Sort(ctx context.Context, userID, playlistID int64, sort SortField, order SortOrder) ([]*TrackInPlaylistModel, error) {
+	if err := s.checkEditPermission(ctx, userID, playlistID); err != nil {
+		return nil, err
+	}
+
+	err := s.withLockedPlaylist(ctx, playlistID, func(txCtx context.Context) error {
+		trackIDs, err := s.playlistTracksRepo.GetSortedTrackIDs(txCtx, playlistID, sort, order)
+		if err != nil {
+			return err
+		}
+
+		if err := s.setOrder(txCtx, playlistID, trackIDs); err != nil {
+			return err
+		}
+
+		return s.playlistRepo.RecordRevision(txCtx, playlistID, userID, playlist.RevisionTracksSorted, map[string]any{"sort": sort, "order": order})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.playlistTracksRepo.GetMany(ctx, playlistID, userID, 0)
+}
+
+func (s *PlaylistTracksService) ReplaceOrder(ctx context.Context, userID, playlistID int64, trackIDs []int64) ([]*TrackInPlaylistModel, error) {
+	if err := s.checkEditPermission(ctx, userID, playlistID); err != nil {
+		return nil, err
+	}
+
+	if hasDuplicates(trackIDs) {
+		return nil, ErrOrderMismatch
+	}
+
+	err := s.withLockedPlaylist(ctx, playlistID, func(txCtx context.Context) error {
+		currentTrackIDs, err := s.playlistTracksRepo.GetTrackIDs(txCtx, playlistID)
+		if err != nil {
+			return err
+		}
+
+		if len(currentTrackIDs) != len(trackIDs) {
+			return ErrOrderMismatch
+		}
+		current := toSet(currentTrackIDs)
+		for _, trackID := range trackIDs {
+			if _, ok := current[trackID]; !ok {
+				return ErrOrderMismatch
+			}
+		}
+
+		if err := s.setOrder(txCtx, playlistID, trackIDs); err != nil {
+			return err
+		}
+
+		return s.playlistRepo.RecordRevision(txCtx, playlistID, userID, playlist.RevisionTracksReordered, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.playlistTracksRepo.GetMany(ctx, playlistID, userID, 0)
+}
+
+func (s *PlaylistTracksService) Restore(ctx context.Context, userID, playlistID int64, snapshot *playlist.SnapshotModel, change playlist.RevisionChange, details any) error {
+	existingTracks, err := s.trackRepo.GetManyByIDs(ctx, snapshot.TrackIDs, userID)
+	if err != nil {
+		return err
+	}
+	existing := make(map[int64]struct{}, len(existingTracks))
+	for _, existingTrack := range existingTracks {
+		existing[existingTrack.ID] = struct{}{}
+	}
+
+	trackIDs := make([]int64, 0, len(snapshot.TrackIDs))
+	for _, trackID := range snapshot.TrackIDs {
+		if _, ok := existing[trackID]; ok {
+			trackIDs = append(trackIDs, trackID)
+		}
+	}
+
+	var visibilityChanged bool
+	var staleImage string
+	err = s.withLockedPlaylist(ctx, playlistID, func(txCtx context.Context) error {
+		current, err := s.playlistRepo.GetForIndexing(txCtx, playlistID)
+		if err != nil {
+			return err
+		}
+		cover, err := s.playlistRepo.GetCover(txCtx, playlistID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.playlistRepo.ChangeTitle(txCtx, playlistID, snapshot.Title); err != nil {
+			return err
+		}
+		if err := s.playlistRepo.ChangeChangeableID(txCtx, playlistID, snapshot.ChangeableID); err != nil {
+			return err
+		}
+		if err := s.playlistRepo.ChangeRules(txCtx, playlistID, snapshot.Rules); err != nil {
+			return err
+		}
+		if snapshot.Visibility != nil && *snapshot.Visibility != current.Visibility {
+			if err := s.playlistRepo.ChangeVisibility(txCtx, playlistID, *snapshot.Visibility); err != nil {
+				return err
+			}
+			visibilityChanged = true
+		}
+		staleImage, err = s.restoreCover(txCtx, playlistID, cover, snapshot)
+		if err != nil {
+			return err
+		}
+
+		currentTrackIDs, err := s.playlistTracksRepo.GetTrackIDs(txCtx, playlistID)
+		if err != nil {
+			return err
+		}
+
+		restored := toSet(trackIDs)
+		var removedTrackIDs []int64
+		for _, trackID := range currentTrackIDs {
+			if _, ok := restored[trackID]; !ok {
+				removedTrackIDs = append(removedTrackIDs, trackID)
+			}
+		}
+		if len(removedTrackIDs) > 0 {
+			if err := s.playlistTracksRepo.RemoveMany(txCtx, playlistID, removedTrackIDs); err != nil {
+				return err
+			}
+		}
+
+		if len(trackIDs) > 0 {
+			ranks, err := ranksBetween("", "", len(trackIDs))
 			if err != nil {
 				return err
 			}
+
+			current := toSet(currentTrackIDs)
+			var keptTrackIDs, addedTrackIDs []int64
+			var keptRanks, addedRanks []string
+			for i, trackID := range trackIDs {
+				if _, ok := current[trackID]; ok {
+					keptTrackIDs = append(keptTrackIDs, trackID)
+					keptRanks = append(keptRanks, ranks[i])
+				} else {
+					addedTrackIDs = append(addedTrackIDs, trackID)
+					addedRanks = append(addedRanks, ranks[i])
+				}
+			}
+
+			if len(keptTrackIDs) > 0 {
+				if err := s.playlistTracksRepo.SetRanks(txCtx, playlistID, keptTrackIDs, keptRanks); err != nil {
+					return err
+				}
+			}
+			if len(addedTrackIDs) > 0 {
+				if err := s.playlistTracksRepo.AddMany(txCtx, playlistID, userID, addedTrackIDs, addedRanks); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := s.playlistRepo.RecordRevision(txCtx, playlistID, userID, change, details); err != nil {
+			return err
+		}
+
+		return s.playlistService.SyncSearch(txCtx, playlistID)
+	})
+	if err != nil {
+		return err
+	}
+
+	if visibilityChanged {
+		s.playlistService.SyncFeed(ctx, playlistID)
+	}
+	if staleImage != "" {
+		return s.playlistService.ReleaseImage(ctx, playlistID, staleImage)
+	}
+
+	return nil
+}
+
+// restoreCover puts back the cover a snapshot recorded and returns the image it
+// replaced. Snapshots taken before covers were tracked leave the cover as is.
+func (s *PlaylistTracksService) restoreCover(ctx context.Context, playlistID int64, cover *playlist.CoverModel, snapshot *playlist.SnapshotModel) (string, error) {
+	if snapshot.ImageAuto == nil {
+		return "", nil
+	}
+
+	if *snapshot.ImageAuto {
+		if cover.Auto {
+			return "", nil
+		}
+		return cover.Image, s.playlistRepo.ResetImage(ctx, playlistID)
+	}
+
+	if snapshot.Image == nil || (!cover.Auto && cover.Image == *snapshot.Image) {
+		return "", nil
+	}
+	return cover.Image, s.playlistRepo.ChangeImage(ctx, playlistID, *snapshot.Image)
+}
+
+// RefreshSmartPlaylists re-evaluates every rule-based playlist and stores its
+// current track count and duration. Rules on followed artists need the owner's
+// credentials to resolve, so those playlists are only refreshed when read.
+func (s *PlaylistTracksService) RefreshSmartPlaylists(ctx context.Context) error {
+	var lastID int64
+
+	for {
+		playlists, err := s.playlistRepo.GetSmartPlaylists(ctx, lastID, smartPlaylistsPageSize)
+		if err != nil {
+			return err
 		}
 
-		playlistTrack, err = s.playlistTracksRepo.Add(txCtx, playlistID, trackID, newPosition)
+		for _, smartPlaylist := range playlists {
+			if smartPlaylist.Rules.UsesFollowing() {
+				continue
+			}
+
+			if err := s.refreshSmartStats(ctx, smartPlaylist.ID, smartPlaylist.UserID, smartPlaylist.Rules, nil); err != nil {
+				s.log.Error("Failed to refresh smart playlist", "error", err, "playlist_id", smartPlaylist.ID)
+			}
+		}
+
+		if len(playlists) < smartPlaylistsPageSize {
+			return nil
+		}
+		lastID = playlists[len(playlists)-1].ID
+	}
+}
+
+// RefreshPending recomputes the covers and smart playlist stats that
+// mutations queued. Failed refreshes are retried after a delay.
+func (s *PlaylistTracksService) RefreshPending(ctx context.Context) error {
+	for {
+		requests, err := s.playlistRepo.GetRefreshRequests(ctx, refreshBatchSize)
 		if err != nil {
 			return err
 		}
 
+		for _, request := range requests {
+			if err := s.refresh(ctx, request); err != nil {
+				s.log.Error("Failed to refresh playlist", "error", err, "playlist_id", request.PlaylistID)
+				if err := s.playlistRepo.DeferRefreshRequest(ctx, request.PlaylistID, request.RequestedAt, refreshRetryDelay); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if err := s.playlistRepo.ClearRefreshRequest(ctx, request.PlaylistID, request.RequestedAt); err != nil {
+				return err
+			}
+		}
+
+		if len(requests) < refreshBatchSize {
+			return nil
+		}
+	}
+}
+
+func (s *PlaylistTracksService) refresh(ctx context.Context, request *playlist.RefreshRequestModel) error {
+	if request.Rules == nil {
+		return s.playlistService.RefreshCover(ctx, request.PlaylistID)
+	}
+	if request.Rules.UsesFollowing() {
 		return nil
-	})
+	}
+
+	return s.refreshSmartStats(ctx, request.PlaylistID, request.UserID, request.Rules, nil)
+}
+
+func (s *PlaylistTracksService) refreshSmartStats(ctx context.Context, playlistID, ownerID int64, rules *playlist.RuleSetModel, followingIDs []int64) error {
+	trackCount, totalDuration, err := s.playlistTracksRepo.GetStatsByRules(ctx, ownerID, rules, followingIDs)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return playlistTrack, nil
+	return s.playlistRepo.ChangeStats(ctx, playlistID, trackCount, totalDuration)
 }
 
-func (s *PlaylistTracksService) UpdatePosition(ctx context.Context, userID, playlistID, trackID int64, position int) error {
-	hasPermission, err := s.playlistRepo.CheckPermission(ctx, userID, playlistID)
+func (s *PlaylistTracksService) setOrder(ctx context.Context, playlistID int64, trackIDs []int64) error {
+	if len(trackIDs) == 0 {
+		return nil
+	}
+
+	ranks, err := ranksBetween("", "", len(trackIDs))
 	if err != nil {
 		return err
 	}
-	if !hasPermission {
-		return ErrPermissionDenied
+
+	return s.playlistTracksRepo.SetRanks(ctx, playlistID, trackIDs, ranks)
+}
+
+func (s *PlaylistTracksService) add(ctx context.Context, userID, playlistID, trackID int64, position int) (*PlaylistTrackModel, error) {
+	trackInPlaylist, err := s.playlistTracksRepo.GetOne(ctx, playlistID, trackID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if trackInPlaylist != nil {
+		return nil, ErrTrackAlreadyInPlaylist
 	}
 
+	rank, err := s.rankAt(ctx, playlistID, trackID, position)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.playlistTracksRepo.Add(ctx, playlistID, trackID, userID, rank)
+}
+
+func (s *PlaylistTracksService) move(ctx context.Context, playlistID, trackID int64, position int) error {
 	trackInPlaylist, err := s.playlistTracksRepo.GetOne(ctx, playlistID, trackID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -135,47 +625,122 @@ func (s *PlaylistTracksService) UpdatePosition(ctx context.Context, userID, play
 		return ErrPositionConflict
 	}
 
-	err = storage.WithTransaction(ctx, s.playlistTracksRepo, func(txCtx context.Context) error {
-		err = s.playlistTracksRepo.MovePositions(txCtx, playlistID, trackInPlaylist.Position, position)
+	rank, err := s.rankAt(ctx, playlistID, trackID, position)
+	if err != nil {
+		return err
+	}
+
+	return s.playlistTracksRepo.UpdateRank(ctx, playlistID, trackID, rank)
+}
+
+func (s *PlaylistTracksService) remove(ctx context.Context, playlistID, trackID int64) error {
+	err := s.playlistTracksRepo.Remove(ctx, playlistID, trackID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrTrackNotInPlaylist
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *PlaylistTracksService) rankAt(ctx context.Context, playlistID, trackID int64, position int) (string, error) {
+	prev, next, err := s.playlistTracksRepo.GetNeighborRanks(ctx, playlistID, trackID, position)
+	if err != nil {
+		return "", err
+	}
+
+	return rankBetween(prev, next)
+}
+
+func (s *PlaylistTracksService) withLockedPlaylist(ctx context.Context, playlistID int64, fn func(txCtx context.Context) error) error {
+	return storage.WithTransaction(ctx, s.playlistTracksRepo, func(txCtx context.Context) error {
+		err := s.playlistTracksRepo.LockPlaylist(txCtx, playlistID)
 		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrPlaylistNotFound
+			}
 			return err
 		}
 
-		err = s.playlistTracksRepo.UpdatePosition(txCtx, playlistID, trackID, position)
-		if err != nil {
+		if err := fn(txCtx); err != nil {
 			return err
 		}
 
-		return nil
+		return s.playlistRepo.RequestRefresh(txCtx, playlistID)
 	})
+}
+
+func (s *PlaylistTracksService) checkTrack(ctx context.Context, userID, trackID int64) error {
+	_, err := s.trackRepo.GetByID(ctx, trackID, userID)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrTrackNotFound
+		}
 		return err
 	}
 
 	return nil
 }
 
-func (s *PlaylistTracksService) Remove(ctx context.Context, userID, playlistID, trackID int64) error {
-	hasPermission, err := s.playlistRepo.CheckPermission(ctx, userID, playlistID)
-	if err != nil {
-		return err
+func (s *PlaylistTracksService) getManyByRules(ctx context.Context, playlistID, ownerID, currentUserID int64, rules *playlist.RuleSetModel, take int) ([]*TrackInPlaylistModel, error) {
+	var followingIDs []int64
+	if rules.UsesFollowing() {
+		following, err := s.userClient.GetAllFollowing(ctx, ownerID)
+		if err != nil {
+			return nil, err
+		}
+
+		followingIDs = make([]int64, 0, len(following))
+		for _, user := range following {
+			followingIDs = append(followingIDs, user.Id)
+		}
 	}
-	if !hasPermission {
-		return ErrPermissionDenied
+
+	if rules.UsesFollowing() {
+		if err := s.refreshSmartStats(ctx, playlistID, ownerID, rules, followingIDs); err != nil {
+			s.log.Error("Failed to refresh smart playlist stats", "error", err, "playlist_id", playlistID)
+		}
 	}
 
-	_, err = s.playlistTracksRepo.GetOne(ctx, playlistID, trackID)
+	return s.playlistTracksRepo.GetManyByRules(ctx, playlistID, ownerID, currentUserID, rules, followingIDs, take)
+}
+
+func (s *PlaylistTracksService) checkEditPermission(ctx context.Context, userID, playlistID int64) error {
+	role, err := s.playlistRepo.GetRole(ctx, userID, playlistID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return ErrTrackNotInPlaylist
+			return ErrPlaylistNotFound
 		}
 		return err
 	}
+	if !role.CanEdit() {
+		return ErrPermissionDenied
+	}
 
-	err = s.playlistTracksRepo.Remove(ctx, playlistID, trackID)
+	rules, err := s.playlistRepo.GetRules(ctx, playlistID)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrPlaylistNotFound
+		}
 		return err
 	}
+	if rules != nil {
+		return ErrSmartPlaylist
+	}
 
 	return nil
 }
+
+func toSet(ids []int64) map[int64]struct{} {
+	set := make(map[int64]struct{}, len(ids))
+	for _, id := range ids {
+		set[id] = struct{}{}
+	}
+	return set
+}
+
+func hasDuplicates(ids []int64) bool {
+	return len(toSet(ids)) != len(ids)
+}
@@ -3,26 +3,60 @@ package playlist
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"log/slog"
 	"time"
+
+	"github.com/lib/pq"
+	"github.com/ocenb/music-go/content-service/internal/utils"
 )
 
 type PlaylistRepoInterface interface {
 	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
 	GetByID(ctx context.Context, playlistID int64, currentUserID int64) (*PlaylistWithSavedModel, error)
-	GetByChangeableID(ctx context.Context, username, changeableID string, currentUserID int64) (*PlaylistWithSavedModel, error)
+	GetForIndexing(ctx context.Context, playlistID int64) (*PlaylistModel, error)
+	GetByChangeableID(ctx context.Context, username, changeableID, shareToken string, currentUserID int64) (*PlaylistWithSavedModel, error)
 	GetMany(ctx context.Context, userID int64, currentUserID int64, take int, lastID int64) ([]*PlaylistWithSavedModel, error)
 	GetManyWithSaved(ctx context.Context, currentUserID int64, take int, lastID int64) ([]*PlaylistWithSavedModel, error)
-	Create(ctx context.Context, userID int64, username, title, changeableID, image string) (*PlaylistModel, error)
+	Create(ctx context.Context, userID int64, username, title, changeableID, image string, rules *RuleSetModel) (*PlaylistModel, error)
 	CheckPermission(ctx context.Context, userID, playlistID int64) (bool, error)
+	GetRole(ctx context.Context, userID, playlistID int64) (Role, error)
+	GetRules(ctx context.Context, playlistID int64) (*RuleSetModel, error)
+	CanView(ctx context.Context, userID, playlistID int64, shareToken string) (bool, error)
 	Delete(ctx context.Context, playlistID int64) error
 	ChangeTitle(ctx context.Context, playlistID int64, title string) error
 	ChangeChangeableID(ctx context.Context, playlistID int64, changeableID string) error
 	ChangeImage(ctx context.Context, playlistID int64, image string) error
+	ResetImage(ctx context.Context, playlistID int64) error
+	GetCover(ctx context.Context, playlistID int64) (*CoverModel, error)
+	GetCoverCandidates(ctx context.Context, playlistID int64, take int) ([]string, error)
+	ChangeCover(ctx context.Context, playlistID int64, image string, source []string) (bool, error)
+	RequestRefresh(ctx context.Context, playlistID int64) error
+	RequestRefreshByCoverImage(ctx context.Context, image string) error
+	GetRefreshRequests(ctx context.Context, take int) ([]*RefreshRequestModel, error)
+	ClearRefreshRequest(ctx context.Context, playlistID int64, requestedAt time.Time) error
+	DeferRefreshRequest(ctx context.Context, playlistID int64, requestedAt time.Time, delay time.Duration) error
+	ChangeVisibility(ctx context.Context, playlistID int64, visibility Visibility) error
+	ChangeShareToken(ctx context.Context, playlistID int64, shareToken string) error
+	ChangeRules(ctx context.Context, playlistID int64, rules *RuleSetModel) error
+	GetSmartPlaylists(ctx context.Context, lastID int64, take int) ([]*PlaylistModel, error)
+	ChangeStats(ctx context.Context, playlistID int64, trackCount, totalDuration int64) error
+	RecordRevision(ctx context.Context, playlistID, actorID int64, change RevisionChange, details any) error
+	GetRevisionImages(ctx context.Context, playlistID int64) ([]string, error)
+	IsImageInRevisions(ctx context.Context, playlistID int64, image string) (bool, error)
 	CheckTitle(ctx context.Context, userID int64, title string) (bool, error)
 	CheckChangeableID(ctx context.Context, userID int64, changeableID string) (bool, error)
-	SavePlaylist(ctx context.Context, userID, playlistID int64) error
+	SavePlaylist(ctx context.Context, userID, playlistID int64, shareToken string) error
 	RemoveFromSaved(ctx context.Context, userID, playlistID int64) error
+	GetManyByIDs(ctx context.Context, playlistIDs []int64, currentUserID int64) ([]*PlaylistWithSavedModel, error)
+	AddRepost(ctx context.Context, userID, playlistID int64) (time.Time, error)
+	RemoveRepost(ctx context.Context, userID, playlistID int64) error
+}
+
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
 }
 
 type PlaylistRepo struct {
@@ -38,92 +72,183 @@ func (r *PlaylistRepo) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.T
 	return r.postgres.BeginTx(ctx, opts)
 }
 
+func (r *PlaylistRepo) db(ctx context.Context) querier {
+	if tx, hasTx := utils.GetTxFromContext(ctx); hasTx {
+		return tx
+	}
+	return r.postgres
+}
+
 func (r *PlaylistRepo) GetByID(ctx context.Context, playlistID int64, currentUserID int64) (*PlaylistWithSavedModel, error) {
 	query := `
-		SELECT p.id, p.user_id, p.title, p.changeable_id, p.image, p.created_at, p.updated_at,
+		SELECT p.id, p.user_id, p.username, p.title, p.changeable_id, p.image, p.reposts_count, p.track_count, p.total_duration, p.visibility, p.rules,
+			CASE WHEN p.user_id = $1 THEN p.share_token END as share_token, p.created_at, p.updated_at,
 			CASE WHEN usp.user_id IS NOT NULL THEN true ELSE false END as is_saved,
-			usp.added_at as saved_at
+			usp.added_at as saved_at,
+			CASE WHEN pr.user_id IS NOT NULL THEN true ELSE false END as is_reposted
 		FROM playlists p
 		LEFT JOIN user_saved_playlists usp ON usp.playlist_id = p.id AND usp.user_id = $1
+		LEFT JOIN playlist_reposts pr ON pr.playlist_id = p.id AND pr.user_id = $1
 		WHERE p.id = $2
+			AND (
+				p.visibility <> 'private'
+				OR p.user_id = $1
+				OR EXISTS (SELECT 1 FROM playlist_collaborators pc WHERE pc.playlist_id = p.id AND pc.user_id = $1)
+			)
 	`
 
 	var playlist PlaylistWithSavedModel
 	var createdAt, updatedAt time.Time
 	var savedAt sql.NullTime
+	var shareToken sql.NullString
+	var rules []byte
 
-	err := r.postgres.QueryRowContext(ctx, query, currentUserID, playlistID).Scan(
+	err := r.db(ctx).QueryRowContext(ctx, query, currentUserID, playlistID).Scan(
 		&playlist.ID,
 		&playlist.UserID,
+		&playlist.Username,
 		&playlist.Title,
 		&playlist.ChangeableID,
 		&playlist.Image,
+		&playlist.RepostsCount,
+		&playlist.TrackCount,
+		&playlist.TotalDuration,
+		&playlist.Visibility,
+		&rules,
+		&shareToken,
 		&createdAt,
 		&updatedAt,
 		&playlist.IsSaved,
 		&savedAt,
+		&playlist.IsReposted,
 	)
 
 	if err != nil {
 		return nil, err
 	}
 
+	playlist.Rules, err = decodeRules(rules)
+	if err != nil {
+		return nil, err
+	}
+
 	playlist.CreatedAt = createdAt
 	playlist.UpdatedAt = updatedAt
 	if savedAt.Valid {
 		playlist.SavedAt = &savedAt.Time
 	}
+	if shareToken.Valid {
+		playlist.ShareToken = &shareToken.String
+	}
+
+	return &playlist, nil
+}
+
+// GetForIndexing loads the fields the search index and feeds need regardless
+// of the playlist's visibility.
+func (r *PlaylistRepo) GetForIndexing(ctx context.Context, playlistID int64) (*PlaylistModel, error) {
+	query := `
+		SELECT id, user_id, title, visibility, created_at
+		FROM playlists
+		WHERE id = $1
+	`
+
+	var playlist PlaylistModel
+	err := r.db(ctx).QueryRowContext(ctx, query, playlistID).Scan(
+		&playlist.ID,
+		&playlist.UserID,
+		&playlist.Title,
+		&playlist.Visibility,
+		&playlist.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
 
 	return &playlist, nil
 }
 
-func (r *PlaylistRepo) GetByChangeableID(ctx context.Context, username, changeableID string, currentUserID int64) (*PlaylistWithSavedModel, error) {
+func (r *PlaylistRepo) GetByChangeableID(ctx context.Context, username, changeableID, shareToken string, currentUserID int64) (*PlaylistWithSavedModel, error) {
 	query := `
-		SELECT p.id, p.user_id, p.title, p.changeable_id, p.image, p.created_at, p.updated_at,
+		SELECT p.id, p.user_id, p.title, p.changeable_id, p.image, p.reposts_count, p.track_count, p.total_duration, p.visibility, p.rules,
+			CASE WHEN p.user_id = $1 THEN p.share_token END as share_token, p.created_at, p.updated_at,
 			CASE WHEN usp.user_id IS NOT NULL THEN true ELSE false END as is_saved,
-			usp.added_at as saved_at
+			usp.added_at as saved_at,
+			CASE WHEN pr.user_id IS NOT NULL THEN true ELSE false END as is_reposted
 		FROM playlists p
 		LEFT JOIN user_saved_playlists usp ON usp.playlist_id = p.id AND usp.user_id = $1
+		LEFT JOIN playlist_reposts pr ON pr.playlist_id = p.id AND pr.user_id = $1
 		WHERE p.changeable_id = $2 AND p.username = $3
+			AND (
+				p.visibility = 'public'
+				OR (p.visibility = 'unlisted' AND p.share_token = $4)
+				OR p.user_id = $1
+				OR EXISTS (SELECT 1 FROM playlist_collaborators pc WHERE pc.playlist_id = p.id AND pc.user_id = $1)
+			)
 	`
 
 	var playlist PlaylistWithSavedModel
 	var createdAt, updatedAt time.Time
 	var savedAt sql.NullTime
+	var ownerShareToken sql.NullString
+	var rules []byte
 
-	err := r.postgres.QueryRowContext(ctx, query, currentUserID, changeableID, username).Scan(
+	err := r.postgres.QueryRowContext(ctx, query, currentUserID, changeableID, username, shareToken).Scan(
 		&playlist.ID,
 		&playlist.UserID,
 		&playlist.Title,
 		&playlist.ChangeableID,
 		&playlist.Image,
+		&playlist.RepostsCount,
+		&playlist.TrackCount,
+		&playlist.TotalDuration,
+		&playlist.Visibility,
+		&rules,
+		&ownerShareToken,
 		&createdAt,
 		&updatedAt,
 		&playlist.IsSaved,
 		&savedAt,
+		&playlist.IsReposted,
 	)
 
 	if err != nil {
 		return nil, err
 	}
 
+	playlist.Rules, err = decodeRules(rules)
+	if err != nil {
+		return nil, err
+	}
+
 	playlist.CreatedAt = createdAt
 	playlist.UpdatedAt = updatedAt
 	if savedAt.Valid {
 		playlist.SavedAt = &savedAt.Time
 	}
+	if ownerShareToken.Valid {
+		playlist.ShareToken = &ownerShareToken.String
+	}
 
 	return &playlist, nil
 }
 
 func (r *PlaylistRepo) GetMany(ctx context.Context, userID, currentUserID int64, take int, lastID int64) ([]*PlaylistWithSavedModel, error) {
 	query := `
-		SELECT p.id, p.user_id, p.title, p.changeable_id, p.image, p.created_at, p.updated_at,
+		SELECT p.id, p.user_id, p.title, p.changeable_id, p.image, p.reposts_count, p.track_count, p.total_duration, p.visibility, p.rules,
+			CASE WHEN p.user_id = $1 THEN p.share_token END as share_token, p.created_at, p.updated_at,
 			CASE WHEN usp.user_id IS NOT NULL THEN true ELSE false END as is_saved,
-			usp.added_at as saved_at
+			usp.added_at as saved_at,
+			CASE WHEN pr.user_id IS NOT NULL THEN true ELSE false END as is_reposted
 		FROM playlists p
 		LEFT JOIN user_saved_playlists usp ON usp.playlist_id = p.id AND usp.user_id = $1
+		LEFT JOIN playlist_reposts pr ON pr.playlist_id = p.id AND pr.user_id = $1
 		WHERE p.user_id = $2 AND ($3 = 0 OR p.id < $3)
+			AND (
+				p.visibility = 'public'
+				OR p.user_id = $1
+				OR EXISTS (SELECT 1 FROM playlist_collaborators pc WHERE pc.playlist_id = p.id AND pc.user_id = $1)
+			)
 		ORDER BY p.id DESC
 		LIMIT $4
 	`
@@ -145,6 +270,8 @@ func (r *PlaylistRepo) GetMany(ctx context.Context, userID, currentUserID int64,
 		var playlist PlaylistWithSavedModel
 		var createdAt, updatedAt time.Time
 		var savedAt sql.NullTime
+		var shareToken sql.NullString
+		var rules []byte
 
 		err := rows.Scan(
 			&playlist.ID,
@@ -152,21 +279,36 @@ func (r *PlaylistRepo) GetMany(ctx context.Context, userID, currentUserID int64,
 			&playlist.Title,
 			&playlist.ChangeableID,
 			&playlist.Image,
+			&playlist.RepostsCount,
+			&playlist.TrackCount,
+			&playlist.TotalDuration,
+			&playlist.Visibility,
+			&rules,
+			&shareToken,
 			&createdAt,
 			&updatedAt,
 			&playlist.IsSaved,
 			&savedAt,
+			&playlist.IsReposted,
 		)
 
 		if err != nil {
 			return nil, err
 		}
 
+		playlist.Rules, err = decodeRules(rules)
+		if err != nil {
+			return nil, err
+		}
+
 		playlist.CreatedAt = createdAt
 		playlist.UpdatedAt = updatedAt
 		if savedAt.Valid {
 			playlist.SavedAt = &savedAt.Time
 		}
+		if shareToken.Valid {
+			playlist.ShareToken = &shareToken.String
+		}
 
 		playlists = append(playlists, &playlist)
 	}
@@ -181,22 +323,32 @@ func (r *PlaylistRepo) GetMany(ctx context.Context, userID, currentUserID int64,
 func (r *PlaylistRepo) GetManyWithSaved(ctx context.Context, userID int64, take int, lastID int64) ([]*PlaylistWithSavedModel, error) {
 	query := `
 		WITH my_playlists AS (
-			SELECT p.id, p.user_id, p.title, p.changeable_id, p.image, p.created_at, p.updated_at,
-				false as is_saved, NULL::timestamp as saved_at, p.created_at as sort_date
+			SELECT p.id, p.user_id, p.title, p.changeable_id, p.image, p.reposts_count, p.track_count, p.total_duration, p.visibility, p.rules,
+				CASE WHEN p.user_id = $1 THEN p.share_token END as share_token, p.created_at, p.updated_at,
+				false as is_saved, NULL::timestamp as saved_at,
+				CASE WHEN pr.user_id IS NOT NULL THEN true ELSE false END as is_reposted, p.created_at as sort_date
 			FROM playlists p
+			LEFT JOIN playlist_reposts pr ON pr.playlist_id = p.id AND pr.user_id = $1
 			WHERE p.user_id = $1 AND ($2 = 0 OR p.id < $2)
 		),
 		saved_playlists AS (
-			SELECT p.id, p.user_id, p.title, p.changeable_id, p.image, p.created_at, p.updated_at,
-				true as is_saved, usp.added_at as saved_at, COALESCE(usp.added_at, p.created_at) as sort_date
+			SELECT p.id, p.user_id, p.title, p.changeable_id, p.image, p.reposts_count, p.track_count, p.total_duration, p.visibility, p.rules,
+				CASE WHEN p.user_id = $1 THEN p.share_token END as share_token, p.created_at, p.updated_at,
+				true as is_saved, usp.added_at as saved_at,
+				CASE WHEN pr.user_id IS NOT NULL THEN true ELSE false END as is_reposted, COALESCE(usp.added_at, p.created_at) as sort_date
 			FROM playlists p
 			JOIN user_saved_playlists usp ON p.id = usp.playlist_id
+			LEFT JOIN playlist_reposts pr ON pr.playlist_id = p.id AND pr.user_id = $1
 			WHERE usp.user_id = $1 AND ($2 = 0 OR p.id < $2)
+				AND (
+					p.visibility <> 'private'
+					OR EXISTS (SELECT 1 FROM playlist_collaborators pc WHERE pc.playlist_id = p.id AND pc.user_id = $1)
+				)
 		)
-		SELECT id, user_id, title, changeable_id, image, created_at, updated_at, is_saved, saved_at FROM my_playlists
+		SELECT id, user_id, title, changeable_id, image, reposts_count, track_count, total_duration, visibility, rules, share_token, created_at, updated_at, is_saved, saved_at, is_reposted FROM my_playlists
 		UNION ALL
-		SELECT id, user_id, title, changeable_id, image, created_at, updated_at, is_saved, saved_at FROM saved_playlists
-		ORDER BY 9 DESC NULLS LAST, 6 DESC
+		SELECT id, user_id, title, changeable_id, image, reposts_count, track_count, total_duration, visibility, rules, share_token, created_at, updated_at, is_saved, saved_at, is_reposted FROM saved_playlists
+		ORDER BY saved_at DESC NULLS LAST, created_at DESC
 		LIMIT $3
 	`
 
@@ -217,6 +369,8 @@ func (r *PlaylistRepo) GetManyWithSaved(ctx context.Context, userID int64, take
 		var playlist PlaylistWithSavedModel
 		var createdAt, updatedAt time.Time
 		var savedAt sql.NullTime
+		var shareToken sql.NullString
+		var rules []byte
 
 		err := rows.Scan(
 			&playlist.ID,
@@ -224,21 +378,36 @@ func (r *PlaylistRepo) GetManyWithSaved(ctx context.Context, userID int64, take
 			&playlist.Title,
 			&playlist.ChangeableID,
 			&playlist.Image,
+			&playlist.RepostsCount,
+			&playlist.TrackCount,
+			&playlist.TotalDuration,
+			&playlist.Visibility,
+			&rules,
+			&shareToken,
 			&createdAt,
 			&updatedAt,
 			&playlist.IsSaved,
 			&savedAt,
+			&playlist.IsReposted,
 		)
 
 		if err != nil {
 			return nil, err
 		}
 
+		playlist.Rules, err = decodeRules(rules)
+		if err != nil {
+			return nil, err
+		}
+
 		playlist.CreatedAt = createdAt
 		playlist.UpdatedAt = updatedAt
 		if savedAt.Valid {
 			playlist.SavedAt = &savedAt.Time
 		}
+		if shareToken.Valid {
+			playlist.ShareToken = &shareToken.String
+		}
 
 		playlists = append(playlists, &playlist)
 	}
@@ -250,18 +419,24 @@ func (r *PlaylistRepo) GetManyWithSaved(ctx context.Context, userID int64, take
 	return playlists, nil
 }
 
-func (r *PlaylistRepo) Create(ctx context.Context, userID int64, username, title, changeableID, image string) (*PlaylistModel, error) {
+func (r *PlaylistRepo) Create(ctx context.Context, userID int64, username, title, changeableID, image string, rules *RuleSetModel) (*PlaylistModel, error) {
 	query := `
-		INSERT INTO playlists (user_id, username, title, changeable_id, image)
-		VALUES ($1, $2, $3, $4, $5)
-		RETURNING id, user_id, username, title, changeable_id, image, created_at, updated_at
+		INSERT INTO playlists (user_id, username, title, changeable_id, image, image_auto, rules)
+		VALUES ($1, $2, $3, $4, $5, $5 = 'default', $6)
+		RETURNING id, user_id, username, title, changeable_id, image, reposts_count, track_count, total_duration, visibility, rules, created_at, updated_at
 	`
 
+	encodedRules, err := encodeRules(rules)
+	if err != nil {
+		return nil, err
+	}
+
 	var playlist PlaylistModel
 	var createdAt, updatedAt time.Time
+	var savedRules []byte
 
-	err := r.postgres.QueryRowContext(
-		ctx, query, userID, username, title, changeableID, image,
+	err = r.db(ctx).QueryRowContext(
+		ctx, query, userID, username, title, changeableID, image, encodedRules,
 	).Scan(
 		&playlist.ID,
 		&playlist.UserID,
@@ -269,6 +444,11 @@ func (r *PlaylistRepo) Create(ctx context.Context, userID int64, username, title
 		&playlist.Title,
 		&playlist.ChangeableID,
 		&playlist.Image,
+		&playlist.RepostsCount,
+		&playlist.TrackCount,
+		&playlist.TotalDuration,
+		&playlist.Visibility,
+		&savedRules,
 		&createdAt,
 		&updatedAt,
 	)
@@ -277,6 +457,11 @@ func (r *PlaylistRepo) Create(ctx context.Context, userID int64, username, title
 		return nil, err
 	}
 
+	playlist.Rules, err = decodeRules(savedRules)
+	if err != nil {
+		return nil, err
+	}
+
 	playlist.CreatedAt = createdAt
 	playlist.UpdatedAt = updatedAt
 
@@ -300,13 +485,61 @@ func (r *PlaylistRepo) CheckPermission(ctx context.Context, userID, playlistID i
 	return exists, nil
 }
 
+func (r *PlaylistRepo) GetRole(ctx context.Context, userID, playlistID int64) (Role, error) {
+	query := `
+		SELECT CASE WHEN p.user_id = $2 THEN 'owner' ELSE COALESCE(pc.role, '') END
+		FROM playlists p
+		LEFT JOIN playlist_collaborators pc ON pc.playlist_id = p.id AND pc.user_id = $2
+		WHERE p.id = $1
+	`
+
+	var role Role
+	err := r.postgres.QueryRowContext(ctx, query, playlistID, userID).Scan(&role)
+	if err != nil {
+		return RoleNone, err
+	}
+
+	return role, nil
+}
+
+func (r *PlaylistRepo) GetRules(ctx context.Context, playlistID int64) (*RuleSetModel, error) {
+	query := `SELECT rules FROM playlists WHERE id = $1`
+
+	var rules []byte
+	err := r.postgres.QueryRowContext(ctx, query, playlistID).Scan(&rules)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeRules(rules)
+}
+
+func (r *PlaylistRepo) CanView(ctx context.Context, userID, playlistID int64, shareToken string) (bool, error) {
+	query := `
+		SELECT p.visibility = 'public'
+			OR (p.visibility = 'unlisted' AND COALESCE(p.share_token = $3, false))
+			OR p.user_id = $2
+			OR EXISTS (SELECT 1 FROM playlist_collaborators pc WHERE pc.playlist_id = p.id AND pc.user_id = $2)
+		FROM playlists p
+		WHERE p.id = $1
+	`
+
+	var canView bool
+	err := r.postgres.QueryRowContext(ctx, query, playlistID, userID, shareToken).Scan(&canView)
+	if err != nil {
+		return false, err
+	}
+
+	return canView, nil
+}
+
 func (r *PlaylistRepo) Delete(ctx context.Context, playlistID int64) error {
 	query := `
 		DELETE FROM playlists
 		WHERE id = $1
 	`
 
-	_, err := r.postgres.ExecContext(ctx, query, playlistID)
+	_, err := r.db(ctx).ExecContext(ctx, query, playlistID)
 	return err
 }
 
@@ -317,7 +550,7 @@ func (r *PlaylistRepo) ChangeTitle(ctx context.Context, playlistID int64, title
 		WHERE id = $2
 	`
 
-	_, err := r.postgres.ExecContext(ctx, query, title, playlistID)
+	_, err := r.db(ctx).ExecContext(ctx, query, title, playlistID)
 	return err
 }
 
@@ -328,21 +561,365 @@ func (r *PlaylistRepo) ChangeChangeableID(ctx context.Context, playlistID int64,
 		WHERE id = $2
 	`
 
-	_, err := r.postgres.ExecContext(ctx, query, changeableID, playlistID)
+	_, err := r.db(ctx).ExecContext(ctx, query, changeableID, playlistID)
 	return err
 }
 
 func (r *PlaylistRepo) ChangeImage(ctx context.Context, playlistID int64, image string) error {
 	query := `
 		UPDATE playlists
-		SET image = $1
+		SET image = $1, image_auto = FALSE, cover_source = '{}'
+		WHERE id = $2
+	`
+
+	_, err := r.db(ctx).ExecContext(ctx, query, image, playlistID)
+	return err
+}
+
+func (r *PlaylistRepo) ResetImage(ctx context.Context, playlistID int64) error {
+	query := `
+		UPDATE playlists
+		SET image = 'default', image_auto = TRUE, cover_source = '{}'
+		WHERE id = $1
+	`
+
+	_, err := r.db(ctx).ExecContext(ctx, query, playlistID)
+	return err
+}
+
+func (r *PlaylistRepo) GetCover(ctx context.Context, playlistID int64) (*CoverModel, error) {
+	query := `
+		SELECT image, image_auto, cover_source, rules IS NOT NULL
+		FROM playlists
+		WHERE id = $1
+	`
+
+	var cover CoverModel
+	err := r.db(ctx).QueryRowContext(ctx, query, playlistID).Scan(
+		&cover.Image,
+		&cover.Auto,
+		pq.Array(&cover.Source),
+		&cover.IsSmart,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cover, nil
+}
+
+func (r *PlaylistRepo) GetCoverCandidates(ctx context.Context, playlistID int64, take int) ([]string, error) {
+	query := `
+		SELECT t.image
+		FROM playlist_tracks pt
+		JOIN tracks t ON t.id = pt.track_id
+		WHERE pt.playlist_id = $1 AND t.image != 'default'
+		GROUP BY t.image
+		ORDER BY MIN(pt.rank)
+		LIMIT $2
+	`
+
+	rows, err := r.postgres.QueryContext(ctx, query, playlistID, take)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			r.log.Error("Failed to close rows", "error", err)
+		}
+	}()
+
+	images := []string{}
+	for rows.Next() {
+		var image string
+		if err := rows.Scan(&image); err != nil {
+			return nil, err
+		}
+		images = append(images, image)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return images, nil
+}
+
+func (r *PlaylistRepo) ChangeCover(ctx context.Context, playlistID int64, image string, source []string) (bool, error) {
+	query := `
+		UPDATE playlists
+		SET image = $1, cover_source = $2
+		WHERE id = $3 AND image_auto
+	`
+
+	result, err := r.postgres.ExecContext(ctx, query, image, pq.Array(source), playlistID)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (r *PlaylistRepo) RequestRefresh(ctx context.Context, playlistID int64) error {
+	query := `
+		UPDATE playlists
+		SET refresh_requested_at = NOW()
+		WHERE id = $1
+	`
+
+	_, err := r.db(ctx).ExecContext(ctx, query, playlistID)
+	return err
+}
+
+func (r *PlaylistRepo) RequestRefreshByCoverImage(ctx context.Context, image string) error {
+	query := `
+		UPDATE playlists
+		SET refresh_requested_at = NOW()
+		WHERE image_auto AND cover_source @> ARRAY[$1]::TEXT[]
+	`
+
+	_, err := r.db(ctx).ExecContext(ctx, query, image)
+	return err
+}
+
+// GetRefreshRequests returns the oldest playlists whose cover or stats are due
+// to be recomputed.
+func (r *PlaylistRepo) GetRefreshRequests(ctx context.Context, take int) ([]*RefreshRequestModel, error) {
+	query := `
+		SELECT id, user_id, rules, refresh_requested_at
+		FROM playlists
+		WHERE refresh_requested_at <= NOW()
+		ORDER BY refresh_requested_at ASC
+		LIMIT $1
+	`
+
+	rows, err := r.postgres.QueryContext(ctx, query, take)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			r.log.Error("Failed to close rows", "error", err)
+		}
+	}()
+
+	var requests []*RefreshRequestModel
+	for rows.Next() {
+		var request RefreshRequestModel
+		var rules []byte
+		if err := rows.Scan(&request.PlaylistID, &request.UserID, &rules, &request.RequestedAt); err != nil {
+			return nil, err
+		}
+
+		request.Rules, err = decodeRules(rules)
+		if err != nil {
+			return nil, err
+		}
+
+		requests = append(requests, &request)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return requests, nil
+}
+
+// ClearRefreshRequest drops a handled request unless the playlist was changed
+// again while it was being refreshed.
+func (r *PlaylistRepo) ClearRefreshRequest(ctx context.Context, playlistID int64, requestedAt time.Time) error {
+	query := `
+		UPDATE playlists
+		SET refresh_requested_at = NULL
+		WHERE id = $1 AND refresh_requested_at = $2
+	`
+
+	_, err := r.postgres.ExecContext(ctx, query, playlistID, requestedAt)
+	return err
+}
+
+func (r *PlaylistRepo) DeferRefreshRequest(ctx context.Context, playlistID int64, requestedAt time.Time, delay time.Duration) error {
+	query := `
+		UPDATE playlists
+		SET refresh_requested_at = NOW() + make_interval(secs => $3)
+		WHERE id = $1 AND refresh_requested_at = $2
+	`
+
+	_, err := r.postgres.ExecContext(ctx, query, playlistID, requestedAt, delay.Seconds())
+	return err
+}
+
+func (r *PlaylistRepo) ChangeVisibility(ctx context.Context, playlistID int64, visibility Visibility) error {
+	query := `
+		UPDATE playlists
+		SET visibility = $1
+		WHERE id = $2
+	`
+
+	_, err := r.db(ctx).ExecContext(ctx, query, visibility, playlistID)
+	return err
+}
+
+func (r *PlaylistRepo) ChangeShareToken(ctx context.Context, playlistID int64, shareToken string) error {
+	query := `
+		UPDATE playlists
+		SET share_token = NULLIF($1, '')
 		WHERE id = $2
 	`
 
-	_, err := r.postgres.ExecContext(ctx, query, image, playlistID)
+	_, err := r.postgres.ExecContext(ctx, query, shareToken, playlistID)
+	return err
+}
+
+func (r *PlaylistRepo) ChangeRules(ctx context.Context, playlistID int64, rules *RuleSetModel) error {
+	query := `
+		UPDATE playlists
+		SET rules = $1
+		WHERE id = $2
+	`
+
+	encodedRules, err := encodeRules(rules)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db(ctx).ExecContext(ctx, query, encodedRules, playlistID)
+	return err
+}
+
+// GetSmartPlaylists pages through rule-based playlists in id order.
+func (r *PlaylistRepo) GetSmartPlaylists(ctx context.Context, lastID int64, take int) ([]*PlaylistModel, error) {
+	query := `
+		SELECT id, user_id, rules
+		FROM playlists
+		WHERE rules IS NOT NULL AND id > $1
+		ORDER BY id ASC
+		LIMIT $2
+	`
+
+	rows, err := r.postgres.QueryContext(ctx, query, lastID, take)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			r.log.Error("Failed to close rows", "error", err)
+		}
+	}()
+
+	var playlists []*PlaylistModel
+
+	for rows.Next() {
+		var playlist PlaylistModel
+		var rules []byte
+
+		if err := rows.Scan(&playlist.ID, &playlist.UserID, &rules); err != nil {
+			return nil, err
+		}
+
+		playlist.Rules, err = decodeRules(rules)
+		if err != nil {
+			return nil, err
+		}
+
+		playlists = append(playlists, &playlist)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return playlists, nil
+}
+
+func (r *PlaylistRepo) ChangeStats(ctx context.Context, playlistID int64, trackCount, totalDuration int64) error {
+	query := `
+		UPDATE playlists
+		SET track_count = $1, total_duration = $2
+		WHERE id = $3 AND (track_count <> $1 OR total_duration <> $2)
+	`
+
+	_, err := r.db(ctx).ExecContext(ctx, query, trackCount, totalDuration, playlistID)
 	return err
 }
 
+func (r *PlaylistRepo) RecordRevision(ctx context.Context, playlistID, actorID int64, change RevisionChange, details any) error {
+	query := `
+		INSERT INTO playlist_revisions (playlist_id, actor_id, change, details, title, changeable_id, image, image_auto, visibility, rules, track_ids)
+		SELECT p.id, $2, $3, $4, p.title, p.changeable_id, p.image, p.image_auto, p.visibility, p.rules,
+			ARRAY(SELECT pt.track_id FROM playlist_tracks pt WHERE pt.playlist_id = p.id ORDER BY pt.rank)
+		FROM playlists p
+		WHERE p.id = $1
+	`
+
+	encodedDetails, err := json.Marshal(details)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db(ctx).ExecContext(ctx, query, playlistID, actorID, change, string(encodedDetails))
+	return err
+}
+
+// GetRevisionImages lists the uploaded covers that the playlist's revisions
+// still reference.
+func (r *PlaylistRepo) GetRevisionImages(ctx context.Context, playlistID int64) ([]string, error) {
+	query := `
+		SELECT DISTINCT image
+		FROM playlist_revisions
+		WHERE playlist_id = $1 AND image_auto = FALSE AND image <> 'default'
+	`
+
+	rows, err := r.db(ctx).QueryContext(ctx, query, playlistID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			r.log.Error("Failed to close rows", "error", err)
+		}
+	}()
+
+	var images []string
+	for rows.Next() {
+		var image string
+		if err := rows.Scan(&image); err != nil {
+			return nil, err
+		}
+		images = append(images, image)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return images, nil
+}
+
+func (r *PlaylistRepo) IsImageInRevisions(ctx context.Context, playlistID int64, image string) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM playlist_revisions
+			WHERE playlist_id = $1 AND image_auto = FALSE AND image = $2
+		)
+	`
+
+	var exists bool
+	err := r.db(ctx).QueryRowContext(ctx, query, playlistID, image).Scan(&exists)
+	if err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
 func (r *PlaylistRepo) CheckTitle(ctx context.Context, userID int64, title string) (bool, error) {
 	query := `
 		SELECT EXISTS(
@@ -377,14 +954,33 @@ func (r *PlaylistRepo) CheckChangeableID(ctx context.Context, userID int64, chan
 	return exists, nil
 }
 
-func (r *PlaylistRepo) SavePlaylist(ctx context.Context, userID, playlistID int64) error {
+func (r *PlaylistRepo) SavePlaylist(ctx context.Context, userID, playlistID int64, shareToken string) error {
 	query := `
 		INSERT INTO user_saved_playlists (user_id, playlist_id, added_at)
-		VALUES ($1, $2, $3)
+		SELECT $1, p.id, $3
+		FROM playlists p
+		WHERE p.id = $2
+			AND (
+				p.visibility = 'public'
+				OR (p.visibility = 'unlisted' AND p.share_token = $4)
+				OR EXISTS (SELECT 1 FROM playlist_collaborators pc WHERE pc.playlist_id = p.id AND pc.user_id = $1)
+			)
 	`
 
-	_, err := r.postgres.ExecContext(ctx, query, userID, playlistID, time.Now())
-	return err
+	result, err := r.postgres.ExecContext(ctx, query, userID, playlistID, time.Now(), shareToken)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
 }
 
 func (r *PlaylistRepo) RemoveFromSaved(ctx context.Context, userID, playlistID int64) error {
@@ -393,6 +989,156 @@ func (r *PlaylistRepo) RemoveFromSaved(ctx context.Context, userID, playlistID i
 		WHERE user_id = $1 AND playlist_id = $2
 	`
 
+	result, err := r.postgres.ExecContext(ctx, query, userID, playlistID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *PlaylistRepo) GetManyByIDs(ctx context.Context, playlistIDs []int64, currentUserID int64) ([]*PlaylistWithSavedModel, error) {
+	query := `
+		SELECT p.id, p.user_id, p.title, p.changeable_id, p.image, p.reposts_count, p.track_count, p.total_duration, p.visibility, p.rules,
+			CASE WHEN p.user_id = $1 THEN p.share_token END as share_token, p.created_at, p.updated_at,
+			CASE WHEN usp.user_id IS NOT NULL THEN true ELSE false END as is_saved,
+			usp.added_at as saved_at,
+			CASE WHEN pr.user_id IS NOT NULL THEN true ELSE false END as is_reposted
+		FROM playlists p
+		LEFT JOIN user_saved_playlists usp ON usp.playlist_id = p.id AND usp.user_id = $1
+		LEFT JOIN playlist_reposts pr ON pr.playlist_id = p.id AND pr.user_id = $1
+		WHERE p.id = ANY($2)
+			AND (
+				p.visibility = 'public'
+				OR p.user_id = $1
+				OR EXISTS (SELECT 1 FROM playlist_collaborators pc WHERE pc.playlist_id = p.id AND pc.user_id = $1)
+			)
+	`
+
+	rows, err := r.postgres.QueryContext(ctx, query, currentUserID, pq.Array(playlistIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			r.log.Error("Failed to close rows", "error", err)
+		}
+	}()
+
+	var playlists []*PlaylistWithSavedModel
+
+	for rows.Next() {
+		var playlist PlaylistWithSavedModel
+		var createdAt, updatedAt time.Time
+		var savedAt sql.NullTime
+		var shareToken sql.NullString
+		var rules []byte
+
+		err := rows.Scan(
+			&playlist.ID,
+			&playlist.UserID,
+			&playlist.Title,
+			&playlist.ChangeableID,
+			&playlist.Image,
+			&playlist.RepostsCount,
+			&playlist.TrackCount,
+			&playlist.TotalDuration,
+			&playlist.Visibility,
+			&rules,
+			&shareToken,
+			&createdAt,
+			&updatedAt,
+			&playlist.IsSaved,
+			&savedAt,
+			&playlist.IsReposted,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		playlist.Rules, err = decodeRules(rules)
+		if err != nil {
+			return nil, err
+		}
+
+		playlist.CreatedAt = createdAt
+		playlist.UpdatedAt = updatedAt
+		if savedAt.Valid {
+			playlist.SavedAt = &savedAt.Time
+		}
+		if shareToken.Valid {
+			playlist.ShareToken = &shareToken.String
+		}
+
+		playlists = append(playlists, &playlist)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return playlists, nil
+}
+
+func (r *PlaylistRepo) AddRepost(ctx context.Context, userID, playlistID int64) (time.Time, error) {
+	query := `
+		INSERT INTO playlist_reposts (user_id, playlist_id, reposted_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, playlist_id) DO NOTHING
+		RETURNING reposted_at
+	`
+
+	var repostedAt time.Time
+	err := r.postgres.QueryRowContext(ctx, query, userID, playlistID, time.Now()).Scan(&repostedAt)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return repostedAt, nil
+}
+
+func (r *PlaylistRepo) RemoveRepost(ctx context.Context, userID, playlistID int64) error {
+	query := `
+		DELETE FROM playlist_reposts
+		WHERE user_id = $1 AND playlist_id = $2
+	`
+
 	_, err := r.postgres.ExecContext(ctx, query, userID, playlistID)
 	return err
 }
+
+func encodeRules(rules *RuleSetModel) (sql.NullString, error) {
+	if rules == nil {
+		return sql.NullString{}, nil
+	}
+
+	data, err := json.Marshal(rules)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+
+	return sql.NullString{String: string(data), Valid: true}, nil
+}
+
+func decodeRules(data []byte) (*RuleSetModel, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	var rules RuleSetModel
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, err
+	}
+
+	return &rules, nil
+}
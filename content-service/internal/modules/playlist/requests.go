@@ -9,6 +9,7 @@ type GetByPlaylistIDUri struct {
 type GetOneForm struct {
 	Username     string `form:"username" binding:"required"`
 	ChangeableID string `form:"changeableId" binding:"required"`
+	ShareToken   string `form:"shareToken"`
 }
 
 type GetManyForm struct {
@@ -25,7 +26,13 @@ type GetManyWithSavedForm struct {
 type CreatePlaylistForm struct {
 	Title        string                `form:"title" binding:"required,min=1,max=20"`
 	ChangeableID string                `form:"changeableId" binding:"required,min=1,max=20"`
-	ImageFile    *multipart.FileHeader `form:"imageFile" binding:"required"`
+	ImageFile    *multipart.FileHeader `form:"imageFile"`
+}
+
+type CreateSmartPlaylistJSON struct {
+	Title        string        `json:"title" binding:"required,min=1,max=20"`
+	ChangeableID string        `json:"changeableId" binding:"required,min=1,max=20"`
+	Rules        *RuleSetModel `json:"rules" binding:"required"`
 }
 
 type ChangeTitleUri struct {
@@ -48,6 +55,10 @@ type ChangeImageUri struct {
 	PlaylistID int64 `uri:"playlistId" binding:"required"`
 }
 
+type ResetImageUri struct {
+	PlaylistID int64 `uri:"playlistId" binding:"required"`
+}
+
 type ChangeImageForm struct {
 	ImageFile *multipart.FileHeader `form:"imageFile" binding:"required"`
 }
@@ -55,3 +66,15 @@ type ChangeImageForm struct {
 type DeleteUri struct {
 	PlaylistID int64 `uri:"playlistId" binding:"required"`
 }
+
+type SavePlaylistForm struct {
+	ShareToken string `form:"shareToken"`
+}
+
+type ChangeVisibilityForm struct {
+	Visibility string `form:"visibility" binding:"required,oneof=public unlisted private"`
+}
+
+type ChangeRulesJSON struct {
+	Rules *RuleSetModel `json:"rules" binding:"required"`
+}
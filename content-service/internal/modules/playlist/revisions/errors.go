@@ -0,0 +1,10 @@
+package revisions
+
+import "errors"
+
+var (
+	ErrPlaylistNotFound = errors.New("playlist not found")
+	ErrRevisionNotFound = errors.New("revision not found")
+	ErrPermissionDenied = errors.New("permission denied")
+	ErrNothingToUndo    = errors.New("nothing to undo")
+)
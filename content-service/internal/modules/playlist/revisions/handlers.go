@@ -0,0 +1,145 @@
+package revisions
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ocenb/music-go/content-service/internal/modules/playlist"
+	"github.com/ocenb/music-go/content-service/internal/utils"
+)
+
+type RevisionsHandlersInterface interface {
+	GetMany(c *gin.Context)
+	GetOne(c *gin.Context)
+	Restore(c *gin.Context)
+	Undo(c *gin.Context)
+	RegisterHandlers(router *gin.RouterGroup)
+}
+
+type RevisionsHandlers struct {
+	revisionsService RevisionsServiceInterface
+}
+
+func NewHandlers(revisionsService RevisionsServiceInterface) RevisionsHandlersInterface {
+	return &RevisionsHandlers{
+		revisionsService: revisionsService,
+	}
+}
+
+func (h *RevisionsHandlers) GetMany(c *gin.Context) {
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.UnauthenticatedError(c, err)
+		return
+	}
+
+	var playlistReq PlaylistUri
+	if err := c.ShouldBindUri(&playlistReq); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	var params GetManyForm
+	if err := c.ShouldBindQuery(&params); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	revisions, err := h.revisionsService.GetMany(c, user.Id, playlistReq.PlaylistID, params.Take, params.LastID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, revisions)
+}
+
+func (h *RevisionsHandlers) GetOne(c *gin.Context) {
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.UnauthenticatedError(c, err)
+		return
+	}
+
+	var revisionReq RevisionUri
+	if err := c.ShouldBindUri(&revisionReq); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	revision, err := h.revisionsService.GetOne(c, user.Id, revisionReq.PlaylistID, revisionReq.RevisionID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, revision)
+}
+
+func (h *RevisionsHandlers) Restore(c *gin.Context) {
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.UnauthenticatedError(c, err)
+		return
+	}
+
+	var revisionReq RevisionUri
+	if err := c.ShouldBindUri(&revisionReq); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	err = h.revisionsService.Restore(c, user.Id, revisionReq.PlaylistID, revisionReq.RevisionID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *RevisionsHandlers) Undo(c *gin.Context) {
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.UnauthenticatedError(c, err)
+		return
+	}
+
+	var playlistReq PlaylistUri
+	if err := c.ShouldBindUri(&playlistReq); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	err = h.revisionsService.Undo(c, user.Id, playlistReq.PlaylistID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrPlaylistNotFound), errors.Is(err, ErrRevisionNotFound):
+		utils.NotFoundError(c, err)
+	case errors.Is(err, ErrPermissionDenied):
+		utils.PermissionDeniedError(c, err)
+	case errors.Is(err, ErrNothingToUndo),
+		errors.Is(err, playlist.ErrPlaylistAlreadyExists),
+		errors.Is(err, playlist.ErrChangeableIDExists):
+		utils.BadRequestError(c, err)
+	default:
+		utils.InternalError(c, err)
+	}
+}
+
+func (h *RevisionsHandlers) RegisterHandlers(router *gin.RouterGroup) {
+	revisionsRouter := router.Group("/playlist-revisions")
+	revisionsRouter.GET("/:playlistId", h.GetMany)
+	revisionsRouter.GET("/:playlistId/:revisionId", h.GetOne)
+	revisionsRouter.POST("/:playlistId/:revisionId/restore", h.Restore)
+	revisionsRouter.POST("/:playlistId/undo", h.Undo)
+}
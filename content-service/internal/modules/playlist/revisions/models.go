@@ -0,0 +1,22 @@
+package revisions
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ocenb/music-go/content-service/internal/modules/playlist"
+)
+
+type RevisionModel struct {
+	ID         int64                   `json:"id"`
+	PlaylistID int64                   `json:"playlistId"`
+	ActorID    int64                   `json:"actorId"`
+	Change     playlist.RevisionChange `json:"change"`
+	Details    json.RawMessage         `json:"details"`
+	CreatedAt  time.Time               `json:"createdAt"`
+}
+
+type RevisionWithSnapshotModel struct {
+	RevisionModel
+	Snapshot *playlist.SnapshotModel `json:"snapshot"`
+}
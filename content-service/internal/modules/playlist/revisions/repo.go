@@ -0,0 +1,127 @@
+package revisions
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/lib/pq"
+	"github.com/ocenb/music-go/content-service/internal/modules/playlist"
+)
+
+type RevisionsRepoInterface interface {
+	GetMany(ctx context.Context, playlistID int64, take int, lastID int64) ([]*RevisionModel, error)
+	GetOne(ctx context.Context, playlistID, revisionID int64) (*RevisionWithSnapshotModel, error)
+	GetBefore(ctx context.Context, playlistID, revisionID int64) (*RevisionWithSnapshotModel, error)
+}
+
+type RevisionsRepo struct {
+	postgres *sql.DB
+	log      *slog.Logger
+}
+
+func NewRevisionsRepo(postgres *sql.DB, log *slog.Logger) RevisionsRepoInterface {
+	return &RevisionsRepo{postgres: postgres, log: log}
+}
+
+func (r *RevisionsRepo) GetMany(ctx context.Context, playlistID int64, take int, lastID int64) ([]*RevisionModel, error) {
+	query := `
+		SELECT id, playlist_id, actor_id, change, details, created_at
+		FROM playlist_revisions
+		WHERE playlist_id = $1 AND ($2 = 0 OR id < $2)
+		ORDER BY id DESC
+		LIMIT $3
+	`
+
+	rows, err := r.postgres.QueryContext(ctx, query, playlistID, lastID, take)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			r.log.Error("Failed to close rows", "error", err)
+		}
+	}()
+
+	revisions := []*RevisionModel{}
+	for rows.Next() {
+		var revision RevisionModel
+		var details []byte
+		if err := rows.Scan(&revision.ID, &revision.PlaylistID, &revision.ActorID, &revision.Change, &details, &revision.CreatedAt); err != nil {
+			return nil, err
+		}
+		revision.Details = details
+		revisions = append(revisions, &revision)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+func (r *RevisionsRepo) GetOne(ctx context.Context, playlistID, revisionID int64) (*RevisionWithSnapshotModel, error) {
+	query := `
+		SELECT id, playlist_id, actor_id, change, details, created_at, title, changeable_id, image, image_auto, visibility, rules, track_ids
+		FROM playlist_revisions
+		WHERE playlist_id = $1 AND id = $2
+	`
+
+	return scanRevision(r.postgres.QueryRowContext(ctx, query, playlistID, revisionID))
+}
+
+// GetBefore returns the latest revision older than revisionID, or the latest
+// revision overall when revisionID is 0.
+func (r *RevisionsRepo) GetBefore(ctx context.Context, playlistID, revisionID int64) (*RevisionWithSnapshotModel, error) {
+	query := `
+		SELECT id, playlist_id, actor_id, change, details, created_at, title, changeable_id, image, image_auto, visibility, rules, track_ids
+		FROM playlist_revisions
+		WHERE playlist_id = $1 AND ($2 = 0 OR id < $2)
+		ORDER BY id DESC
+		LIMIT 1
+	`
+
+	return scanRevision(r.postgres.QueryRowContext(ctx, query, playlistID, revisionID))
+}
+
+func scanRevision(row *sql.Row) (*RevisionWithSnapshotModel, error) {
+	var revision RevisionWithSnapshotModel
+	var details, rules []byte
+	var snapshot playlist.SnapshotModel
+
+	err := row.Scan(
+		&revision.ID,
+		&revision.PlaylistID,
+		&revision.ActorID,
+		&revision.Change,
+		&details,
+		&revision.CreatedAt,
+		&snapshot.Title,
+		&snapshot.ChangeableID,
+		&snapshot.Image,
+		&snapshot.ImageAuto,
+		&snapshot.Visibility,
+		&rules,
+		pq.Array(&snapshot.TrackIDs),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if rules != nil {
+		snapshot.Rules = &playlist.RuleSetModel{}
+		if err := json.Unmarshal(rules, snapshot.Rules); err != nil {
+			return nil, err
+		}
+	}
+	if snapshot.TrackIDs == nil {
+		snapshot.TrackIDs = []int64{}
+	}
+
+	revision.Details = details
+	revision.Snapshot = &snapshot
+
+	return &revision, nil
+}
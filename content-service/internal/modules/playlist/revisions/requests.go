@@ -0,0 +1,15 @@
+package revisions
+
+type PlaylistUri struct {
+	PlaylistID int64 `uri:"playlistId" binding:"required"`
+}
+
+type RevisionUri struct {
+	PlaylistID int64 `uri:"playlistId" binding:"required"`
+	RevisionID int64 `uri:"revisionId" binding:"required"`
+}
+
+type GetManyForm struct {
+	Take   int   `form:"take" binding:"omitempty,min=1,max=100"`
+	LastID int64 `form:"lastId" binding:"omitempty,min=1"`
+}
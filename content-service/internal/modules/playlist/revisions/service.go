@@ -0,0 +1,195 @@
+package revisions
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+
+	"github.com/ocenb/music-go/content-service/internal/modules/playlist"
+	"github.com/ocenb/music-go/content-service/internal/modules/playlist/playlisttracks"
+)
+
+const defaultTake = 50
+
+type RevisionsServiceInterface interface {
+	GetMany(ctx context.Context, currentUserID, playlistID int64, take int, lastID int64) ([]*RevisionModel, error)
+	GetOne(ctx context.Context, currentUserID, playlistID, revisionID int64) (*RevisionWithSnapshotModel, error)
+	Restore(ctx context.Context, currentUserID, playlistID, revisionID int64) error
+	Undo(ctx context.Context, currentUserID, playlistID int64) error
+}
+
+type RevisionsService struct {
+	log                   *slog.Logger
+	revisionsRepo         RevisionsRepoInterface
+	playlistRepo          playlist.PlaylistRepoInterface
+	playlistTracksService playlisttracks.PlaylistTracksServiceInterface
+}
+
+func NewRevisionsService(
+	log *slog.Logger,
+	revisionsRepo RevisionsRepoInterface,
+	playlistRepo playlist.PlaylistRepoInterface,
+	playlistTracksService playlisttracks.PlaylistTracksServiceInterface,
+) RevisionsServiceInterface {
+	return &RevisionsService{
+		log:                   log,
+		revisionsRepo:         revisionsRepo,
+		playlistRepo:          playlistRepo,
+		playlistTracksService: playlistTracksService,
+	}
+}
+
+func (s *RevisionsService) GetMany(ctx context.Context, currentUserID, playlistID int64, take int, lastID int64) ([]*RevisionModel, error) {
+	if err := s.checkViewPermission(ctx, currentUserID, playlistID); err != nil {
+		return nil, err
+	}
+
+	if take == 0 {
+		take = defaultTake
+	}
+
+	return s.revisionsRepo.GetMany(ctx, playlistID, take, lastID)
+}
+
+func (s *RevisionsService) GetOne(ctx context.Context, currentUserID, playlistID, revisionID int64) (*RevisionWithSnapshotModel, error) {
+	if err := s.checkViewPermission(ctx, currentUserID, playlistID); err != nil {
+		return nil, err
+	}
+
+	revision, err := s.revisionsRepo.GetOne(ctx, playlistID, revisionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRevisionNotFound
+		}
+		return nil, err
+	}
+
+	return revision, nil
+}
+
+func (s *RevisionsService) Restore(ctx context.Context, currentUserID, playlistID, revisionID int64) error {
+	existingPlaylist, err := s.getOwnedPlaylist(ctx, currentUserID, playlistID)
+	if err != nil {
+		return err
+	}
+
+	revision, err := s.revisionsRepo.GetOne(ctx, playlistID, revisionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrRevisionNotFound
+		}
+		return err
+	}
+
+	return s.restore(ctx, currentUserID, existingPlaylist, revision, playlist.RevisionRestored, map[string]any{"revisionId": revision.ID})
+}
+
+// Undo reverts the latest revision that is still in effect. Undo revisions
+// themselves are skipped over, so repeated undos keep walking back through
+// history instead of toggling between the last two states.
+func (s *RevisionsService) Undo(ctx context.Context, currentUserID, playlistID int64) error {
+	existingPlaylist, err := s.getOwnedPlaylist(ctx, currentUserID, playlistID)
+	if err != nil {
+		return err
+	}
+
+	undone, err := s.revisionsRepo.GetBefore(ctx, playlistID, 0)
+	for err == nil && undone.Change == playlist.RevisionUndone {
+		undone, err = s.revisionsRepo.GetBefore(ctx, playlistID, undoneRevisionID(undone))
+	}
+
+	var revision *RevisionWithSnapshotModel
+	if err == nil {
+		revision, err = s.revisionsRepo.GetBefore(ctx, playlistID, undone.ID)
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNothingToUndo
+		}
+		return err
+	}
+
+	return s.restore(ctx, currentUserID, existingPlaylist, revision, playlist.RevisionUndone, map[string]any{
+		"revisionId":       revision.ID,
+		"undoneRevisionId": undone.ID,
+	})
+}
+
+func (s *RevisionsService) restore(ctx context.Context, currentUserID int64, existingPlaylist *playlist.PlaylistWithSavedModel, revision *RevisionWithSnapshotModel, change playlist.RevisionChange, details any) error {
+	snapshot := revision.Snapshot
+
+	if snapshot.Title != existingPlaylist.Title {
+		exists, err := s.playlistRepo.CheckTitle(ctx, currentUserID, snapshot.Title)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return playlist.ErrPlaylistAlreadyExists
+		}
+	}
+
+	if snapshot.ChangeableID != existingPlaylist.ChangeableID {
+		exists, err := s.playlistRepo.CheckChangeableID(ctx, currentUserID, snapshot.ChangeableID)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return playlist.ErrChangeableIDExists
+		}
+	}
+
+	err := s.playlistTracksService.Restore(ctx, currentUserID, existingPlaylist.ID, snapshot, change, details)
+	if err != nil {
+		if errors.Is(err, playlisttracks.ErrPlaylistNotFound) {
+			return ErrPlaylistNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// undoneRevisionID reads the revision an undo reverted, falling back to the
+// undo itself so the walk always moves backwards.
+func undoneRevisionID(revision *RevisionWithSnapshotModel) int64 {
+	var details struct {
+		UndoneRevisionID int64 `json:"undoneRevisionId"`
+	}
+	if err := json.Unmarshal(revision.Details, &details); err != nil || details.UndoneRevisionID <= 0 || details.UndoneRevisionID >= revision.ID {
+		return revision.ID
+	}
+
+	return details.UndoneRevisionID
+}
+
+func (s *RevisionsService) getOwnedPlaylist(ctx context.Context, currentUserID, playlistID int64) (*playlist.PlaylistWithSavedModel, error) {
+	existingPlaylist, err := s.playlistRepo.GetByID(ctx, playlistID, currentUserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPlaylistNotFound
+		}
+		return nil, err
+	}
+	if existingPlaylist.UserID != currentUserID {
+		return nil, ErrPermissionDenied
+	}
+
+	return existingPlaylist, nil
+}
+
+func (s *RevisionsService) checkViewPermission(ctx context.Context, currentUserID, playlistID int64) error {
+	role, err := s.playlistRepo.GetRole(ctx, currentUserID, playlistID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrPlaylistNotFound
+		}
+		return err
+	}
+	if !role.CanView() {
+		return ErrPermissionDenied
+	}
+
+	return nil
+}
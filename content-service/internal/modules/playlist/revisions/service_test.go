@@ -0,0 +1,166 @@
+package revisions
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/ocenb/music-go/content-service/internal/modules/playlist"
+	"github.com/ocenb/music-go/content-service/internal/modules/playlist/playlisttracks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	ownerID    = int64(1)
+	playlistID = int64(10)
+)
+
+// memoryRevisions keeps the revision history of one playlist.
+type memoryRevisions struct {
+	revisions []*RevisionWithSnapshotModel
+	title     string
+}
+
+func (m *memoryRevisions) add(change playlist.RevisionChange, title string, details any) {
+	data, _ := json.Marshal(details)
+	m.revisions = append(m.revisions, &RevisionWithSnapshotModel{
+		RevisionModel: RevisionModel{
+			ID:         int64(len(m.revisions) + 1),
+			PlaylistID: playlistID,
+			ActorID:    ownerID,
+			Change:     change,
+			Details:    data,
+		},
+		Snapshot: &playlist.SnapshotModel{Title: title},
+	})
+	m.title = title
+}
+
+func (m *memoryRevisions) GetMany(ctx context.Context, playlistID int64, take int, lastID int64) ([]*RevisionModel, error) {
+	return nil, nil
+}
+
+func (m *memoryRevisions) GetOne(ctx context.Context, playlistID, revisionID int64) (*RevisionWithSnapshotModel, error) {
+	for _, revision := range m.revisions {
+		if revision.ID == revisionID {
+			return revision, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (m *memoryRevisions) GetBefore(ctx context.Context, playlistID, revisionID int64) (*RevisionWithSnapshotModel, error) {
+	for i := len(m.revisions) - 1; i >= 0; i-- {
+		if revisionID == 0 || m.revisions[i].ID < revisionID {
+			return m.revisions[i], nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+// memoryTracksService records restores as new revisions, the way the
+// playlist tracks service does.
+type memoryTracksService struct {
+	playlisttracks.PlaylistTracksServiceInterface
+	revisions *memoryRevisions
+}
+
+func (s *memoryTracksService) Restore(ctx context.Context, userID, playlistID int64, snapshot *playlist.SnapshotModel, change playlist.RevisionChange, details any) error {
+	s.revisions.add(change, snapshot.Title, details)
+	return nil
+}
+
+type memoryPlaylistRepo struct {
+	playlist.PlaylistRepoInterface
+	revisions *memoryRevisions
+}
+
+func (r *memoryPlaylistRepo) GetByID(ctx context.Context, playlistID int64, currentUserID int64) (*playlist.PlaylistWithSavedModel, error) {
+	return &playlist.PlaylistWithSavedModel{PlaylistModel: playlist.PlaylistModel{
+		ID:     playlistID,
+		UserID: ownerID,
+		Title:  r.revisions.title,
+	}}, nil
+}
+
+func (r *memoryPlaylistRepo) CheckTitle(ctx context.Context, userID int64, title string) (bool, error) {
+	return false, nil
+}
+
+func newTestService(revisions *memoryRevisions) RevisionsServiceInterface {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return NewRevisionsService(log, revisions, &memoryPlaylistRepo{revisions: revisions}, &memoryTracksService{revisions: revisions})
+}
+
+func TestUndoneRevisionID(t *testing.T) {
+	tests := []struct {
+		name    string
+		details string
+		want    int64
+	}{
+		{name: "reads the undone revision", details: `{"revisionId":3,"undoneRevisionId":4}`, want: 4},
+		{name: "missing details", details: `{}`, want: 7},
+		{name: "invalid details", details: `not json`, want: 7},
+		{name: "null details", details: `null`, want: 7},
+		{name: "zero undone revision", details: `{"undoneRevisionId":0}`, want: 7},
+		{name: "undone revision after the undo", details: `{"undoneRevisionId":9}`, want: 7},
+		{name: "undone revision is the undo", details: `{"undoneRevisionId":7}`, want: 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			revision := &RevisionWithSnapshotModel{RevisionModel: RevisionModel{ID: 7, Details: json.RawMessage(tt.details)}}
+			assert.Equal(t, tt.want, undoneRevisionID(revision))
+		})
+	}
+}
+
+func TestUndoWalksBackThroughHistory(t *testing.T) {
+	ctx := context.Background()
+	revisions := &memoryRevisions{}
+	revisions.add(playlist.RevisionCreated, "first", nil)
+	revisions.add(playlist.RevisionTitleChanged, "second", map[string]any{})
+	revisions.add(playlist.RevisionTitleChanged, "third", map[string]any{})
+	service := newTestService(revisions)
+
+	require.NoError(t, service.Undo(ctx, ownerID, playlistID))
+	assert.Equal(t, "second", revisions.title)
+	assert.JSONEq(t, `{"revisionId":2,"undoneRevisionId":3}`, string(revisions.revisions[3].Details))
+
+	require.NoError(t, service.Undo(ctx, ownerID, playlistID))
+	assert.Equal(t, "first", revisions.title)
+	assert.JSONEq(t, `{"revisionId":1,"undoneRevisionId":2}`, string(revisions.revisions[4].Details))
+
+	assert.ErrorIs(t, service.Undo(ctx, ownerID, playlistID), ErrNothingToUndo)
+	assert.Len(t, revisions.revisions, 5)
+}
+
+func TestUndoAfterRestore(t *testing.T) {
+	ctx := context.Background()
+	revisions := &memoryRevisions{}
+	revisions.add(playlist.RevisionCreated, "first", nil)
+	revisions.add(playlist.RevisionTitleChanged, "second", map[string]any{})
+	service := newTestService(revisions)
+
+	require.NoError(t, service.Restore(ctx, ownerID, playlistID, 1))
+	assert.Equal(t, "first", revisions.title)
+
+	require.NoError(t, service.Undo(ctx, ownerID, playlistID))
+	assert.Equal(t, "second", revisions.title, "undo reverts the restore")
+
+	assert.ErrorIs(t, service.Restore(ctx, ownerID, playlistID, 99), ErrRevisionNotFound)
+}
+
+func TestUndoRequiresOwner(t *testing.T) {
+	revisions := &memoryRevisions{}
+	revisions.add(playlist.RevisionCreated, "first", nil)
+	revisions.add(playlist.RevisionTitleChanged, "second", map[string]any{})
+
+	err := newTestService(revisions).Undo(context.Background(), ownerID+1, playlistID)
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+	assert.Len(t, revisions.revisions, 2)
+}
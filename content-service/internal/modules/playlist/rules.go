@@ -0,0 +1,118 @@
+package playlist
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+const (
+	MaxRules              = 10
+	MaxSmartPlaylistLimit = 500
+	maxRuleValueLength    = 100
+)
+
+var ruleOperators = map[RuleField][]RuleOperator{
+	RuleFieldLiked:          {RuleOperatorEq},
+	RuleFieldFollowedArtist: {RuleOperatorEq},
+	RuleFieldLikedAt:        {RuleOperatorInLast},
+	RuleFieldCreatedAt:      {RuleOperatorInLast},
+	RuleFieldArtist:         {RuleOperatorEq, RuleOperatorNeq, RuleOperatorContains},
+	RuleFieldTitle:          {RuleOperatorEq, RuleOperatorNeq, RuleOperatorContains},
+	RuleFieldGenre:          {RuleOperatorEq, RuleOperatorNeq, RuleOperatorContains},
+	RuleFieldDuration:       {RuleOperatorEq, RuleOperatorNeq, RuleOperatorGt, RuleOperatorGte, RuleOperatorLt, RuleOperatorLte},
+	RuleFieldPlays:          {RuleOperatorEq, RuleOperatorNeq, RuleOperatorGt, RuleOperatorGte, RuleOperatorLt, RuleOperatorLte},
+}
+
+func validateRules(rules *RuleSetModel) error {
+	if rules == nil || len(rules.Rules) == 0 {
+		return fmt.Errorf("%w: at least one rule is required", ErrInvalidRules)
+	}
+	if len(rules.Rules) > MaxRules {
+		return fmt.Errorf("%w: at most %d rules are allowed", ErrInvalidRules, MaxRules)
+	}
+
+	switch rules.Match {
+	case "":
+		rules.Match = RuleMatchAll
+	case RuleMatchAll, RuleMatchAny:
+	default:
+		return fmt.Errorf("%w: unknown match %q", ErrInvalidRules, rules.Match)
+	}
+
+	switch rules.Sort {
+	case "":
+		rules.Sort = RuleSortNewest
+	case RuleSortNewest, RuleSortOldest, RuleSortMostPlayed, RuleSortRecentlyLiked, RuleSortTitle:
+	default:
+		return fmt.Errorf("%w: unknown sort %q", ErrInvalidRules, rules.Sort)
+	}
+
+	if rules.Limit < 0 || rules.Limit > MaxSmartPlaylistLimit {
+		return fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidRules, MaxSmartPlaylistLimit)
+	}
+	if rules.Limit == 0 {
+		rules.Limit = MaxSmartPlaylistLimit
+	}
+
+	for _, rule := range rules.Rules {
+		if err := validateRule(rule); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateRule(rule *RuleModel) error {
+	if rule == nil {
+		return fmt.Errorf("%w: rule is empty", ErrInvalidRules)
+	}
+
+	operators, ok := ruleOperators[rule.Field]
+	if !ok {
+		return fmt.Errorf("%w: unknown field %q", ErrInvalidRules, rule.Field)
+	}
+	if !slices.Contains(operators, rule.Operator) {
+		return fmt.Errorf("%w: operator %q is not supported for %q", ErrInvalidRules, rule.Operator, rule.Field)
+	}
+
+	switch rule.Field {
+	case RuleFieldLiked, RuleFieldFollowedArtist:
+		if _, ok := rule.Value.(bool); !ok {
+			return fmt.Errorf("%w: %q expects a boolean", ErrInvalidRules, rule.Field)
+		}
+	case RuleFieldLikedAt, RuleFieldCreatedAt:
+		value, ok := rule.Value.(float64)
+		if !ok || value < 1 || value != math.Trunc(value) {
+			return fmt.Errorf("%w: %q expects a positive number of days", ErrInvalidRules, rule.Field)
+		}
+	case RuleFieldDuration, RuleFieldPlays:
+		value, ok := rule.Value.(float64)
+		if !ok || value < 0 || value != math.Trunc(value) {
+			return fmt.Errorf("%w: %q expects a non-negative integer", ErrInvalidRules, rule.Field)
+		}
+	default:
+		value, ok := rule.Value.(string)
+		if !ok {
+			return fmt.Errorf("%w: %q expects a string", ErrInvalidRules, rule.Field)
+		}
+		value = strings.TrimSpace(value)
+		if value == "" || len(value) > maxRuleValueLength {
+			return fmt.Errorf("%w: %q expects a string of 1 to %d characters", ErrInvalidRules, rule.Field, maxRuleValueLength)
+		}
+		rule.Value = value
+	}
+
+	return nil
+}
+
+func (r *RuleSetModel) UsesFollowing() bool {
+	for _, rule := range r.Rules {
+		if rule.Field == RuleFieldFollowedArtist {
+			return true
+		}
+	}
+	return false
+}
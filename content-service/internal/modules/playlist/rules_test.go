@@ -0,0 +1,62 @@
+package playlist
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRulesDefaults(t *testing.T) {
+	rules := &RuleSetModel{Rules: []*RuleModel{
+		{Field: RuleFieldTitle, Operator: RuleOperatorContains, Value: "  night  "},
+	}}
+
+	require.NoError(t, validateRules(rules))
+
+	assert.Equal(t, RuleMatchAll, rules.Match)
+	assert.Equal(t, RuleSortNewest, rules.Sort)
+	assert.Equal(t, MaxSmartPlaylistLimit, rules.Limit)
+	assert.Equal(t, "night", rules.Rules[0].Value)
+}
+
+func TestValidateRulesRejectsInvalidRules(t *testing.T) {
+	tooMany := make([]*RuleModel, MaxRules+1)
+	for i := range tooMany {
+		tooMany[i] = &RuleModel{Field: RuleFieldLiked, Operator: RuleOperatorEq, Value: true}
+	}
+
+	tests := []struct {
+		name  string
+		rules *RuleSetModel
+	}{
+		{name: "no rules", rules: &RuleSetModel{}},
+		{name: "too many rules", rules: &RuleSetModel{Rules: tooMany}},
+		{name: "unknown match", rules: ruleSet(RuleModel{Field: RuleFieldLiked, Operator: RuleOperatorEq, Value: true}, func(r *RuleSetModel) { r.Match = "some" })},
+		{name: "unknown sort", rules: ruleSet(RuleModel{Field: RuleFieldLiked, Operator: RuleOperatorEq, Value: true}, func(r *RuleSetModel) { r.Sort = "random" })},
+		{name: "limit too large", rules: ruleSet(RuleModel{Field: RuleFieldLiked, Operator: RuleOperatorEq, Value: true}, func(r *RuleSetModel) { r.Limit = MaxSmartPlaylistLimit + 1 })},
+		{name: "unknown field", rules: ruleSet(RuleModel{Field: "mood", Operator: RuleOperatorEq, Value: "calm"}, nil)},
+		{name: "unsupported operator", rules: ruleSet(RuleModel{Field: RuleFieldLiked, Operator: RuleOperatorGt, Value: true}, nil)},
+		{name: "liked expects boolean", rules: ruleSet(RuleModel{Field: RuleFieldLiked, Operator: RuleOperatorEq, Value: "yes"}, nil)},
+		{name: "days must be positive", rules: ruleSet(RuleModel{Field: RuleFieldLikedAt, Operator: RuleOperatorInLast, Value: float64(0)}, nil)},
+		{name: "days must be whole", rules: ruleSet(RuleModel{Field: RuleFieldCreatedAt, Operator: RuleOperatorInLast, Value: 1.5}, nil)},
+		{name: "plays must not be negative", rules: ruleSet(RuleModel{Field: RuleFieldPlays, Operator: RuleOperatorGt, Value: float64(-1)}, nil)},
+		{name: "text must not be blank", rules: ruleSet(RuleModel{Field: RuleFieldGenre, Operator: RuleOperatorEq, Value: "   "}, nil)},
+		{name: "text must not be too long", rules: ruleSet(RuleModel{Field: RuleFieldArtist, Operator: RuleOperatorEq, Value: strings.Repeat("a", maxRuleValueLength+1)}, nil)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, validateRules(tt.rules), ErrInvalidRules)
+		})
+	}
+}
+
+func ruleSet(rule RuleModel, modify func(r *RuleSetModel)) *RuleSetModel {
+	rules := &RuleSetModel{Rules: []*RuleModel{&rule}}
+	if modify != nil {
+		modify(rules)
+	}
+	return rules
+}
@@ -4,42 +4,70 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"log/slog"
 	"mime/multipart"
+	"slices"
 
+	"github.com/ocenb/music-go/content-service/internal/modules/feed/fanout"
 	"github.com/ocenb/music-go/content-service/internal/modules/file"
 	"github.com/ocenb/music-go/content-service/internal/storage"
+	"github.com/ocenb/music-go/content-service/internal/utils"
+	"github.com/ocenb/music-go/shared/searchindex"
+	"github.com/ocenb/music-protos/gen/searchservice"
+)
+
+const (
+	MosaicSize         = 4
+	searchDocumentType = "playlist"
 )
 
 type PlaylistServiceInterface interface {
-	GetOne(ctx context.Context, currentUserID int64, username, changeableID string) (*PlaylistWithSavedModel, error)
+	GetOne(ctx context.Context, currentUserID int64, username, changeableID, shareToken string) (*PlaylistWithSavedModel, error)
 	GetMany(ctx context.Context, userID int64, currentUserID int64, take int, lastID int64) ([]*PlaylistWithSavedModel, error)
 	GetManyWithSaved(ctx context.Context, currentUserID int64, take int, lastID int64) ([]*PlaylistWithSavedModel, error)
 	Create(ctx context.Context, userID int64, username, title, changeableID string, imageFile *multipart.FileHeader) (*PlaylistModel, error)
+	CreateSmart(ctx context.Context, userID int64, username, title, changeableID string, rules *RuleSetModel) (*PlaylistModel, error)
 	Delete(ctx context.Context, userID, playlistID int64) error
 	ChangeTitle(ctx context.Context, userID, playlistID int64, title string) error
 	ChangeChangeableId(ctx context.Context, userID, playlistID int64, changeableID string) error
 	ChangeImage(ctx context.Context, userID, playlistID int64, imageFile *multipart.FileHeader) error
-	SavePlaylist(ctx context.Context, userID, playlistID int64) error
+	ResetImage(ctx context.Context, userID, playlistID int64) error
+	RefreshCover(ctx context.Context, playlistID int64) error
+	RefreshCoversWithImage(ctx context.Context, image string) error
+	SyncSearch(ctx context.Context, playlistID int64) error
+	SyncFeed(ctx context.Context, playlistID int64)
+	ReleaseImage(ctx context.Context, playlistID int64, image string) error
+	ChangeRules(ctx context.Context, userID, playlistID int64, rules *RuleSetModel) error
+	SavePlaylist(ctx context.Context, userID, playlistID int64, shareToken string) error
 	RemoveFromSaved(ctx context.Context, userID, playlistID int64) error
+	Repost(ctx context.Context, userID, playlistID int64) error
+	RemoveRepost(ctx context.Context, userID, playlistID int64) error
+	ChangeVisibility(ctx context.Context, userID, playlistID int64, visibility Visibility) error
+	CreateShareToken(ctx context.Context, userID, playlistID int64) (*ShareTokenModel, error)
+	RevokeShareToken(ctx context.Context, userID, playlistID int64) error
 }
 
 type PlaylistService struct {
-	log          *slog.Logger
-	playlistRepo PlaylistRepoInterface
-	fileService  file.FileServiceInterface
+	log           *slog.Logger
+	playlistRepo  PlaylistRepoInterface
+	fileService   file.FileServiceInterface
+	fanOutService fanout.FanOutServiceInterface
+	searchIndex   searchindex.SearchIndexClientInterface
 }
 
-func NewPlaylistService(log *slog.Logger, playlistRepo PlaylistRepoInterface, fileService file.FileServiceInterface) PlaylistServiceInterface {
+func NewPlaylistService(log *slog.Logger, playlistRepo PlaylistRepoInterface, fileService file.FileServiceInterface, fanOutService fanout.FanOutServiceInterface, searchIndex searchindex.SearchIndexClientInterface) PlaylistServiceInterface {
 	return &PlaylistService{
-		log:          log,
-		playlistRepo: playlistRepo,
-		fileService:  fileService,
+		log:           log,
+		playlistRepo:  playlistRepo,
+		fileService:   fileService,
+		fanOutService: fanOutService,
+		searchIndex:   searchIndex,
 	}
 }
 
-func (s *PlaylistService) GetOne(ctx context.Context, currentUserID int64, username, changeableID string) (*PlaylistWithSavedModel, error) {
-	playlist, err := s.playlistRepo.GetByChangeableID(ctx, username, changeableID, currentUserID)
+func (s *PlaylistService) GetOne(ctx context.Context, currentUserID int64, username, changeableID, shareToken string) (*PlaylistWithSavedModel, error) {
+	playlist, err := s.playlistRepo.GetByChangeableID(ctx, username, changeableID, shareToken, currentUserID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrPlaylistNotFound
@@ -69,25 +97,15 @@ func (s *PlaylistService) GetManyWithSaved(ctx context.Context, currentUserID in
 }
 
 func (s *PlaylistService) Create(ctx context.Context, userID int64, username, title, changeableID string, imageFile *multipart.FileHeader) (*PlaylistModel, error) {
-	if err := s.validatePlaylistTitle(ctx, userID, title); err != nil {
-		return nil, err
-	}
-
-	if err := s.validateChangeableId(ctx, userID, changeableID); err != nil {
-		return nil, err
-	}
-
-	imageName, err := s.fileService.SaveImage(ctx, imageFile)
-	if err != nil {
-		return nil, err
-	}
+	return s.create(ctx, userID, username, title, changeableID, imageFile, nil)
+}
 
-	playlist, err := s.playlistRepo.Create(ctx, userID, username, title, changeableID, imageName)
-	if err != nil {
+func (s *PlaylistService) CreateSmart(ctx context.Context, userID int64, username, title, changeableID string, rules *RuleSetModel) (*PlaylistModel, error) {
+	if err := validateRules(rules); err != nil {
 		return nil, err
 	}
 
-	return playlist, nil
+	return s.create(ctx, userID, username, title, changeableID, nil, rules)
 }
 
 func (s *PlaylistService) Delete(ctx context.Context, userID, playlistID int64) error {
@@ -108,19 +126,37 @@ func (s *PlaylistService) Delete(ctx context.Context, userID, playlistID int64)
 	}
 
 	err = storage.WithTransaction(ctx, s.playlistRepo, func(txCtx context.Context) error {
+		images, err := s.playlistRepo.GetRevisionImages(txCtx, playlistID)
+		if err != nil {
+			return err
+		}
+
 		if err := s.playlistRepo.Delete(txCtx, playlistID); err != nil {
 			return err
 		}
 
-		err = s.fileService.DeleteFile(txCtx, playlist.Image, file.ImagesCategory)
-		if err != nil {
+		if err := s.deleteFromSearch(txCtx, playlistID); err != nil {
 			return err
 		}
+
+		if playlist.Image != file.DefaultImage && !slices.Contains(images, playlist.Image) {
+			images = append(images, playlist.Image)
+		}
+
+		for _, image := range images {
+			if err := s.fileService.DeleteFile(txCtx, image, file.ImagesCategory); err != nil {
+				return err
+			}
+		}
 		return nil
 	})
 	if err != nil {
 		return err
 	}
+
+	if err := s.fanOutService.RetractItem(ctx, fanout.PlaylistItem, playlistID); err != nil {
+		s.log.Error("Failed to remove playlist from feeds", "error", err, "playlist_id", playlistID)
+	}
 	return nil
 }
 
@@ -137,11 +173,17 @@ func (s *PlaylistService) ChangeTitle(ctx context.Context, userID, playlistID in
 		return err
 	}
 
-	if err := s.playlistRepo.ChangeTitle(ctx, playlistID, title); err != nil {
-		return err
-	}
+	return storage.WithTransaction(ctx, s.playlistRepo, func(txCtx context.Context) error {
+		if err := s.playlistRepo.ChangeTitle(txCtx, playlistID, title); err != nil {
+			return err
+		}
 
-	return nil
+		if err := s.playlistRepo.RecordRevision(txCtx, playlistID, userID, RevisionTitleChanged, map[string]any{"title": title}); err != nil {
+			return err
+		}
+
+		return s.SyncSearch(txCtx, playlistID)
+	})
 }
 
 func (s *PlaylistService) ChangeChangeableId(ctx context.Context, userID, playlistID int64, changeableID string) error {
@@ -165,11 +207,13 @@ func (s *PlaylistService) ChangeChangeableId(ctx context.Context, userID, playli
 		return err
 	}
 
-	if err := s.playlistRepo.ChangeChangeableID(ctx, playlistID, changeableID); err != nil {
-		return err
-	}
+	return storage.WithTransaction(ctx, s.playlistRepo, func(txCtx context.Context) error {
+		if err := s.playlistRepo.ChangeChangeableID(txCtx, playlistID, changeableID); err != nil {
+			return err
+		}
 
-	return nil
+		return s.playlistRepo.RecordRevision(txCtx, playlistID, userID, RevisionChangeableIDChanged, map[string]any{"changeableId": changeableID})
+	})
 }
 
 func (s *PlaylistService) ChangeImage(ctx context.Context, userID, playlistID int64, imageFile *multipart.FileHeader) error {
@@ -182,23 +226,181 @@ func (s *PlaylistService) ChangeImage(ctx context.Context, userID, playlistID in
 		return ErrPermissionDenied
 	}
 
+	cover, err := s.playlistRepo.GetCover(ctx, playlistID)
+	if err != nil {
+		return err
+	}
+
 	imageName, err := s.fileService.SaveImage(ctx, imageFile)
 	if err != nil {
 		return err
 	}
 
-	if err := s.playlistRepo.ChangeImage(ctx, playlistID, imageName); err != nil {
+	err = storage.WithTransaction(ctx, s.playlistRepo, func(txCtx context.Context) error {
+		if err := s.playlistRepo.ChangeImage(txCtx, playlistID, imageName); err != nil {
+			return err
+		}
+
+		return s.playlistRepo.RecordRevision(txCtx, playlistID, userID, RevisionImageChanged, nil)
+	})
+	if err != nil {
 		return err
 	}
 
-	if err := s.fileService.DeleteFile(ctx, imageName, file.ImagesCategory); err != nil {
+	return s.ReleaseImage(ctx, playlistID, cover.Image)
+}
+
+func (s *PlaylistService) ResetImage(ctx context.Context, userID, playlistID int64) error {
+	hasPermission, err := s.playlistRepo.CheckPermission(ctx, userID, playlistID)
+	if err != nil {
 		return err
 	}
 
-	return nil
+	if !hasPermission {
+		return ErrPermissionDenied
+	}
+
+	cover, err := s.playlistRepo.GetCover(ctx, playlistID)
+	if err != nil {
+		return err
+	}
+	if cover.Auto {
+		return nil
+	}
+
+	err = storage.WithTransaction(ctx, s.playlistRepo, func(txCtx context.Context) error {
+		if err := s.playlistRepo.ResetImage(txCtx, playlistID); err != nil {
+			return err
+		}
+
+		if err := s.playlistRepo.RequestRefresh(txCtx, playlistID); err != nil {
+			return err
+		}
+
+		return s.playlistRepo.RecordRevision(txCtx, playlistID, userID, RevisionImageChanged, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.ReleaseImage(ctx, playlistID, cover.Image)
 }
 
-func (s *PlaylistService) SavePlaylist(ctx context.Context, userID, playlistID int64) error {
+// ReleaseImage deletes a cover that is no longer in use, keeping uploaded
+// covers that a revision may still restore.
+func (s *PlaylistService) ReleaseImage(ctx context.Context, playlistID int64, image string) error {
+	if image == file.DefaultImage {
+		return nil
+	}
+
+	referenced, err := s.playlistRepo.IsImageInRevisions(ctx, playlistID, image)
+	if err != nil {
+		return err
+	}
+	if referenced {
+		return nil
+	}
+
+	return s.fileService.DeleteFile(ctx, image, file.ImagesCategory)
+}
+
+func (s *PlaylistService) RefreshCover(ctx context.Context, playlistID int64) error {
+	cover, err := s.playlistRepo.GetCover(ctx, playlistID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+	if !cover.Auto || cover.IsSmart {
+		return nil
+	}
+
+	source, err := s.playlistRepo.GetCoverCandidates(ctx, playlistID, MosaicSize)
+	if err != nil {
+		return err
+	}
+	if len(source) < MosaicSize && len(source) > 0 {
+		source = source[:1]
+	}
+	if slices.Equal(source, cover.Source) {
+		return nil
+	}
+
+	imageName := file.DefaultImage
+	if len(source) > 0 {
+		imageName, err = s.fileService.SaveMosaic(ctx, source)
+		if err != nil {
+			return err
+		}
+	}
+
+	changed, err := s.playlistRepo.ChangeCover(ctx, playlistID, imageName, source)
+	if err != nil {
+		return err
+	}
+
+	staleImage := cover.Image
+	if !changed {
+		staleImage = imageName
+	}
+	if staleImage == file.DefaultImage {
+		return nil
+	}
+
+	return s.fileService.DeleteFile(ctx, staleImage, file.ImagesCategory)
+}
+
+// RefreshCoversWithImage queues a cover refresh for every mosaic built from
+// the image.
+func (s *PlaylistService) RefreshCoversWithImage(ctx context.Context, image string) error {
+	return s.playlistRepo.RequestRefreshByCoverImage(ctx, image)
+}
+
+func (s *PlaylistService) ChangeRules(ctx context.Context, userID, playlistID int64, rules *RuleSetModel) error {
+	playlist, err := s.playlistRepo.GetByID(ctx, playlistID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrPlaylistNotFound
+		}
+		return err
+	}
+	if playlist.UserID != userID {
+		return ErrPermissionDenied
+	}
+	if playlist.Rules == nil {
+		return ErrPlaylistIsNotSmart
+	}
+
+	if err := validateRules(rules); err != nil {
+		return err
+	}
+
+	return storage.WithTransaction(ctx, s.playlistRepo, func(txCtx context.Context) error {
+		if err := s.playlistRepo.ChangeRules(txCtx, playlistID, rules); err != nil {
+			return err
+		}
+
+		if err := s.playlistRepo.RequestRefresh(txCtx, playlistID); err != nil {
+			return err
+		}
+
+		return s.playlistRepo.RecordRevision(txCtx, playlistID, userID, RevisionRulesChanged, nil)
+	})
+}
+
+func (s *PlaylistService) SavePlaylist(ctx context.Context, userID, playlistID int64, shareToken string) error {
+	canView, err := s.playlistRepo.CanView(ctx, userID, playlistID, shareToken)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrPlaylistNotFound
+		}
+		return err
+	}
+	if !canView {
+		return ErrPlaylistNotFound
+	}
+
 	playlist, err := s.playlistRepo.GetByID(ctx, playlistID, userID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -213,10 +415,63 @@ func (s *PlaylistService) SavePlaylist(ctx context.Context, userID, playlistID i
 		return ErrPlaylistAlreadySaved
 	}
 
-	return s.playlistRepo.SavePlaylist(ctx, userID, playlistID)
+	err = s.playlistRepo.SavePlaylist(ctx, userID, playlistID, shareToken)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrPlaylistNotFound
+		}
+		return err
+	}
+
+	return nil
 }
 
+// RemoveFromSaved does not look the playlist up first, so a saved playlist
+// can still be dropped from the library after its owner made it private.
 func (s *PlaylistService) RemoveFromSaved(ctx context.Context, userID, playlistID int64) error {
+	err := s.playlistRepo.RemoveFromSaved(ctx, userID, playlistID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrPlaylistIsNotSaved
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *PlaylistService) Repost(ctx context.Context, userID, playlistID int64) error {
+	playlist, err := s.playlistRepo.GetByID(ctx, playlistID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrPlaylistNotFound
+		}
+		return err
+	}
+	if playlist.UserID == userID {
+		return ErrPlaylistIsYours
+	}
+	if playlist.Visibility != VisibilityPublic {
+		return ErrPlaylistIsNotPublic
+	}
+	if playlist.IsReposted {
+		return ErrPlaylistAlreadyReposted
+	}
+
+	repostedAt, err := s.playlistRepo.AddRepost(ctx, userID, playlistID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrPlaylistAlreadyReposted
+		}
+		return err
+	}
+
+	s.fanOutService.Publish(ctx, userID, fanout.PlaylistItem, playlistID, true, repostedAt)
+
+	return nil
+}
+
+func (s *PlaylistService) RemoveRepost(ctx context.Context, userID, playlistID int64) error {
 	playlist, err := s.playlistRepo.GetByID(ctx, playlistID, userID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -224,11 +479,198 @@ func (s *PlaylistService) RemoveFromSaved(ctx context.Context, userID, playlistI
 		}
 		return err
 	}
-	if !playlist.IsSaved {
-		return ErrPlaylistIsNotSaved
+	if !playlist.IsReposted {
+		return ErrPlaylistIsNotReposted
+	}
+
+	if err := s.playlistRepo.RemoveRepost(ctx, userID, playlistID); err != nil {
+		return err
+	}
+
+	if err := s.fanOutService.Retract(ctx, userID, fanout.PlaylistItem, playlistID, true); err != nil {
+		s.log.Error("Failed to remove playlist repost from feeds", "error", err, "playlist_id", playlistID)
+	}
+
+	return nil
+}
+
+func (s *PlaylistService) ChangeVisibility(ctx context.Context, userID, playlistID int64, visibility Visibility) error {
+	playlist, err := s.playlistRepo.GetByID(ctx, playlistID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrPlaylistNotFound
+		}
+		return err
+	}
+	if playlist.UserID != userID {
+		return ErrPermissionDenied
+	}
+	if playlist.Visibility == visibility {
+		return nil
+	}
+
+	err = storage.WithTransaction(ctx, s.playlistRepo, func(txCtx context.Context) error {
+		if err := s.playlistRepo.ChangeVisibility(txCtx, playlistID, visibility); err != nil {
+			return err
+		}
+
+		if err := s.playlistRepo.RecordRevision(txCtx, playlistID, userID, RevisionVisibilityChanged, map[string]any{"visibility": visibility}); err != nil {
+			return err
+		}
+
+		return s.SyncSearch(txCtx, playlistID)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.SyncFeed(ctx, playlistID)
+
+	return nil
+}
+
+func (s *PlaylistService) CreateShareToken(ctx context.Context, userID, playlistID int64) (*ShareTokenModel, error) {
+	if err := s.checkOwner(ctx, userID, playlistID); err != nil {
+		return nil, err
+	}
+
+	shareToken, err := utils.GenerateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.playlistRepo.ChangeShareToken(ctx, playlistID, shareToken); err != nil {
+		return nil, err
+	}
+
+	return &ShareTokenModel{ShareToken: shareToken}, nil
+}
+
+func (s *PlaylistService) RevokeShareToken(ctx context.Context, userID, playlistID int64) error {
+	if err := s.checkOwner(ctx, userID, playlistID); err != nil {
+		return err
+	}
+
+	return s.playlistRepo.ChangeShareToken(ctx, playlistID, "")
+}
+
+func (s *PlaylistService) checkOwner(ctx context.Context, userID, playlistID int64) error {
+	role, err := s.playlistRepo.GetRole(ctx, userID, playlistID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrPlaylistNotFound
+		}
+		return err
+	}
+	if role != RoleOwner {
+		return ErrPermissionDenied
+	}
+
+	return nil
+}
+
+func (s *PlaylistService) create(ctx context.Context, userID int64, username, title, changeableID string, imageFile *multipart.FileHeader, rules *RuleSetModel) (*PlaylistModel, error) {
+	if err := s.validatePlaylistTitle(ctx, userID, title); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateChangeableId(ctx, userID, changeableID); err != nil {
+		return nil, err
+	}
+
+	imageName := file.DefaultImage
+	if imageFile != nil {
+		var err error
+		imageName, err = s.fileService.SaveImage(ctx, imageFile)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	return s.playlistRepo.RemoveFromSaved(ctx, userID, playlistID)
+	var playlist *PlaylistModel
+	err := storage.WithTransaction(ctx, s.playlistRepo, func(txCtx context.Context) error {
+		var err error
+		playlist, err = s.playlistRepo.Create(txCtx, userID, username, title, changeableID, imageName, rules)
+		if err != nil {
+			return err
+		}
+
+		if err := s.playlistRepo.RecordRevision(txCtx, playlist.ID, userID, RevisionCreated, nil); err != nil {
+			return err
+		}
+
+		if rules != nil {
+			if err := s.playlistRepo.RequestRefresh(txCtx, playlist.ID); err != nil {
+				return err
+			}
+		}
+
+		if playlist.Visibility != VisibilityPublic {
+			return nil
+		}
+
+		return s.searchIndex.Upsert(txCtx, searchDocumentType, &searchservice.AddOrUpdateRequest{
+			Id:   playlist.ID,
+			Name: playlist.Title,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if playlist.Visibility == VisibilityPublic {
+		s.fanOutService.Publish(ctx, userID, fanout.PlaylistItem, playlist.ID, false, playlist.CreatedAt)
+	}
+
+	return playlist, nil
+}
+
+func (s *PlaylistService) SyncSearch(ctx context.Context, playlistID int64) error {
+	playlist, err := s.playlistRepo.GetForIndexing(ctx, playlistID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrPlaylistNotFound
+		}
+		return err
+	}
+
+	if playlist.Visibility != VisibilityPublic {
+		return s.deleteFromSearch(ctx, playlistID)
+	}
+
+	err = s.searchIndex.Upsert(ctx, searchDocumentType, &searchservice.AddOrUpdateRequest{
+		Id:   playlistID,
+		Name: playlist.Title,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to queue playlist for search indexing: %w", err)
+	}
+
+	return nil
+}
+
+func (s *PlaylistService) deleteFromSearch(ctx context.Context, playlistID int64) error {
+	if err := s.searchIndex.Delete(ctx, searchDocumentType, playlistID); err != nil {
+		return fmt.Errorf("failed to queue playlist for search removal: %w", err)
+	}
+
+	return nil
+}
+
+// SyncFeed publishes the playlist to its owner's followers while it is public
+// and retracts it otherwise.
+func (s *PlaylistService) SyncFeed(ctx context.Context, playlistID int64) {
+	playlist, err := s.playlistRepo.GetForIndexing(ctx, playlistID)
+	if err != nil {
+		s.log.Error("Failed to load playlist for feeds", "error", err, "playlist_id", playlistID)
+		return
+	}
+
+	if playlist.Visibility == VisibilityPublic {
+		s.fanOutService.Publish(ctx, playlist.UserID, fanout.PlaylistItem, playlistID, false, playlist.CreatedAt)
+	} else if err := s.fanOutService.RetractItem(ctx, fanout.PlaylistItem, playlistID); err != nil {
+		s.log.Error("Failed to remove playlist from feeds", "error", err, "playlist_id", playlistID)
+	}
 }
 
 func (s *PlaylistService) validatePlaylistTitle(ctx context.Context, userID int64, title string) error {
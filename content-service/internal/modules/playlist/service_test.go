@@ -0,0 +1,293 @@
+package playlist
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/ocenb/music-go/content-service/internal/modules/feed/fanout"
+	"github.com/ocenb/music-go/shared/searchindex"
+	"github.com/ocenb/music-protos/gen/searchservice"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	ownerID    = int64(1)
+	otherID    = int64(2)
+	playlistID = int64(10)
+)
+
+// txConn only supports transactions, so storage.WithTransaction can run
+// without a database.
+type txConn struct{}
+
+func (txConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("queries are not supported")
+}
+
+func (txConn) Close() error {
+	return nil
+}
+
+func (txConn) Begin() (driver.Tx, error) {
+	return txConn{}, nil
+}
+
+func (txConn) Commit() error {
+	return nil
+}
+
+func (txConn) Rollback() error {
+	return nil
+}
+
+type txConnector struct{}
+
+func (txConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	return txConn{}, nil
+}
+
+func (txConnector) Driver() driver.Driver {
+	return nil
+}
+
+// memoryPlaylistRepo holds a single playlist owned by ownerID.
+type memoryPlaylistRepo struct {
+	PlaylistRepoInterface
+	postgres  *sql.DB
+	playlist  PlaylistWithSavedModel
+	revisions []RevisionChange
+}
+
+func newMemoryPlaylistRepo(t *testing.T, visibility Visibility) *memoryPlaylistRepo {
+	t.Helper()
+
+	postgres := sql.OpenDB(txConnector{})
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	return &memoryPlaylistRepo{
+		postgres: postgres,
+		playlist: PlaylistWithSavedModel{PlaylistModel: PlaylistModel{
+			ID:         playlistID,
+			Title:      "Road Trip",
+			Visibility: visibility,
+			UserID:     ownerID,
+		}},
+	}
+}
+
+func (r *memoryPlaylistRepo) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	return r.postgres.BeginTx(ctx, opts)
+}
+
+func (r *memoryPlaylistRepo) GetByID(ctx context.Context, id int64, currentUserID int64) (*PlaylistWithSavedModel, error) {
+	if id != playlistID {
+		return nil, sql.ErrNoRows
+	}
+	playlist := r.playlist
+	return &playlist, nil
+}
+
+func (r *memoryPlaylistRepo) GetForIndexing(ctx context.Context, id int64) (*PlaylistModel, error) {
+	if id != playlistID {
+		return nil, sql.ErrNoRows
+	}
+	playlist := r.playlist.PlaylistModel
+	return &playlist, nil
+}
+
+func (r *memoryPlaylistRepo) GetRole(ctx context.Context, userID, id int64) (Role, error) {
+	if id != playlistID {
+		return RoleNone, sql.ErrNoRows
+	}
+	if userID == ownerID {
+		return RoleOwner, nil
+	}
+	return RoleNone, nil
+}
+
+func (r *memoryPlaylistRepo) CanView(ctx context.Context, userID, id int64, shareToken string) (bool, error) {
+	if id != playlistID {
+		return false, sql.ErrNoRows
+	}
+	if r.playlist.Visibility == VisibilityPublic || userID == ownerID {
+		return true, nil
+	}
+	return r.playlist.ShareToken != nil && *r.playlist.ShareToken != "" && *r.playlist.ShareToken == shareToken, nil
+}
+
+func (r *memoryPlaylistRepo) ChangeVisibility(ctx context.Context, id int64, visibility Visibility) error {
+	r.playlist.Visibility = visibility
+	return nil
+}
+
+func (r *memoryPlaylistRepo) ChangeShareToken(ctx context.Context, id int64, shareToken string) error {
+	r.playlist.ShareToken = &shareToken
+	return nil
+}
+
+func (r *memoryPlaylistRepo) RecordRevision(ctx context.Context, id, actorID int64, change RevisionChange, details any) error {
+	r.revisions = append(r.revisions, change)
+	return nil
+}
+
+func (r *memoryPlaylistRepo) AddRepost(ctx context.Context, userID, id int64) (time.Time, error) {
+	r.playlist.IsReposted = true
+	return time.Now(), nil
+}
+
+type fakeSearchIndex struct {
+	searchindex.SearchIndexClientInterface
+	upserted []int64
+	deleted  []int64
+}
+
+func (c *fakeSearchIndex) Upsert(ctx context.Context, documentType string, document *searchservice.AddOrUpdateRequest) error {
+	c.upserted = append(c.upserted, document.Id)
+	return nil
+}
+
+func (c *fakeSearchIndex) Delete(ctx context.Context, documentType string, id int64) error {
+	c.deleted = append(c.deleted, id)
+	return nil
+}
+
+type fakeFanOut struct {
+	fanout.FanOutServiceInterface
+	published []int64
+	retracted []int64
+}
+
+func (f *fakeFanOut) Publish(ctx context.Context, actorID int64, itemType fanout.ItemType, itemID int64, isRepost bool, createdAt time.Time) {
+	f.published = append(f.published, itemID)
+}
+
+func (f *fakeFanOut) RetractItem(ctx context.Context, itemType fanout.ItemType, itemID int64) error {
+	f.retracted = append(f.retracted, itemID)
+	return nil
+}
+
+func newTestService(t *testing.T, visibility Visibility) (PlaylistServiceInterface, *memoryPlaylistRepo, *fakeSearchIndex, *fakeFanOut) {
+	t.Helper()
+
+	repo := newMemoryPlaylistRepo(t, visibility)
+	searchIndex := &fakeSearchIndex{}
+	fanOut := &fakeFanOut{}
+	service := NewPlaylistService(slog.New(slog.NewTextHandler(io.Discard, nil)), repo, nil, fanOut, searchIndex)
+
+	return service, repo, searchIndex, fanOut
+}
+
+func TestChangeVisibilityHidesPlaylist(t *testing.T) {
+	ctx := context.Background()
+	service, repo, searchIndex, fanOut := newTestService(t, VisibilityPublic)
+
+	require.NoError(t, service.ChangeVisibility(ctx, ownerID, playlistID, VisibilityPrivate))
+
+	assert.Equal(t, VisibilityPrivate, repo.playlist.Visibility)
+	assert.Equal(t, []RevisionChange{RevisionVisibilityChanged}, repo.revisions)
+	assert.Equal(t, []int64{playlistID}, searchIndex.deleted)
+	assert.Empty(t, searchIndex.upserted)
+	assert.Equal(t, []int64{playlistID}, fanOut.retracted)
+	assert.Empty(t, fanOut.published)
+}
+
+func TestChangeVisibilityPublishesPlaylist(t *testing.T) {
+	ctx := context.Background()
+	service, repo, searchIndex, fanOut := newTestService(t, VisibilityUnlisted)
+
+	require.NoError(t, service.ChangeVisibility(ctx, ownerID, playlistID, VisibilityPublic))
+
+	assert.Equal(t, VisibilityPublic, repo.playlist.Visibility)
+	assert.Equal(t, []int64{playlistID}, searchIndex.upserted)
+	assert.Equal(t, []int64{playlistID}, fanOut.published)
+	assert.Empty(t, fanOut.retracted)
+}
+
+func TestChangeVisibilityToSameVisibility(t *testing.T) {
+	ctx := context.Background()
+	service, repo, searchIndex, fanOut := newTestService(t, VisibilityPrivate)
+
+	require.NoError(t, service.ChangeVisibility(ctx, ownerID, playlistID, VisibilityPrivate))
+
+	assert.Empty(t, repo.revisions)
+	assert.Empty(t, searchIndex.deleted)
+	assert.Empty(t, fanOut.retracted)
+}
+
+func TestOnlyOwnerChangesVisibility(t *testing.T) {
+	ctx := context.Background()
+	service, repo, _, _ := newTestService(t, VisibilityPublic)
+
+	err := service.ChangeVisibility(ctx, otherID, playlistID, VisibilityPrivate)
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+
+	_, err = service.CreateShareToken(ctx, otherID, playlistID)
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+
+	err = service.ChangeVisibility(ctx, ownerID, playlistID+1, VisibilityPrivate)
+	assert.ErrorIs(t, err, ErrPlaylistNotFound)
+
+	assert.Equal(t, VisibilityPublic, repo.playlist.Visibility)
+}
+
+func TestShareToken(t *testing.T) {
+	ctx := context.Background()
+	service, repo, _, _ := newTestService(t, VisibilityUnlisted)
+
+	token, err := service.CreateShareToken(ctx, ownerID, playlistID)
+	require.NoError(t, err)
+	require.NotEmpty(t, token.ShareToken)
+	assert.Equal(t, token.ShareToken, *repo.playlist.ShareToken)
+
+	require.NoError(t, service.RevokeShareToken(ctx, ownerID, playlistID))
+	assert.Empty(t, *repo.playlist.ShareToken)
+}
+
+func TestSaveHiddenPlaylistRequiresAccess(t *testing.T) {
+	ctx := context.Background()
+	service, repo, _, _ := newTestService(t, VisibilityPrivate)
+
+	err := service.SavePlaylist(ctx, otherID, playlistID, "")
+	assert.ErrorIs(t, err, ErrPlaylistNotFound)
+
+	err = service.SavePlaylist(ctx, otherID, playlistID+1, "")
+	assert.ErrorIs(t, err, ErrPlaylistNotFound)
+
+	token, err := service.CreateShareToken(ctx, ownerID, playlistID)
+	require.NoError(t, err)
+	err = service.SavePlaylist(ctx, otherID, playlistID, "wrong")
+	assert.ErrorIs(t, err, ErrPlaylistNotFound)
+
+	require.NoError(t, service.RevokeShareToken(ctx, ownerID, playlistID))
+	err = service.SavePlaylist(ctx, otherID, playlistID, token.ShareToken)
+	assert.ErrorIs(t, err, ErrPlaylistNotFound, "a revoked token no longer grants access")
+
+	assert.Empty(t, repo.revisions)
+}
+
+func TestOnlyPublicPlaylistsAreReposted(t *testing.T) {
+	ctx := context.Background()
+
+	for _, visibility := range []Visibility{VisibilityUnlisted, VisibilityPrivate} {
+		service, repo, _, fanOut := newTestService(t, visibility)
+
+		err := service.Repost(ctx, otherID, playlistID)
+		assert.ErrorIs(t, err, ErrPlaylistIsNotPublic, string(visibility))
+		assert.False(t, repo.playlist.IsReposted)
+		assert.Empty(t, fanOut.published)
+	}
+
+	service, repo, _, fanOut := newTestService(t, VisibilityPublic)
+	require.NoError(t, service.Repost(ctx, otherID, playlistID))
+	assert.True(t, repo.playlist.IsReposted)
+	assert.Equal(t, []int64{playlistID}, fanOut.published)
+}
@@ -0,0 +1,28 @@
+package transfer
+
+import (
+	"errors"
+
+	"github.com/ocenb/music-go/content-service/internal/modules/playlist"
+	"github.com/ocenb/music-go/content-service/internal/modules/playlist/playlisttracks"
+)
+
+var (
+	ErrPlaylistNotFound  = errors.New("playlist not found")
+	ErrUnsupportedFormat = errors.New("unsupported playlist format")
+	ErrInvalidFile       = errors.New("invalid playlist file")
+	ErrFileTooLarge      = errors.New("playlist file is too large")
+	ErrTooManyEntries    = errors.New("playlist file has too many entries")
+	ErrNoEntries         = errors.New("playlist file has no entries")
+	ErrTitleRequired     = errors.New("playlist title is required")
+)
+
+var BadRequestErrors = append([]error{
+	ErrUnsupportedFormat,
+	ErrInvalidFile,
+	ErrFileTooLarge,
+	ErrTooManyEntries,
+	ErrNoEntries,
+	ErrTitleRequired,
+	playlisttracks.ErrTrackNotFound,
+}, append(playlist.BadRequestErrors, playlisttracks.BadRequestErrors...)...)
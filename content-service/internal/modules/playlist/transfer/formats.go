@@ -0,0 +1,282 @@
+package transfer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+)
+
+const xspfNamespace = "http://xspf.org/ns/0/"
+
+type xspfPlaylist struct {
+	XMLName   xml.Name    `xml:"playlist"`
+	Version   string      `xml:"version,attr"`
+	Namespace string      `xml:"xmlns,attr,omitempty"`
+	Title     string      `xml:"title,omitempty"`
+	Creator   string      `xml:"creator,omitempty"`
+	Tracks    []xspfTrack `xml:"trackList>track"`
+}
+
+type xspfTrack struct {
+	Location []string `xml:"location,omitempty"`
+	Title    string   `xml:"title,omitempty"`
+	Creator  string   `xml:"creator,omitempty"`
+	Duration int      `xml:"duration,omitempty"`
+	Image    string   `xml:"image,omitempty"`
+}
+
+type jspfDocument struct {
+	Playlist jspfPlaylist `json:"playlist"`
+}
+
+type jspfPlaylist struct {
+	Title   string      `json:"title,omitempty"`
+	Creator string      `json:"creator,omitempty"`
+	Tracks  []jspfTrack `json:"track"`
+}
+
+type jspfTrack struct {
+	Location []string `json:"location,omitempty"`
+	Title    string   `json:"title,omitempty"`
+	Creator  string   `json:"creator,omitempty"`
+	Duration int      `json:"duration,omitempty"`
+	Image    string   `json:"image,omitempty"`
+}
+
+func encode(format Format, file *PlaylistFileModel) ([]byte, error) {
+	switch format {
+	case FormatM3U8:
+		return encodeM3U8(file), nil
+	case FormatXSPF:
+		return encodeXSPF(file)
+	case FormatJSPF:
+		return encodeJSPF(file)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+func decode(format Format, data []byte) (*PlaylistFileModel, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	switch format {
+	case FormatM3U8:
+		return decodeM3U8(data)
+	case FormatXSPF:
+		return decodeXSPF(data)
+	case FormatJSPF:
+		return decodeJSPF(data)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+func encodeM3U8(file *PlaylistFileModel) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("#EXTM3U\n")
+	fmt.Fprintf(&buf, "#PLAYLIST:%s\n", file.Title)
+
+	for _, entry := range file.Entries {
+		fmt.Fprintf(&buf, "#EXTINF:%d,%s - %s\n", entry.Duration, entry.Artist, entry.Title)
+		if entry.Image != "" {
+			fmt.Fprintf(&buf, "#EXTIMG:%s\n", entry.Image)
+		}
+		buf.WriteString(entry.Location)
+		buf.WriteString("\n")
+	}
+
+	return buf.Bytes()
+}
+
+func decodeM3U8(data []byte) (*PlaylistFileModel, error) {
+	file := &PlaylistFileModel{}
+	var current *EntryModel
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "" || line == "#EXTM3U":
+		case strings.HasPrefix(line, "#PLAYLIST:"):
+			file.Title = strings.TrimSpace(strings.TrimPrefix(line, "#PLAYLIST:"))
+		case strings.HasPrefix(line, "#EXTINF:"):
+			current = parseExtinf(strings.TrimPrefix(line, "#EXTINF:"))
+		case strings.HasPrefix(line, "#EXTIMG:"):
+			if current != nil {
+				current.Image = strings.TrimSpace(strings.TrimPrefix(line, "#EXTIMG:"))
+			}
+		case strings.HasPrefix(line, "#"):
+		default:
+			if current == nil {
+				name := path.Base(strings.ReplaceAll(line, `\`, "/"))
+				current = &EntryModel{Title: strings.TrimSuffix(name, path.Ext(name))}
+			}
+			current.Location = line
+			file.Entries = append(file.Entries, current)
+			current = nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, ErrInvalidFile
+	}
+
+	return file, nil
+}
+
+func parseExtinf(value string) *EntryModel {
+	entry := &EntryModel{}
+
+	info, name, found := strings.Cut(value, ",")
+	if !found {
+		name = value
+	}
+
+	durationField, _, _ := strings.Cut(strings.TrimSpace(info), " ")
+	if duration, err := strconv.ParseFloat(durationField, 64); err == nil && duration > 0 {
+		entry.Duration = int(duration)
+	}
+
+	name = strings.TrimSpace(name)
+	if artist, title, found := strings.Cut(name, " - "); found {
+		entry.Artist = strings.TrimSpace(artist)
+		entry.Title = strings.TrimSpace(title)
+	} else {
+		entry.Title = name
+	}
+
+	return entry
+}
+
+func encodeXSPF(file *PlaylistFileModel) ([]byte, error) {
+	playlist := xspfPlaylist{
+		Version:   "1",
+		Namespace: xspfNamespace,
+		Title:     file.Title,
+		Creator:   file.Creator,
+		Tracks:    make([]xspfTrack, 0, len(file.Entries)),
+	}
+
+	for _, entry := range file.Entries {
+		playlist.Tracks = append(playlist.Tracks, xspfTrack{
+			Location: []string{entry.Location},
+			Title:    entry.Title,
+			Creator:  entry.Artist,
+			Duration: entry.Duration * 1000,
+			Image:    entry.Image,
+		})
+	}
+
+	data, err := xml.MarshalIndent(playlist, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), data...), nil
+}
+
+func decodeXSPF(data []byte) (*PlaylistFileModel, error) {
+	var playlist xspfPlaylist
+	if err := xml.Unmarshal(data, &playlist); err != nil {
+		return nil, ErrInvalidFile
+	}
+
+	file := &PlaylistFileModel{
+		Title:   strings.TrimSpace(playlist.Title),
+		Creator: strings.TrimSpace(playlist.Creator),
+	}
+
+	for _, track := range playlist.Tracks {
+		entry := &EntryModel{
+			Title:    strings.TrimSpace(track.Title),
+			Artist:   strings.TrimSpace(track.Creator),
+			Duration: track.Duration / 1000,
+			Image:    strings.TrimSpace(track.Image),
+		}
+		if len(track.Location) > 0 {
+			entry.Location = strings.TrimSpace(track.Location[0])
+		}
+		file.Entries = append(file.Entries, entry)
+	}
+
+	return file, nil
+}
+
+func encodeJSPF(file *PlaylistFileModel) ([]byte, error) {
+	document := jspfDocument{
+		Playlist: jspfPlaylist{
+			Title:   file.Title,
+			Creator: file.Creator,
+			Tracks:  make([]jspfTrack, 0, len(file.Entries)),
+		},
+	}
+
+	for _, entry := range file.Entries {
+		document.Playlist.Tracks = append(document.Playlist.Tracks, jspfTrack{
+			Location: []string{entry.Location},
+			Title:    entry.Title,
+			Creator:  entry.Artist,
+			Duration: entry.Duration * 1000,
+			Image:    entry.Image,
+		})
+	}
+
+	return json.MarshalIndent(document, "", "  ")
+}
+
+func decodeJSPF(data []byte) (*PlaylistFileModel, error) {
+	var document jspfDocument
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, ErrInvalidFile
+	}
+
+	file := &PlaylistFileModel{
+		Title:   strings.TrimSpace(document.Playlist.Title),
+		Creator: strings.TrimSpace(document.Playlist.Creator),
+	}
+
+	for _, track := range document.Playlist.Tracks {
+		entry := &EntryModel{
+			Title:    strings.TrimSpace(track.Title),
+			Artist:   strings.TrimSpace(track.Creator),
+			Duration: track.Duration / 1000,
+			Image:    strings.TrimSpace(track.Image),
+		}
+		if len(track.Location) > 0 {
+			entry.Location = strings.TrimSpace(track.Location[0])
+		}
+		file.Entries = append(file.Entries, entry)
+	}
+
+	return file, nil
+}
+
+func formatFromFileName(fileName string) (Format, error) {
+	switch strings.ToLower(path.Ext(fileName)) {
+	case ".m3u8", ".m3u":
+		return FormatM3U8, nil
+	case ".xspf":
+		return FormatXSPF, nil
+	case ".jspf", ".json":
+		return FormatJSPF, nil
+	default:
+		return "", ErrUnsupportedFormat
+	}
+}
+
+func contentType(format Format) string {
+	switch format {
+	case FormatXSPF:
+		return "application/xspf+xml"
+	case FormatJSPF:
+		return "application/jspf+json"
+	default:
+		return "application/vnd.apple.mpegurl"
+	}
+}
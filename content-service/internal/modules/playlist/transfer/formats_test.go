@@ -0,0 +1,92 @@
+package transfer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPlaylistFile() *PlaylistFileModel {
+	return &PlaylistFileModel{
+		Title:   "Road Trip",
+		Creator: "listener",
+		Entries: []*EntryModel{
+			{Title: "Night Drive", Artist: "Neon", Duration: 215, Location: "https://example.com/tracks/1", Image: "https://example.com/images/1.jpg"},
+			{Title: "Open Road", Artist: "The Highways", Duration: 187, Location: "https://example.com/tracks/2"},
+		},
+	}
+}
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	for _, format := range []Format{FormatM3U8, FormatXSPF, FormatJSPF} {
+		t.Run(string(format), func(t *testing.T) {
+			file := testPlaylistFile()
+
+			data, err := encode(format, file)
+			require.NoError(t, err)
+
+			decoded, err := decode(format, data)
+			require.NoError(t, err)
+
+			if format == FormatM3U8 {
+				// M3U8 has no creator.
+				file.Creator = ""
+			}
+			assert.Equal(t, file, decoded)
+		})
+	}
+}
+
+func TestDecodeM3U8(t *testing.T) {
+	data := []byte("\xef\xbb\xbf#EXTM3U\n" +
+		"#PLAYLIST: Mix \n" +
+		"#EXTINF:201.6 tvg-id=\"1\",Neon - Night Drive\n" +
+		"#EXTVLCOPT:network-caching=1000\n" +
+		"music/night-drive.mp3\n" +
+		"#EXTINF:-1,Untitled Stream\n" +
+		"http://radio.example.com/live\n" +
+		"C:\\Music\\Open Road.flac\n")
+
+	file, err := decode(FormatM3U8, data)
+	require.NoError(t, err)
+
+	assert.Equal(t, &PlaylistFileModel{
+		Title: "Mix",
+		Entries: []*EntryModel{
+			{Title: "Night Drive", Artist: "Neon", Duration: 201, Location: "music/night-drive.mp3"},
+			{Title: "Untitled Stream", Location: "http://radio.example.com/live"},
+			{Title: "Open Road", Location: "C:\\Music\\Open Road.flac"},
+		},
+	}, file)
+}
+
+func TestDecodeInvalidFile(t *testing.T) {
+	_, err := decode(FormatXSPF, []byte("<playlist><trackList>"))
+	assert.ErrorIs(t, err, ErrInvalidFile)
+
+	_, err = decode(FormatJSPF, []byte(`{"playlist":`))
+	assert.ErrorIs(t, err, ErrInvalidFile)
+
+	_, err = decode("pls", []byte("[playlist]"))
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
+
+func TestFormatFromFileName(t *testing.T) {
+	tests := map[string]Format{
+		"mix.m3u8":        FormatM3U8,
+		"Mix.M3U":         FormatM3U8,
+		"mix.xspf":        FormatXSPF,
+		"mix.jspf":        FormatJSPF,
+		"export.json":     FormatJSPF,
+		"dir.v2/mix.xspf": FormatXSPF,
+	}
+	for fileName, want := range tests {
+		got, err := formatFromFileName(fileName)
+		require.NoError(t, err, fileName)
+		assert.Equal(t, want, got, fileName)
+	}
+
+	_, err := formatFromFileName("mix.pls")
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
@@ -0,0 +1,105 @@
+package transfer
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ocenb/music-go/content-service/internal/utils"
+)
+
+type TransferHandlersInterface interface {
+	Export(c *gin.Context)
+	Import(c *gin.Context)
+	RegisterHandlers(router *gin.RouterGroup)
+}
+
+type TransferHandlers struct {
+	transferService TransferServiceInterface
+}
+
+func NewHandlers(transferService TransferServiceInterface) TransferHandlersInterface {
+	return &TransferHandlers{
+		transferService: transferService,
+	}
+}
+
+func (h *TransferHandlers) Export(c *gin.Context) {
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.UnauthenticatedError(c, err)
+		return
+	}
+
+	var playlistReq PlaylistUri
+	if err := c.ShouldBindUri(&playlistReq); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	var exportReq ExportForm
+	if err := c.ShouldBindQuery(&exportReq); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	export, err := h.transferService.Export(c, user.Id, playlistReq.PlaylistID, Format(exportReq.Format), exportReq.ShareToken)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrPlaylistNotFound):
+			utils.NotFoundError(c, err)
+		case errors.Is(err, ErrUnsupportedFormat):
+			utils.BadRequestError(c, err)
+		default:
+			utils.InternalError(c, err)
+		}
+
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", export.FileName))
+	c.Data(http.StatusOK, export.ContentType, export.Content)
+}
+
+func (h *TransferHandlers) Import(c *gin.Context) {
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.UnauthenticatedError(c, err)
+		return
+	}
+
+	var importReq ImportForm
+	if err := c.ShouldBind(&importReq); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	report, err := h.transferService.Import(
+		c,
+		user.Id,
+		user.Username,
+		importReq.File,
+		Format(importReq.Format),
+		importReq.Title,
+		importReq.ChangeableID,
+	)
+	if err != nil {
+		for _, badRequestError := range BadRequestErrors {
+			if errors.Is(err, badRequestError) {
+				utils.BadRequestError(c, err)
+				return
+			}
+		}
+		utils.InternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, report)
+}
+
+func (h *TransferHandlers) RegisterHandlers(router *gin.RouterGroup) {
+	transferRouter := router.Group("/playlist-transfer")
+	transferRouter.GET("/:playlistId/export", h.Export)
+	transferRouter.POST("/import", h.Import)
+}
@@ -0,0 +1,50 @@
+package transfer
+
+import "github.com/ocenb/music-go/content-service/internal/modules/playlist"
+
+type Format string
+
+const (
+	FormatM3U8 Format = "m3u8"
+	FormatXSPF Format = "xspf"
+	FormatJSPF Format = "jspf"
+)
+
+type UnmatchedReason string
+
+const (
+	ReasonNotFound  UnmatchedReason = "notFound"
+	ReasonDuplicate UnmatchedReason = "duplicate"
+)
+
+type EntryModel struct {
+	Title    string `json:"title"`
+	Artist   string `json:"artist"`
+	Duration int    `json:"duration"`
+	Location string `json:"location,omitempty"`
+	Image    string `json:"image,omitempty"`
+}
+
+type PlaylistFileModel struct {
+	Title   string
+	Creator string
+	Entries []*EntryModel
+}
+
+type UnmatchedEntryModel struct {
+	EntryModel
+	Reason UnmatchedReason `json:"reason"`
+}
+
+type ImportReportModel struct {
+	Playlist  *playlist.PlaylistModel `json:"playlist"`
+	Total     int                     `json:"total"`
+	Matched   int                     `json:"matched"`
+	Unmatched []*UnmatchedEntryModel  `json:"unmatched"`
+}
+
+type ExportModel struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
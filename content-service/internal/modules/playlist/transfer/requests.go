@@ -0,0 +1,19 @@
+package transfer
+
+import "mime/multipart"
+
+type PlaylistUri struct {
+	PlaylistID int64 `uri:"playlistId" binding:"required"`
+}
+
+type ExportForm struct {
+	Format     string `form:"format" binding:"required,oneof=m3u8 xspf jspf"`
+	ShareToken string `form:"shareToken"`
+}
+
+type ImportForm struct {
+	File         *multipart.FileHeader `form:"file" binding:"required"`
+	Format       string                `form:"format" binding:"omitempty,oneof=m3u8 xspf jspf"`
+	Title        string                `form:"title" binding:"omitempty,min=1,max=20"`
+	ChangeableID string                `form:"changeableId" binding:"required,min=1,max=20"`
+}
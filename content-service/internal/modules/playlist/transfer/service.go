@@ -0,0 +1,318 @@
+package transfer
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/ocenb/music-go/content-service/internal/clients/searchclient"
+	"github.com/ocenb/music-go/content-service/internal/modules/file"
+	"github.com/ocenb/music-go/content-service/internal/modules/playlist"
+	"github.com/ocenb/music-go/content-service/internal/modules/playlist/playlisttracks"
+	"github.com/ocenb/music-go/content-service/internal/modules/track"
+	"github.com/ocenb/music-go/content-service/internal/storage"
+	"github.com/ocenb/music-protos/gen/searchservice"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	maxFileSize      = 1 << 20
+	maxEntries       = 500
+	maxTitleLength   = 20
+	matchConcurrency = 8
+	matchCandidates  = 50
+)
+
+type TransferServiceInterface interface {
+	Export(ctx context.Context, userID, playlistID int64, format Format, shareToken string) (*ExportModel, error)
+	Import(ctx context.Context, userID int64, username string, fileHeader *multipart.FileHeader, format Format, title, changeableID string) (*ImportReportModel, error)
+}
+
+type TransferService struct {
+	log                   *slog.Logger
+	playlistRepo          playlist.PlaylistRepoInterface
+	playlistService       playlist.PlaylistServiceInterface
+	playlistTracksService playlisttracks.PlaylistTracksServiceInterface
+	trackRepo             track.TrackRepoInterface
+	fileService           file.FileServiceInterface
+	searchClient          *searchclient.SearchServiceClient
+}
+
+func NewTransferService(
+	log *slog.Logger,
+	playlistRepo playlist.PlaylistRepoInterface,
+	playlistService playlist.PlaylistServiceInterface,
+	playlistTracksService playlisttracks.PlaylistTracksServiceInterface,
+	trackRepo track.TrackRepoInterface,
+	fileService file.FileServiceInterface,
+	searchClient *searchclient.SearchServiceClient,
+) TransferServiceInterface {
+	return &TransferService{
+		log:                   log,
+		playlistRepo:          playlistRepo,
+		playlistService:       playlistService,
+		playlistTracksService: playlistTracksService,
+		trackRepo:             trackRepo,
+		fileService:           fileService,
+		searchClient:          searchClient,
+	}
+}
+
+func (s *TransferService) Export(ctx context.Context, userID, playlistID int64, format Format, shareToken string) (*ExportModel, error) {
+	tracks, err := s.playlistTracksService.GetMany(ctx, userID, playlistID, 0, shareToken)
+	if err != nil {
+		if errors.Is(err, playlisttracks.ErrPlaylistNotFound) {
+			return nil, ErrPlaylistNotFound
+		}
+		return nil, err
+	}
+
+	existingPlaylist, err := s.playlistRepo.GetByID(ctx, playlistID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPlaylistNotFound
+		}
+		return nil, err
+	}
+
+	playlistFile := &PlaylistFileModel{
+		Title:   existingPlaylist.Title,
+		Creator: existingPlaylist.Username,
+		Entries: make([]*EntryModel, 0, len(tracks)),
+	}
+	for _, track := range tracks {
+		playlistFile.Entries = append(playlistFile.Entries, &EntryModel{
+			Title:    track.Title,
+			Artist:   track.Artist,
+			Duration: track.Duration,
+			Location: s.fileService.GetAudioURL(track.Audio),
+			Image:    s.fileService.GetImageURL(track.CoverImagePath),
+		})
+	}
+
+	content, err := encode(format, playlistFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExportModel{
+		FileName:    fmt.Sprintf("%s.%s", existingPlaylist.ChangeableID, format),
+		ContentType: contentType(format),
+		Content:     content,
+	}, nil
+}
+
+func (s *TransferService) Import(ctx context.Context, userID int64, username string, fileHeader *multipart.FileHeader, format Format, title, changeableID string) (*ImportReportModel, error) {
+	if format == "" {
+		var err error
+		format, err = formatFromFileName(fileHeader.Filename)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	playlistFile, err := s.readFile(fileHeader, format)
+	if err != nil {
+		return nil, err
+	}
+
+	if title == "" {
+		title = truncate(playlistFile.Title, maxTitleLength)
+	}
+	if title == "" {
+		return nil, ErrTitleRequired
+	}
+
+	trackIDs, unmatched, err := s.match(ctx, userID, playlistFile.Entries)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdPlaylist *playlist.PlaylistModel
+	err = storage.WithTransaction(ctx, s.playlistRepo, func(txCtx context.Context) error {
+		var err error
+		createdPlaylist, err = s.playlistService.Create(txCtx, userID, username, title, changeableID, nil)
+		if err != nil {
+			return err
+		}
+
+		if len(trackIDs) == 0 {
+			return nil
+		}
+
+		_, err = s.playlistTracksService.AddMany(txCtx, userID, createdPlaylist.ID, trackIDs, 0)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Create publishes before the transaction commits, when the playlist is
+	// not visible to the fan-out yet, so it is published again now.
+	s.playlistService.SyncFeed(ctx, createdPlaylist.ID)
+
+	return &ImportReportModel{
+		Playlist:  createdPlaylist,
+		Total:     len(playlistFile.Entries),
+		Matched:   len(trackIDs),
+		Unmatched: unmatched,
+	}, nil
+}
+
+func (s *TransferService) readFile(fileHeader *multipart.FileHeader, format Format) (*PlaylistFileModel, error) {
+	if fileHeader.Size > maxFileSize {
+		return nil, ErrFileTooLarge
+	}
+
+	src, err := fileHeader.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := src.Close(); err != nil {
+			s.log.Error("Failed to close file", "error", err)
+		}
+	}()
+
+	data, err := io.ReadAll(io.LimitReader(src, maxFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxFileSize {
+		return nil, ErrFileTooLarge
+	}
+
+	playlistFile, err := decode(format, data)
+	if err != nil {
+		return nil, err
+	}
+	if len(playlistFile.Entries) == 0 {
+		return nil, ErrNoEntries
+	}
+	if len(playlistFile.Entries) > maxEntries {
+		return nil, ErrTooManyEntries
+	}
+
+	return playlistFile, nil
+}
+
+func (s *TransferService) match(ctx context.Context, userID int64, entries []*EntryModel) ([]int64, []*UnmatchedEntryModel, error) {
+	found, err := s.findTracks(ctx, userID, entries)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	trackIDs := make([]int64, 0, len(entries))
+	unmatched := make([]*UnmatchedEntryModel, 0)
+	matched := make(map[int64]struct{}, len(entries))
+
+	for _, entry := range entries {
+		trackID := found[entryKey(entry)]
+
+		if trackID == 0 {
+			unmatched = append(unmatched, &UnmatchedEntryModel{EntryModel: *entry, Reason: ReasonNotFound})
+			continue
+		}
+		if _, ok := matched[trackID]; ok {
+			unmatched = append(unmatched, &UnmatchedEntryModel{EntryModel: *entry, Reason: ReasonDuplicate})
+			continue
+		}
+
+		matched[trackID] = struct{}{}
+		trackIDs = append(trackIDs, trackID)
+	}
+
+	return trackIDs, unmatched, nil
+}
+
+// findTracks resolves every distinct title and artist pair once, with a
+// bounded number of searches in flight.
+func (s *TransferService) findTracks(ctx context.Context, userID int64, entries []*EntryModel) (map[string]int64, error) {
+	var mu sync.Mutex
+	found := make(map[string]int64, len(entries))
+	seen := make(map[string]struct{}, len(entries))
+
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(matchConcurrency)
+
+	for _, entry := range entries {
+		key := entryKey(entry)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		g.Go(func() error {
+			trackID, err := s.findTrack(gCtx, userID, entry)
+			if err != nil {
+				return err
+			}
+
+			mu.Lock()
+			found[key] = trackID
+			mu.Unlock()
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return found, nil
+}
+
+func entryKey(entry *EntryModel) string {
+	return strings.ToLower(strings.TrimSpace(entry.Title)) + "\x00" + strings.ToLower(strings.TrimSpace(entry.Artist))
+}
+
+func (s *TransferService) findTrack(ctx context.Context, userID int64, entry *EntryModel) (int64, error) {
+	title := strings.TrimSpace(entry.Title)
+	if title == "" {
+		return 0, nil
+	}
+
+	response, err := s.searchClient.Client.SearchTracks(ctx, &searchservice.SearchRequest{
+		Query: title,
+		Limit: matchCandidates,
+	})
+	if err != nil {
+		s.log.Error("Failed to search tracks", "error", err, "title", title)
+		return 0, err
+	}
+	if len(response.Ids) == 0 {
+		return 0, nil
+	}
+
+	candidates, err := s.trackRepo.GetManyByIDs(ctx, response.Ids, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	artist := strings.TrimSpace(entry.Artist)
+	for _, candidate := range candidates {
+		if !strings.EqualFold(strings.TrimSpace(candidate.Title), title) {
+			continue
+		}
+		if artist != "" && !strings.EqualFold(candidate.Username, artist) {
+			continue
+		}
+		return candidate.ID, nil
+	}
+
+	return 0, nil
+}
+
+func truncate(value string, length int) string {
+	if utf8.RuneCountInString(value) <= length {
+		return value
+	}
+	return string([]rune(value)[:length])
+}
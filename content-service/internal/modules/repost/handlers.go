@@ -0,0 +1,79 @@
+package repost
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ocenb/music-go/content-service/internal/modules/feed/fanout"
+	"github.com/ocenb/music-go/content-service/internal/utils"
+)
+
+type RepostHandlerInterface interface {
+	getMany(c *gin.Context)
+	getStream(c *gin.Context)
+	RegisterHandlers(router *gin.RouterGroup)
+}
+
+type RepostHandler struct {
+	repostService RepostServiceInterface
+}
+
+func NewRepostHandler(repostService RepostServiceInterface) RepostHandlerInterface {
+	return &RepostHandler{
+		repostService: repostService,
+	}
+}
+
+func (h *RepostHandler) getMany(c *gin.Context) {
+	var params GetManyForm
+	if err := c.ShouldBindQuery(&params); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.InternalError(c, err)
+		return
+	}
+
+	reposts, err := h.repostService.GetMany(c.Request.Context(), user.Id, params.UserID, params.Take, streamCursor(params))
+	if err != nil {
+		utils.InternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reposts)
+}
+
+func (h *RepostHandler) getStream(c *gin.Context) {
+	var params GetManyForm
+	if err := c.ShouldBindQuery(&params); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.InternalError(c, err)
+		return
+	}
+
+	stream, err := h.repostService.GetStream(c.Request.Context(), user.Id, params.UserID, params.Take, streamCursor(params))
+	if err != nil {
+		utils.InternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stream)
+}
+
+func (h *RepostHandler) RegisterHandlers(router *gin.RouterGroup) {
+	repostRouter := router.Group("/repost")
+	repostRouter.GET("", h.getMany)
+	repostRouter.GET("/stream", h.getStream)
+}
+
+func streamCursor(params GetManyForm) StreamCursorModel {
+	return StreamCursorModel{Date: params.LastDate, Type: fanout.ItemType(params.LastType), ItemID: params.LastID}
+}
@@ -0,0 +1,41 @@
+package repost
+
+import (
+	"time"
+
+	"github.com/ocenb/music-go/content-service/internal/modules/feed/fanout"
+	"github.com/ocenb/music-go/content-service/internal/modules/playlist"
+	"github.com/ocenb/music-go/content-service/internal/modules/track"
+)
+
+type StreamEntryModel struct {
+	ActorID  int64
+	Type     fanout.ItemType
+	ItemID   int64
+	IsRepost bool
+	Date     time.Time
+}
+
+// StreamCursorModel points after the last entry of a page. Track and playlist
+// ids come from separate sequences, so the type is part of the cursor.
+type StreamCursorModel struct {
+	Date   time.Time
+	Type   fanout.ItemType
+	ItemID int64
+}
+
+type RepostModel struct {
+	Type       fanout.ItemType                  `json:"type"`
+	Track      *track.TrackWithLikedModel       `json:"track,omitempty"`
+	Playlist   *playlist.PlaylistWithSavedModel `json:"playlist,omitempty"`
+	RepostedAt time.Time                        `json:"repostedAt"`
+}
+
+type StreamItemModel struct {
+	ActorID  int64                            `json:"actorId"`
+	Type     fanout.ItemType                  `json:"type"`
+	IsRepost bool                             `json:"isRepost"`
+	Track    *track.TrackWithLikedModel       `json:"track,omitempty"`
+	Playlist *playlist.PlaylistWithSavedModel `json:"playlist,omitempty"`
+	Date     time.Time                        `json:"date"`
+}
@@ -0,0 +1,101 @@
+package repost
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+)
+
+type RepostRepoInterface interface {
+	GetMany(ctx context.Context, userID int64, take int, cursor StreamCursorModel) ([]*StreamEntryModel, error)
+	GetStream(ctx context.Context, userID int64, take int, cursor StreamCursorModel) ([]*StreamEntryModel, error)
+}
+
+type RepostRepo struct {
+	postgres *sql.DB
+	log      *slog.Logger
+}
+
+func NewRepostRepo(postgres *sql.DB, log *slog.Logger) RepostRepoInterface {
+	return &RepostRepo{postgres: postgres, log: log}
+}
+
+func (r *RepostRepo) GetMany(ctx context.Context, userID int64, take int, cursor StreamCursorModel) ([]*StreamEntryModel, error) {
+	query := `
+		SELECT actor_id, item_type, item_id, is_repost, item_date FROM (
+			SELECT user_id as actor_id, 'track' as item_type, track_id as item_id, true as is_repost, reposted_at as item_date
+			FROM track_reposts
+			WHERE user_id = $1
+			UNION ALL
+			SELECT user_id as actor_id, 'playlist' as item_type, playlist_id as item_id, true as is_repost, reposted_at as item_date
+			FROM playlist_reposts
+			WHERE user_id = $1
+		) reposts
+		WHERE $2::timestamp IS NULL OR (item_date, item_type, item_id) < ($2, $3, $4)
+		ORDER BY item_date DESC, item_type DESC, item_id DESC
+		LIMIT $5
+	`
+
+	return r.getEntries(ctx, query, userID, take, cursor)
+}
+
+func (r *RepostRepo) GetStream(ctx context.Context, userID int64, take int, cursor StreamCursorModel) ([]*StreamEntryModel, error) {
+	query := `
+		SELECT actor_id, item_type, item_id, is_repost, item_date FROM (
+			SELECT user_id as actor_id, 'track' as item_type, id as item_id, false as is_repost, created_at as item_date
+			FROM tracks
+			WHERE user_id = $1
+			UNION ALL
+			SELECT user_id as actor_id, 'playlist' as item_type, id as item_id, false as is_repost, created_at as item_date
+			FROM playlists
+			WHERE user_id = $1
+			UNION ALL
+			SELECT user_id as actor_id, 'track' as item_type, track_id as item_id, true as is_repost, reposted_at as item_date
+			FROM track_reposts
+			WHERE user_id = $1
+			UNION ALL
+			SELECT user_id as actor_id, 'playlist' as item_type, playlist_id as item_id, true as is_repost, reposted_at as item_date
+			FROM playlist_reposts
+			WHERE user_id = $1
+		) stream
+		WHERE $2::timestamp IS NULL OR (item_date, item_type, item_id) < ($2, $3, $4)
+		ORDER BY item_date DESC, item_type DESC, item_id DESC
+		LIMIT $5
+	`
+
+	return r.getEntries(ctx, query, userID, take, cursor)
+}
+
+func (r *RepostRepo) getEntries(ctx context.Context, query string, userID int64, take int, cursor StreamCursorModel) ([]*StreamEntryModel, error) {
+	before := sql.NullTime{Time: cursor.Date, Valid: !cursor.Date.IsZero()}
+
+	rows, err := r.postgres.QueryContext(ctx, query, userID, before, string(cursor.Type), cursor.ItemID, take)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			r.log.Error("Failed to close rows", "error", err)
+		}
+	}()
+
+	var entries []*StreamEntryModel
+
+	for rows.Next() {
+		var entry StreamEntryModel
+
+		err := rows.Scan(&entry.ActorID, &entry.Type, &entry.ItemID, &entry.IsRepost, &entry.Date)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, &entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
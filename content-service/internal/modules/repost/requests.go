@@ -0,0 +1,11 @@
+package repost
+
+import "time"
+
+type GetManyForm struct {
+	UserID   int64     `form:"userId" binding:"required"`
+	Take     int       `form:"take" binding:"omitempty,min=1"`
+	LastDate time.Time `form:"lastDate" binding:"omitempty"`
+	LastType string    `form:"lastType" binding:"required_with=LastDate,omitempty,oneof=track playlist"`
+	LastID   int64     `form:"lastId" binding:"omitempty,min=1"`
+}
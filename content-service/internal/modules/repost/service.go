@@ -0,0 +1,128 @@
+package repost
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ocenb/music-go/content-service/internal/modules/feed/fanout"
+	"github.com/ocenb/music-go/content-service/internal/modules/playlist"
+	"github.com/ocenb/music-go/content-service/internal/modules/track"
+)
+
+type RepostServiceInterface interface {
+	GetMany(ctx context.Context, currentUserID, userID int64, take int, cursor StreamCursorModel) ([]*RepostModel, error)
+	GetStream(ctx context.Context, currentUserID, userID int64, take int, cursor StreamCursorModel) ([]*StreamItemModel, error)
+	Hydrate(ctx context.Context, currentUserID int64, entries []*StreamEntryModel) ([]*StreamItemModel, error)
+}
+
+type RepostService struct {
+	log          *slog.Logger
+	repostRepo   RepostRepoInterface
+	trackRepo    track.TrackRepoInterface
+	playlistRepo playlist.PlaylistRepoInterface
+}
+
+func NewRepostService(log *slog.Logger, repostRepo RepostRepoInterface, trackRepo track.TrackRepoInterface, playlistRepo playlist.PlaylistRepoInterface) RepostServiceInterface {
+	return &RepostService{
+		log:          log,
+		repostRepo:   repostRepo,
+		trackRepo:    trackRepo,
+		playlistRepo: playlistRepo,
+	}
+}
+
+func (s *RepostService) GetMany(ctx context.Context, currentUserID, userID int64, take int, cursor StreamCursorModel) ([]*RepostModel, error) {
+	entries, err := s.repostRepo.GetMany(ctx, userID, take, cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.Hydrate(ctx, currentUserID, entries)
+	if err != nil {
+		return nil, err
+	}
+
+	reposts := make([]*RepostModel, 0, len(items))
+	for _, item := range items {
+		reposts = append(reposts, &RepostModel{
+			Type:       item.Type,
+			Track:      item.Track,
+			Playlist:   item.Playlist,
+			RepostedAt: item.Date,
+		})
+	}
+
+	return reposts, nil
+}
+
+func (s *RepostService) GetStream(ctx context.Context, currentUserID, userID int64, take int, cursor StreamCursorModel) ([]*StreamItemModel, error) {
+	entries, err := s.repostRepo.GetStream(ctx, userID, take, cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Hydrate(ctx, currentUserID, entries)
+}
+
+func (s *RepostService) Hydrate(ctx context.Context, currentUserID int64, entries []*StreamEntryModel) ([]*StreamItemModel, error) {
+	var trackIDs, playlistIDs []int64
+	for _, entry := range entries {
+		switch entry.Type {
+		case fanout.TrackItem:
+			trackIDs = append(trackIDs, entry.ItemID)
+		case fanout.PlaylistItem:
+			playlistIDs = append(playlistIDs, entry.ItemID)
+		}
+	}
+
+	tracksByID := make(map[int64]*track.TrackWithLikedModel, len(trackIDs))
+	if len(trackIDs) > 0 {
+		tracks, err := s.trackRepo.GetManyByIDs(ctx, trackIDs, currentUserID)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tracks {
+			tracksByID[t.ID] = t
+		}
+	}
+
+	playlistsByID := make(map[int64]*playlist.PlaylistWithSavedModel, len(playlistIDs))
+	if len(playlistIDs) > 0 {
+		playlists, err := s.playlistRepo.GetManyByIDs(ctx, playlistIDs, currentUserID)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range playlists {
+			playlistsByID[p.ID] = p
+		}
+	}
+
+	items := make([]*StreamItemModel, 0, len(entries))
+	for _, entry := range entries {
+		item := &StreamItemModel{
+			ActorID:  entry.ActorID,
+			Type:     entry.Type,
+			IsRepost: entry.IsRepost,
+			Date:     entry.Date,
+		}
+
+		switch entry.Type {
+		case fanout.TrackItem:
+			t, ok := tracksByID[entry.ItemID]
+			if !ok {
+				continue
+			}
+			item.Track = t
+		case fanout.PlaylistItem:
+			p, ok := playlistsByID[entry.ItemID]
+			if !ok {
+				continue
+			}
+			item.Playlist = p
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
@@ -0,0 +1,9 @@
+package search
+
+import "errors"
+
+var (
+	ErrInvalidSearchRequest = errors.New("invalid search request")
+	ErrSearchQueryNotFound  = errors.New("search query not found")
+	ErrClickNotAllowed      = errors.New("search query belongs to another user")
+)
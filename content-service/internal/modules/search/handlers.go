@@ -1,7 +1,9 @@
 package search
 
 import (
+	"errors"
 	"net/http"
+	"slices"
 
 	"github.com/gin-gonic/gin"
 	"github.com/ocenb/music-go/content-service/internal/clients/searchclient"
@@ -10,21 +12,120 @@ import (
 )
 
 type SearchHandlerInterface interface {
+	search(c *gin.Context)
+	suggest(c *gin.Context)
+	click(c *gin.Context)
 	searchUsers(c *gin.Context)
 	searchTracks(c *gin.Context)
 	RegisterHandlers(router *gin.RouterGroup)
 }
 
 type SearchHandler struct {
-	searchClient *searchclient.SearchServiceClient
+	searchClient  *searchclient.SearchServiceClient
+	searchService SearchServiceInterface
 }
 
-func NewSearchHandler(searchClient *searchclient.SearchServiceClient) SearchHandlerInterface {
+func NewSearchHandler(searchClient *searchclient.SearchServiceClient, searchService SearchServiceInterface) SearchHandlerInterface {
 	return &SearchHandler{
-		searchClient: searchClient,
+		searchClient:  searchClient,
+		searchService: searchService,
 	}
 }
 
+func (h *SearchHandler) search(c *gin.Context) {
+	var params SearchAllForm
+	if err := c.ShouldBindQuery(&params); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.InternalError(c, err)
+		return
+	}
+
+	requested := map[SearchType]*PageRequestModel{
+		SearchTypeUsers:     {Take: params.UsersTake, Cursor: params.UsersCursor, Highlight: params.Highlight, AutoCorrect: params.AutoCorrect},
+		SearchTypeTracks:    {Take: params.TracksTake, Cursor: params.TracksCursor, Highlight: params.Highlight, AutoCorrect: params.AutoCorrect},
+		SearchTypeAlbums:    {Take: params.AlbumsTake, Cursor: params.AlbumsCursor, Highlight: params.Highlight, AutoCorrect: params.AutoCorrect},
+		SearchTypePlaylists: {Take: params.PlaylistsTake, Cursor: params.PlaylistsCursor, Highlight: params.Highlight, AutoCorrect: params.AutoCorrect},
+	}
+
+	pages := make(map[SearchType]*PageRequestModel, len(requested))
+	for _, searchType := range SearchTypes {
+		if len(params.Types) == 0 || slices.Contains(params.Types, string(searchType)) {
+			pages[searchType] = requested[searchType]
+		}
+	}
+
+	trackFilters := &TrackFiltersModel{
+		Genres:         params.Genres,
+		Tags:           params.Tags,
+		MinDuration:    params.MinDuration,
+		MaxDuration:    params.MaxDuration,
+		UploadedAfter:  params.UploadedAfter,
+		UploadedBefore: params.UploadedBefore,
+		ArtistIDs:      params.ArtistIDs,
+	}
+
+	result, err := h.searchService.Search(c.Request.Context(), user.Id, params.Query, pages, trackFilters)
+	if err != nil {
+		if errors.Is(err, ErrInvalidSearchRequest) {
+			utils.BadRequestError(c, err)
+			return
+		}
+		utils.InternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *SearchHandler) suggest(c *gin.Context) {
+	var params SuggestForm
+	if err := c.ShouldBindQuery(&params); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	suggestions, err := h.searchService.Suggest(c.Request.Context(), params.Query, params.Limit)
+	if err != nil {
+		utils.InternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, suggestions)
+}
+
+func (h *SearchHandler) click(c *gin.Context) {
+	var form ClickForm
+	if err := c.ShouldBindJSON(&form); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	err := h.searchService.RecordClick(c.Request.Context(), form.QueryID, form.DocumentID, form.Position)
+	if err != nil {
+		if errors.Is(err, ErrSearchQueryNotFound) {
+			utils.NotFoundError(c, err)
+			return
+		}
+		if errors.Is(err, ErrClickNotAllowed) {
+			utils.PermissionDeniedError(c, err)
+			return
+		}
+		if errors.Is(err, ErrInvalidSearchRequest) {
+			utils.BadRequestError(c, err)
+			return
+		}
+		utils.InternalError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
 func (h *SearchHandler) searchUsers(c *gin.Context) {
 	var params SearchForm
 	if err := c.ShouldBindQuery(&params); err != nil {
@@ -63,6 +164,9 @@ func (h *SearchHandler) searchTracks(c *gin.Context) {
 
 func (h *SearchHandler) RegisterHandlers(router *gin.RouterGroup) {
 	searchRouter := router.Group("/search")
+	searchRouter.GET("", h.search)
+	searchRouter.GET("/suggest", h.suggest)
+	searchRouter.POST("/click", h.click)
 	searchRouter.GET("/users", h.searchUsers)
 	searchRouter.GET("/tracks", h.searchTracks)
 }
@@ -0,0 +1,128 @@
+package search
+
+import (
+	"time"
+
+	"github.com/ocenb/music-go/content-service/internal/modules/playlist"
+	"github.com/ocenb/music-go/content-service/internal/modules/track"
+)
+
+type SearchType string
+
+const (
+	SearchTypeUsers     SearchType = "users"
+	SearchTypeTracks    SearchType = "tracks"
+	SearchTypeAlbums    SearchType = "albums"
+	SearchTypePlaylists SearchType = "playlists"
+)
+
+var SearchTypes = []SearchType{
+	SearchTypeUsers,
+	SearchTypeTracks,
+	SearchTypeAlbums,
+	SearchTypePlaylists,
+}
+
+type PageRequestModel struct {
+	Take        int
+	Cursor      string
+	Highlight   bool
+	AutoCorrect bool
+	Correction  string
+}
+
+type UserModel struct {
+	ID             int64  `json:"id"`
+	Username       string `json:"username"`
+	FollowersCount int64  `json:"followersCount"`
+}
+
+type AlbumModel struct {
+	ID int64 `json:"id"`
+}
+
+// CorrectionModel reports the did-you-mean suggestion of a result and
+// whether the result was found for it instead of the query.
+type CorrectionModel struct {
+	SuggestedQuery string `json:"suggestedQuery,omitempty"`
+	Corrected      bool   `json:"corrected,omitempty"`
+}
+
+type UsersResultModel struct {
+	Items      []*UserModel       `json:"items"`
+	Total      int64              `json:"total"`
+	NextCursor string             `json:"nextCursor,omitempty"`
+	Highlights map[int64][]string `json:"highlights,omitempty"`
+	QueryID    string             `json:"queryId,omitempty"`
+	CorrectionModel
+}
+
+type TrackFiltersModel struct {
+	Genres         []string
+	Tags           []string
+	MinDuration    int64
+	MaxDuration    int64
+	UploadedAfter  time.Time
+	UploadedBefore time.Time
+	ArtistIDs      []int64
+}
+
+type FacetBucketModel struct {
+	Key   string `json:"key"`
+	Count int64  `json:"count"`
+	From  int64  `json:"from,omitempty"`
+	To    int64  `json:"to,omitempty"`
+}
+
+type FacetsModel struct {
+	Genres    []*FacetBucketModel `json:"genres"`
+	Durations []*FacetBucketModel `json:"durations"`
+}
+
+type TracksResultModel struct {
+	Items      []*track.TrackWithLikedModel `json:"items"`
+	Total      int64                        `json:"total"`
+	NextCursor string                       `json:"nextCursor,omitempty"`
+	Highlights map[int64][]string           `json:"highlights,omitempty"`
+	Facets     *FacetsModel                 `json:"facets,omitempty"`
+	QueryID    string                       `json:"queryId,omitempty"`
+	CorrectionModel
+}
+
+type AlbumsResultModel struct {
+	Items      []*AlbumModel      `json:"items"`
+	Total      int64              `json:"total"`
+	NextCursor string             `json:"nextCursor,omitempty"`
+	Highlights map[int64][]string `json:"highlights,omitempty"`
+	QueryID    string             `json:"queryId,omitempty"`
+	CorrectionModel
+}
+
+type PlaylistsResultModel struct {
+	Items      []*playlist.PlaylistWithSavedModel `json:"items"`
+	Total      int64                              `json:"total"`
+	NextCursor string                             `json:"nextCursor,omitempty"`
+	Highlights map[int64][]string                 `json:"highlights,omitempty"`
+	QueryID    string                             `json:"queryId,omitempty"`
+	CorrectionModel
+}
+
+type SearchResultModel struct {
+	Users     *UsersResultModel     `json:"users,omitempty"`
+	Tracks    *TracksResultModel    `json:"tracks,omitempty"`
+	Albums    *AlbumsResultModel    `json:"albums,omitempty"`
+	Playlists *PlaylistsResultModel `json:"playlists,omitempty"`
+}
+
+type SuggestionModel struct {
+	Type string `json:"type"`
+	ID   int64  `json:"id"`
+	Text string `json:"text"`
+}
+
+type SignalsModel struct {
+	ID        int64
+	Plays     int64
+	Likes     int64
+	CreatedAt time.Time
+}
@@ -0,0 +1,76 @@
+package search
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+)
+
+type SearchRepoInterface interface {
+	GetTrackSignals(ctx context.Context, lastID int64, take int) ([]*SignalsModel, error)
+	GetPlaylistSignals(ctx context.Context, lastID int64, take int) ([]*SignalsModel, error)
+}
+
+type SearchRepo struct {
+	postgres *sql.DB
+	log      *slog.Logger
+}
+
+func NewSearchRepo(postgres *sql.DB, log *slog.Logger) SearchRepoInterface {
+	return &SearchRepo{postgres: postgres, log: log}
+}
+
+func (r *SearchRepo) GetTrackSignals(ctx context.Context, lastID int64, take int) ([]*SignalsModel, error) {
+	query := `
+		SELECT t.id, t.plays,
+			(SELECT COUNT(*) FROM user_liked_tracks ult WHERE ult.track_id = t.id) AS likes,
+			t.created_at
+		FROM tracks t
+		WHERE t.id > $1
+		ORDER BY t.id
+		LIMIT $2
+	`
+
+	return r.getSignals(ctx, query, lastID, take)
+}
+
+func (r *SearchRepo) GetPlaylistSignals(ctx context.Context, lastID int64, take int) ([]*SignalsModel, error) {
+	query := `
+		SELECT p.id, 0 AS plays,
+			(SELECT COUNT(*) FROM user_saved_playlists usp WHERE usp.playlist_id = p.id) AS likes,
+			p.created_at
+		FROM playlists p
+		WHERE p.id > $1 AND p.visibility = 'public'
+		ORDER BY p.id
+		LIMIT $2
+	`
+
+	return r.getSignals(ctx, query, lastID, take)
+}
+
+func (r *SearchRepo) getSignals(ctx context.Context, query string, lastID int64, take int) ([]*SignalsModel, error) {
+	rows, err := r.postgres.QueryContext(ctx, query, lastID, take)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			r.log.Error("Failed to close rows", "error", err)
+		}
+	}()
+
+	var signals []*SignalsModel
+	for rows.Next() {
+		var signal SignalsModel
+		if err := rows.Scan(&signal.ID, &signal.Plays, &signal.Likes, &signal.CreatedAt); err != nil {
+			return nil, err
+		}
+		signals = append(signals, &signal)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return signals, nil
+}
@@ -1,5 +1,40 @@
 package search
 
+import "time"
+
 type SearchForm struct {
 	Query string `form:"query" binding:"required"`
 }
+
+type SearchAllForm struct {
+	Query           string    `form:"query" binding:"required,max=100"`
+	Types           []string  `form:"types" binding:"omitempty,dive,oneof=users tracks albums playlists"`
+	UsersTake       int       `form:"usersTake" binding:"omitempty,min=1,max=50"`
+	UsersCursor     string    `form:"usersCursor"`
+	TracksTake      int       `form:"tracksTake" binding:"omitempty,min=1,max=50"`
+	TracksCursor    string    `form:"tracksCursor"`
+	AlbumsTake      int       `form:"albumsTake" binding:"omitempty,min=1,max=50"`
+	AlbumsCursor    string    `form:"albumsCursor"`
+	PlaylistsTake   int       `form:"playlistsTake" binding:"omitempty,min=1,max=50"`
+	PlaylistsCursor string    `form:"playlistsCursor"`
+	Highlight       bool      `form:"highlight"`
+	AutoCorrect     bool      `form:"autoCorrect"`
+	Genres          []string  `form:"genres" binding:"omitempty,max=10,dive,min=1,max=30"`
+	Tags            []string  `form:"tags" binding:"omitempty,max=10,dive,min=1,max=30"`
+	MinDuration     int64     `form:"minDuration" binding:"omitempty,min=1"`
+	MaxDuration     int64     `form:"maxDuration" binding:"omitempty,min=1,gtefield=MinDuration"`
+	UploadedAfter   time.Time `form:"uploadedAfter"`
+	UploadedBefore  time.Time `form:"uploadedBefore" binding:"omitempty,gtfield=UploadedAfter"`
+	ArtistIDs       []int64   `form:"artistIds" binding:"omitempty,max=20,dive,min=1"`
+}
+
+type ClickForm struct {
+	QueryID    string `json:"queryId" binding:"required,uuid"`
+	DocumentID int64  `json:"documentId" binding:"required,min=1"`
+	Position   int    `json:"position" binding:"min=0"`
+}
+
+type SuggestForm struct {
+	Query string `form:"query" binding:"required,max=100"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=10"`
+}
@@ -0,0 +1,552 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/ocenb/music-go/content-service/internal/clients/searchclient"
+	"github.com/ocenb/music-go/content-service/internal/clients/userclient"
+	"github.com/ocenb/music-go/content-service/internal/config"
+	"github.com/ocenb/music-go/content-service/internal/modules/playlist"
+	"github.com/ocenb/music-go/content-service/internal/modules/track"
+	"github.com/ocenb/music-go/content-service/internal/utils"
+	"github.com/ocenb/music-go/shared/searchindex"
+	"github.com/ocenb/music-protos/gen/searchservice"
+	"github.com/ocenb/music-protos/gen/userservice"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const defaultTake = 5
+
+var searchDocumentTypes = map[SearchType]string{
+	SearchTypeUsers:     "user",
+	SearchTypeTracks:    "track",
+	SearchTypeAlbums:    "album",
+	SearchTypePlaylists: "playlist",
+}
+
+type SearchServiceInterface interface {
+	Search(ctx context.Context, currentUserID int64, query string, pages map[SearchType]*PageRequestModel, trackFilters *TrackFiltersModel) (*SearchResultModel, error)
+	Suggest(ctx context.Context, query string, limit int) ([]*SuggestionModel, error)
+	PushSignals(ctx context.Context) error
+	RecordClick(ctx context.Context, queryID string, documentID int64, position int) error
+}
+
+type SearchService struct {
+	cfg          *config.Config
+	log          *slog.Logger
+	searchRepo   SearchRepoInterface
+	searchClient *searchclient.SearchServiceClient
+	searchIndex  searchindex.SearchIndexClientInterface
+	userClient   *userclient.UserServiceClient
+	trackRepo    track.TrackRepoInterface
+	playlistRepo playlist.PlaylistRepoInterface
+}
+
+func NewSearchService(
+	cfg *config.Config,
+	log *slog.Logger,
+	searchRepo SearchRepoInterface,
+	searchClient *searchclient.SearchServiceClient,
+	searchIndex searchindex.SearchIndexClientInterface,
+	userClient *userclient.UserServiceClient,
+	trackRepo track.TrackRepoInterface,
+	playlistRepo playlist.PlaylistRepoInterface,
+) SearchServiceInterface {
+	return &SearchService{
+		cfg:          cfg,
+		log:          log,
+		searchRepo:   searchRepo,
+		searchClient: searchClient,
+		searchIndex:  searchIndex,
+		userClient:   userClient,
+		trackRepo:    trackRepo,
+		playlistRepo: playlistRepo,
+	}
+}
+
+func (s *SearchService) Search(ctx context.Context, currentUserID int64, query string, pages map[SearchType]*PageRequestModel, trackFilters *TrackFiltersModel) (*SearchResultModel, error) {
+	for _, page := range pages {
+		if page.Take == 0 {
+			page.Take = defaultTake
+		}
+	}
+
+	result, err := s.searchPages(ctx, currentUserID, query, pages, trackFilters)
+	if err != nil {
+		return nil, searchError(err)
+	}
+
+	s.correct(ctx, currentUserID, query, pages, trackFilters, result)
+
+	return result, nil
+}
+
+// searchPages searches every requested type at once. The types are searched
+// without their own correction, Search corrects the query once for all of
+// them.
+func (s *SearchService) searchPages(ctx context.Context, currentUserID int64, query string, pages map[SearchType]*PageRequestModel, trackFilters *TrackFiltersModel) (*SearchResultModel, error) {
+	result := &SearchResultModel{}
+	g, gCtx := errgroup.WithContext(ctx)
+
+	if page, ok := pages[SearchTypeUsers]; ok {
+		g.Go(func() (err error) {
+			result.Users, err = s.searchUsers(gCtx, query, page)
+			return err
+		})
+	}
+
+	if page, ok := pages[SearchTypeTracks]; ok {
+		g.Go(func() (err error) {
+			result.Tracks, err = s.searchTracks(gCtx, currentUserID, query, page, trackFilters)
+			return err
+		})
+	}
+
+	if page, ok := pages[SearchTypeAlbums]; ok {
+		g.Go(func() (err error) {
+			result.Albums, err = s.searchAlbums(gCtx, query, page)
+			return err
+		})
+	}
+
+	if page, ok := pages[SearchTypePlaylists]; ok {
+		g.Go(func() (err error) {
+			result.Playlists, err = s.searchPlaylists(gCtx, currentUserID, query, page)
+			return err
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// correct asks search-service for one did-you-mean suggestion for all the
+// first pages of the request and re-searches the types that found nothing
+// and asked for auto-correction. Following pages keep the query their cursor
+// was found for. A failed correction leaves the result as it is.
+func (s *SearchService) correct(ctx context.Context, currentUserID int64, query string, pages map[SearchType]*PageRequestModel, trackFilters *TrackFiltersModel, result *SearchResultModel) {
+	var (
+		firstPages    []SearchType
+		documentTypes []string
+		hits          int64
+	)
+	retry := make(map[SearchType]*PageRequestModel)
+	for _, searchType := range SearchTypes {
+		page, ok := pages[searchType]
+		if !ok || page.Cursor != "" {
+			continue
+		}
+
+		total, _ := result.correction(searchType)
+		firstPages = append(firstPages, searchType)
+		documentTypes = append(documentTypes, searchDocumentTypes[searchType])
+		hits += total
+		if page.AutoCorrect && total == 0 {
+			corrected := *page
+			retry[searchType] = &corrected
+		}
+	}
+	if len(firstPages) == 0 {
+		return
+	}
+
+	response, err := s.searchClient.Client.CorrectQuery(ctx, &searchservice.CorrectQueryRequest{
+		Query: query,
+		Types: documentTypes,
+		Hits:  hits,
+	})
+	if err != nil {
+		s.log.Warn("Failed to correct search query", slog.String("query", query), utils.ErrLog(err))
+		return
+	}
+	suggestion := response.SuggestedQuery
+	if suggestion == "" {
+		return
+	}
+
+	for _, searchType := range firstPages {
+		_, correction := result.correction(searchType)
+		correction.SuggestedQuery = suggestion
+	}
+	if len(retry) == 0 {
+		return
+	}
+	for _, page := range retry {
+		page.Correction = suggestion
+	}
+
+	// The correction is searched under the query, so its cursors keep
+	// continuing the search the user made.
+	corrected, err := s.searchPages(ctx, currentUserID, query, retry, trackFilters)
+	if err != nil {
+		s.log.Warn("Failed to search corrected query", slog.String("query", query), slog.String("suggestion", suggestion), utils.ErrLog(err))
+		return
+	}
+
+	for searchType := range retry {
+		if total, _ := corrected.correction(searchType); total == 0 {
+			continue
+		}
+		result.replace(searchType, corrected)
+	}
+}
+
+func (s *SearchService) Suggest(ctx context.Context, query string, limit int) ([]*SuggestionModel, error) {
+	response, err := s.searchClient.Client.Suggest(ctx, &searchservice.SuggestRequest{
+		Query: query,
+		Limit: int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := make([]*SuggestionModel, 0, len(response.Suggestions))
+	for _, suggestion := range response.Suggestions {
+		suggestions = append(suggestions, &SuggestionModel{
+			Type: suggestion.Type,
+			ID:   suggestion.Id,
+			Text: suggestion.Text,
+		})
+	}
+
+	return suggestions, nil
+}
+
+func (s *SearchService) RecordClick(ctx context.Context, queryID string, documentID int64, position int) error {
+	_, err := s.searchClient.Client.RecordClick(ctx, &searchservice.ClickRequest{
+		QueryId:    queryID,
+		DocumentId: documentID,
+		Position:   int32(position),
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return ErrSearchQueryNotFound
+		}
+		if status.Code(err) == codes.PermissionDenied {
+			return ErrClickNotAllowed
+		}
+		return searchError(err)
+	}
+
+	return nil
+}
+
+func (s *SearchService) PushSignals(ctx context.Context) error {
+	if err := s.pushSignals(ctx, "track", s.searchRepo.GetTrackSignals); err != nil {
+		return err
+	}
+
+	return s.pushSignals(ctx, "playlist", s.searchRepo.GetPlaylistSignals)
+}
+
+func (s *SearchService) pushSignals(ctx context.Context, documentType string, getSignals func(ctx context.Context, lastID int64, take int) ([]*SignalsModel, error)) error {
+	var lastID int64
+	for {
+		batch, err := getSignals(ctx, lastID, s.cfg.SearchSignalsBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+
+		signals := make([]*searchservice.DocumentSignals, 0, len(batch))
+		for _, signal := range batch {
+			signals = append(signals, &searchservice.DocumentSignals{
+				Id:        signal.ID,
+				Plays:     signal.Plays,
+				Likes:     signal.Likes,
+				CreatedAt: signal.CreatedAt.Unix(),
+			})
+		}
+
+		err = s.searchIndex.UpdateSignals(ctx, &searchservice.UpdateSignalsRequest{
+			Type:    documentType,
+			Signals: signals,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to queue %s signals for search indexing: %w", documentType, err)
+		}
+
+		lastID = batch[len(batch)-1].ID
+	}
+}
+
+func (s *SearchService) searchUsers(ctx context.Context, query string, page *PageRequestModel) (*UsersResultModel, error) {
+	response, err := s.searchClient.Client.SearchUsers(ctx, searchRequest(query, page))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &UsersResultModel{
+		Items:      []*UserModel{},
+		Total:      response.Total,
+		NextCursor: response.NextCursor,
+		Highlights: highlights(response.Hits),
+		QueryID:    response.QueryId,
+		CorrectionModel: CorrectionModel{
+			SuggestedQuery: response.SuggestedQuery,
+			Corrected:      response.Corrected,
+		},
+	}
+	if len(response.Ids) == 0 {
+		return result, nil
+	}
+
+	usersResponse, err := s.userClient.Client.GetUsersByIds(ctx, &userservice.GetUsersByIdsRequest{
+		UserIds: response.Ids,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	usersByID := make(map[int64]*userservice.UserPublicModel, len(usersResponse.Users))
+	for _, u := range usersResponse.Users {
+		usersByID[u.Id] = u
+	}
+
+	for _, id := range response.Ids {
+		u, ok := usersByID[id]
+		if !ok {
+			continue
+		}
+		result.Items = append(result.Items, &UserModel{
+			ID:             u.Id,
+			Username:       u.Username,
+			FollowersCount: u.FollowersCount,
+		})
+	}
+
+	return result, nil
+}
+
+func (s *SearchService) searchTracks(ctx context.Context, currentUserID int64, query string, page *PageRequestModel, filters *TrackFiltersModel) (*TracksResultModel, error) {
+	request := searchRequest(query, page)
+	request.TrackFilters = trackFiltersRequest(filters)
+
+	response, err := s.searchClient.Client.SearchTracks(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TracksResultModel{
+		Items:      []*track.TrackWithLikedModel{},
+		Total:      response.Total,
+		NextCursor: response.NextCursor,
+		Highlights: highlights(response.Hits),
+		QueryID:    response.QueryId,
+		CorrectionModel: CorrectionModel{
+			SuggestedQuery: response.SuggestedQuery,
+			Corrected:      response.Corrected,
+		},
+		Facets: facetsModel(response.Facets),
+	}
+	if len(response.Ids) == 0 {
+		return result, nil
+	}
+
+	tracks, err := s.trackRepo.GetManyByIDs(ctx, response.Ids, currentUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	tracksByID := make(map[int64]*track.TrackWithLikedModel, len(tracks))
+	for _, t := range tracks {
+		tracksByID[t.ID] = t
+	}
+
+	for _, id := range response.Ids {
+		t, ok := tracksByID[id]
+		if !ok {
+			continue
+		}
+		result.Items = append(result.Items, t)
+	}
+
+	return result, nil
+}
+
+func (s *SearchService) searchAlbums(ctx context.Context, query string, page *PageRequestModel) (*AlbumsResultModel, error) {
+	response, err := s.searchClient.Client.SearchAlbums(ctx, searchRequest(query, page))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &AlbumsResultModel{
+		Items:      make([]*AlbumModel, 0, len(response.Ids)),
+		Total:      response.Total,
+		NextCursor: response.NextCursor,
+		Highlights: highlights(response.Hits),
+		QueryID:    response.QueryId,
+		CorrectionModel: CorrectionModel{
+			SuggestedQuery: response.SuggestedQuery,
+			Corrected:      response.Corrected,
+		},
+	}
+	for _, id := range response.Ids {
+		result.Items = append(result.Items, &AlbumModel{ID: id})
+	}
+
+	return result, nil
+}
+
+func (s *SearchService) searchPlaylists(ctx context.Context, currentUserID int64, query string, page *PageRequestModel) (*PlaylistsResultModel, error) {
+	response, err := s.searchClient.Client.SearchPlaylists(ctx, searchRequest(query, page))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PlaylistsResultModel{
+		Items:      []*playlist.PlaylistWithSavedModel{},
+		Total:      response.Total,
+		NextCursor: response.NextCursor,
+		Highlights: highlights(response.Hits),
+		QueryID:    response.QueryId,
+		CorrectionModel: CorrectionModel{
+			SuggestedQuery: response.SuggestedQuery,
+			Corrected:      response.Corrected,
+		},
+	}
+	if len(response.Ids) == 0 {
+		return result, nil
+	}
+
+	playlists, err := s.playlistRepo.GetManyByIDs(ctx, response.Ids, currentUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	playlistsByID := make(map[int64]*playlist.PlaylistWithSavedModel, len(playlists))
+	for _, p := range playlists {
+		playlistsByID[p.ID] = p
+	}
+
+	for _, id := range response.Ids {
+		p, ok := playlistsByID[id]
+		if !ok {
+			continue
+		}
+		result.Items = append(result.Items, p)
+	}
+
+	return result, nil
+}
+
+func searchRequest(query string, page *PageRequestModel) *searchservice.SearchRequest {
+	return &searchservice.SearchRequest{
+		Query:          query,
+		Limit:          int32(page.Take),
+		Cursor:         page.Cursor,
+		Highlight:      page.Highlight,
+		AutoCorrect:    page.AutoCorrect,
+		SkipCorrection: true,
+		Correction:     page.Correction,
+	}
+}
+
+func highlights(hits []*searchservice.SearchHit) map[int64][]string {
+	result := make(map[int64][]string)
+	for _, hit := range hits {
+		if len(hit.Highlights) > 0 {
+			result[hit.Id] = hit.Highlights
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// correction returns the total of one type's result and where its correction
+// is reported.
+func (r *SearchResultModel) correction(searchType SearchType) (int64, *CorrectionModel) {
+	switch searchType {
+	case SearchTypeUsers:
+		return r.Users.Total, &r.Users.CorrectionModel
+	case SearchTypeTracks:
+		return r.Tracks.Total, &r.Tracks.CorrectionModel
+	case SearchTypeAlbums:
+		return r.Albums.Total, &r.Albums.CorrectionModel
+	default:
+		return r.Playlists.Total, &r.Playlists.CorrectionModel
+	}
+}
+
+// replace takes one type's result from a corrected search. Corrections are
+// not logged on their own, so the query id of the logged search is kept.
+func (r *SearchResultModel) replace(searchType SearchType, corrected *SearchResultModel) {
+	switch searchType {
+	case SearchTypeUsers:
+		corrected.Users.QueryID = r.Users.QueryID
+		r.Users = corrected.Users
+	case SearchTypeTracks:
+		corrected.Tracks.QueryID = r.Tracks.QueryID
+		r.Tracks = corrected.Tracks
+	case SearchTypeAlbums:
+		corrected.Albums.QueryID = r.Albums.QueryID
+		r.Albums = corrected.Albums
+	default:
+		corrected.Playlists.QueryID = r.Playlists.QueryID
+		r.Playlists = corrected.Playlists
+	}
+}
+
+func searchError(err error) error {
+	if status.Code(err) == codes.InvalidArgument {
+		return fmt.Errorf("%w: %s", ErrInvalidSearchRequest, status.Convert(err).Message())
+	}
+	return err
+}
+
+func trackFiltersRequest(filters *TrackFiltersModel) *searchservice.TrackFilters {
+	if filters == nil {
+		return nil
+	}
+
+	request := &searchservice.TrackFilters{
+		Genres:      filters.Genres,
+		Tags:        filters.Tags,
+		MinDuration: filters.MinDuration,
+		MaxDuration: filters.MaxDuration,
+		ArtistIds:   filters.ArtistIDs,
+	}
+	if !filters.UploadedAfter.IsZero() {
+		request.UploadedAfter = filters.UploadedAfter.Unix()
+	}
+	if !filters.UploadedBefore.IsZero() {
+		request.UploadedBefore = filters.UploadedBefore.Unix()
+	}
+
+	return request
+}
+
+func facetsModel(facets *searchservice.Facets) *FacetsModel {
+	if facets == nil {
+		return nil
+	}
+
+	return &FacetsModel{
+		Genres:    facetBucketModels(facets.Genres),
+		Durations: facetBucketModels(facets.Durations),
+	}
+}
+
+func facetBucketModels(buckets []*searchservice.FacetBucket) []*FacetBucketModel {
+	models := make([]*FacetBucketModel, 0, len(buckets))
+	for _, bucket := range buckets {
+		models = append(models, &FacetBucketModel{
+			Key:   bucket.Key,
+			Count: bucket.Count,
+			From:  bucket.From,
+			To:    bucket.To,
+		})
+	}
+	return models
+}
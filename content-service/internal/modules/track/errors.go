@@ -7,10 +7,14 @@ import (
 )
 
 var (
-	ErrTrackNotFound      = errors.New("track not found")
-	ErrTrackAlreadyExists = errors.New("track with this title already exists")
-	ErrChangeableIDExists = errors.New("track with this changeableId already exists")
-	ErrPermissionDenied   = errors.New("you don't have permission for this action")
+	ErrTrackNotFound        = errors.New("track not found")
+	ErrTrackAlreadyExists   = errors.New("track with this title already exists")
+	ErrChangeableIDExists   = errors.New("track with this changeableId already exists")
+	ErrPermissionDenied     = errors.New("you don't have permission for this action")
+	ErrTrackIsYours         = errors.New("track is yours")
+	ErrTrackAlreadyReposted = errors.New("track is already reposted")
+	ErrTrackIsNotReposted   = errors.New("track is not reposted")
+	ErrInvalidCursor        = errors.New("invalid cursor")
 )
 
 var BadRequestErrors = []error{
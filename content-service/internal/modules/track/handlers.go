@@ -17,12 +17,16 @@ type TrackHandlerInterface interface {
 	upload(c *gin.Context)
 	addPlay(c *gin.Context)
 	changeTitle(c *gin.Context)
+	changeGenre(c *gin.Context)
+	changeTags(c *gin.Context)
 	changeChangeableId(c *gin.Context)
 	changeImage(c *gin.Context)
 	delete(c *gin.Context)
 	getManyLiked(c *gin.Context)
 	addToLiked(c *gin.Context)
 	removeFromLiked(c *gin.Context)
+	repost(c *gin.Context)
+	removeRepost(c *gin.Context)
 	RegisterHandlers(router *gin.RouterGroup)
 }
 
@@ -152,6 +156,8 @@ func (h *TrackHandler) upload(c *gin.Context) {
 		user.Email,
 		request.Title,
 		request.ChangeableID,
+		request.Genre,
+		request.Tags,
 		request.AudioFile,
 		request.ImageFile,
 	)
@@ -229,6 +235,82 @@ func (h *TrackHandler) changeTitle(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+func (h *TrackHandler) changeGenre(c *gin.Context) {
+	var params ChangeGenreUri
+	if err := c.ShouldBindUri(&params); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	var request ChangeGenreForm
+	if err := c.ShouldBind(&request); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.InternalError(c, err)
+		return
+	}
+	err = h.trackService.ChangeGenre(
+		c.Request.Context(),
+		user.Id,
+		params.TrackID,
+		request.Genre,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrTrackNotFound):
+			utils.NotFoundError(c, err)
+		case errors.Is(err, ErrPermissionDenied):
+			utils.PermissionDeniedError(c, err)
+		default:
+			utils.InternalError(c, err)
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *TrackHandler) changeTags(c *gin.Context) {
+	var params ChangeTagsUri
+	if err := c.ShouldBindUri(&params); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	var request ChangeTagsForm
+	if err := c.ShouldBind(&request); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.InternalError(c, err)
+		return
+	}
+	err = h.trackService.ChangeTags(
+		c.Request.Context(),
+		user.Id,
+		params.TrackID,
+		request.Tags,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrPermissionDenied):
+			utils.PermissionDeniedError(c, err)
+		default:
+			utils.InternalError(c, err)
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
 func (h *TrackHandler) changeChangeableId(c *gin.Context) {
 	var params ChangeChangeableIdUri
 	if err := c.ShouldBindUri(&params); err != nil {
@@ -342,14 +424,32 @@ func (h *TrackHandler) delete(c *gin.Context) {
 }
 
 func (h *TrackHandler) getManyLiked(c *gin.Context) {
+	var params GetManyLikedForm
+	if err := c.ShouldBindQuery(&params); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
 	user, err := utils.GetInfoFromContext(c)
 	if err != nil {
 		utils.InternalError(c, err)
 		return
 	}
 
-	likedTracks, err := h.trackService.GetManyLiked(c.Request.Context(), user.Id)
+	likedTracks, err := h.trackService.GetManyLiked(
+		c.Request.Context(),
+		user.Id,
+		params.Take,
+		params.Cursor,
+		LikedSort(params.Sort),
+		SortOrder(params.Order),
+		params.Query,
+	)
 	if err != nil {
+		if errors.Is(err, ErrInvalidCursor) {
+			utils.BadRequestError(c, err)
+			return
+		}
 		utils.InternalError(c, err)
 		return
 	}
@@ -403,6 +503,62 @@ func (h *TrackHandler) removeFromLiked(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+func (h *TrackHandler) repost(c *gin.Context) {
+	var params GetByTrackIDUri
+	if err := c.ShouldBindUri(&params); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.InternalError(c, err)
+		return
+	}
+
+	if err := h.trackService.Repost(c.Request.Context(), user.Id, params.TrackID); err != nil {
+		switch {
+		case errors.Is(err, ErrTrackNotFound):
+			utils.NotFoundError(c, err)
+		case errors.Is(err, ErrTrackIsYours), errors.Is(err, ErrTrackAlreadyReposted):
+			utils.BadRequestError(c, err)
+		default:
+			utils.InternalError(c, err)
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *TrackHandler) removeRepost(c *gin.Context) {
+	var params GetByTrackIDUri
+	if err := c.ShouldBindUri(&params); err != nil {
+		utils.BadRequestError(c, err)
+		return
+	}
+
+	user, err := utils.GetInfoFromContext(c)
+	if err != nil {
+		utils.InternalError(c, err)
+		return
+	}
+
+	if err := h.trackService.RemoveRepost(c.Request.Context(), user.Id, params.TrackID); err != nil {
+		switch {
+		case errors.Is(err, ErrTrackNotFound):
+			utils.NotFoundError(c, err)
+		case errors.Is(err, ErrTrackIsNotReposted):
+			utils.BadRequestError(c, err)
+		default:
+			utils.InternalError(c, err)
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
 func (h *TrackHandler) RegisterHandlers(router *gin.RouterGroup) {
 	trackRouter := router.Group("/track")
 	trackRouter.GET("/oneById/:trackId", h.getOneByID)
@@ -412,10 +568,14 @@ func (h *TrackHandler) RegisterHandlers(router *gin.RouterGroup) {
 	trackRouter.POST("", h.upload)
 	trackRouter.PATCH("/:trackId/add-play", h.addPlay)
 	trackRouter.PATCH("/:trackId/title", h.changeTitle)
+	trackRouter.PATCH("/:trackId/genre", h.changeGenre)
+	trackRouter.PATCH("/:trackId/tags", h.changeTags)
 	trackRouter.PATCH("/:trackId/changeable-id", h.changeChangeableId)
 	trackRouter.PATCH("/:trackId/image", h.changeImage)
 	trackRouter.DELETE("/:trackId", h.delete)
 	trackRouter.GET("/liked", h.getManyLiked)
 	trackRouter.POST("/:trackId/like", h.addToLiked)
 	trackRouter.DELETE("/:trackId/like", h.removeFromLiked)
+	trackRouter.POST("/:trackId/repost", h.repost)
+	trackRouter.DELETE("/:trackId/repost", h.removeRepost)
 }
@@ -7,7 +7,9 @@ type TrackModel struct {
 	ChangeableID string    `json:"changeableId"`
 	Title        string    `json:"title"`
 	Duration     int64     `json:"duration"`
+	Genre        string    `json:"genre"`
 	Plays        int64     `json:"plays"`
+	RepostsCount int64     `json:"repostsCount"`
 	Audio        string    `json:"audio"`
 	Image        string    `json:"image"`
 	UserID       int64     `json:"userId"`
@@ -18,12 +20,38 @@ type TrackModel struct {
 
 type TrackWithLikedModel struct {
 	TrackModel
-	IsLiked bool       `json:"isLiked"`
-	LikedAt *time.Time `json:"likedAt,omitempty"`
+	IsLiked    bool       `json:"isLiked"`
+	LikedAt    *time.Time `json:"likedAt,omitempty"`
+	IsReposted bool       `json:"isReposted"`
 }
 
-type UserLikedTrackModel struct {
-	UserID  int64     `json:"userId"`
-	TrackID int64     `json:"trackId"`
-	AddedAt time.Time `json:"addedAt"`
+type LikedSort string
+
+const (
+	LikedSortLikedAt  LikedSort = "likedAt"
+	LikedSortTitle    LikedSort = "title"
+	LikedSortArtist   LikedSort = "artist"
+	LikedSortDuration LikedSort = "duration"
+)
+
+type SortOrder string
+
+const (
+	OrderAsc  SortOrder = "asc"
+	OrderDesc SortOrder = "desc"
+)
+
+type LikedQueryModel struct {
+	Sort      LikedSort
+	Order     SortOrder
+	Query     string
+	Take      int
+	LastID    int64
+	LastValue string
+}
+
+type LikedTracksModel struct {
+	Tracks     []*TrackWithLikedModel `json:"tracks"`
+	Total      int64                  `json:"total"`
+	NextCursor string                 `json:"nextCursor,omitempty"`
 }
@@ -3,8 +3,13 @@ package track
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"log/slog"
+	"strings"
 	"time"
+
+	"github.com/lib/pq"
+	"github.com/ocenb/music-go/content-service/internal/utils"
 )
 
 type TrackRepoInterface interface {
@@ -13,18 +18,51 @@ type TrackRepoInterface interface {
 	GetByChangeableID(ctx context.Context, username, changeableID string, currentUserID int64) (*TrackWithLikedModel, error)
 	GetMany(ctx context.Context, userID, currentUserID int64, take int, lastID int64) ([]*TrackWithLikedModel, error)
 	GetManyPopular(ctx context.Context, userID, currentUserID int64, take int, lastID int64) ([]*TrackWithLikedModel, error)
-	Create(ctx context.Context, userID int64, username, title, changeableID, audio, image string, duration int64) (*TrackModel, error)
+	Create(ctx context.Context, userID int64, username, title, changeableID, audio, image, genre string, tags []string, duration int64) (*TrackModel, error)
 	AddPlay(ctx context.Context, trackID int64) error
 	CheckPermission(ctx context.Context, userID, trackID int64) (bool, error)
 	Delete(ctx context.Context, trackID int64) error
 	ChangeTitle(ctx context.Context, trackID int64, title string) error
+	ChangeGenre(ctx context.Context, trackID int64, genre string) error
+	ChangeTags(ctx context.Context, trackID int64, tags []string) error
+	GetTags(ctx context.Context, trackID int64) ([]string, error)
 	ChangeChangeableID(ctx context.Context, trackID int64, changeableID string) error
 	ChangeImage(ctx context.Context, trackID int64, image string) error
 	CheckTitle(ctx context.Context, userID int64, title string) (bool, error)
 	CheckChangeableID(ctx context.Context, userID int64, changeableID string) (bool, error)
-	GetManyLiked(ctx context.Context, currentUserID int64) ([]*UserLikedTrackModel, error)
+	GetManyLiked(ctx context.Context, currentUserID int64, params LikedQueryModel) ([]*TrackWithLikedModel, error)
+	CountLiked(ctx context.Context, currentUserID int64, search string) (int64, error)
 	AddToLiked(ctx context.Context, currentUserID, trackID int64) error
 	RemoveFromLiked(ctx context.Context, currentUserID, trackID int64) error
+	GetManyByIDs(ctx context.Context, trackIDs []int64, currentUserID int64) ([]*TrackWithLikedModel, error)
+	AddRepost(ctx context.Context, currentUserID, trackID int64) (time.Time, error)
+	RemoveRepost(ctx context.Context, currentUserID, trackID int64) error
+}
+
+var likedSortColumns = map[LikedSort]string{
+	LikedSortLikedAt:  "ult.added_at",
+	LikedSortTitle:    "t.title",
+	LikedSortArtist:   "t.username",
+	LikedSortDuration: "t.duration",
+}
+
+var likedSortCasts = map[LikedSort]string{
+	LikedSortLikedAt:  "timestamp",
+	LikedSortTitle:    "text",
+	LikedSortArtist:   "text",
+	LikedSortDuration: "int",
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func escapeLike(value string) string {
+	return likeEscaper.Replace(value)
+}
+
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
 }
 
 type TrackRepo struct {
@@ -40,19 +78,28 @@ func (r *TrackRepo) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx,
 	return r.postgres.BeginTx(ctx, opts)
 }
 
+func (r *TrackRepo) db(ctx context.Context) querier {
+	if tx, hasTx := utils.GetTxFromContext(ctx); hasTx {
+		return tx
+	}
+	return r.postgres
+}
+
 func (r *TrackRepo) GetByID(ctx context.Context, trackID int64, currentUserID int64) (*TrackWithLikedModel, error) {
 	query := `
-		SELECT t.id, t.user_id, t.username, t.title, t.changeable_id, t.audio, t.image, t.duration, t.plays, t.created_at, t.updated_at,
-			CASE WHEN ult.user_id IS NOT NULL THEN true ELSE false END as is_liked
+		SELECT t.id, t.user_id, t.username, t.title, t.changeable_id, t.audio, t.image, t.duration, t.genre, t.plays, t.reposts_count, t.created_at, t.updated_at,
+			CASE WHEN ult.user_id IS NOT NULL THEN true ELSE false END as is_liked,
+			CASE WHEN tr.user_id IS NOT NULL THEN true ELSE false END as is_reposted
 		FROM tracks t
 		LEFT JOIN user_liked_tracks ult ON ult.track_id = t.id AND ult.user_id = $1
+		LEFT JOIN track_reposts tr ON tr.track_id = t.id AND tr.user_id = $1
 		WHERE t.id = $2
 	`
 
 	var track TrackWithLikedModel
 	var createdAt, updatedAt time.Time
 
-	err := r.postgres.QueryRowContext(ctx, query, currentUserID, trackID).Scan(
+	err := r.db(ctx).QueryRowContext(ctx, query, currentUserID, trackID).Scan(
 		&track.ID,
 		&track.UserID,
 		&track.Username,
@@ -61,10 +108,13 @@ func (r *TrackRepo) GetByID(ctx context.Context, trackID int64, currentUserID in
 		&track.Audio,
 		&track.Image,
 		&track.Duration,
+		&track.Genre,
 		&track.Plays,
+		&track.RepostsCount,
 		&createdAt,
 		&updatedAt,
 		&track.IsLiked,
+		&track.IsReposted,
 	)
 
 	if err != nil {
@@ -79,10 +129,12 @@ func (r *TrackRepo) GetByID(ctx context.Context, trackID int64, currentUserID in
 
 func (r *TrackRepo) GetByChangeableID(ctx context.Context, username, changeableID string, currentUserID int64) (*TrackWithLikedModel, error) {
 	query := `
-		SELECT t.id, t.user_id, t.username, t.title, t.changeable_id, t.audio, t.image, t.duration, t.plays, t.created_at, t.updated_at,
-			CASE WHEN ult.user_id IS NOT NULL THEN true ELSE false END as is_liked
+		SELECT t.id, t.user_id, t.username, t.title, t.changeable_id, t.audio, t.image, t.duration, t.genre, t.plays, t.reposts_count, t.created_at, t.updated_at,
+			CASE WHEN ult.user_id IS NOT NULL THEN true ELSE false END as is_liked,
+			CASE WHEN tr.user_id IS NOT NULL THEN true ELSE false END as is_reposted
 		FROM tracks t
 		LEFT JOIN user_liked_tracks ult ON ult.track_id = t.id AND ult.user_id = $1
+		LEFT JOIN track_reposts tr ON tr.track_id = t.id AND tr.user_id = $1
 		WHERE t.changeable_id = $2 AND t.username = $3
 	`
 
@@ -98,10 +150,13 @@ func (r *TrackRepo) GetByChangeableID(ctx context.Context, username, changeableI
 		&track.Audio,
 		&track.Image,
 		&track.Duration,
+		&track.Genre,
 		&track.Plays,
+		&track.RepostsCount,
 		&createdAt,
 		&updatedAt,
 		&track.IsLiked,
+		&track.IsReposted,
 	)
 
 	if err != nil {
@@ -116,10 +171,12 @@ func (r *TrackRepo) GetByChangeableID(ctx context.Context, username, changeableI
 
 func (r *TrackRepo) GetMany(ctx context.Context, userID, currentUserID int64, take int, lastID int64) ([]*TrackWithLikedModel, error) {
 	query := `
-		SELECT t.id, t.user_id, t.username, t.title, t.changeable_id, t.audio, t.image, t.duration, t.plays, t.created_at, t.updated_at,
-			CASE WHEN ult.user_id IS NOT NULL THEN true ELSE false END as is_liked
+		SELECT t.id, t.user_id, t.username, t.title, t.changeable_id, t.audio, t.image, t.duration, t.genre, t.plays, t.reposts_count, t.created_at, t.updated_at,
+			CASE WHEN ult.user_id IS NOT NULL THEN true ELSE false END as is_liked,
+			CASE WHEN tr.user_id IS NOT NULL THEN true ELSE false END as is_reposted
 		FROM tracks t
 		LEFT JOIN user_liked_tracks ult ON ult.track_id = t.id AND ult.user_id = $1
+		LEFT JOIN track_reposts tr ON tr.track_id = t.id AND tr.user_id = $1
 		WHERE t.user_id = $2 AND ($3 = 0 OR t.id < $3)
 		ORDER BY t.id DESC
 		LIMIT $4
@@ -151,10 +208,13 @@ func (r *TrackRepo) GetMany(ctx context.Context, userID, currentUserID int64, ta
 			&track.Audio,
 			&track.Image,
 			&track.Duration,
+			&track.Genre,
 			&track.Plays,
+			&track.RepostsCount,
 			&createdAt,
 			&updatedAt,
 			&track.IsLiked,
+			&track.IsReposted,
 		)
 
 		if err != nil {
@@ -176,10 +236,12 @@ func (r *TrackRepo) GetMany(ctx context.Context, userID, currentUserID int64, ta
 
 func (r *TrackRepo) GetManyPopular(ctx context.Context, userID, currentUserID int64, take int, lastID int64) ([]*TrackWithLikedModel, error) {
 	query := `
-		SELECT t.id, t.user_id, t.username, t.title, t.changeable_id, t.audio, t.image, t.duration, t.plays, t.created_at, t.updated_at,
-			CASE WHEN ult.user_id IS NOT NULL THEN true ELSE false END as is_liked
+		SELECT t.id, t.user_id, t.username, t.title, t.changeable_id, t.audio, t.image, t.duration, t.genre, t.plays, t.reposts_count, t.created_at, t.updated_at,
+			CASE WHEN ult.user_id IS NOT NULL THEN true ELSE false END as is_liked,
+			CASE WHEN tr.user_id IS NOT NULL THEN true ELSE false END as is_reposted
 		FROM tracks t
 		LEFT JOIN user_liked_tracks ult ON ult.track_id = t.id AND ult.user_id = $1
+		LEFT JOIN track_reposts tr ON tr.track_id = t.id AND tr.user_id = $1
 		WHERE t.user_id = $2 AND ($3 = 0 OR t.id < $3)
 		ORDER BY t.plays DESC, t.id DESC
 		LIMIT $4
@@ -211,10 +273,13 @@ func (r *TrackRepo) GetManyPopular(ctx context.Context, userID, currentUserID in
 			&track.Audio,
 			&track.Image,
 			&track.Duration,
+			&track.Genre,
 			&track.Plays,
+			&track.RepostsCount,
 			&createdAt,
 			&updatedAt,
 			&track.IsLiked,
+			&track.IsReposted,
 		)
 
 		if err != nil {
@@ -234,18 +299,18 @@ func (r *TrackRepo) GetManyPopular(ctx context.Context, userID, currentUserID in
 	return tracks, nil
 }
 
-func (r *TrackRepo) Create(ctx context.Context, userID int64, username, title, changeableID, audio, image string, duration int64) (*TrackModel, error) {
+func (r *TrackRepo) Create(ctx context.Context, userID int64, username, title, changeableID, audio, image, genre string, tags []string, duration int64) (*TrackModel, error) {
 	query := `
-		INSERT INTO tracks (user_id, username, title, changeable_id, audio, image, duration)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
-		RETURNING id, user_id, username, title, changeable_id, audio, image, duration, plays, created_at, updated_at
+		INSERT INTO tracks (user_id, username, title, changeable_id, audio, image, genre, tags, duration)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, user_id, username, title, changeable_id, audio, image, duration, genre, plays, reposts_count, created_at, updated_at
 	`
 
 	var track TrackModel
 	var createdAt, updatedAt time.Time
 
-	err := r.postgres.QueryRowContext(
-		ctx, query, userID, username, title, changeableID, audio, image, duration,
+	err := r.db(ctx).QueryRowContext(
+		ctx, query, userID, username, title, changeableID, audio, image, genre, pq.Array(tags), duration,
 	).Scan(
 		&track.ID,
 		&track.UserID,
@@ -255,7 +320,9 @@ func (r *TrackRepo) Create(ctx context.Context, userID int64, username, title, c
 		&track.Audio,
 		&track.Image,
 		&track.Duration,
+		&track.Genre,
 		&track.Plays,
+		&track.RepostsCount,
 		&createdAt,
 		&updatedAt,
 	)
@@ -304,7 +371,7 @@ func (r *TrackRepo) Delete(ctx context.Context, trackID int64) error {
 		WHERE id = $1
 	`
 
-	_, err := r.postgres.ExecContext(ctx, query, trackID)
+	_, err := r.db(ctx).ExecContext(ctx, query, trackID)
 	return err
 }
 
@@ -315,10 +382,48 @@ func (r *TrackRepo) ChangeTitle(ctx context.Context, trackID int64, title string
 		WHERE id = $2
 	`
 
-	_, err := r.postgres.ExecContext(ctx, query, title, trackID)
+	_, err := r.db(ctx).ExecContext(ctx, query, title, trackID)
+	return err
+}
+
+func (r *TrackRepo) ChangeGenre(ctx context.Context, trackID int64, genre string) error {
+	query := `
+		UPDATE tracks
+		SET genre = $1
+		WHERE id = $2
+	`
+
+	_, err := r.db(ctx).ExecContext(ctx, query, genre, trackID)
+	return err
+}
+
+func (r *TrackRepo) ChangeTags(ctx context.Context, trackID int64, tags []string) error {
+	query := `
+		UPDATE tracks
+		SET tags = $1
+		WHERE id = $2
+	`
+
+	_, err := r.db(ctx).ExecContext(ctx, query, pq.Array(tags), trackID)
 	return err
 }
 
+func (r *TrackRepo) GetTags(ctx context.Context, trackID int64) ([]string, error) {
+	query := `
+		SELECT tags
+		FROM tracks
+		WHERE id = $1
+	`
+
+	var tags []string
+	err := r.db(ctx).QueryRowContext(ctx, query, trackID).Scan(pq.Array(&tags))
+	if err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
 func (r *TrackRepo) ChangeChangeableID(ctx context.Context, trackID int64, changeableID string) error {
 	query := `
 		UPDATE tracks
@@ -375,15 +480,30 @@ func (r *TrackRepo) CheckChangeableID(ctx context.Context, userID int64, changea
 	return exists, nil
 }
 
-func (r *TrackRepo) GetManyLiked(ctx context.Context, currentUserID int64) ([]*UserLikedTrackModel, error) {
-	query := `
-		SELECT user_id, track_id, added_at
-		FROM user_liked_tracks
-		WHERE user_id = $1
-		ORDER BY added_at DESC
-	`
+func (r *TrackRepo) GetManyLiked(ctx context.Context, currentUserID int64, params LikedQueryModel) ([]*TrackWithLikedModel, error) {
+	sortColumn := likedSortColumns[params.Sort]
+	sortCast := likedSortCasts[params.Sort]
+	direction, comparison := "DESC", "<"
+	if params.Order == OrderAsc {
+		direction, comparison = "ASC", ">"
+	}
 
-	rows, err := r.postgres.QueryContext(ctx, query, currentUserID)
+	query := fmt.Sprintf(`
+		SELECT t.id, t.user_id, t.username, t.title, t.changeable_id, t.audio, t.image, t.duration, t.genre, t.plays, t.reposts_count, t.created_at, t.updated_at,
+			true as is_liked,
+			ult.added_at as liked_at,
+			CASE WHEN tr.user_id IS NOT NULL THEN true ELSE false END as is_reposted
+		FROM user_liked_tracks ult
+		JOIN tracks t ON t.id = ult.track_id
+		LEFT JOIN track_reposts tr ON tr.track_id = t.id AND tr.user_id = $1
+		WHERE ult.user_id = $1
+			AND ($2 = '' OR t.title ILIKE '%%' || $2 || '%%' OR t.username ILIKE '%%' || $2 || '%%')
+			AND ($3 = 0 OR (%[1]s, t.id) %[3]s (NULLIF($4, '')::%[2]s, $3))
+		ORDER BY %[1]s %[4]s, t.id %[4]s
+		LIMIT $5
+	`, sortColumn, sortCast, comparison, direction)
+
+	rows, err := r.postgres.QueryContext(ctx, query, currentUserID, escapeLike(params.Query), params.LastID, params.LastValue, params.Take)
 	if err != nil {
 		return nil, err
 	}
@@ -394,21 +514,65 @@ func (r *TrackRepo) GetManyLiked(ctx context.Context, currentUserID int64) ([]*U
 		}
 	}()
 
-	var likedTracks []*UserLikedTrackModel
+	var tracks []*TrackWithLikedModel
+
 	for rows.Next() {
-		model := &UserLikedTrackModel{}
-		err := rows.Scan(&model.UserID, &model.TrackID, &model.AddedAt)
+		var track TrackWithLikedModel
+		var createdAt, updatedAt, likedAt time.Time
+
+		err := rows.Scan(
+			&track.ID,
+			&track.UserID,
+			&track.Username,
+			&track.Title,
+			&track.ChangeableID,
+			&track.Audio,
+			&track.Image,
+			&track.Duration,
+			&track.Genre,
+			&track.Plays,
+			&track.RepostsCount,
+			&createdAt,
+			&updatedAt,
+			&track.IsLiked,
+			&likedAt,
+			&track.IsReposted,
+		)
+
 		if err != nil {
 			return nil, err
 		}
-		likedTracks = append(likedTracks, model)
+
+		track.CreatedAt = createdAt
+		track.UpdatedAt = updatedAt
+		track.LikedAt = &likedAt
+
+		tracks = append(tracks, &track)
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 
-	return likedTracks, nil
+	return tracks, nil
+}
+
+func (r *TrackRepo) CountLiked(ctx context.Context, currentUserID int64, search string) (int64, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM user_liked_tracks ult
+		JOIN tracks t ON t.id = ult.track_id
+		WHERE ult.user_id = $1
+			AND ($2 = '' OR t.title ILIKE '%' || $2 || '%' OR t.username ILIKE '%' || $2 || '%')
+	`
+
+	var count int64
+	err := r.postgres.QueryRowContext(ctx, query, currentUserID, escapeLike(search)).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
 }
 
 func (r *TrackRepo) AddToLiked(ctx context.Context, currentUserID, trackID int64) error {
@@ -429,3 +593,90 @@ func (r *TrackRepo) RemoveFromLiked(ctx context.Context, currentUserID, trackID
 	_, err := r.postgres.ExecContext(ctx, query, currentUserID, trackID)
 	return err
 }
+
+func (r *TrackRepo) GetManyByIDs(ctx context.Context, trackIDs []int64, currentUserID int64) ([]*TrackWithLikedModel, error) {
+	query := `
+		SELECT t.id, t.user_id, t.username, t.title, t.changeable_id, t.audio, t.image, t.duration, t.genre, t.plays, t.reposts_count, t.created_at, t.updated_at,
+			CASE WHEN ult.user_id IS NOT NULL THEN true ELSE false END as is_liked,
+			CASE WHEN tr.user_id IS NOT NULL THEN true ELSE false END as is_reposted
+		FROM tracks t
+		LEFT JOIN user_liked_tracks ult ON ult.track_id = t.id AND ult.user_id = $1
+		LEFT JOIN track_reposts tr ON tr.track_id = t.id AND tr.user_id = $1
+		WHERE t.id = ANY($2)
+	`
+
+	rows, err := r.postgres.QueryContext(ctx, query, currentUserID, pq.Array(trackIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			r.log.Error("Failed to close rows", "error", err)
+		}
+	}()
+
+	var tracks []*TrackWithLikedModel
+
+	for rows.Next() {
+		var track TrackWithLikedModel
+		var createdAt, updatedAt time.Time
+
+		err := rows.Scan(
+			&track.ID,
+			&track.UserID,
+			&track.Username,
+			&track.Title,
+			&track.ChangeableID,
+			&track.Audio,
+			&track.Image,
+			&track.Duration,
+			&track.Genre,
+			&track.Plays,
+			&track.RepostsCount,
+			&createdAt,
+			&updatedAt,
+			&track.IsLiked,
+			&track.IsReposted,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		track.CreatedAt = createdAt
+		track.UpdatedAt = updatedAt
+
+		tracks = append(tracks, &track)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tracks, nil
+}
+
+func (r *TrackRepo) AddRepost(ctx context.Context, currentUserID, trackID int64) (time.Time, error) {
+	query := `
+		INSERT INTO track_reposts (user_id, track_id, reposted_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, track_id) DO NOTHING
+		RETURNING reposted_at
+	`
+
+	var repostedAt time.Time
+	err := r.postgres.QueryRowContext(ctx, query, currentUserID, trackID, time.Now()).Scan(&repostedAt)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return repostedAt, nil
+}
+
+func (r *TrackRepo) RemoveRepost(ctx context.Context, currentUserID, trackID int64) error {
+	query := `DELETE FROM track_reposts WHERE user_id = $1 AND track_id = $2`
+
+	_, err := r.postgres.ExecContext(ctx, query, currentUserID, trackID)
+	return err
+}
@@ -17,9 +17,19 @@ type GetManyForm struct {
 	LastID int64 `form:"lastId" binding:"omitempty,min=1"`
 }
 
+type GetManyLikedForm struct {
+	Take   int    `form:"take" binding:"omitempty,min=1,max=100"`
+	Cursor string `form:"cursor"`
+	Sort   string `form:"sort" binding:"omitempty,oneof=likedAt title artist duration"`
+	Order  string `form:"order" binding:"omitempty,oneof=asc desc"`
+	Query  string `form:"query" binding:"omitempty,max=100"`
+}
+
 type UploadTrackForm struct {
 	Title        string                `form:"title" binding:"required,min=1,max=20"`
 	ChangeableID string                `form:"changeableId" binding:"required,min=1,max=20"`
+	Genre        string                `form:"genre" binding:"omitempty,max=30"`
+	Tags         []string              `form:"tags" binding:"omitempty,max=10,dive,min=1,max=30"`
 	AudioFile    *multipart.FileHeader `form:"audioFile" binding:"required"`
 	ImageFile    *multipart.FileHeader `form:"imageFile" binding:"required"`
 }
@@ -36,6 +46,22 @@ type ChangeTitleForm struct {
 	Title string `form:"title" binding:"required,min=1,max=20"`
 }
 
+type ChangeGenreUri struct {
+	TrackID int64 `uri:"trackId" binding:"required"`
+}
+
+type ChangeGenreForm struct {
+	Genre string `form:"genre" binding:"max=30"`
+}
+
+type ChangeTagsUri struct {
+	TrackID int64 `uri:"trackId" binding:"required"`
+}
+
+type ChangeTagsForm struct {
+	Tags []string `form:"tags" binding:"max=10,dive,min=1,max=30"`
+}
+
 type ChangeChangeableIdUri struct {
 	TrackID int64 `uri:"trackId" binding:"required"`
 }
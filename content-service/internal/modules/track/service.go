@@ -3,15 +3,23 @@ package track
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
 	"mime/multipart"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/ocenb/music-go/content-service/internal/clients/notificationclient"
-	"github.com/ocenb/music-go/content-service/internal/clients/searchclient"
+	"github.com/ocenb/music-go/content-service/internal/modules/feed/fanout"
 	"github.com/ocenb/music-go/content-service/internal/modules/file"
+	"github.com/ocenb/music-go/content-service/internal/modules/playlist"
 	"github.com/ocenb/music-go/content-service/internal/storage"
+	"github.com/ocenb/music-go/shared/searchindex"
 	"github.com/ocenb/music-protos/gen/searchservice"
 )
 
@@ -20,32 +28,53 @@ type TrackServiceInterface interface {
 	GetOne(ctx context.Context, currentUserID int64, username, changeableID string) (*TrackWithLikedModel, error)
 	GetMany(ctx context.Context, currentUserID, userID int64, take int, lastID int64) ([]*TrackWithLikedModel, error)
 	GetManyPopular(ctx context.Context, currentUserID, userID int64, take int, lastID int64) ([]*TrackWithLikedModel, error)
-	Upload(ctx context.Context, userID int64, username, email, title, changeableID string, audioFile *multipart.FileHeader, imageFile *multipart.FileHeader) (*TrackModel, error)
+	Upload(ctx context.Context, userID int64, username, email, title, changeableID, genre string, tags []string, audioFile *multipart.FileHeader, imageFile *multipart.FileHeader) (*TrackModel, error)
 	AddPlay(ctx context.Context, trackID int64) error
 	Delete(ctx context.Context, userID, trackID int64) error
 	ChangeTitle(ctx context.Context, userID, trackID int64, title string) error
+	ChangeGenre(ctx context.Context, userID, trackID int64, genre string) error
+	ChangeTags(ctx context.Context, userID, trackID int64, tags []string) error
 	ChangeChangeableId(ctx context.Context, userID, trackID int64, changeableID string) error
 	ChangeImage(ctx context.Context, userID, trackID int64, imageFile *multipart.FileHeader) error
-	GetManyLiked(ctx context.Context, currentUserID int64) ([]*UserLikedTrackModel, error)
+	GetManyLiked(ctx context.Context, currentUserID int64, take int, cursor string, sort LikedSort, order SortOrder, query string) (*LikedTracksModel, error)
 	AddToLiked(ctx context.Context, currentUserID, trackID int64) error
 	RemoveFromLiked(ctx context.Context, currentUserID, trackID int64) error
+	Repost(ctx context.Context, currentUserID, trackID int64) error
+	RemoveRepost(ctx context.Context, currentUserID, trackID int64) error
+}
+
+const (
+	defaultLikedTake   = 20
+	searchDocumentType = "track"
+)
+
+type likedCursor struct {
+	Sort  LikedSort `json:"s"`
+	Order SortOrder `json:"o"`
+	Query string    `json:"q,omitempty"`
+	ID    int64     `json:"id"`
+	Value string    `json:"v"`
 }
 
 type TrackService struct {
 	log                *slog.Logger
 	trackRepo          TrackRepoInterface
 	fileService        file.FileServiceInterface
-	searchClient       *searchclient.SearchServiceClient
+	searchIndex        searchindex.SearchIndexClientInterface
 	notificationClient notificationclient.NotificationClientInterface
+	fanOutService      fanout.FanOutServiceInterface
+	playlistService    playlist.PlaylistServiceInterface
 }
 
-func NewTrackService(log *slog.Logger, trackRepo TrackRepoInterface, fileService file.FileServiceInterface, searchClient *searchclient.SearchServiceClient, notificationClient notificationclient.NotificationClientInterface) TrackServiceInterface {
+func NewTrackService(log *slog.Logger, trackRepo TrackRepoInterface, fileService file.FileServiceInterface, searchIndex searchindex.SearchIndexClientInterface, notificationClient notificationclient.NotificationClientInterface, fanOutService fanout.FanOutServiceInterface, playlistService playlist.PlaylistServiceInterface) TrackServiceInterface {
 	return &TrackService{
 		log:                log,
 		trackRepo:          trackRepo,
 		fileService:        fileService,
-		searchClient:       searchClient,
+		searchIndex:        searchIndex,
 		notificationClient: notificationClient,
+		fanOutService:      fanOutService,
+		playlistService:    playlistService,
 	}
 }
 
@@ -91,7 +120,7 @@ func (s *TrackService) GetManyPopular(ctx context.Context, currentUserID, userID
 	return tracks, nil
 }
 
-func (s *TrackService) Upload(ctx context.Context, userID int64, username, email, title, changeableID string, audioFile *multipart.FileHeader, imageFile *multipart.FileHeader) (*TrackModel, error) {
+func (s *TrackService) Upload(ctx context.Context, userID int64, username, email, title, changeableID, genre string, tags []string, audioFile *multipart.FileHeader, imageFile *multipart.FileHeader) (*TrackModel, error) {
 	if err := s.validateTrackTitle(ctx, userID, title); err != nil {
 		return nil, err
 	}
@@ -112,29 +141,28 @@ func (s *TrackService) Upload(ctx context.Context, userID int64, username, email
 
 	var newTrack *TrackModel
 	err = storage.WithTransaction(ctx, s.trackRepo, func(txCtx context.Context) error {
-		newTrack, err = s.trackRepo.Create(txCtx, userID, username, title, changeableID, audioResult.FileName, imageName, int64(audioResult.Duration))
+		tags = normalizeTags(tags)
+		newTrack, err = s.trackRepo.Create(txCtx, userID, username, title, changeableID, audioResult.FileName, imageName, normalizeGenre(genre), tags, int64(audioResult.Duration))
 		if err != nil {
 			return err
 		}
-		_, err = s.searchClient.Client.AddTrack(txCtx, &searchservice.AddOrUpdateRequest{
-			Id:   newTrack.ID,
-			Name: newTrack.Title,
+		err = s.searchIndex.Upsert(txCtx, searchDocumentType, &searchservice.AddOrUpdateRequest{
+			Id:    newTrack.ID,
+			Name:  newTrack.Title,
+			Track: trackAttributes(newTrack, tags),
 		})
 		if err != nil {
 			return err
 		}
 
-		err = s.notificationClient.SendEmailNotification(email, newTrack.Title)
-		if err != nil {
-			s.log.Error("Failed to send email notification", "error", err)
-		}
-
-		return nil
+		return s.notificationClient.SendEmailNotification(txCtx, email, newTrack.Title)
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	s.fanOutService.Publish(ctx, userID, fanout.TrackItem, newTrack.ID, false, newTrack.CreatedAt)
+
 	return newTrack, nil
 }
 
@@ -165,11 +193,8 @@ func (s *TrackService) Delete(ctx context.Context, userID, trackID int64) error
 			return err
 		}
 
-		deleteResp, err := s.searchClient.Client.DeleteTrack(txCtx, &searchservice.DeleteRequest{
-			Id: trackID,
-		})
-		if err != nil || !deleteResp.Success {
-			return fmt.Errorf("failed to delete track in search service: %w", err)
+		if err := s.searchIndex.Delete(txCtx, searchDocumentType, trackID); err != nil {
+			return err
 		}
 
 		err = s.fileService.DeleteFile(txCtx, track.Audio, file.AudioCategory)
@@ -186,6 +211,14 @@ func (s *TrackService) Delete(ctx context.Context, userID, trackID int64) error
 	if err != nil {
 		return err
 	}
+
+	if err := s.fanOutService.RetractItem(ctx, fanout.TrackItem, trackID); err != nil {
+		s.log.Error("Failed to remove track from feeds", "error", err, "track_id", trackID)
+	}
+
+	if err := s.playlistService.RefreshCoversWithImage(ctx, track.Image); err != nil {
+		s.log.Error("Failed to refresh playlist covers", "error", err, "track_id", trackID)
+	}
 	return nil
 }
 
@@ -202,16 +235,77 @@ func (s *TrackService) ChangeTitle(ctx context.Context, userID, trackID int64, t
 		return err
 	}
 
-	if err := s.trackRepo.ChangeTitle(ctx, trackID, title); err != nil {
+	return storage.WithTransaction(ctx, s.trackRepo, func(txCtx context.Context) error {
+		if err := s.trackRepo.ChangeTitle(txCtx, trackID, title); err != nil {
+			return err
+		}
+
+		return s.syncSearch(txCtx, trackID)
+	})
+}
+
+func (s *TrackService) ChangeGenre(ctx context.Context, userID, trackID int64, genre string) error {
+	_, err := s.trackRepo.GetByID(ctx, trackID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrTrackNotFound
+		}
+		return err
+	}
+
+	hasPermission, err := s.trackRepo.CheckPermission(ctx, userID, trackID)
+	if err != nil {
+		return err
+	}
+	if !hasPermission {
+		return ErrPermissionDenied
+	}
+
+	return storage.WithTransaction(ctx, s.trackRepo, func(txCtx context.Context) error {
+		if err := s.trackRepo.ChangeGenre(txCtx, trackID, normalizeGenre(genre)); err != nil {
+			return err
+		}
+
+		return s.syncSearch(txCtx, trackID)
+	})
+}
+
+func (s *TrackService) ChangeTags(ctx context.Context, userID, trackID int64, tags []string) error {
+	hasPermission, err := s.trackRepo.CheckPermission(ctx, userID, trackID)
+	if err != nil {
+		return err
+	}
+	if !hasPermission {
+		return ErrPermissionDenied
+	}
+
+	return storage.WithTransaction(ctx, s.trackRepo, func(txCtx context.Context) error {
+		if err := s.trackRepo.ChangeTags(txCtx, trackID, normalizeTags(tags)); err != nil {
+			return err
+		}
+
+		return s.syncSearch(txCtx, trackID)
+	})
+}
+
+func (s *TrackService) syncSearch(ctx context.Context, trackID int64) error {
+	track, err := s.trackRepo.GetByID(ctx, trackID, 0)
+	if err != nil {
+		return err
+	}
+
+	tags, err := s.trackRepo.GetTags(ctx, trackID)
+	if err != nil {
 		return err
 	}
 
-	updateResp, err := s.searchClient.Client.UpdateTrack(ctx, &searchservice.AddOrUpdateRequest{
-		Id:   trackID,
-		Name: title,
+	err = s.searchIndex.Upsert(ctx, searchDocumentType, &searchservice.AddOrUpdateRequest{
+		Id:    trackID,
+		Name:  track.Title,
+		Track: trackAttributes(&track.TrackModel, tags),
 	})
-	if err != nil || !updateResp.Success {
-		return fmt.Errorf("failed to update track in search service: %w", err)
+	if err != nil {
+		return fmt.Errorf("failed to queue track for search indexing: %w", err)
 	}
 
 	return nil
@@ -272,6 +366,10 @@ func (s *TrackService) ChangeImage(ctx context.Context, userID, trackID int64, i
 		return err
 	}
 
+	if err := s.playlistService.RefreshCoversWithImage(ctx, track.Image); err != nil {
+		s.log.Error("Failed to refresh playlist covers", "error", err, "track_id", trackID)
+	}
+
 	if err := s.fileService.DeleteFile(ctx, track.Image, file.ImagesCategory); err != nil {
 		return err
 	}
@@ -303,13 +401,71 @@ func (s *TrackService) validateChangeableId(ctx context.Context, userID int64, c
 	return nil
 }
 
-func (s *TrackService) GetManyLiked(ctx context.Context, currentUserID int64) ([]*UserLikedTrackModel, error) {
-	likedTracks, err := s.trackRepo.GetManyLiked(ctx, currentUserID)
+func (s *TrackService) GetManyLiked(ctx context.Context, currentUserID int64, take int, cursor string, sort LikedSort, order SortOrder, query string) (*LikedTracksModel, error) {
+	if take == 0 {
+		take = defaultLikedTake
+	}
+	if sort == "" {
+		sort = LikedSortLikedAt
+	}
+	if order == "" {
+		order = OrderDesc
+	}
+
+	params := LikedQueryModel{
+		Sort:  sort,
+		Order: order,
+		Query: query,
+		Take:  take + 1,
+	}
+
+	if cursor != "" {
+		decoded, err := decodeLikedCursor(cursor)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		if decoded.Sort != sort || decoded.Order != order || decoded.Query != query {
+			return nil, ErrInvalidCursor
+		}
+		params.LastID = decoded.ID
+		params.LastValue = decoded.Value
+	}
+
+	tracks, err := s.trackRepo.GetManyLiked(ctx, currentUserID, params)
 	if err != nil {
 		return nil, err
 	}
 
-	return likedTracks, nil
+	total, err := s.trackRepo.CountLiked(ctx, currentUserID, query)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &LikedTracksModel{
+		Tracks: tracks,
+		Total:  total,
+	}
+
+	if len(tracks) > take {
+		result.Tracks = tracks[:take]
+		last := result.Tracks[take-1]
+		result.NextCursor, err = encodeLikedCursor(likedCursor{
+			Sort:  sort,
+			Order: order,
+			Query: query,
+			ID:    last.ID,
+			Value: likedSortValue(last, sort),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if result.Tracks == nil {
+		result.Tracks = []*TrackWithLikedModel{}
+	}
+
+	return result, nil
 }
 
 func (s *TrackService) AddToLiked(ctx context.Context, currentUserID, trackID int64) error {
@@ -335,3 +491,117 @@ func (s *TrackService) RemoveFromLiked(ctx context.Context, currentUserID, track
 	}
 	return nil
 }
+
+func (s *TrackService) Repost(ctx context.Context, currentUserID, trackID int64) error {
+	track, err := s.trackRepo.GetByID(ctx, trackID, currentUserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrTrackNotFound
+		}
+		return err
+	}
+	if track.UserID == currentUserID {
+		return ErrTrackIsYours
+	}
+	if track.IsReposted {
+		return ErrTrackAlreadyReposted
+	}
+
+	repostedAt, err := s.trackRepo.AddRepost(ctx, currentUserID, trackID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrTrackAlreadyReposted
+		}
+		return err
+	}
+
+	s.fanOutService.Publish(ctx, currentUserID, fanout.TrackItem, trackID, true, repostedAt)
+
+	return nil
+}
+
+func (s *TrackService) RemoveRepost(ctx context.Context, currentUserID, trackID int64) error {
+	track, err := s.trackRepo.GetByID(ctx, trackID, currentUserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrTrackNotFound
+		}
+		return err
+	}
+	if !track.IsReposted {
+		return ErrTrackIsNotReposted
+	}
+
+	if err := s.trackRepo.RemoveRepost(ctx, currentUserID, trackID); err != nil {
+		return err
+	}
+
+	if err := s.fanOutService.Retract(ctx, currentUserID, fanout.TrackItem, trackID, true); err != nil {
+		s.log.Error("Failed to remove track repost from feeds", "error", err, "track_id", trackID)
+	}
+
+	return nil
+}
+
+func likedSortValue(track *TrackWithLikedModel, sort LikedSort) string {
+	switch sort {
+	case LikedSortTitle:
+		return track.Title
+	case LikedSortArtist:
+		return track.Username
+	case LikedSortDuration:
+		return strconv.FormatInt(track.Duration, 10)
+	default:
+		return track.LikedAt.Format(time.RFC3339Nano)
+	}
+}
+
+func encodeLikedCursor(cursor likedCursor) (string, error) {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeLikedCursor(value string) (*likedCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor likedCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, err
+	}
+	if cursor.ID <= 0 {
+		return nil, ErrInvalidCursor
+	}
+
+	return &cursor, nil
+}
+
+func normalizeGenre(genre string) string {
+	return strings.ToLower(strings.TrimSpace(genre))
+}
+
+func normalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || slices.Contains(normalized, tag) {
+			continue
+		}
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+func trackAttributes(track *TrackModel, tags []string) *searchservice.TrackAttributes {
+	return &searchservice.TrackAttributes{
+		Genre:    track.Genre,
+		Tags:     tags,
+		Duration: track.Duration,
+		UserId:   track.UserID,
+	}
+}
@@ -0,0 +1,142 @@
+package track
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryLikedRepo serves liked tracks sorted by title, enough to page through
+// them with the cursors of the service.
+type memoryLikedRepo struct {
+	TrackRepoInterface
+	tracks  []*TrackWithLikedModel
+	queries []LikedQueryModel
+}
+
+func (r *memoryLikedRepo) GetManyLiked(ctx context.Context, currentUserID int64, params LikedQueryModel) ([]*TrackWithLikedModel, error) {
+	r.queries = append(r.queries, params)
+
+	tracks := slices.Clone(r.tracks)
+	slices.SortFunc(tracks, func(a, b *TrackWithLikedModel) int {
+		if c := strings.Compare(a.Title, b.Title); c != 0 {
+			return c
+		}
+		return int(a.ID - b.ID)
+	})
+
+	result := []*TrackWithLikedModel{}
+	for _, track := range tracks {
+		if len(result) == params.Take {
+			break
+		}
+		if params.LastID != 0 && (track.Title < params.LastValue || track.Title == params.LastValue && track.ID <= params.LastID) {
+			continue
+		}
+		result = append(result, track)
+	}
+	return result, nil
+}
+
+func (r *memoryLikedRepo) CountLiked(ctx context.Context, currentUserID int64, search string) (int64, error) {
+	return int64(len(r.tracks)), nil
+}
+
+func likedTrack(id int64, title string) *TrackWithLikedModel {
+	return &TrackWithLikedModel{TrackModel: TrackModel{ID: id, Title: title}, IsLiked: true}
+}
+
+func TestLikedCursorRoundTrip(t *testing.T) {
+	cursor := likedCursor{Sort: LikedSortDuration, Order: OrderAsc, Query: "night", ID: 42, Value: "215"}
+
+	encoded, err := encodeLikedCursor(cursor)
+	require.NoError(t, err)
+	assert.NotContains(t, encoded, "=")
+
+	decoded, err := decodeLikedCursor(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, cursor, *decoded)
+}
+
+func TestDecodeLikedCursorRejectsInvalidCursors(t *testing.T) {
+	for name, value := range map[string]string{
+		"not base64":  "%%%",
+		"not json":    base64.RawURLEncoding.EncodeToString([]byte("cursor")),
+		"missing id":  base64.RawURLEncoding.EncodeToString([]byte(`{"s":"title","o":"asc","v":"a"}`)),
+		"negative id": base64.RawURLEncoding.EncodeToString([]byte(`{"s":"title","o":"asc","id":-1}`)),
+	} {
+		_, err := decodeLikedCursor(value)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestLikedSortValue(t *testing.T) {
+	likedAt := time.Date(2026, 3, 1, 12, 30, 0, 500, time.UTC)
+	track := &TrackWithLikedModel{
+		TrackModel: TrackModel{Title: "Night Drive", Username: "neon", Duration: 215},
+		LikedAt:    &likedAt,
+	}
+
+	assert.Equal(t, "Night Drive", likedSortValue(track, LikedSortTitle))
+	assert.Equal(t, "neon", likedSortValue(track, LikedSortArtist))
+	assert.Equal(t, "215", likedSortValue(track, LikedSortDuration))
+	assert.Equal(t, "2026-03-01T12:30:00.0000005Z", likedSortValue(track, LikedSortLikedAt))
+}
+
+func TestGetManyLikedPages(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryLikedRepo{tracks: []*TrackWithLikedModel{
+		likedTrack(1, "c"), likedTrack(2, "a"), likedTrack(3, "b"), likedTrack(4, "a"), likedTrack(5, "d"),
+	}}
+	service := &TrackService{log: slog.New(slog.NewTextHandler(io.Discard, nil)), trackRepo: repo}
+
+	var ids []int64
+	cursor := ""
+	for range 3 {
+		page, err := service.GetManyLiked(ctx, 1, 2, cursor, LikedSortTitle, OrderAsc, "")
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), page.Total)
+
+		for _, track := range page.Tracks {
+			ids = append(ids, track.ID)
+		}
+		cursor = page.NextCursor
+		if cursor == "" {
+			break
+		}
+	}
+
+	assert.Equal(t, []int64{2, 4, 3, 1, 5}, ids)
+	assert.Empty(t, cursor)
+	assert.Equal(t, 3, repo.queries[0].Take, "one extra row tells whether there is a next page")
+}
+
+func TestGetManyLikedRejectsCursorOfAnotherQuery(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryLikedRepo{tracks: []*TrackWithLikedModel{likedTrack(1, "a"), likedTrack(2, "b")}}
+	service := &TrackService{log: slog.New(slog.NewTextHandler(io.Discard, nil)), trackRepo: repo}
+
+	page, err := service.GetManyLiked(ctx, 1, 1, "", LikedSortTitle, OrderAsc, "")
+	require.NoError(t, err)
+	require.NotEmpty(t, page.NextCursor)
+
+	_, err = service.GetManyLiked(ctx, 1, 1, page.NextCursor, LikedSortArtist, OrderAsc, "")
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	_, err = service.GetManyLiked(ctx, 1, 1, page.NextCursor, LikedSortTitle, OrderDesc, "")
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	_, err = service.GetManyLiked(ctx, 1, 1, page.NextCursor, LikedSortTitle, OrderAsc, "b")
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	_, err = service.GetManyLiked(ctx, 1, 1, "garbage", LikedSortTitle, OrderAsc, "")
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
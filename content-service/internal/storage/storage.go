@@ -13,6 +13,10 @@ type BeginTx interface {
 }
 
 func WithTransaction(ctx context.Context, repo BeginTx, fn func(txCtx context.Context) error) error {
+	if _, hasTx := utils.GetTxFromContext(ctx); hasTx {
+		return fn(ctx)
+	}
+
 	tx, err := repo.BeginTx(ctx, nil)
 	if err != nil {
 		return err
@@ -0,0 +1,13 @@
+DROP TRIGGER IF EXISTS playlist_reposts_count_trigger ON playlist_reposts;
+DROP TRIGGER IF EXISTS track_reposts_count_trigger ON track_reposts;
+DROP FUNCTION IF EXISTS update_playlist_reposts_count();
+DROP FUNCTION IF EXISTS update_track_reposts_count();
+
+DROP INDEX IF EXISTS idx_playlists_user_id_created_at;
+DROP INDEX IF EXISTS idx_tracks_user_id_created_at;
+
+DROP TABLE IF EXISTS playlist_reposts;
+DROP TABLE IF EXISTS track_reposts;
+
+ALTER TABLE playlists DROP COLUMN IF EXISTS reposts_count;
+ALTER TABLE tracks DROP COLUMN IF EXISTS reposts_count;
@@ -0,0 +1,65 @@
+ALTER TABLE tracks ADD COLUMN reposts_count INT NOT NULL DEFAULT 0;
+ALTER TABLE playlists ADD COLUMN reposts_count INT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS track_reposts (
+    user_id INT NOT NULL,
+    track_id INT NOT NULL,
+    reposted_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, track_id),
+    CONSTRAINT fk_track_reposts_track FOREIGN KEY (track_id) REFERENCES tracks(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_track_reposts_user_id_reposted_at ON track_reposts(user_id, reposted_at DESC);
+CREATE INDEX IF NOT EXISTS idx_track_reposts_track_id ON track_reposts(track_id);
+
+CREATE TABLE IF NOT EXISTS playlist_reposts (
+    user_id INT NOT NULL,
+    playlist_id INT NOT NULL,
+    reposted_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, playlist_id),
+    CONSTRAINT fk_playlist_reposts_playlist FOREIGN KEY (playlist_id) REFERENCES playlists(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_playlist_reposts_user_id_reposted_at ON playlist_reposts(user_id, reposted_at DESC);
+CREATE INDEX IF NOT EXISTS idx_playlist_reposts_playlist_id ON playlist_reposts(playlist_id);
+
+CREATE INDEX IF NOT EXISTS idx_tracks_user_id_created_at ON tracks(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_playlists_user_id_created_at ON playlists(user_id, created_at DESC);
+
+CREATE OR REPLACE FUNCTION update_track_reposts_count()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE tracks SET reposts_count = reposts_count + 1 WHERE id = NEW.track_id;
+        RETURN NEW;
+    ELSIF TG_OP = 'DELETE' THEN
+        UPDATE tracks SET reposts_count = reposts_count - 1 WHERE id = OLD.track_id;
+        RETURN OLD;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER track_reposts_count_trigger
+AFTER INSERT OR DELETE ON track_reposts
+FOR EACH ROW
+EXECUTE FUNCTION update_track_reposts_count();
+
+CREATE OR REPLACE FUNCTION update_playlist_reposts_count()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE playlists SET reposts_count = reposts_count + 1 WHERE id = NEW.playlist_id;
+        RETURN NEW;
+    ELSIF TG_OP = 'DELETE' THEN
+        UPDATE playlists SET reposts_count = reposts_count - 1 WHERE id = OLD.playlist_id;
+        RETURN OLD;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER playlist_reposts_count_trigger
+AFTER INSERT OR DELETE ON playlist_reposts
+FOR EACH ROW
+EXECUTE FUNCTION update_playlist_reposts_count();
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS feed_items (
+    user_id INT NOT NULL,
+    actor_id INT NOT NULL,
+    item_type TEXT NOT NULL,
+    item_id INT NOT NULL,
+    is_repost BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (user_id, actor_id, item_type, item_id, is_repost)
+);
+
+CREATE INDEX IF NOT EXISTS idx_feed_items_user_id_created_at ON feed_items(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_feed_items_item ON feed_items(item_type, item_id);
+CREATE INDEX IF NOT EXISTS idx_feed_items_actor_id ON feed_items(actor_id);
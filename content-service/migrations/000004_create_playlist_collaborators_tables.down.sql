@@ -0,0 +1,4 @@
+ALTER TABLE playlist_tracks DROP COLUMN IF EXISTS added_by;
+
+DROP TABLE IF EXISTS playlist_invites;
+DROP TABLE IF EXISTS playlist_collaborators;
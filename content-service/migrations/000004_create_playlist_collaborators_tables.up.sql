@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS playlist_collaborators (
+    playlist_id INT NOT NULL,
+    user_id INT NOT NULL,
+    role TEXT NOT NULL,
+    added_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (playlist_id, user_id),
+    CONSTRAINT fk_playlist_collaborators_playlist FOREIGN KEY (playlist_id) REFERENCES playlists(id) ON DELETE CASCADE,
+    CONSTRAINT check_playlist_collaborators_role CHECK (role IN ('editor', 'viewer'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_playlist_collaborators_user_id ON playlist_collaborators(user_id);
+
+CREATE TABLE IF NOT EXISTS playlist_invites (
+    id SERIAL PRIMARY KEY,
+    playlist_id INT NOT NULL,
+    inviter_id INT NOT NULL,
+    invitee_id INT,
+    role TEXT NOT NULL,
+    token TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP,
+    CONSTRAINT fk_playlist_invites_playlist FOREIGN KEY (playlist_id) REFERENCES playlists(id) ON DELETE CASCADE,
+    CONSTRAINT check_playlist_invites_role CHECK (role IN ('editor', 'viewer')),
+    CONSTRAINT check_playlist_invites_target CHECK ((invitee_id IS NULL) <> (token IS NULL)),
+    CONSTRAINT unique_playlist_invites_token UNIQUE (token)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_playlist_invites_playlist_invitee ON playlist_invites(playlist_id, invitee_id) WHERE invitee_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_playlist_invites_invitee_id ON playlist_invites(invitee_id);
+
+ALTER TABLE playlist_tracks ADD COLUMN added_by INT;
+UPDATE playlist_tracks pt SET added_by = p.user_id FROM playlists p WHERE p.id = pt.playlist_id;
+ALTER TABLE playlist_tracks ALTER COLUMN added_by SET NOT NULL;
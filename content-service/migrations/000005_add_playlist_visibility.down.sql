@@ -0,0 +1,4 @@
+ALTER TABLE playlists DROP CONSTRAINT IF EXISTS unique_playlists_share_token;
+ALTER TABLE playlists DROP CONSTRAINT IF EXISTS check_playlists_visibility;
+ALTER TABLE playlists DROP COLUMN IF EXISTS share_token;
+ALTER TABLE playlists DROP COLUMN IF EXISTS visibility;
@@ -0,0 +1,4 @@
+ALTER TABLE playlists ADD COLUMN visibility TEXT NOT NULL DEFAULT 'public';
+ALTER TABLE playlists ADD COLUMN share_token TEXT;
+ALTER TABLE playlists ADD CONSTRAINT check_playlists_visibility CHECK (visibility IN ('public', 'unlisted', 'private'));
+ALTER TABLE playlists ADD CONSTRAINT unique_playlists_share_token UNIQUE (share_token);
@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS idx_playlist_tracks_playlist_id_rank;
+
+ALTER TABLE playlist_tracks ADD COLUMN position INT;
+
+UPDATE playlist_tracks pt
+SET position = ranked.position
+FROM (
+    SELECT playlist_id, track_id, ROW_NUMBER() OVER (PARTITION BY playlist_id ORDER BY rank) AS position
+    FROM playlist_tracks
+) ranked
+WHERE pt.playlist_id = ranked.playlist_id AND pt.track_id = ranked.track_id;
+
+ALTER TABLE playlist_tracks ALTER COLUMN position SET NOT NULL;
+ALTER TABLE playlist_tracks DROP COLUMN rank;
@@ -0,0 +1,15 @@
+ALTER TABLE playlist_tracks ADD COLUMN rank TEXT COLLATE "C";
+
+UPDATE playlist_tracks pt
+SET rank = ranked.rank
+FROM (
+    SELECT playlist_id, track_id,
+        'V' || lpad(ROW_NUMBER() OVER (PARTITION BY playlist_id ORDER BY position, added_at)::text, 9, '0') || 'V' AS rank
+    FROM playlist_tracks
+) ranked
+WHERE pt.playlist_id = ranked.playlist_id AND pt.track_id = ranked.track_id;
+
+ALTER TABLE playlist_tracks ALTER COLUMN rank SET NOT NULL;
+ALTER TABLE playlist_tracks DROP COLUMN position;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_playlist_tracks_playlist_id_rank ON playlist_tracks(playlist_id, rank);
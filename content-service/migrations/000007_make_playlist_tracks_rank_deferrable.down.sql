@@ -0,0 +1,3 @@
+ALTER TABLE playlist_tracks DROP CONSTRAINT IF EXISTS unique_playlist_tracks_playlist_id_rank;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_playlist_tracks_playlist_id_rank ON playlist_tracks(playlist_id, rank);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_playlist_tracks_playlist_id_rank;
+
+ALTER TABLE playlist_tracks ADD CONSTRAINT unique_playlist_tracks_playlist_id_rank UNIQUE (playlist_id, rank) DEFERRABLE INITIALLY DEFERRED;
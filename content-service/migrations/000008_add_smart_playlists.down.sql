@@ -0,0 +1,4 @@
+ALTER TABLE playlists DROP COLUMN IF EXISTS rules;
+
+DROP INDEX IF EXISTS idx_tracks_genre;
+ALTER TABLE tracks DROP COLUMN IF EXISTS genre;
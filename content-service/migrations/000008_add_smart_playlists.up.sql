@@ -0,0 +1,4 @@
+ALTER TABLE tracks ADD COLUMN genre TEXT NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_tracks_genre ON tracks(genre);
+
+ALTER TABLE playlists ADD COLUMN rules JSONB;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS playlist_folder_items;
+DROP TRIGGER IF EXISTS update_playlist_folders_updated_at ON playlist_folders;
+DROP TABLE IF EXISTS playlist_folders;
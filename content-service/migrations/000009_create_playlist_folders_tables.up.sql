@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS playlist_folders (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL,
+    parent_id INT,
+    title TEXT NOT NULL,
+    position INT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_playlist_folders_parent FOREIGN KEY (parent_id) REFERENCES playlist_folders(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_playlist_folders_user_id ON playlist_folders(user_id);
+CREATE INDEX IF NOT EXISTS idx_playlist_folders_parent_id ON playlist_folders(parent_id);
+
+CREATE TRIGGER update_playlist_folders_updated_at
+BEFORE UPDATE ON playlist_folders
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE IF NOT EXISTS playlist_folder_items (
+    user_id INT NOT NULL,
+    playlist_id INT NOT NULL,
+    folder_id INT NOT NULL,
+    position INT NOT NULL,
+    added_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, playlist_id),
+    CONSTRAINT fk_playlist_folder_items_playlist FOREIGN KEY (playlist_id) REFERENCES playlists(id) ON DELETE CASCADE,
+    CONSTRAINT fk_playlist_folder_items_folder FOREIGN KEY (folder_id) REFERENCES playlist_folders(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_playlist_folder_items_folder_id ON playlist_folder_items(folder_id);
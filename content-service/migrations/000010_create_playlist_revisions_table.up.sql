@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS playlist_revisions (
+    id BIGSERIAL PRIMARY KEY,
+    playlist_id INT NOT NULL,
+    actor_id INT NOT NULL,
+    change TEXT NOT NULL,
+    details JSONB,
+    title TEXT NOT NULL,
+    changeable_id TEXT NOT NULL,
+    rules JSONB,
+    track_ids INT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_playlist_revisions_playlist FOREIGN KEY (playlist_id) REFERENCES playlists(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_playlist_revisions_playlist_id ON playlist_revisions(playlist_id, id);
+
+INSERT INTO playlist_revisions (playlist_id, actor_id, change, title, changeable_id, rules, track_ids)
+SELECT p.id, p.user_id, 'created', p.title, p.changeable_id, p.rules,
+    ARRAY(SELECT pt.track_id FROM playlist_tracks pt WHERE pt.playlist_id = p.id ORDER BY pt.rank)
+FROM playlists p;
@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS idx_playlists_cover_source;
+
+DROP TRIGGER IF EXISTS playlist_track_stats_trigger ON playlist_tracks;
+DROP TRIGGER IF EXISTS playlist_track_duration_trigger ON playlist_tracks;
+DROP FUNCTION IF EXISTS update_playlist_track_stats();
+DROP FUNCTION IF EXISTS set_playlist_track_duration();
+
+ALTER TABLE playlist_tracks DROP COLUMN IF EXISTS duration;
+
+ALTER TABLE playlists DROP COLUMN IF EXISTS total_duration;
+ALTER TABLE playlists DROP COLUMN IF EXISTS track_count;
+ALTER TABLE playlists DROP COLUMN IF EXISTS cover_source;
+ALTER TABLE playlists DROP COLUMN IF EXISTS image_auto;
@@ -0,0 +1,57 @@
+ALTER TABLE playlists ADD COLUMN image_auto BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE playlists ADD COLUMN cover_source TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE playlists ADD COLUMN track_count INT NOT NULL DEFAULT 0;
+ALTER TABLE playlists ADD COLUMN total_duration INT NOT NULL DEFAULT 0;
+
+ALTER TABLE playlist_tracks ADD COLUMN duration INT NOT NULL DEFAULT 0;
+
+UPDATE playlist_tracks pt SET duration = t.duration FROM tracks t WHERE t.id = pt.track_id;
+
+UPDATE playlists SET image_auto = TRUE WHERE image = 'default';
+
+UPDATE playlists p
+SET track_count = s.track_count, total_duration = s.total_duration
+FROM (
+    SELECT playlist_id, COUNT(*) AS track_count, SUM(duration) AS total_duration
+    FROM playlist_tracks
+    GROUP BY playlist_id
+) s
+WHERE s.playlist_id = p.id;
+
+CREATE OR REPLACE FUNCTION set_playlist_track_duration()
+RETURNS TRIGGER AS $$
+BEGIN
+    SELECT duration INTO NEW.duration FROM tracks WHERE id = NEW.track_id;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER playlist_track_duration_trigger
+BEFORE INSERT ON playlist_tracks
+FOR EACH ROW
+EXECUTE FUNCTION set_playlist_track_duration();
+
+CREATE OR REPLACE FUNCTION update_playlist_track_stats()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE playlists
+        SET track_count = track_count + 1, total_duration = total_duration + NEW.duration
+        WHERE id = NEW.playlist_id;
+        RETURN NEW;
+    ELSIF TG_OP = 'DELETE' THEN
+        UPDATE playlists
+        SET track_count = track_count - 1, total_duration = total_duration - OLD.duration
+        WHERE id = OLD.playlist_id;
+        RETURN OLD;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER playlist_track_stats_trigger
+AFTER INSERT OR DELETE ON playlist_tracks
+FOR EACH ROW
+EXECUTE FUNCTION update_playlist_track_stats();
+
+CREATE INDEX IF NOT EXISTS idx_playlists_cover_source ON playlists USING GIN (cover_source);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_user_saved_playlists_playlist_id;
+DROP INDEX IF EXISTS idx_user_liked_tracks_track_id;
@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS idx_user_liked_tracks_track_id ON user_liked_tracks(track_id);
+CREATE INDEX IF NOT EXISTS idx_user_saved_playlists_playlist_id ON user_saved_playlists(playlist_id);
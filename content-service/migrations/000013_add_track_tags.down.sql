@@ -0,0 +1 @@
+ALTER TABLE tracks DROP COLUMN IF EXISTS tags;
@@ -0,0 +1 @@
+ALTER TABLE tracks ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS feed_sources (
+    user_id INT NOT NULL,
+    actor_id INT NOT NULL,
+    backfilled_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, actor_id)
+);
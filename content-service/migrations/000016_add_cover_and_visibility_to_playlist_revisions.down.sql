@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_playlist_revisions_image;
+ALTER TABLE playlist_revisions DROP COLUMN IF EXISTS visibility;
+ALTER TABLE playlist_revisions DROP COLUMN IF EXISTS image_auto;
+ALTER TABLE playlist_revisions DROP COLUMN IF EXISTS image;
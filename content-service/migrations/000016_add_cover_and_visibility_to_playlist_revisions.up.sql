@@ -0,0 +1,5 @@
+ALTER TABLE playlist_revisions ADD COLUMN image TEXT;
+ALTER TABLE playlist_revisions ADD COLUMN image_auto BOOLEAN;
+ALTER TABLE playlist_revisions ADD COLUMN visibility TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_playlist_revisions_image ON playlist_revisions(playlist_id, image) WHERE image_auto = FALSE;
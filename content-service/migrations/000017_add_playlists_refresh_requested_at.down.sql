@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_playlists_refresh_requested_at;
+ALTER TABLE playlists DROP COLUMN IF EXISTS refresh_requested_at;
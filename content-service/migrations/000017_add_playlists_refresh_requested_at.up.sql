@@ -0,0 +1,5 @@
+ALTER TABLE playlists ADD COLUMN refresh_requested_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_playlists_refresh_requested_at ON playlists(refresh_requested_at) WHERE refresh_requested_at IS NOT NULL;
+
+UPDATE playlists SET refresh_requested_at = NOW() WHERE rules IS NOT NULL;
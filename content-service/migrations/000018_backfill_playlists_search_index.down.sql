@@ -0,0 +1 @@
+-- Queued index events may already be published, so there is nothing to revert.
@@ -0,0 +1,8 @@
+INSERT INTO outbox (topic, key, payload)
+SELECT
+    'search-index-events',
+    'playlist:' || id,
+    jsonb_build_object('type', 'playlist', 'upsert', jsonb_build_object('id', id::TEXT, 'name', title))
+FROM playlists
+WHERE visibility = 'public'
+ORDER BY id;
@@ -0,0 +1,1799 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: searchservice/searchservice.proto
+
+package searchservice
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SearchRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Query          string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Limit          int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset         int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	TrackFilters   *TrackFilters          `protobuf:"bytes,4,opt,name=track_filters,json=trackFilters,proto3" json:"track_filters,omitempty"`
+	Cursor         string                 `protobuf:"bytes,5,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Highlight      bool                   `protobuf:"varint,6,opt,name=highlight,proto3" json:"highlight,omitempty"`
+	AutoCorrect    bool                   `protobuf:"varint,7,opt,name=auto_correct,json=autoCorrect,proto3" json:"auto_correct,omitempty"`
+	SkipCorrection bool                   `protobuf:"varint,8,opt,name=skip_correction,json=skipCorrection,proto3" json:"skip_correction,omitempty"`
+	Correction     string                 `protobuf:"bytes,9,opt,name=correction,proto3" json:"correction,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SearchRequest) Reset() {
+	*x = SearchRequest{}
+	mi := &file_searchservice_searchservice_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRequest) ProtoMessage() {}
+
+func (x *SearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_searchservice_searchservice_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return file_searchservice_searchservice_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SearchRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetTrackFilters() *TrackFilters {
+	if x != nil {
+		return x.TrackFilters
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetHighlight() bool {
+	if x != nil {
+		return x.Highlight
+	}
+	return false
+}
+
+func (x *SearchRequest) GetAutoCorrect() bool {
+	if x != nil {
+		return x.AutoCorrect
+	}
+	return false
+}
+
+func (x *SearchRequest) GetSkipCorrection() bool {
+	if x != nil {
+		return x.SkipCorrection
+	}
+	return false
+}
+
+func (x *SearchRequest) GetCorrection() string {
+	if x != nil {
+		return x.Correction
+	}
+	return ""
+}
+
+type TrackFilters struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Genres         []string               `protobuf:"bytes,1,rep,name=genres,proto3" json:"genres,omitempty"`
+	Tags           []string               `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	MinDuration    int64                  `protobuf:"varint,3,opt,name=min_duration,json=minDuration,proto3" json:"min_duration,omitempty"`
+	MaxDuration    int64                  `protobuf:"varint,4,opt,name=max_duration,json=maxDuration,proto3" json:"max_duration,omitempty"`
+	UploadedAfter  int64                  `protobuf:"varint,5,opt,name=uploaded_after,json=uploadedAfter,proto3" json:"uploaded_after,omitempty"`
+	UploadedBefore int64                  `protobuf:"varint,6,opt,name=uploaded_before,json=uploadedBefore,proto3" json:"uploaded_before,omitempty"`
+	ArtistIds      []int64                `protobuf:"varint,7,rep,packed,name=artist_ids,json=artistIds,proto3" json:"artist_ids,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TrackFilters) Reset() {
+	*x = TrackFilters{}
+	mi := &file_searchservice_searchservice_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrackFilters) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrackFilters) ProtoMessage() {}
+
+func (x *TrackFilters) ProtoReflect() protoreflect.Message {
+	mi := &file_searchservice_searchservice_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrackFilters.ProtoReflect.Descriptor instead.
+func (*TrackFilters) Descriptor() ([]byte, []int) {
+	return file_searchservice_searchservice_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TrackFilters) GetGenres() []string {
+	if x != nil {
+		return x.Genres
+	}
+	return nil
+}
+
+func (x *TrackFilters) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *TrackFilters) GetMinDuration() int64 {
+	if x != nil {
+		return x.MinDuration
+	}
+	return 0
+}
+
+func (x *TrackFilters) GetMaxDuration() int64 {
+	if x != nil {
+		return x.MaxDuration
+	}
+	return 0
+}
+
+func (x *TrackFilters) GetUploadedAfter() int64 {
+	if x != nil {
+		return x.UploadedAfter
+	}
+	return 0
+}
+
+func (x *TrackFilters) GetUploadedBefore() int64 {
+	if x != nil {
+		return x.UploadedBefore
+	}
+	return 0
+}
+
+func (x *TrackFilters) GetArtistIds() []int64 {
+	if x != nil {
+		return x.ArtistIds
+	}
+	return nil
+}
+
+type SearchResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Ids            []int64                `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	Total          int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Facets         *Facets                `protobuf:"bytes,3,opt,name=facets,proto3" json:"facets,omitempty"`
+	NextCursor     string                 `protobuf:"bytes,4,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	Hits           []*SearchHit           `protobuf:"bytes,5,rep,name=hits,proto3" json:"hits,omitempty"`
+	QueryId        string                 `protobuf:"bytes,6,opt,name=query_id,json=queryId,proto3" json:"query_id,omitempty"`
+	SuggestedQuery string                 `protobuf:"bytes,7,opt,name=suggested_query,json=suggestedQuery,proto3" json:"suggested_query,omitempty"`
+	Corrected      bool                   `protobuf:"varint,8,opt,name=corrected,proto3" json:"corrected,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SearchResponse) Reset() {
+	*x = SearchResponse{}
+	mi := &file_searchservice_searchservice_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResponse) ProtoMessage() {}
+
+func (x *SearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_searchservice_searchservice_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResponse.ProtoReflect.Descriptor instead.
+func (*SearchResponse) Descriptor() ([]byte, []int) {
+	return file_searchservice_searchservice_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SearchResponse) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *SearchResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *SearchResponse) GetFacets() *Facets {
+	if x != nil {
+		return x.Facets
+	}
+	return nil
+}
+
+func (x *SearchResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *SearchResponse) GetHits() []*SearchHit {
+	if x != nil {
+		return x.Hits
+	}
+	return nil
+}
+
+func (x *SearchResponse) GetQueryId() string {
+	if x != nil {
+		return x.QueryId
+	}
+	return ""
+}
+
+func (x *SearchResponse) GetSuggestedQuery() string {
+	if x != nil {
+		return x.SuggestedQuery
+	}
+	return ""
+}
+
+func (x *SearchResponse) GetCorrected() bool {
+	if x != nil {
+		return x.Corrected
+	}
+	return false
+}
+
+type SearchHit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	Highlights    []string               `protobuf:"bytes,3,rep,name=highlights,proto3" json:"highlights,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchHit) Reset() {
+	*x = SearchHit{}
+	mi := &file_searchservice_searchservice_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchHit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchHit) ProtoMessage() {}
+
+func (x *SearchHit) ProtoReflect() protoreflect.Message {
+	mi := &file_searchservice_searchservice_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchHit.ProtoReflect.Descriptor instead.
+func (*SearchHit) Descriptor() ([]byte, []int) {
+	return file_searchservice_searchservice_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SearchHit) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SearchHit) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *SearchHit) GetHighlights() []string {
+	if x != nil {
+		return x.Highlights
+	}
+	return nil
+}
+
+type Facets struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Genres        []*FacetBucket         `protobuf:"bytes,1,rep,name=genres,proto3" json:"genres,omitempty"`
+	Durations     []*FacetBucket         `protobuf:"bytes,2,rep,name=durations,proto3" json:"durations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Facets) Reset() {
+	*x = Facets{}
+	mi := &file_searchservice_searchservice_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Facets) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Facets) ProtoMessage() {}
+
+func (x *Facets) ProtoReflect() protoreflect.Message {
+	mi := &file_searchservice_searchservice_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Facets.ProtoReflect.Descriptor instead.
+func (*Facets) Descriptor() ([]byte, []int) {
+	return file_searchservice_searchservice_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Facets) GetGenres() []*FacetBucket {
+	if x != nil {
+		return x.Genres
+	}
+	return nil
+}
+
+func (x *Facets) GetDurations() []*FacetBucket {
+	if x != nil {
+		return x.Durations
+	}
+	return nil
+}
+
+type FacetBucket struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	From          int64                  `protobuf:"varint,3,opt,name=from,proto3" json:"from,omitempty"`
+	To            int64                  `protobuf:"varint,4,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FacetBucket) Reset() {
+	*x = FacetBucket{}
+	mi := &file_searchservice_searchservice_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FacetBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FacetBucket) ProtoMessage() {}
+
+func (x *FacetBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_searchservice_searchservice_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FacetBucket.ProtoReflect.Descriptor instead.
+func (*FacetBucket) Descriptor() ([]byte, []int) {
+	return file_searchservice_searchservice_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *FacetBucket) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *FacetBucket) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *FacetBucket) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *FacetBucket) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+type SuggestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestRequest) Reset() {
+	*x = SuggestRequest{}
+	mi := &file_searchservice_searchservice_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestRequest) ProtoMessage() {}
+
+func (x *SuggestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_searchservice_searchservice_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestRequest.ProtoReflect.Descriptor instead.
+func (*SuggestRequest) Descriptor() ([]byte, []int) {
+	return file_searchservice_searchservice_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SuggestRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SuggestRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type Suggestion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Id            int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	Score         float64                `protobuf:"fixed64,4,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Suggestion) Reset() {
+	*x = Suggestion{}
+	mi := &file_searchservice_searchservice_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Suggestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Suggestion) ProtoMessage() {}
+
+func (x *Suggestion) ProtoReflect() protoreflect.Message {
+	mi := &file_searchservice_searchservice_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Suggestion.ProtoReflect.Descriptor instead.
+func (*Suggestion) Descriptor() ([]byte, []int) {
+	return file_searchservice_searchservice_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Suggestion) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Suggestion) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Suggestion) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Suggestion) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type SuggestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Suggestions   []*Suggestion          `protobuf:"bytes,1,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestResponse) Reset() {
+	*x = SuggestResponse{}
+	mi := &file_searchservice_searchservice_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestResponse) ProtoMessage() {}
+
+func (x *SuggestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_searchservice_searchservice_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestResponse.ProtoReflect.Descriptor instead.
+func (*SuggestResponse) Descriptor() ([]byte, []int) {
+	return file_searchservice_searchservice_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SuggestResponse) GetSuggestions() []*Suggestion {
+	if x != nil {
+		return x.Suggestions
+	}
+	return nil
+}
+
+type CorrectQueryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Types         []string               `protobuf:"bytes,2,rep,name=types,proto3" json:"types,omitempty"`
+	Hits          int64                  `protobuf:"varint,3,opt,name=hits,proto3" json:"hits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CorrectQueryRequest) Reset() {
+	*x = CorrectQueryRequest{}
+	mi := &file_searchservice_searchservice_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CorrectQueryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CorrectQueryRequest) ProtoMessage() {}
+
+func (x *CorrectQueryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_searchservice_searchservice_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CorrectQueryRequest.ProtoReflect.Descriptor instead.
+func (*CorrectQueryRequest) Descriptor() ([]byte, []int) {
+	return file_searchservice_searchservice_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CorrectQueryRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *CorrectQueryRequest) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *CorrectQueryRequest) GetHits() int64 {
+	if x != nil {
+		return x.Hits
+	}
+	return 0
+}
+
+type CorrectQueryResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SuggestedQuery string                 `protobuf:"bytes,1,opt,name=suggested_query,json=suggestedQuery,proto3" json:"suggested_query,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CorrectQueryResponse) Reset() {
+	*x = CorrectQueryResponse{}
+	mi := &file_searchservice_searchservice_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CorrectQueryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CorrectQueryResponse) ProtoMessage() {}
+
+func (x *CorrectQueryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_searchservice_searchservice_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CorrectQueryResponse.ProtoReflect.Descriptor instead.
+func (*CorrectQueryResponse) Descriptor() ([]byte, []int) {
+	return file_searchservice_searchservice_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CorrectQueryResponse) GetSuggestedQuery() string {
+	if x != nil {
+		return x.SuggestedQuery
+	}
+	return ""
+}
+
+type AddOrUpdateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Track         *TrackAttributes       `protobuf:"bytes,3,opt,name=track,proto3" json:"track,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddOrUpdateRequest) Reset() {
+	*x = AddOrUpdateRequest{}
+	mi := &file_searchservice_searchservice_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddOrUpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddOrUpdateRequest) ProtoMessage() {}
+
+func (x *AddOrUpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_searchservice_searchservice_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddOrUpdateRequest.ProtoReflect.Descriptor instead.
+func (*AddOrUpdateRequest) Descriptor() ([]byte, []int) {
+	return file_searchservice_searchservice_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AddOrUpdateRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AddOrUpdateRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AddOrUpdateRequest) GetTrack() *TrackAttributes {
+	if x != nil {
+		return x.Track
+	}
+	return nil
+}
+
+type TrackAttributes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Genre         string                 `protobuf:"bytes,1,opt,name=genre,proto3" json:"genre,omitempty"`
+	Tags          []string               `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	Duration      int64                  `protobuf:"varint,3,opt,name=duration,proto3" json:"duration,omitempty"`
+	UserId        int64                  `protobuf:"varint,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrackAttributes) Reset() {
+	*x = TrackAttributes{}
+	mi := &file_searchservice_searchservice_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrackAttributes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrackAttributes) ProtoMessage() {}
+
+func (x *TrackAttributes) ProtoReflect() protoreflect.Message {
+	mi := &file_searchservice_searchservice_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrackAttributes.ProtoReflect.Descriptor instead.
+func (*TrackAttributes) Descriptor() ([]byte, []int) {
+	return file_searchservice_searchservice_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TrackAttributes) GetGenre() string {
+	if x != nil {
+		return x.Genre
+	}
+	return ""
+}
+
+func (x *TrackAttributes) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *TrackAttributes) GetDuration() int64 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *TrackAttributes) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type DeleteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRequest) Reset() {
+	*x = DeleteRequest{}
+	mi := &file_searchservice_searchservice_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRequest) ProtoMessage() {}
+
+func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_searchservice_searchservice_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
+	return file_searchservice_searchservice_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DeleteRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DocumentSignals struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Plays         int64                  `protobuf:"varint,2,opt,name=plays,proto3" json:"plays,omitempty"`
+	Likes         int64                  `protobuf:"varint,3,opt,name=likes,proto3" json:"likes,omitempty"`
+	Followers     int64                  `protobuf:"varint,4,opt,name=followers,proto3" json:"followers,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DocumentSignals) Reset() {
+	*x = DocumentSignals{}
+	mi := &file_searchservice_searchservice_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DocumentSignals) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentSignals) ProtoMessage() {}
+
+func (x *DocumentSignals) ProtoReflect() protoreflect.Message {
+	mi := &file_searchservice_searchservice_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocumentSignals.ProtoReflect.Descriptor instead.
+func (*DocumentSignals) Descriptor() ([]byte, []int) {
+	return file_searchservice_searchservice_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DocumentSignals) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DocumentSignals) GetPlays() int64 {
+	if x != nil {
+		return x.Plays
+	}
+	return 0
+}
+
+func (x *DocumentSignals) GetLikes() int64 {
+	if x != nil {
+		return x.Likes
+	}
+	return 0
+}
+
+func (x *DocumentSignals) GetFollowers() int64 {
+	if x != nil {
+		return x.Followers
+	}
+	return 0
+}
+
+func (x *DocumentSignals) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type UpdateSignalsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Signals       []*DocumentSignals     `protobuf:"bytes,2,rep,name=signals,proto3" json:"signals,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSignalsRequest) Reset() {
+	*x = UpdateSignalsRequest{}
+	mi := &file_searchservice_searchservice_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSignalsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSignalsRequest) ProtoMessage() {}
+
+func (x *UpdateSignalsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_searchservice_searchservice_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSignalsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSignalsRequest) Descriptor() ([]byte, []int) {
+	return file_searchservice_searchservice_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *UpdateSignalsRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *UpdateSignalsRequest) GetSignals() []*DocumentSignals {
+	if x != nil {
+		return x.Signals
+	}
+	return nil
+}
+
+type IndexEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// Types that are valid to be assigned to Change:
+	//
+	//	*IndexEvent_Upsert
+	//	*IndexEvent_Delete
+	//	*IndexEvent_Signals
+	Change        isIndexEvent_Change `protobuf_oneof:"change"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IndexEvent) Reset() {
+	*x = IndexEvent{}
+	mi := &file_searchservice_searchservice_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IndexEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IndexEvent) ProtoMessage() {}
+
+func (x *IndexEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_searchservice_searchservice_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IndexEvent.ProtoReflect.Descriptor instead.
+func (*IndexEvent) Descriptor() ([]byte, []int) {
+	return file_searchservice_searchservice_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *IndexEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *IndexEvent) GetChange() isIndexEvent_Change {
+	if x != nil {
+		return x.Change
+	}
+	return nil
+}
+
+func (x *IndexEvent) GetUpsert() *AddOrUpdateRequest {
+	if x != nil {
+		if x, ok := x.Change.(*IndexEvent_Upsert); ok {
+			return x.Upsert
+		}
+	}
+	return nil
+}
+
+func (x *IndexEvent) GetDelete() *DeleteRequest {
+	if x != nil {
+		if x, ok := x.Change.(*IndexEvent_Delete); ok {
+			return x.Delete
+		}
+	}
+	return nil
+}
+
+func (x *IndexEvent) GetSignals() *UpdateSignalsRequest {
+	if x != nil {
+		if x, ok := x.Change.(*IndexEvent_Signals); ok {
+			return x.Signals
+		}
+	}
+	return nil
+}
+
+type isIndexEvent_Change interface {
+	isIndexEvent_Change()
+}
+
+type IndexEvent_Upsert struct {
+	Upsert *AddOrUpdateRequest `protobuf:"bytes,2,opt,name=upsert,proto3,oneof"`
+}
+
+type IndexEvent_Delete struct {
+	Delete *DeleteRequest `protobuf:"bytes,3,opt,name=delete,proto3,oneof"`
+}
+
+type IndexEvent_Signals struct {
+	Signals *UpdateSignalsRequest `protobuf:"bytes,4,opt,name=signals,proto3,oneof"`
+}
+
+func (*IndexEvent_Upsert) isIndexEvent_Change() {}
+
+func (*IndexEvent_Delete) isIndexEvent_Change() {}
+
+func (*IndexEvent_Signals) isIndexEvent_Change() {}
+
+type ClickRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	QueryId       string                 `protobuf:"bytes,1,opt,name=query_id,json=queryId,proto3" json:"query_id,omitempty"`
+	DocumentId    int64                  `protobuf:"varint,2,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	Position      int32                  `protobuf:"varint,3,opt,name=position,proto3" json:"position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClickRequest) Reset() {
+	*x = ClickRequest{}
+	mi := &file_searchservice_searchservice_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClickRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClickRequest) ProtoMessage() {}
+
+func (x *ClickRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_searchservice_searchservice_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClickRequest.ProtoReflect.Descriptor instead.
+func (*ClickRequest) Descriptor() ([]byte, []int) {
+	return file_searchservice_searchservice_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ClickRequest) GetQueryId() string {
+	if x != nil {
+		return x.QueryId
+	}
+	return ""
+}
+
+func (x *ClickRequest) GetDocumentId() int64 {
+	if x != nil {
+		return x.DocumentId
+	}
+	return 0
+}
+
+func (x *ClickRequest) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+type AnalyticsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          int64                  `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            int64                  `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnalyticsRequest) Reset() {
+	*x = AnalyticsRequest{}
+	mi := &file_searchservice_searchservice_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalyticsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalyticsRequest) ProtoMessage() {}
+
+func (x *AnalyticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_searchservice_searchservice_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalyticsRequest.ProtoReflect.Descriptor instead.
+func (*AnalyticsRequest) Descriptor() ([]byte, []int) {
+	return file_searchservice_searchservice_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AnalyticsRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *AnalyticsRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *AnalyticsRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *AnalyticsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type QueryStat struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Query            string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Searches         int64                  `protobuf:"varint,2,opt,name=searches,proto3" json:"searches,omitempty"`
+	ClickedSearches  int64                  `protobuf:"varint,3,opt,name=clicked_searches,json=clickedSearches,proto3" json:"clicked_searches,omitempty"`
+	ClickThroughRate float64                `protobuf:"fixed64,4,opt,name=click_through_rate,json=clickThroughRate,proto3" json:"click_through_rate,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *QueryStat) Reset() {
+	*x = QueryStat{}
+	mi := &file_searchservice_searchservice_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryStat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryStat) ProtoMessage() {}
+
+func (x *QueryStat) ProtoReflect() protoreflect.Message {
+	mi := &file_searchservice_searchservice_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryStat.ProtoReflect.Descriptor instead.
+func (*QueryStat) Descriptor() ([]byte, []int) {
+	return file_searchservice_searchservice_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *QueryStat) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *QueryStat) GetSearches() int64 {
+	if x != nil {
+		return x.Searches
+	}
+	return 0
+}
+
+func (x *QueryStat) GetClickedSearches() int64 {
+	if x != nil {
+		return x.ClickedSearches
+	}
+	return 0
+}
+
+func (x *QueryStat) GetClickThroughRate() float64 {
+	if x != nil {
+		return x.ClickThroughRate
+	}
+	return 0
+}
+
+type QueryStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Queries       []*QueryStat           `protobuf:"bytes,1,rep,name=queries,proto3" json:"queries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryStatsResponse) Reset() {
+	*x = QueryStatsResponse{}
+	mi := &file_searchservice_searchservice_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryStatsResponse) ProtoMessage() {}
+
+func (x *QueryStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_searchservice_searchservice_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryStatsResponse.ProtoReflect.Descriptor instead.
+func (*QueryStatsResponse) Descriptor() ([]byte, []int) {
+	return file_searchservice_searchservice_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *QueryStatsResponse) GetQueries() []*QueryStat {
+	if x != nil {
+		return x.Queries
+	}
+	return nil
+}
+
+type ClickThroughRateResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Searches         int64                  `protobuf:"varint,1,opt,name=searches,proto3" json:"searches,omitempty"`
+	ClickedSearches  int64                  `protobuf:"varint,2,opt,name=clicked_searches,json=clickedSearches,proto3" json:"clicked_searches,omitempty"`
+	ClickThroughRate float64                `protobuf:"fixed64,3,opt,name=click_through_rate,json=clickThroughRate,proto3" json:"click_through_rate,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ClickThroughRateResponse) Reset() {
+	*x = ClickThroughRateResponse{}
+	mi := &file_searchservice_searchservice_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClickThroughRateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClickThroughRateResponse) ProtoMessage() {}
+
+func (x *ClickThroughRateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_searchservice_searchservice_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClickThroughRateResponse.ProtoReflect.Descriptor instead.
+func (*ClickThroughRateResponse) Descriptor() ([]byte, []int) {
+	return file_searchservice_searchservice_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ClickThroughRateResponse) GetSearches() int64 {
+	if x != nil {
+		return x.Searches
+	}
+	return 0
+}
+
+func (x *ClickThroughRateResponse) GetClickedSearches() int64 {
+	if x != nil {
+		return x.ClickedSearches
+	}
+	return 0
+}
+
+func (x *ClickThroughRateResponse) GetClickThroughRate() float64 {
+	if x != nil {
+		return x.ClickThroughRate
+	}
+	return 0
+}
+
+type SuccessResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuccessResponse) Reset() {
+	*x = SuccessResponse{}
+	mi := &file_searchservice_searchservice_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuccessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuccessResponse) ProtoMessage() {}
+
+func (x *SuccessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_searchservice_searchservice_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuccessResponse.ProtoReflect.Descriptor instead.
+func (*SuccessResponse) Descriptor() ([]byte, []int) {
+	return file_searchservice_searchservice_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SuccessResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+var File_searchservice_searchservice_proto protoreflect.FileDescriptor
+
+const file_searchservice_searchservice_proto_rawDesc = "" +
+	"\n" +
+	"!searchservice/searchservice.proto\x12\rsearchservice\"\xb7\x02\n" +
+	"\rSearchRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12@\n" +
+	"\rtrack_filters\x18\x04 \x01(\v2\x1b.searchservice.TrackFiltersR\ftrackFilters\x12\x16\n" +
+	"\x06cursor\x18\x05 \x01(\tR\x06cursor\x12\x1c\n" +
+	"\thighlight\x18\x06 \x01(\bR\thighlight\x12!\n" +
+	"\fauto_correct\x18\a \x01(\bR\vautoCorrect\x12'\n" +
+	"\x0fskip_correction\x18\b \x01(\bR\x0eskipCorrection\x12\x1e\n" +
+	"\n" +
+	"correction\x18\t \x01(\tR\n" +
+	"correction\"\xef\x01\n" +
+	"\fTrackFilters\x12\x16\n" +
+	"\x06genres\x18\x01 \x03(\tR\x06genres\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\x12!\n" +
+	"\fmin_duration\x18\x03 \x01(\x03R\vminDuration\x12!\n" +
+	"\fmax_duration\x18\x04 \x01(\x03R\vmaxDuration\x12%\n" +
+	"\x0euploaded_after\x18\x05 \x01(\x03R\ruploadedAfter\x12'\n" +
+	"\x0fuploaded_before\x18\x06 \x01(\x03R\x0euploadedBefore\x12\x1d\n" +
+	"\n" +
+	"artist_ids\x18\a \x03(\x03R\tartistIds\"\x98\x02\n" +
+	"\x0eSearchResponse\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x03R\x03ids\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12-\n" +
+	"\x06facets\x18\x03 \x01(\v2\x15.searchservice.FacetsR\x06facets\x12\x1f\n" +
+	"\vnext_cursor\x18\x04 \x01(\tR\n" +
+	"nextCursor\x12,\n" +
+	"\x04hits\x18\x05 \x03(\v2\x18.searchservice.SearchHitR\x04hits\x12\x19\n" +
+	"\bquery_id\x18\x06 \x01(\tR\aqueryId\x12'\n" +
+	"\x0fsuggested_query\x18\a \x01(\tR\x0esuggestedQuery\x12\x1c\n" +
+	"\tcorrected\x18\b \x01(\bR\tcorrected\"Q\n" +
+	"\tSearchHit\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x12\x1e\n" +
+	"\n" +
+	"highlights\x18\x03 \x03(\tR\n" +
+	"highlights\"v\n" +
+	"\x06Facets\x122\n" +
+	"\x06genres\x18\x01 \x03(\v2\x1a.searchservice.FacetBucketR\x06genres\x128\n" +
+	"\tdurations\x18\x02 \x03(\v2\x1a.searchservice.FacetBucketR\tdurations\"Y\n" +
+	"\vFacetBucket\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\x12\x12\n" +
+	"\x04from\x18\x03 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x04 \x01(\x03R\x02to\"<\n" +
+	"\x0eSuggestRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"Z\n" +
+	"\n" +
+	"Suggestion\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\x12\x14\n" +
+	"\x05score\x18\x04 \x01(\x01R\x05score\"N\n" +
+	"\x0fSuggestResponse\x12;\n" +
+	"\vsuggestions\x18\x01 \x03(\v2\x19.searchservice.SuggestionR\vsuggestions\"U\n" +
+	"\x13CorrectQueryRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
+	"\x05types\x18\x02 \x03(\tR\x05types\x12\x12\n" +
+	"\x04hits\x18\x03 \x01(\x03R\x04hits\"?\n" +
+	"\x14CorrectQueryResponse\x12'\n" +
+	"\x0fsuggested_query\x18\x01 \x01(\tR\x0esuggestedQuery\"n\n" +
+	"\x12AddOrUpdateRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x124\n" +
+	"\x05track\x18\x03 \x01(\v2\x1e.searchservice.TrackAttributesR\x05track\"p\n" +
+	"\x0fTrackAttributes\x12\x14\n" +
+	"\x05genre\x18\x01 \x01(\tR\x05genre\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\x12\x1a\n" +
+	"\bduration\x18\x03 \x01(\x03R\bduration\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\x03R\x06userId\"\x1f\n" +
+	"\rDeleteRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x8a\x01\n" +
+	"\x0fDocumentSignals\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05plays\x18\x02 \x01(\x03R\x05plays\x12\x14\n" +
+	"\x05likes\x18\x03 \x01(\x03R\x05likes\x12\x1c\n" +
+	"\tfollowers\x18\x04 \x01(\x03R\tfollowers\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\x03R\tcreatedAt\"d\n" +
+	"\x14UpdateSignalsRequest\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x128\n" +
+	"\asignals\x18\x02 \x03(\v2\x1e.searchservice.DocumentSignalsR\asignals\"\xe0\x01\n" +
+	"\n" +
+	"IndexEvent\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12;\n" +
+	"\x06upsert\x18\x02 \x01(\v2!.searchservice.AddOrUpdateRequestH\x00R\x06upsert\x126\n" +
+	"\x06delete\x18\x03 \x01(\v2\x1c.searchservice.DeleteRequestH\x00R\x06delete\x12?\n" +
+	"\asignals\x18\x04 \x01(\v2#.searchservice.UpdateSignalsRequestH\x00R\asignalsB\b\n" +
+	"\x06change\"f\n" +
+	"\fClickRequest\x12\x19\n" +
+	"\bquery_id\x18\x01 \x01(\tR\aqueryId\x12\x1f\n" +
+	"\vdocument_id\x18\x02 \x01(\x03R\n" +
+	"documentId\x12\x1a\n" +
+	"\bposition\x18\x03 \x01(\x05R\bposition\"`\n" +
+	"\x10AnalyticsRequest\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\x03R\x02to\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"\x96\x01\n" +
+	"\tQueryStat\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1a\n" +
+	"\bsearches\x18\x02 \x01(\x03R\bsearches\x12)\n" +
+	"\x10clicked_searches\x18\x03 \x01(\x03R\x0fclickedSearches\x12,\n" +
+	"\x12click_through_rate\x18\x04 \x01(\x01R\x10clickThroughRate\"H\n" +
+	"\x12QueryStatsResponse\x122\n" +
+	"\aqueries\x18\x01 \x03(\v2\x18.searchservice.QueryStatR\aqueries\"\x8f\x01\n" +
+	"\x18ClickThroughRateResponse\x12\x1a\n" +
+	"\bsearches\x18\x01 \x01(\x03R\bsearches\x12)\n" +
+	"\x10clicked_searches\x18\x02 \x01(\x03R\x0fclickedSearches\x12,\n" +
+	"\x12click_through_rate\x18\x03 \x01(\x01R\x10clickThroughRate\"+\n" +
+	"\x0fSuccessResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess2\xda\x0e\n" +
+	"\rSearchService\x12J\n" +
+	"\vSearchUsers\x12\x1c.searchservice.SearchRequest\x1a\x1d.searchservice.SearchResponse\x12K\n" +
+	"\fSearchAlbums\x12\x1c.searchservice.SearchRequest\x1a\x1d.searchservice.SearchResponse\x12K\n" +
+	"\fSearchTracks\x12\x1c.searchservice.SearchRequest\x1a\x1d.searchservice.SearchResponse\x12N\n" +
+	"\x0fSearchPlaylists\x12\x1c.searchservice.SearchRequest\x1a\x1d.searchservice.SearchResponse\x12H\n" +
+	"\aSuggest\x12\x1d.searchservice.SuggestRequest\x1a\x1e.searchservice.SuggestResponse\x12W\n" +
+	"\fCorrectQuery\x12\".searchservice.CorrectQueryRequest\x1a#.searchservice.CorrectQueryResponse\x12L\n" +
+	"\aAddUser\x12!.searchservice.AddOrUpdateRequest\x1a\x1e.searchservice.SuccessResponse\x12M\n" +
+	"\bAddAlbum\x12!.searchservice.AddOrUpdateRequest\x1a\x1e.searchservice.SuccessResponse\x12M\n" +
+	"\bAddTrack\x12!.searchservice.AddOrUpdateRequest\x1a\x1e.searchservice.SuccessResponse\x12P\n" +
+	"\vAddPlaylist\x12!.searchservice.AddOrUpdateRequest\x1a\x1e.searchservice.SuccessResponse\x12O\n" +
+	"\n" +
+	"UpdateUser\x12!.searchservice.AddOrUpdateRequest\x1a\x1e.searchservice.SuccessResponse\x12P\n" +
+	"\vUpdateAlbum\x12!.searchservice.AddOrUpdateRequest\x1a\x1e.searchservice.SuccessResponse\x12P\n" +
+	"\vUpdateTrack\x12!.searchservice.AddOrUpdateRequest\x1a\x1e.searchservice.SuccessResponse\x12S\n" +
+	"\x0eUpdatePlaylist\x12!.searchservice.AddOrUpdateRequest\x1a\x1e.searchservice.SuccessResponse\x12J\n" +
+	"\n" +
+	"DeleteUser\x12\x1c.searchservice.DeleteRequest\x1a\x1e.searchservice.SuccessResponse\x12K\n" +
+	"\vDeleteTrack\x12\x1c.searchservice.DeleteRequest\x1a\x1e.searchservice.SuccessResponse\x12K\n" +
+	"\vDeleteAlbum\x12\x1c.searchservice.DeleteRequest\x1a\x1e.searchservice.SuccessResponse\x12N\n" +
+	"\x0eDeletePlaylist\x12\x1c.searchservice.DeleteRequest\x1a\x1e.searchservice.SuccessResponse\x12T\n" +
+	"\rUpdateSignals\x12#.searchservice.UpdateSignalsRequest\x1a\x1e.searchservice.SuccessResponse\x12J\n" +
+	"\vRecordClick\x12\x1b.searchservice.ClickRequest\x1a\x1e.searchservice.SuccessResponse\x12S\n" +
+	"\rGetTopQueries\x12\x1f.searchservice.AnalyticsRequest\x1a!.searchservice.QueryStatsResponse\x12Z\n" +
+	"\x14GetZeroResultQueries\x12\x1f.searchservice.AnalyticsRequest\x1a!.searchservice.QueryStatsResponse\x12_\n" +
+	"\x13GetClickThroughRate\x12\x1f.searchservice.AnalyticsRequest\x1a'.searchservice.ClickThroughRateResponseB\xac\x01\n" +
+	"\x11com.searchserviceB\x12SearchserviceProtoP\x01Z/github.com/ocenb/music-protos/gen/searchservice\xa2\x02\x03SXX\xaa\x02\rSearchservice\xca\x02\rSearchservice\xe2\x02\x19Searchservice\\GPBMetadata\xea\x02\rSearchserviceb\x06proto3"
+
+var (
+	file_searchservice_searchservice_proto_rawDescOnce sync.Once
+	file_searchservice_searchservice_proto_rawDescData []byte
+)
+
+func file_searchservice_searchservice_proto_rawDescGZIP() []byte {
+	file_searchservice_searchservice_proto_rawDescOnce.Do(func() {
+		file_searchservice_searchservice_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_searchservice_searchservice_proto_rawDesc), len(file_searchservice_searchservice_proto_rawDesc)))
+	})
+	return file_searchservice_searchservice_proto_rawDescData
+}
+
+var file_searchservice_searchservice_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_searchservice_searchservice_proto_goTypes = []any{
+	(*SearchRequest)(nil),            // 0: searchservice.SearchRequest
+	(*TrackFilters)(nil),             // 1: searchservice.TrackFilters
+	(*SearchResponse)(nil),           // 2: searchservice.SearchResponse
+	(*SearchHit)(nil),                // 3: searchservice.SearchHit
+	(*Facets)(nil),                   // 4: searchservice.Facets
+	(*FacetBucket)(nil),              // 5: searchservice.FacetBucket
+	(*SuggestRequest)(nil),           // 6: searchservice.SuggestRequest
+	(*Suggestion)(nil),               // 7: searchservice.Suggestion
+	(*SuggestResponse)(nil),          // 8: searchservice.SuggestResponse
+	(*CorrectQueryRequest)(nil),      // 9: searchservice.CorrectQueryRequest
+	(*CorrectQueryResponse)(nil),     // 10: searchservice.CorrectQueryResponse
+	(*AddOrUpdateRequest)(nil),       // 11: searchservice.AddOrUpdateRequest
+	(*TrackAttributes)(nil),          // 12: searchservice.TrackAttributes
+	(*DeleteRequest)(nil),            // 13: searchservice.DeleteRequest
+	(*DocumentSignals)(nil),          // 14: searchservice.DocumentSignals
+	(*UpdateSignalsRequest)(nil),     // 15: searchservice.UpdateSignalsRequest
+	(*IndexEvent)(nil),               // 16: searchservice.IndexEvent
+	(*ClickRequest)(nil),             // 17: searchservice.ClickRequest
+	(*AnalyticsRequest)(nil),         // 18: searchservice.AnalyticsRequest
+	(*QueryStat)(nil),                // 19: searchservice.QueryStat
+	(*QueryStatsResponse)(nil),       // 20: searchservice.QueryStatsResponse
+	(*ClickThroughRateResponse)(nil), // 21: searchservice.ClickThroughRateResponse
+	(*SuccessResponse)(nil),          // 22: searchservice.SuccessResponse
+}
+var file_searchservice_searchservice_proto_depIdxs = []int32{
+	1,  // 0: searchservice.SearchRequest.track_filters:type_name -> searchservice.TrackFilters
+	4,  // 1: searchservice.SearchResponse.facets:type_name -> searchservice.Facets
+	3,  // 2: searchservice.SearchResponse.hits:type_name -> searchservice.SearchHit
+	5,  // 3: searchservice.Facets.genres:type_name -> searchservice.FacetBucket
+	5,  // 4: searchservice.Facets.durations:type_name -> searchservice.FacetBucket
+	7,  // 5: searchservice.SuggestResponse.suggestions:type_name -> searchservice.Suggestion
+	12, // 6: searchservice.AddOrUpdateRequest.track:type_name -> searchservice.TrackAttributes
+	14, // 7: searchservice.UpdateSignalsRequest.signals:type_name -> searchservice.DocumentSignals
+	11, // 8: searchservice.IndexEvent.upsert:type_name -> searchservice.AddOrUpdateRequest
+	13, // 9: searchservice.IndexEvent.delete:type_name -> searchservice.DeleteRequest
+	15, // 10: searchservice.IndexEvent.signals:type_name -> searchservice.UpdateSignalsRequest
+	19, // 11: searchservice.QueryStatsResponse.queries:type_name -> searchservice.QueryStat
+	0,  // 12: searchservice.SearchService.SearchUsers:input_type -> searchservice.SearchRequest
+	0,  // 13: searchservice.SearchService.SearchAlbums:input_type -> searchservice.SearchRequest
+	0,  // 14: searchservice.SearchService.SearchTracks:input_type -> searchservice.SearchRequest
+	0,  // 15: searchservice.SearchService.SearchPlaylists:input_type -> searchservice.SearchRequest
+	6,  // 16: searchservice.SearchService.Suggest:input_type -> searchservice.SuggestRequest
+	9,  // 17: searchservice.SearchService.CorrectQuery:input_type -> searchservice.CorrectQueryRequest
+	11, // 18: searchservice.SearchService.AddUser:input_type -> searchservice.AddOrUpdateRequest
+	11, // 19: searchservice.SearchService.AddAlbum:input_type -> searchservice.AddOrUpdateRequest
+	11, // 20: searchservice.SearchService.AddTrack:input_type -> searchservice.AddOrUpdateRequest
+	11, // 21: searchservice.SearchService.AddPlaylist:input_type -> searchservice.AddOrUpdateRequest
+	11, // 22: searchservice.SearchService.UpdateUser:input_type -> searchservice.AddOrUpdateRequest
+	11, // 23: searchservice.SearchService.UpdateAlbum:input_type -> searchservice.AddOrUpdateRequest
+	11, // 24: searchservice.SearchService.UpdateTrack:input_type -> searchservice.AddOrUpdateRequest
+	11, // 25: searchservice.SearchService.UpdatePlaylist:input_type -> searchservice.AddOrUpdateRequest
+	13, // 26: searchservice.SearchService.DeleteUser:input_type -> searchservice.DeleteRequest
+	13, // 27: searchservice.SearchService.DeleteTrack:input_type -> searchservice.DeleteRequest
+	13, // 28: searchservice.SearchService.DeleteAlbum:input_type -> searchservice.DeleteRequest
+	13, // 29: searchservice.SearchService.DeletePlaylist:input_type -> searchservice.DeleteRequest
+	15, // 30: searchservice.SearchService.UpdateSignals:input_type -> searchservice.UpdateSignalsRequest
+	17, // 31: searchservice.SearchService.RecordClick:input_type -> searchservice.ClickRequest
+	18, // 32: searchservice.SearchService.GetTopQueries:input_type -> searchservice.AnalyticsRequest
+	18, // 33: searchservice.SearchService.GetZeroResultQueries:input_type -> searchservice.AnalyticsRequest
+	18, // 34: searchservice.SearchService.GetClickThroughRate:input_type -> searchservice.AnalyticsRequest
+	2,  // 35: searchservice.SearchService.SearchUsers:output_type -> searchservice.SearchResponse
+	2,  // 36: searchservice.SearchService.SearchAlbums:output_type -> searchservice.SearchResponse
+	2,  // 37: searchservice.SearchService.SearchTracks:output_type -> searchservice.SearchResponse
+	2,  // 38: searchservice.SearchService.SearchPlaylists:output_type -> searchservice.SearchResponse
+	8,  // 39: searchservice.SearchService.Suggest:output_type -> searchservice.SuggestResponse
+	10, // 40: searchservice.SearchService.CorrectQuery:output_type -> searchservice.CorrectQueryResponse
+	22, // 41: searchservice.SearchService.AddUser:output_type -> searchservice.SuccessResponse
+	22, // 42: searchservice.SearchService.AddAlbum:output_type -> searchservice.SuccessResponse
+	22, // 43: searchservice.SearchService.AddTrack:output_type -> searchservice.SuccessResponse
+	22, // 44: searchservice.SearchService.AddPlaylist:output_type -> searchservice.SuccessResponse
+	22, // 45: searchservice.SearchService.UpdateUser:output_type -> searchservice.SuccessResponse
+	22, // 46: searchservice.SearchService.UpdateAlbum:output_type -> searchservice.SuccessResponse
+	22, // 47: searchservice.SearchService.UpdateTrack:output_type -> searchservice.SuccessResponse
+	22, // 48: searchservice.SearchService.UpdatePlaylist:output_type -> searchservice.SuccessResponse
+	22, // 49: searchservice.SearchService.DeleteUser:output_type -> searchservice.SuccessResponse
+	22, // 50: searchservice.SearchService.DeleteTrack:output_type -> searchservice.SuccessResponse
+	22, // 51: searchservice.SearchService.DeleteAlbum:output_type -> searchservice.SuccessResponse
+	22, // 52: searchservice.SearchService.DeletePlaylist:output_type -> searchservice.SuccessResponse
+	22, // 53: searchservice.SearchService.UpdateSignals:output_type -> searchservice.SuccessResponse
+	22, // 54: searchservice.SearchService.RecordClick:output_type -> searchservice.SuccessResponse
+	20, // 55: searchservice.SearchService.GetTopQueries:output_type -> searchservice.QueryStatsResponse
+	20, // 56: searchservice.SearchService.GetZeroResultQueries:output_type -> searchservice.QueryStatsResponse
+	21, // 57: searchservice.SearchService.GetClickThroughRate:output_type -> searchservice.ClickThroughRateResponse
+	35, // [35:58] is the sub-list for method output_type
+	12, // [12:35] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_searchservice_searchservice_proto_init() }
+func file_searchservice_searchservice_proto_init() {
+	if File_searchservice_searchservice_proto != nil {
+		return
+	}
+	file_searchservice_searchservice_proto_msgTypes[16].OneofWrappers = []any{
+		(*IndexEvent_Upsert)(nil),
+		(*IndexEvent_Delete)(nil),
+		(*IndexEvent_Signals)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_searchservice_searchservice_proto_rawDesc), len(file_searchservice_searchservice_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   23,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_searchservice_searchservice_proto_goTypes,
+		DependencyIndexes: file_searchservice_searchservice_proto_depIdxs,
+		MessageInfos:      file_searchservice_searchservice_proto_msgTypes,
+	}.Build()
+	File_searchservice_searchservice_proto = out.File
+	file_searchservice_searchservice_proto_goTypes = nil
+	file_searchservice_searchservice_proto_depIdxs = nil
+}
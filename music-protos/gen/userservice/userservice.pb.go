@@ -0,0 +1,2080 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: userservice/userservice.proto
+
+package userservice
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type UserPrivateModel struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,4,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserPrivateModel) Reset() {
+	*x = UserPrivateModel{}
+	mi := &file_userservice_userservice_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserPrivateModel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserPrivateModel) ProtoMessage() {}
+
+func (x *UserPrivateModel) ProtoReflect() protoreflect.Message {
+	mi := &file_userservice_userservice_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserPrivateModel.ProtoReflect.Descriptor instead.
+func (*UserPrivateModel) Descriptor() ([]byte, []int) {
+	return file_userservice_userservice_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UserPrivateModel) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UserPrivateModel) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *UserPrivateModel) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *UserPrivateModel) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type UserPublicModel struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Username       string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	FollowersCount int64                  `protobuf:"varint,3,opt,name=followersCount,proto3" json:"followersCount,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UserPublicModel) Reset() {
+	*x = UserPublicModel{}
+	mi := &file_userservice_userservice_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserPublicModel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserPublicModel) ProtoMessage() {}
+
+func (x *UserPublicModel) ProtoReflect() protoreflect.Message {
+	mi := &file_userservice_userservice_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserPublicModel.ProtoReflect.Descriptor instead.
+func (*UserPublicModel) Descriptor() ([]byte, []int) {
+	return file_userservice_userservice_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UserPublicModel) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UserPublicModel) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *UserPublicModel) GetFollowersCount() int64 {
+	if x != nil {
+		return x.FollowersCount
+	}
+	return 0
+}
+
+type RegisterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Password      string                 `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterRequest) Reset() {
+	*x = RegisterRequest{}
+	mi := &file_userservice_userservice_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterRequest) ProtoMessage() {}
+
+func (x *RegisterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_userservice_userservice_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterRequest.ProtoReflect.Descriptor instead.
+func (*RegisterRequest) Descriptor() ([]byte, []int) {
+	return file_userservice_userservice_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RegisterRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *RegisterRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *RegisterRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type RegisterResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *UserPrivateModel      `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterResponse) Reset() {
+	*x = RegisterResponse{}
+	mi := &file_userservice_userservice_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterResponse) ProtoMessage() {}
+
+func (x *RegisterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_userservice_userservice_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterResponse.ProtoReflect.Descriptor instead.
+func (*RegisterResponse) Descriptor() ([]byte, []int) {
+	return file_userservice_userservice_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RegisterResponse) GetUser() *UserPrivateModel {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type LoginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginRequest) Reset() {
+	*x = LoginRequest{}
+	mi := &file_userservice_userservice_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginRequest) ProtoMessage() {}
+
+func (x *LoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_userservice_userservice_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
+func (*LoginRequest) Descriptor() ([]byte, []int) {
+	return file_userservice_userservice_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *LoginRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type LoginResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *UserPrivateModel      `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	AccessToken   string                 `protobuf:"bytes,2,opt,name=accessToken,proto3" json:"accessToken,omitempty"`
+	RefreshToken  string                 `protobuf:"bytes,3,opt,name=refreshToken,proto3" json:"refreshToken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginResponse) Reset() {
+	*x = LoginResponse{}
+	mi := &file_userservice_userservice_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginResponse) ProtoMessage() {}
+
+func (x *LoginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_userservice_userservice_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginResponse.ProtoReflect.Descriptor instead.
+func (*LoginResponse) Descriptor() ([]byte, []int) {
+	return file_userservice_userservice_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *LoginResponse) GetUser() *UserPrivateModel {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *LoginResponse) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type LogoutResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutResponse) Reset() {
+	*x = LogoutResponse{}
+	mi := &file_userservice_userservice_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutResponse) ProtoMessage() {}
+
+func (x *LogoutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_userservice_userservice_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutResponse.ProtoReflect.Descriptor instead.
+func (*LogoutResponse) Descriptor() ([]byte, []int) {
+	return file_userservice_userservice_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *LogoutResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type LogoutAllResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutAllResponse) Reset() {
+	*x = LogoutAllResponse{}
+	mi := &file_userservice_userservice_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutAllResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutAllResponse) ProtoMessage() {}
+
+func (x *LogoutAllResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_userservice_userservice_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutAllResponse.ProtoReflect.Descriptor instead.
+func (*LogoutAllResponse) Descriptor() ([]byte, []int) {
+	return file_userservice_userservice_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *LogoutAllResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type RefreshRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refreshToken,proto3" json:"refreshToken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshRequest) Reset() {
+	*x = RefreshRequest{}
+	mi := &file_userservice_userservice_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshRequest) ProtoMessage() {}
+
+func (x *RefreshRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_userservice_userservice_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshRequest.ProtoReflect.Descriptor instead.
+func (*RefreshRequest) Descriptor() ([]byte, []int) {
+	return file_userservice_userservice_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RefreshRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type RefreshResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *UserPrivateModel      `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	AccessToken   string                 `protobuf:"bytes,2,opt,name=accessToken,proto3" json:"accessToken,omitempty"`
+	RefreshToken  string                 `protobuf:"bytes,3,opt,name=refreshToken,proto3" json:"refreshToken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshResponse) Reset() {
+	*x = RefreshResponse{}
+	mi := &file_userservice_userservice_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshResponse) ProtoMessage() {}
+
+func (x *RefreshResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_userservice_userservice_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshResponse.ProtoReflect.Descriptor instead.
+func (*RefreshResponse) Descriptor() ([]byte, []int) {
+	return file_userservice_userservice_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RefreshResponse) GetUser() *UserPrivateModel {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *RefreshResponse) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *RefreshResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type VerifyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VerifyToken   string                 `protobuf:"bytes,1,opt,name=verifyToken,proto3" json:"verifyToken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyRequest) Reset() {
+	*x = VerifyRequest{}
+	mi := &file_userservice_userservice_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyRequest) ProtoMessage() {}
+
+func (x *VerifyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_userservice_userservice_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyRequest.ProtoReflect.Descriptor instead.
+func (*VerifyRequest) Descriptor() ([]byte, []int) {
+	return file_userservice_userservice_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *VerifyRequest) GetVerifyToken() string {
+	if x != nil {
+		return x.VerifyToken
+	}
+	return ""
+}
+
+type VerifyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *UserPrivateModel      `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	AccessToken   string                 `protobuf:"bytes,2,opt,name=accessToken,proto3" json:"accessToken,omitempty"`
+	RefreshToken  string                 `protobuf:"bytes,3,opt,name=refreshToken,proto3" json:"refreshToken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyResponse) Reset() {
+	*x = VerifyResponse{}
+	mi := &file_userservice_userservice_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyResponse) ProtoMessage() {}
+
+func (x *VerifyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_userservice_userservice_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyResponse.ProtoReflect.Descriptor instead.
+func (*VerifyResponse) Descriptor() ([]byte, []int) {
+	return file_userservice_userservice_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *VerifyResponse) GetUser() *UserPrivateModel {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *VerifyResponse) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *VerifyResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type NewVerificationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NewVerificationRequest) Reset() {
+	*x = NewVerificationRequest{}
+	mi := &file_userservice_userservice_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NewVerificationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewVerificationRequest) ProtoMessage() {}
+
+func (x *NewVerificationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_userservice_userservice_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewVerificationRequest.ProtoReflect.Descriptor instead.
+func (*NewVerificationRequest) Descriptor() ([]byte, []int) {
+	return file_userservice_userservice_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *NewVerificationRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *NewVerificationRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type NewVerificationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *UserPrivateModel      `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NewVerificationResponse) Reset() {
+	*x = NewVerificationResponse{}
+	mi := &file_userservice_userservice_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NewVerificationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewVerificationResponse) ProtoMessage() {}
+
+func (x *NewVerificationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_userservice_userservice_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewVerificationResponse.ProtoReflect.Descriptor instead.
+func (*NewVerificationResponse) Descriptor() ([]byte, []int) {
+	return file_userservice_userservice_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *NewVerificationResponse) GetUser() *UserPrivateModel {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type ChangeEmailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeEmailRequest) Reset() {
+	*x = ChangeEmailRequest{}
+	mi := &file_userservice_userservice_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeEmailRequest) ProtoMessage() {}
+
+func (x *ChangeEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_userservice_userservice_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeEmailRequest.ProtoReflect.Descriptor instead.
+func (*ChangeEmailRequest) Descriptor() ([]byte, []int) {
+	return file_userservice_userservice_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ChangeEmailRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type ChangeEmailResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *UserPrivateModel      `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	AccessToken   string                 `protobuf:"bytes,2,opt,name=accessToken,proto3" json:"accessToken,omitempty"`
+	RefreshToken  string                 `protobuf:"bytes,3,opt,name=refreshToken,proto3" json:"refreshToken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeEmailResponse) Reset() {
+	*x = ChangeEmailResponse{}
+	mi := &file_userservice_userservice_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeEmailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeEmailResponse) ProtoMessage() {}
+
+func (x *ChangeEmailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_userservice_userservice_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeEmailResponse.ProtoReflect.Descriptor instead.
+func (*ChangeEmailResponse) Descriptor() ([]byte, []int) {
+	return file_userservice_userservice_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ChangeEmailResponse) GetUser() *UserPrivateModel {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *ChangeEmailResponse) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *ChangeEmailResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type ChangePasswordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OldPassword   string                 `protobuf:"bytes,1,opt,name=oldPassword,proto3" json:"oldPassword,omitempty"`
+	NewPassword   string                 `protobuf:"bytes,2,opt,name=newPassword,proto3" json:"newPassword,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangePasswordRequest) Reset() {
+	*x = ChangePasswordRequest{}
+	mi := &file_userservice_userservice_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangePasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePasswordRequest) ProtoMessage() {}
+
+func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_userservice_userservice_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePasswordRequest.ProtoReflect.Descriptor instead.
+func (*ChangePasswordRequest) Descriptor() ([]byte, []int) {
+	return file_userservice_userservice_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ChangePasswordRequest) GetOldPassword() string {
+	if x != nil {
+		return x.OldPassword
+	}
+	return ""
+}
+
+func (x *ChangePasswordRequest) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
+type ChangePasswordResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *UserPrivateModel      `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	AccessToken   string                 `protobuf:"bytes,2,opt,name=accessToken,proto3" json:"accessToken,omitempty"`
+	RefreshToken  string                 `protobuf:"bytes,3,opt,name=refreshToken,proto3" json:"refreshToken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangePasswordResponse) Reset() {
+	*x = ChangePasswordResponse{}
+	mi := &file_userservice_userservice_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangePasswordResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePasswordResponse) ProtoMessage() {}
+
+func (x *ChangePasswordResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_userservice_userservice_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePasswordResponse.ProtoReflect.Descriptor instead.
+func (*ChangePasswordResponse) Descriptor() ([]byte, []int) {
+	return file_userservice_userservice_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ChangePasswordResponse) GetUser() *UserPrivateModel {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *ChangePasswordResponse) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *ChangePasswordResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type CheckAuthResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *UserPrivateModel      `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	TokenId       string                 `protobuf:"bytes,2,opt,name=tokenId,proto3" json:"tokenId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckAuthResponse) Reset() {
+	*x = CheckAuthResponse{}
+	mi := &file_userservice_userservice_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckAuthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckAuthResponse) ProtoMessage() {}
+
+func (x *CheckAuthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_userservice_userservice_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckAuthResponse.ProtoReflect.Descriptor instead.
+func (*CheckAuthResponse) Descriptor() ([]byte, []int) {
+	return file_userservice_userservice_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CheckAuthResponse) GetUser() *UserPrivateModel {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *CheckAuthResponse) GetTokenId() string {
+	if x != nil {
+		return x.TokenId
+	}
+	return ""
+}
+
+type GetUserByUsernameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserByUsernameRequest) Reset() {
+	*x = GetUserByUsernameRequest{}
+	mi := &file_userservice_userservice_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserByUsernameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserByUsernameRequest) ProtoMessage() {}
+
+func (x *GetUserByUsernameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_userservice_userservice_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserByUsernameRequest.ProtoReflect.Descriptor instead.
+func (*GetUserByUsernameRequest) Descriptor() ([]byte, []int) {
+	return file_userservice_userservice_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetUserByUsernameRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type GetUserByUsernameResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *UserPublicModel       `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserByUsernameResponse) Reset() {
+	*x = GetUserByUsernameResponse{}
+	mi := &file_userservice_userservice_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserByUsernameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserByUsernameResponse) ProtoMessage() {}
+
+func (x *GetUserByUsernameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_userservice_userservice_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserByUsernameResponse.ProtoReflect.Descriptor instead.
+func (*GetUserByUsernameResponse) Descriptor() ([]byte, []int) {
+	return file_userservice_userservice_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetUserByUsernameResponse) GetUser() *UserPublicModel {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type ChangeUsernameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeUsernameRequest) Reset() {
+	*x = ChangeUsernameRequest{}
+	mi := &file_userservice_userservice_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeUsernameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeUsernameRequest) ProtoMessage() {}
+
+func (x *ChangeUsernameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_userservice_userservice_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeUsernameRequest.ProtoReflect.Descriptor instead.
+func (*ChangeUsernameRequest) Descriptor() ([]byte, []int) {
+	return file_userservice_userservice_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ChangeUsernameRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type ChangeUsernameResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *UserPublicModel       `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeUsernameResponse) Reset() {
+	*x = ChangeUsernameResponse{}
+	mi := &file_userservice_userservice_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeUsernameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeUsernameResponse) ProtoMessage() {}
+
+func (x *ChangeUsernameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_userservice_userservice_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeUsernameResponse.ProtoReflect.Descriptor instead.
+func (*ChangeUsernameResponse) Descriptor() ([]byte, []int) {
+	return file_userservice_userservice_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ChangeUsernameResponse) GetUser() *UserPublicModel {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type DeleteUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserResponse) Reset() {
+	*x = DeleteUserResponse{}
+	mi := &file_userservice_userservice_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserResponse) ProtoMessage() {}
+
+func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_userservice_userservice_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserResponse.ProtoReflect.Descriptor instead.
+func (*DeleteUserResponse) Descriptor() ([]byte, []int) {
+	return file_userservice_userservice_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *DeleteUserResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type CheckFollowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckFollowRequest) Reset() {
+	*x = CheckFollowRequest{}
+	mi := &file_userservice_userservice_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckFollowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckFollowRequest) ProtoMessage() {}
+
+func (x *CheckFollowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_userservice_userservice_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckFollowRequest.ProtoReflect.Descriptor instead.
+func (*CheckFollowRequest) Descriptor() ([]byte, []int) {
+	return file_userservice_userservice_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CheckFollowRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type CheckFollowResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IsFollowed    bool                   `protobuf:"varint,1,opt,name=isFollowed,proto3" json:"isFollowed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckFollowResponse) Reset() {
+	*x = CheckFollowResponse{}
+	mi := &file_userservice_userservice_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckFollowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckFollowResponse) ProtoMessage() {}
+
+func (x *CheckFollowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_userservice_userservice_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckFollowResponse.ProtoReflect.Descriptor instead.
+func (*CheckFollowResponse) Descriptor() ([]byte, []int) {
+	return file_userservice_userservice_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CheckFollowResponse) GetIsFollowed() bool {
+	if x != nil {
+		return x.IsFollowed
+	}
+	return false
+}
+
+type FollowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FollowRequest) Reset() {
+	*x = FollowRequest{}
+	mi := &file_userservice_userservice_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FollowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FollowRequest) ProtoMessage() {}
+
+func (x *FollowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_userservice_userservice_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FollowRequest.ProtoReflect.Descriptor instead.
+func (*FollowRequest) Descriptor() ([]byte, []int) {
+	return file_userservice_userservice_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *FollowRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type FollowResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FollowResponse) Reset() {
+	*x = FollowResponse{}
+	mi := &file_userservice_userservice_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FollowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FollowResponse) ProtoMessage() {}
+
+func (x *FollowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_userservice_userservice_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FollowResponse.ProtoReflect.Descriptor instead.
+func (*FollowResponse) Descriptor() ([]byte, []int) {
+	return file_userservice_userservice_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *FollowResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type UnfollowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnfollowRequest) Reset() {
+	*x = UnfollowRequest{}
+	mi := &file_userservice_userservice_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnfollowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnfollowRequest) ProtoMessage() {}
+
+func (x *UnfollowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_userservice_userservice_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnfollowRequest.ProtoReflect.Descriptor instead.
+func (*UnfollowRequest) Descriptor() ([]byte, []int) {
+	return file_userservice_userservice_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *UnfollowRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type UnfollowResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnfollowResponse) Reset() {
+	*x = UnfollowResponse{}
+	mi := &file_userservice_userservice_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnfollowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnfollowResponse) ProtoMessage() {}
+
+func (x *UnfollowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_userservice_userservice_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnfollowResponse.ProtoReflect.Descriptor instead.
+func (*UnfollowResponse) Descriptor() ([]byte, []int) {
+	return file_userservice_userservice_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *UnfollowResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type GetFollowingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Take          int64                  `protobuf:"varint,2,opt,name=take,proto3" json:"take,omitempty"`
+	LastId        int64                  `protobuf:"varint,3,opt,name=lastId,proto3" json:"lastId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFollowingRequest) Reset() {
+	*x = GetFollowingRequest{}
+	mi := &file_userservice_userservice_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFollowingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFollowingRequest) ProtoMessage() {}
+
+func (x *GetFollowingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_userservice_userservice_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFollowingRequest.ProtoReflect.Descriptor instead.
+func (*GetFollowingRequest) Descriptor() ([]byte, []int) {
+	return file_userservice_userservice_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetFollowingRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GetFollowingRequest) GetTake() int64 {
+	if x != nil {
+		return x.Take
+	}
+	return 0
+}
+
+func (x *GetFollowingRequest) GetLastId() int64 {
+	if x != nil {
+		return x.LastId
+	}
+	return 0
+}
+
+type GetFollowingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*UserPublicModel     `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFollowingResponse) Reset() {
+	*x = GetFollowingResponse{}
+	mi := &file_userservice_userservice_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFollowingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFollowingResponse) ProtoMessage() {}
+
+func (x *GetFollowingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_userservice_userservice_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFollowingResponse.ProtoReflect.Descriptor instead.
+func (*GetFollowingResponse) Descriptor() ([]byte, []int) {
+	return file_userservice_userservice_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetFollowingResponse) GetUsers() []*UserPublicModel {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+type GetFollowersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Take          int64                  `protobuf:"varint,2,opt,name=take,proto3" json:"take,omitempty"`
+	LastId        int64                  `protobuf:"varint,3,opt,name=lastId,proto3" json:"lastId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFollowersRequest) Reset() {
+	*x = GetFollowersRequest{}
+	mi := &file_userservice_userservice_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFollowersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFollowersRequest) ProtoMessage() {}
+
+func (x *GetFollowersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_userservice_userservice_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFollowersRequest.ProtoReflect.Descriptor instead.
+func (*GetFollowersRequest) Descriptor() ([]byte, []int) {
+	return file_userservice_userservice_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetFollowersRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GetFollowersRequest) GetTake() int64 {
+	if x != nil {
+		return x.Take
+	}
+	return 0
+}
+
+func (x *GetFollowersRequest) GetLastId() int64 {
+	if x != nil {
+		return x.LastId
+	}
+	return 0
+}
+
+type GetFollowersResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserIds        []int64                `protobuf:"varint,1,rep,packed,name=userIds,proto3" json:"userIds,omitempty"`
+	FollowersCount int64                  `protobuf:"varint,2,opt,name=followersCount,proto3" json:"followersCount,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetFollowersResponse) Reset() {
+	*x = GetFollowersResponse{}
+	mi := &file_userservice_userservice_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFollowersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFollowersResponse) ProtoMessage() {}
+
+func (x *GetFollowersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_userservice_userservice_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFollowersResponse.ProtoReflect.Descriptor instead.
+func (*GetFollowersResponse) Descriptor() ([]byte, []int) {
+	return file_userservice_userservice_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetFollowersResponse) GetUserIds() []int64 {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+func (x *GetFollowersResponse) GetFollowersCount() int64 {
+	if x != nil {
+		return x.FollowersCount
+	}
+	return 0
+}
+
+type GetUsersByIdsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserIds       []int64                `protobuf:"varint,1,rep,packed,name=userIds,proto3" json:"userIds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsersByIdsRequest) Reset() {
+	*x = GetUsersByIdsRequest{}
+	mi := &file_userservice_userservice_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsersByIdsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsersByIdsRequest) ProtoMessage() {}
+
+func (x *GetUsersByIdsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_userservice_userservice_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsersByIdsRequest.ProtoReflect.Descriptor instead.
+func (*GetUsersByIdsRequest) Descriptor() ([]byte, []int) {
+	return file_userservice_userservice_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetUsersByIdsRequest) GetUserIds() []int64 {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+type GetUsersByIdsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*UserPublicModel     `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsersByIdsResponse) Reset() {
+	*x = GetUsersByIdsResponse{}
+	mi := &file_userservice_userservice_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsersByIdsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsersByIdsResponse) ProtoMessage() {}
+
+func (x *GetUsersByIdsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_userservice_userservice_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsersByIdsResponse.ProtoReflect.Descriptor instead.
+func (*GetUsersByIdsResponse) Descriptor() ([]byte, []int) {
+	return file_userservice_userservice_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetUsersByIdsResponse) GetUsers() []*UserPublicModel {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+var File_userservice_userservice_proto protoreflect.FileDescriptor
+
+const file_userservice_userservice_proto_rawDesc = "" +
+	"\n" +
+	"\x1duserservice/userservice.proto\x12\vuserservice\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1bbuf/validate/validate.proto\"r\n" +
+	"\x10UserPrivateModel\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x1c\n" +
+	"\tcreatedAt\x18\x04 \x01(\tR\tcreatedAt\"e\n" +
+	"\x0fUserPublicModel\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12&\n" +
+	"\x0efollowersCount\x18\x03 \x01(\x03R\x0efollowersCount\"\xaf\x01\n" +
+	"\x0fRegisterRequest\x12<\n" +
+	"\busername\x18\x01 \x01(\tB \xbaH\x1dr\x1b\x10\x01\x18\x142\x15^[a-z0-9][a-z0-9_-]*$R\busername\x12\x1d\n" +
+	"\x05email\x18\x02 \x01(\tB\a\xbaH\x04r\x02`\x01R\x05email\x12?\n" +
+	"\bpassword\x18\x03 \x01(\tB#\xbaH r\x1e\x10\x05\x1822\x18^[a-zA-Z0-9!@#$%^&*?-]*$R\bpassword\"E\n" +
+	"\x10RegisterResponse\x121\n" +
+	"\x04user\x18\x01 \x01(\v2\x1d.userservice.UserPrivateModelR\x04user\"n\n" +
+	"\fLoginRequest\x12\x1d\n" +
+	"\x05email\x18\x01 \x01(\tB\a\xbaH\x04r\x02`\x01R\x05email\x12?\n" +
+	"\bpassword\x18\x02 \x01(\tB#\xbaH r\x1e\x10\x05\x1822\x18^[a-zA-Z0-9!@#$%^&*?-]*$R\bpassword\"\x88\x01\n" +
+	"\rLoginResponse\x121\n" +
+	"\x04user\x18\x01 \x01(\v2\x1d.userservice.UserPrivateModelR\x04user\x12 \n" +
+	"\vaccessToken\x18\x02 \x01(\tR\vaccessToken\x12\"\n" +
+	"\frefreshToken\x18\x03 \x01(\tR\frefreshToken\"*\n" +
+	"\x0eLogoutResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"-\n" +
+	"\x11LogoutAllResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"4\n" +
+	"\x0eRefreshRequest\x12\"\n" +
+	"\frefreshToken\x18\x01 \x01(\tR\frefreshToken\"\x8a\x01\n" +
+	"\x0fRefreshResponse\x121\n" +
+	"\x04user\x18\x01 \x01(\v2\x1d.userservice.UserPrivateModelR\x04user\x12 \n" +
+	"\vaccessToken\x18\x02 \x01(\tR\vaccessToken\x12\"\n" +
+	"\frefreshToken\x18\x03 \x01(\tR\frefreshToken\"1\n" +
+	"\rVerifyRequest\x12 \n" +
+	"\vverifyToken\x18\x01 \x01(\tR\vverifyToken\"\x89\x01\n" +
+	"\x0eVerifyResponse\x121\n" +
+	"\x04user\x18\x01 \x01(\v2\x1d.userservice.UserPrivateModelR\x04user\x12 \n" +
+	"\vaccessToken\x18\x02 \x01(\tR\vaccessToken\x12\"\n" +
+	"\frefreshToken\x18\x03 \x01(\tR\frefreshToken\"x\n" +
+	"\x16NewVerificationRequest\x12\x1d\n" +
+	"\x05email\x18\x01 \x01(\tB\a\xbaH\x04r\x02`\x01R\x05email\x12?\n" +
+	"\bpassword\x18\x02 \x01(\tB#\xbaH r\x1e\x10\x05\x1822\x18^[a-zA-Z0-9!@#$%^&*?-]*$R\bpassword\"L\n" +
+	"\x17NewVerificationResponse\x121\n" +
+	"\x04user\x18\x01 \x01(\v2\x1d.userservice.UserPrivateModelR\x04user\"3\n" +
+	"\x12ChangeEmailRequest\x12\x1d\n" +
+	"\x05email\x18\x01 \x01(\tB\a\xbaH\x04r\x02`\x01R\x05email\"\x8e\x01\n" +
+	"\x13ChangeEmailResponse\x121\n" +
+	"\x04user\x18\x01 \x01(\v2\x1d.userservice.UserPrivateModelR\x04user\x12 \n" +
+	"\vaccessToken\x18\x02 \x01(\tR\vaccessToken\x12\"\n" +
+	"\frefreshToken\x18\x03 \x01(\tR\frefreshToken\"\xa5\x01\n" +
+	"\x15ChangePasswordRequest\x12E\n" +
+	"\voldPassword\x18\x01 \x01(\tB#\xbaH r\x1e\x10\x05\x1822\x18^[a-zA-Z0-9!@#$%^&*?-]*$R\voldPassword\x12E\n" +
+	"\vnewPassword\x18\x02 \x01(\tB#\xbaH r\x1e\x10\x05\x1822\x18^[a-zA-Z0-9!@#$%^&*?-]*$R\vnewPassword\"\x91\x01\n" +
+	"\x16ChangePasswordResponse\x121\n" +
+	"\x04user\x18\x01 \x01(\v2\x1d.userservice.UserPrivateModelR\x04user\x12 \n" +
+	"\vaccessToken\x18\x02 \x01(\tR\vaccessToken\x12\"\n" +
+	"\frefreshToken\x18\x03 \x01(\tR\frefreshToken\"`\n" +
+	"\x11CheckAuthResponse\x121\n" +
+	"\x04user\x18\x01 \x01(\v2\x1d.userservice.UserPrivateModelR\x04user\x12\x18\n" +
+	"\atokenId\x18\x02 \x01(\tR\atokenId\"X\n" +
+	"\x18GetUserByUsernameRequest\x12<\n" +
+	"\busername\x18\x01 \x01(\tB \xbaH\x1dr\x1b\x10\x01\x18\x142\x15^[a-z0-9][a-z0-9_-]*$R\busername\"M\n" +
+	"\x19GetUserByUsernameResponse\x120\n" +
+	"\x04user\x18\x01 \x01(\v2\x1c.userservice.UserPublicModelR\x04user\"U\n" +
+	"\x15ChangeUsernameRequest\x12<\n" +
+	"\busername\x18\x01 \x01(\tB \xbaH\x1dr\x1b\x10\x01\x18\x142\x15^[a-z0-9][a-z0-9_-]*$R\busername\"J\n" +
+	"\x16ChangeUsernameResponse\x120\n" +
+	"\x04user\x18\x01 \x01(\v2\x1c.userservice.UserPublicModelR\x04user\".\n" +
+	"\x12DeleteUserResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\",\n" +
+	"\x12CheckFollowRequest\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\x03R\x06userId\"5\n" +
+	"\x13CheckFollowResponse\x12\x1e\n" +
+	"\n" +
+	"isFollowed\x18\x01 \x01(\bR\n" +
+	"isFollowed\"'\n" +
+	"\rFollowRequest\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\x03R\x06userId\"*\n" +
+	"\x0eFollowResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\")\n" +
+	"\x0fUnfollowRequest\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\x03R\x06userId\",\n" +
+	"\x10UnfollowResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"n\n" +
+	"\x13GetFollowingRequest\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\x03R\x06userId\x12\x1e\n" +
+	"\x04take\x18\x02 \x01(\x03B\n" +
+	"\xbaH\a\"\x05\x18\xe8\a(\x01R\x04take\x12\x1f\n" +
+	"\x06lastId\x18\x03 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\x06lastId\"J\n" +
+	"\x14GetFollowingResponse\x122\n" +
+	"\x05users\x18\x01 \x03(\v2\x1c.userservice.UserPublicModelR\x05users\"n\n" +
+	"\x13GetFollowersRequest\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\x03R\x06userId\x12\x1e\n" +
+	"\x04take\x18\x02 \x01(\x03B\n" +
+	"\xbaH\a\"\x05\x18\x90N(\x00R\x04take\x12\x1f\n" +
+	"\x06lastId\x18\x03 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\x06lastId\"X\n" +
+	"\x14GetFollowersResponse\x12\x18\n" +
+	"\auserIds\x18\x01 \x03(\x03R\auserIds\x12&\n" +
+	"\x0efollowersCount\x18\x02 \x01(\x03R\x0efollowersCount\":\n" +
+	"\x14GetUsersByIdsRequest\x12\"\n" +
+	"\auserIds\x18\x01 \x03(\x03B\b\xbaH\x05\x92\x01\x02\x10dR\auserIds\"K\n" +
+	"\x15GetUsersByIdsResponse\x122\n" +
+	"\x05users\x18\x01 \x03(\v2\x1c.userservice.UserPublicModelR\x05users2\xd9\v\n" +
+	"\vUserService\x12G\n" +
+	"\bRegister\x12\x1c.userservice.RegisterRequest\x1a\x1d.userservice.RegisterResponse\x12>\n" +
+	"\x05Login\x12\x19.userservice.LoginRequest\x1a\x1a.userservice.LoginResponse\x12=\n" +
+	"\x06Logout\x12\x16.google.protobuf.Empty\x1a\x1b.userservice.LogoutResponse\x12C\n" +
+	"\tLogoutAll\x12\x16.google.protobuf.Empty\x1a\x1e.userservice.LogoutAllResponse\x12D\n" +
+	"\aRefresh\x12\x1b.userservice.RefreshRequest\x1a\x1c.userservice.RefreshResponse\x12A\n" +
+	"\x06Verify\x12\x1a.userservice.VerifyRequest\x1a\x1b.userservice.VerifyResponse\x12\\\n" +
+	"\x0fNewVerification\x12#.userservice.NewVerificationRequest\x1a$.userservice.NewVerificationResponse\x12P\n" +
+	"\vChangeEmail\x12\x1f.userservice.ChangeEmailRequest\x1a .userservice.ChangeEmailResponse\x12Y\n" +
+	"\x0eChangePassword\x12\".userservice.ChangePasswordRequest\x1a#.userservice.ChangePasswordResponse\x12C\n" +
+	"\tCheckAuth\x12\x16.google.protobuf.Empty\x1a\x1e.userservice.CheckAuthResponse\x12b\n" +
+	"\x11GetUserByUsername\x12%.userservice.GetUserByUsernameRequest\x1a&.userservice.GetUserByUsernameResponse\x12Y\n" +
+	"\x0eChangeUsername\x12\".userservice.ChangeUsernameRequest\x1a#.userservice.ChangeUsernameResponse\x12E\n" +
+	"\n" +
+	"DeleteUser\x12\x16.google.protobuf.Empty\x1a\x1f.userservice.DeleteUserResponse\x12P\n" +
+	"\vCheckFollow\x12\x1f.userservice.CheckFollowRequest\x1a .userservice.CheckFollowResponse\x12A\n" +
+	"\x06Follow\x12\x1a.userservice.FollowRequest\x1a\x1b.userservice.FollowResponse\x12G\n" +
+	"\bUnfollow\x12\x1c.userservice.UnfollowRequest\x1a\x1d.userservice.UnfollowResponse\x12S\n" +
+	"\fGetFollowing\x12 .userservice.GetFollowingRequest\x1a!.userservice.GetFollowingResponse\x12S\n" +
+	"\fGetFollowers\x12 .userservice.GetFollowersRequest\x1a!.userservice.GetFollowersResponse\x12V\n" +
+	"\rGetUsersByIds\x12!.userservice.GetUsersByIdsRequest\x1a\".userservice.GetUsersByIdsResponseB\x9e\x01\n" +
+	"\x0fcom.userserviceB\x10UserserviceProtoP\x01Z-github.com/ocenb/music-protos/gen/userservice\xa2\x02\x03UXX\xaa\x02\vUserservice\xca\x02\vUserservice\xe2\x02\x17Userservice\\GPBMetadata\xea\x02\vUserserviceb\x06proto3"
+
+var (
+	file_userservice_userservice_proto_rawDescOnce sync.Once
+	file_userservice_userservice_proto_rawDescData []byte
+)
+
+func file_userservice_userservice_proto_rawDescGZIP() []byte {
+	file_userservice_userservice_proto_rawDescOnce.Do(func() {
+		file_userservice_userservice_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_userservice_userservice_proto_rawDesc), len(file_userservice_userservice_proto_rawDesc)))
+	})
+	return file_userservice_userservice_proto_rawDescData
+}
+
+var file_userservice_userservice_proto_msgTypes = make([]protoimpl.MessageInfo, 36)
+var file_userservice_userservice_proto_goTypes = []any{
+	(*UserPrivateModel)(nil),          // 0: userservice.UserPrivateModel
+	(*UserPublicModel)(nil),           // 1: userservice.UserPublicModel
+	(*RegisterRequest)(nil),           // 2: userservice.RegisterRequest
+	(*RegisterResponse)(nil),          // 3: userservice.RegisterResponse
+	(*LoginRequest)(nil),              // 4: userservice.LoginRequest
+	(*LoginResponse)(nil),             // 5: userservice.LoginResponse
+	(*LogoutResponse)(nil),            // 6: userservice.LogoutResponse
+	(*LogoutAllResponse)(nil),         // 7: userservice.LogoutAllResponse
+	(*RefreshRequest)(nil),            // 8: userservice.RefreshRequest
+	(*RefreshResponse)(nil),           // 9: userservice.RefreshResponse
+	(*VerifyRequest)(nil),             // 10: userservice.VerifyRequest
+	(*VerifyResponse)(nil),            // 11: userservice.VerifyResponse
+	(*NewVerificationRequest)(nil),    // 12: userservice.NewVerificationRequest
+	(*NewVerificationResponse)(nil),   // 13: userservice.NewVerificationResponse
+	(*ChangeEmailRequest)(nil),        // 14: userservice.ChangeEmailRequest
+	(*ChangeEmailResponse)(nil),       // 15: userservice.ChangeEmailResponse
+	(*ChangePasswordRequest)(nil),     // 16: userservice.ChangePasswordRequest
+	(*ChangePasswordResponse)(nil),    // 17: userservice.ChangePasswordResponse
+	(*CheckAuthResponse)(nil),         // 18: userservice.CheckAuthResponse
+	(*GetUserByUsernameRequest)(nil),  // 19: userservice.GetUserByUsernameRequest
+	(*GetUserByUsernameResponse)(nil), // 20: userservice.GetUserByUsernameResponse
+	(*ChangeUsernameRequest)(nil),     // 21: userservice.ChangeUsernameRequest
+	(*ChangeUsernameResponse)(nil),    // 22: userservice.ChangeUsernameResponse
+	(*DeleteUserResponse)(nil),        // 23: userservice.DeleteUserResponse
+	(*CheckFollowRequest)(nil),        // 24: userservice.CheckFollowRequest
+	(*CheckFollowResponse)(nil),       // 25: userservice.CheckFollowResponse
+	(*FollowRequest)(nil),             // 26: userservice.FollowRequest
+	(*FollowResponse)(nil),            // 27: userservice.FollowResponse
+	(*UnfollowRequest)(nil),           // 28: userservice.UnfollowRequest
+	(*UnfollowResponse)(nil),          // 29: userservice.UnfollowResponse
+	(*GetFollowingRequest)(nil),       // 30: userservice.GetFollowingRequest
+	(*GetFollowingResponse)(nil),      // 31: userservice.GetFollowingResponse
+	(*GetFollowersRequest)(nil),       // 32: userservice.GetFollowersRequest
+	(*GetFollowersResponse)(nil),      // 33: userservice.GetFollowersResponse
+	(*GetUsersByIdsRequest)(nil),      // 34: userservice.GetUsersByIdsRequest
+	(*GetUsersByIdsResponse)(nil),     // 35: userservice.GetUsersByIdsResponse
+	(*emptypb.Empty)(nil),             // 36: google.protobuf.Empty
+}
+var file_userservice_userservice_proto_depIdxs = []int32{
+	0,  // 0: userservice.RegisterResponse.user:type_name -> userservice.UserPrivateModel
+	0,  // 1: userservice.LoginResponse.user:type_name -> userservice.UserPrivateModel
+	0,  // 2: userservice.RefreshResponse.user:type_name -> userservice.UserPrivateModel
+	0,  // 3: userservice.VerifyResponse.user:type_name -> userservice.UserPrivateModel
+	0,  // 4: userservice.NewVerificationResponse.user:type_name -> userservice.UserPrivateModel
+	0,  // 5: userservice.ChangeEmailResponse.user:type_name -> userservice.UserPrivateModel
+	0,  // 6: userservice.ChangePasswordResponse.user:type_name -> userservice.UserPrivateModel
+	0,  // 7: userservice.CheckAuthResponse.user:type_name -> userservice.UserPrivateModel
+	1,  // 8: userservice.GetUserByUsernameResponse.user:type_name -> userservice.UserPublicModel
+	1,  // 9: userservice.ChangeUsernameResponse.user:type_name -> userservice.UserPublicModel
+	1,  // 10: userservice.GetFollowingResponse.users:type_name -> userservice.UserPublicModel
+	1,  // 11: userservice.GetUsersByIdsResponse.users:type_name -> userservice.UserPublicModel
+	2,  // 12: userservice.UserService.Register:input_type -> userservice.RegisterRequest
+	4,  // 13: userservice.UserService.Login:input_type -> userservice.LoginRequest
+	36, // 14: userservice.UserService.Logout:input_type -> google.protobuf.Empty
+	36, // 15: userservice.UserService.LogoutAll:input_type -> google.protobuf.Empty
+	8,  // 16: userservice.UserService.Refresh:input_type -> userservice.RefreshRequest
+	10, // 17: userservice.UserService.Verify:input_type -> userservice.VerifyRequest
+	12, // 18: userservice.UserService.NewVerification:input_type -> userservice.NewVerificationRequest
+	14, // 19: userservice.UserService.ChangeEmail:input_type -> userservice.ChangeEmailRequest
+	16, // 20: userservice.UserService.ChangePassword:input_type -> userservice.ChangePasswordRequest
+	36, // 21: userservice.UserService.CheckAuth:input_type -> google.protobuf.Empty
+	19, // 22: userservice.UserService.GetUserByUsername:input_type -> userservice.GetUserByUsernameRequest
+	21, // 23: userservice.UserService.ChangeUsername:input_type -> userservice.ChangeUsernameRequest
+	36, // 24: userservice.UserService.DeleteUser:input_type -> google.protobuf.Empty
+	24, // 25: userservice.UserService.CheckFollow:input_type -> userservice.CheckFollowRequest
+	26, // 26: userservice.UserService.Follow:input_type -> userservice.FollowRequest
+	28, // 27: userservice.UserService.Unfollow:input_type -> userservice.UnfollowRequest
+	30, // 28: userservice.UserService.GetFollowing:input_type -> userservice.GetFollowingRequest
+	32, // 29: userservice.UserService.GetFollowers:input_type -> userservice.GetFollowersRequest
+	34, // 30: userservice.UserService.GetUsersByIds:input_type -> userservice.GetUsersByIdsRequest
+	3,  // 31: userservice.UserService.Register:output_type -> userservice.RegisterResponse
+	5,  // 32: userservice.UserService.Login:output_type -> userservice.LoginResponse
+	6,  // 33: userservice.UserService.Logout:output_type -> userservice.LogoutResponse
+	7,  // 34: userservice.UserService.LogoutAll:output_type -> userservice.LogoutAllResponse
+	9,  // 35: userservice.UserService.Refresh:output_type -> userservice.RefreshResponse
+	11, // 36: userservice.UserService.Verify:output_type -> userservice.VerifyResponse
+	13, // 37: userservice.UserService.NewVerification:output_type -> userservice.NewVerificationResponse
+	15, // 38: userservice.UserService.ChangeEmail:output_type -> userservice.ChangeEmailResponse
+	17, // 39: userservice.UserService.ChangePassword:output_type -> userservice.ChangePasswordResponse
+	18, // 40: userservice.UserService.CheckAuth:output_type -> userservice.CheckAuthResponse
+	20, // 41: userservice.UserService.GetUserByUsername:output_type -> userservice.GetUserByUsernameResponse
+	22, // 42: userservice.UserService.ChangeUsername:output_type -> userservice.ChangeUsernameResponse
+	23, // 43: userservice.UserService.DeleteUser:output_type -> userservice.DeleteUserResponse
+	25, // 44: userservice.UserService.CheckFollow:output_type -> userservice.CheckFollowResponse
+	27, // 45: userservice.UserService.Follow:output_type -> userservice.FollowResponse
+	29, // 46: userservice.UserService.Unfollow:output_type -> userservice.UnfollowResponse
+	31, // 47: userservice.UserService.GetFollowing:output_type -> userservice.GetFollowingResponse
+	33, // 48: userservice.UserService.GetFollowers:output_type -> userservice.GetFollowersResponse
+	35, // 49: userservice.UserService.GetUsersByIds:output_type -> userservice.GetUsersByIdsResponse
+	31, // [31:50] is the sub-list for method output_type
+	12, // [12:31] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_userservice_userservice_proto_init() }
+func file_userservice_userservice_proto_init() {
+	if File_userservice_userservice_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_userservice_userservice_proto_rawDesc), len(file_userservice_userservice_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   36,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_userservice_userservice_proto_goTypes,
+		DependencyIndexes: file_userservice_userservice_proto_depIdxs,
+		MessageInfos:      file_userservice_userservice_proto_msgTypes,
+	}.Build()
+	File_userservice_userservice_proto = out.File
+	file_userservice_userservice_proto_goTypes = nil
+	file_userservice_userservice_proto_depIdxs = nil
+}
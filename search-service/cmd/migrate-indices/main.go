@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/ocenb/music-go/search-service/internal/clients/elastic"
+	"github.com/ocenb/music-go/search-service/internal/config"
+	"github.com/ocenb/music-go/search-service/internal/logger"
+	"github.com/ocenb/music-go/search-service/internal/utils"
+)
+
+func main() {
+	cfg := config.MustLoad()
+	log := logger.Setup(cfg)
+
+	if cfg.SearchBackend != config.SearchBackendElasticsearch {
+		log.Error("Index migrations require the elasticsearch search backend", slog.String("search_backend", cfg.SearchBackend))
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
+	elasticClient, err := elastic.New(cfg, log)
+	if err != nil {
+		log.Error("Failed to connect to elasticsearch", utils.ErrLog(err))
+		os.Exit(1)
+	}
+
+	if err := elasticClient.MigrateIndices(ctx, log); err != nil {
+		log.Error("Index migration failed", utils.ErrLog(err))
+		os.Exit(1)
+	}
+
+	log.Info("Index migrations applied")
+}
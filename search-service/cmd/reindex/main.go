@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+
+	"github.com/ocenb/music-go/search-service/internal/clients/elastic"
+	"github.com/ocenb/music-go/search-service/internal/config"
+	"github.com/ocenb/music-go/search-service/internal/logger"
+	"github.com/ocenb/music-go/search-service/internal/reindex"
+	"github.com/ocenb/music-go/search-service/internal/storage/postgres"
+	"github.com/ocenb/music-go/search-service/internal/utils"
+)
+
+func main() {
+	indices := flag.String("indices", "all", "comma-separated list of aliases to rebuild (users, tracks, playlists) or all")
+	resume := flag.Bool("resume", true, "continue an interrupted reindex from its last checkpoint")
+	batchSize := flag.Int("batch-size", 0, "rows per bulk request, overrides reindex.batch_size")
+	flag.Parse()
+
+	cfg := config.MustLoad()
+	log := logger.Setup(cfg)
+
+	if cfg.SearchBackend != config.SearchBackendElasticsearch {
+		log.Error("Reindex requires the elasticsearch search backend", slog.String("search_backend", cfg.SearchBackend))
+		os.Exit(1)
+	}
+	if cfg.Reindex.UserDatabaseUrl == "" || cfg.Reindex.ContentDatabaseUrl == "" {
+		log.Error("REINDEX_USER_DATABASE_URL and REINDEX_CONTENT_DATABASE_URL must be set")
+		os.Exit(1)
+	}
+	if *batchSize > 0 {
+		cfg.Reindex.BatchSize = *batchSize
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
+	elasticClient, err := elastic.New(cfg, log)
+	if err != nil {
+		log.Error("Failed to connect to elasticsearch", utils.ErrLog(err))
+		os.Exit(1)
+	}
+
+	userDB, err := postgres.New(cfg.Reindex.UserDatabaseUrl)
+	if err != nil {
+		log.Error("Failed to connect to user database", utils.ErrLog(err))
+		os.Exit(1)
+	}
+	defer closeDB(log, "user", userDB.Close)
+
+	contentDB, err := postgres.New(cfg.Reindex.ContentDatabaseUrl)
+	if err != nil {
+		log.Error("Failed to connect to content database", utils.ErrLog(err))
+		os.Exit(1)
+	}
+	defer closeDB(log, "content", contentDB.Close)
+
+	selected := strings.Split(*indices, ",")
+	reindexer := reindex.New(log, elasticClient, cfg.Reindex.BatchSize)
+
+	for _, source := range reindex.NewSources(userDB, contentDB) {
+		if *indices != "all" && !slices.Contains(selected, source.Alias) {
+			continue
+		}
+
+		if err := reindexer.Run(ctx, source, *resume); err != nil {
+			log.Error("Reindex failed", slog.String("alias", source.Alias), utils.ErrLog(err))
+			os.Exit(1)
+		}
+	}
+}
+
+func closeDB(log *slog.Logger, name string, closeFn func() error) {
+	if err := closeFn(); err != nil {
+		log.Error("Failed to close database connection", slog.String("database", name), utils.ErrLog(err))
+	}
+}
@@ -1,16 +1,21 @@
 package main
 
 import (
+	"context"
 	"log/slog"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/ocenb/music-go/search-service/internal/app"
 	"github.com/ocenb/music-go/search-service/internal/clients/elastic"
+	"github.com/ocenb/music-go/search-service/internal/clients/embedded"
 	"github.com/ocenb/music-go/search-service/internal/clients/userservice"
 	"github.com/ocenb/music-go/search-service/internal/config"
+	"github.com/ocenb/music-go/search-service/internal/handlers"
+	"github.com/ocenb/music-go/search-service/internal/kafka"
 	"github.com/ocenb/music-go/search-service/internal/logger"
 	"github.com/ocenb/music-go/search-service/internal/service"
 	"github.com/ocenb/music-go/search-service/internal/utils"
@@ -21,14 +26,32 @@ func main() {
 	cfg := config.MustLoad()
 	log := logger.Setup(cfg)
 
-	log.Info("Connecting to elasticsearch",
-		slog.String("host", cfg.ElasticHost),
-		slog.String("port", cfg.ElasticPort),
-	)
-	elasticClient, err := elastic.New(cfg, log)
-	if err != nil {
-		log.Error("Failed to connect to elasticsearch", utils.ErrLog(err))
-		os.Exit(1)
+	var backend service.SearchBackend
+	if cfg.SearchBackend == config.SearchBackendEmbedded {
+		log.Info("Starting embedded search engine", slog.String("snapshot_path", cfg.Embedded.SnapshotPath))
+		engine, err := embedded.New(cfg, log)
+		if err != nil {
+			log.Error("Failed to start embedded search engine", utils.ErrLog(err))
+			os.Exit(1)
+		}
+		defer func() {
+			log.Info("Closing embedded search engine")
+			if err := engine.Close(); err != nil {
+				log.Error("Failed to close embedded search engine", utils.ErrLog(err))
+			}
+		}()
+		backend = engine
+	} else {
+		log.Info("Connecting to elasticsearch",
+			slog.String("host", cfg.ElasticHost),
+			slog.String("port", cfg.ElasticPort),
+		)
+		elasticClient, err := elastic.New(cfg, log)
+		if err != nil {
+			log.Error("Failed to connect to elasticsearch", utils.ErrLog(err))
+			os.Exit(1)
+		}
+		backend = elasticClient
 	}
 	userServiceClient, err := userservice.New(cfg)
 	if err != nil {
@@ -43,10 +66,38 @@ func main() {
 		}
 	}()
 
-	searchService := service.NewSearchService(cfg, log, elasticClient)
+	searchService := service.NewSearchService(cfg, log, backend)
+
+	log.Info("Connecting to kafka",
+		slog.String("brokers", strings.Join(cfg.KafkaBrokers, ",")),
+		slog.String("topic", cfg.KafkaTopic),
+		slog.String("group_id", cfg.KafkaGroupID),
+	)
+	consumer, err := kafka.NewConsumer(cfg, log)
+	if err != nil {
+		log.Error("Failed to create kafka consumer", utils.ErrLog(err))
+		os.Exit(1)
+	}
+	defer func() {
+		log.Info("Closing kafka consumer")
+		if err := consumer.Close(); err != nil {
+			log.Error("Failed to close kafka consumer", utils.ErrLog(err))
+		}
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		if err := consumer.Consume(ctx, handlers.IndexEventHandler(log, searchService)); err != nil {
+			log.Error("Error consuming index events", utils.ErrLog(err))
+		}
+	}()
+
+	go runTombstonePurge(ctx, searchService, cfg.Tombstones.PurgeInterval, log)
 
 	log.Info("Initializing gRPC server", slog.Int("port", cfg.GRPC.Port))
-	grpcApp := app.New(searchService, userServiceClient, cfg, log)
+	grpcApp := app.New(searchService, userServiceClient.Client, cfg, log)
 
 	go func() {
 		grpcApp.Run()
@@ -58,9 +109,29 @@ func main() {
 
 	shutdownStart := time.Now()
 
+	cancel()
 	grpcApp.Stop()
 
 	log.Info("Service shutdown complete",
 		slog.Duration("shutdown_time", time.Since(shutdownStart)),
 		slog.Duration("uptime", time.Since(startTime)))
 }
+
+func runTombstonePurge(ctx context.Context, searchService service.SearchServiceInterface, interval time.Duration, log *slog.Logger) {
+	log.Info("Tombstone purge scheduled", slog.Duration("interval", interval))
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purgeCtx, cancel := context.WithTimeout(ctx, interval)
+			if err := searchService.PurgeTombstones(purgeCtx); err != nil {
+				log.Error("Failed to purge tombstones", utils.ErrLog(err))
+			}
+			cancel()
+		}
+	}
+}
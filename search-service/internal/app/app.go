@@ -9,11 +9,11 @@ import (
 	authmw "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/auth"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/recovery"
-	"github.com/ocenb/music-go/search-service/internal/clients/userservice"
 	"github.com/ocenb/music-go/search-service/internal/config"
 	"github.com/ocenb/music-go/search-service/internal/handlers"
 	"github.com/ocenb/music-go/search-service/internal/service"
 	"github.com/ocenb/music-go/search-service/internal/utils"
+	"github.com/ocenb/music-protos/gen/userservice"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -29,7 +29,7 @@ type App struct {
 
 func New(
 	searchService service.SearchServiceInterface,
-	userServiceClient *userservice.UserServiceClient,
+	userServiceClient userservice.UserServiceClient,
 	cfg *config.Config,
 	log *slog.Logger,
 ) *App {
@@ -70,9 +70,12 @@ func loggerInterceptor(l *slog.Logger) logging.Logger {
 
 func checkAuth(ctx context.Context) bool {
 	authMethods := map[string]bool{
-		"/searchservice.SearchService/SearchUsers":  true,
-		"/searchservice.SearchService/SearchAlbums": true,
-		"/searchservice.SearchService/SearchTracks": true,
+		"/searchservice.SearchService/SearchUsers":     true,
+		"/searchservice.SearchService/SearchAlbums":    true,
+		"/searchservice.SearchService/SearchTracks":    true,
+		"/searchservice.SearchService/SearchPlaylists": true,
+		"/searchservice.SearchService/Suggest":         true,
+		"/searchservice.SearchService/RecordClick":     true,
 	}
 	fullMethod, ok := grpc.Method(ctx)
 
@@ -82,7 +85,7 @@ func checkAuth(ctx context.Context) bool {
 	return false
 }
 
-func authFunc(userServiceClient *userservice.UserServiceClient) func(ctx context.Context) (context.Context, error) {
+func authFunc(userServiceClient userservice.UserServiceClient) func(ctx context.Context) (context.Context, error) {
 	return func(ctx context.Context) (context.Context, error) {
 		if !checkAuth(ctx) {
 			return ctx, nil
@@ -103,11 +106,11 @@ func authFunc(userServiceClient *userservice.UserServiceClient) func(ctx context
 		})
 		outCtx := metadata.NewOutgoingContext(ctx, outMD)
 
-		_, err := userServiceClient.Client.CheckAuth(outCtx, &emptypb.Empty{})
+		res, err := userServiceClient.CheckAuth(outCtx, &emptypb.Empty{})
 		if err != nil {
 			return nil, utils.UnauthenticatedError(err.Error())
 		}
-		return ctx, nil
+		return context.WithValue(ctx, utils.UserIDKey{}, res.GetUser().GetId()), nil
 	}
 }
 
@@ -119,14 +122,17 @@ func (a *App) Run() {
 		panic(fmt.Errorf("%s: %w", op, err))
 	}
 
-	a.log.Info("grpc server started", slog.String("addr", l.Addr().String()))
-
-	err = a.gRPCServer.Serve(l)
-	if err != nil {
+	if err := a.Serve(l); err != nil {
 		panic(fmt.Errorf("%s: %w", op, err))
 	}
 }
 
+func (a *App) Serve(l net.Listener) error {
+	a.log.Info("grpc server started", slog.String("addr", l.Addr().String()))
+
+	return a.gRPCServer.Serve(l)
+}
+
 func (a *App) Stop() {
 	const op = "app.Stop"
 
@@ -0,0 +1,256 @@
+package elastic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+)
+
+const SearchQueriesIndexName = "search_queries"
+
+const (
+	queriesAggregation = "queries"
+	clickedAggregation = "clicked"
+)
+
+// The script repeats the owner and duplicate checks of RecordClick, so a
+// click racing with another one for the same document is still counted once.
+const recordClickScript = `
+boolean skip = ((Number) ctx._source.user_id).longValue() != ((Number) params.user_id).longValue();
+if (ctx._source.clicked_ids != null) {
+	for (def id : ctx._source.clicked_ids) {
+		if (((Number) id).longValue() == ((Number) params.document_id).longValue()) {
+			skip = true;
+		}
+	}
+}
+if (skip) {
+	ctx.op = 'noop';
+} else {
+	ctx._source.clicks = (ctx._source.clicks == null ? 0 : ctx._source.clicks) + 1;
+	if (ctx._source.clicked_ids == null) {
+		ctx._source.clicked_ids = [];
+	}
+	if (ctx._source.clicked_positions == null) {
+		ctx._source.clicked_positions = [];
+	}
+	ctx._source.clicked_ids.add(params.document_id);
+	ctx._source.clicked_positions.add(params.position);
+	ctx._source.last_clicked_at = params.clicked_at;
+}
+`
+
+var (
+	ErrSearchQueryNotFound = errors.New("search query not found")
+	ErrClickNotAllowed     = errors.New("search query belongs to another user")
+)
+
+type SearchQuery struct {
+	QueryID    string    `json:"query_id"`
+	Type       string    `json:"type"`
+	Query      string    `json:"query"`
+	UserID     int64     `json:"user_id"`
+	Results    int64     `json:"results"`
+	LatencyMs  int64     `json:"latency_ms"`
+	Clicks     int64     `json:"clicks"`
+	ClickedIDs []int64   `json:"clicked_ids,omitempty"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+type AnalyticsRange struct {
+	From  time.Time
+	To    time.Time
+	Type  string
+	Limit int
+}
+
+type QueryStat struct {
+	Query           string
+	Searches        int64
+	ClickedSearches int64
+}
+
+type ClickThroughRate struct {
+	Searches        int64
+	ClickedSearches int64
+}
+
+func (r AnalyticsRange) filters() []types.Query {
+	filters := []types.Query{
+		{Range: map[string]types.RangeQuery{"timestamp": types.DateRangeQuery{
+			Gte: stringPtr(r.From.Format(time.RFC3339)),
+			Lte: stringPtr(r.To.Format(time.RFC3339)),
+		}}},
+	}
+	if r.Type != "" {
+		filters = append(filters, termsQuery("type", []string{r.Type}))
+	}
+
+	return filters
+}
+
+func (c *ElasticClient) LogSearchQuery(ctx context.Context, query *SearchQuery) error {
+	_, err := c.elastic.Index(SearchQueriesIndexName).
+		Id(query.QueryID).
+		Document(query).
+		Do(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to log search query: %w", err)
+	}
+
+	return c.mirror(ctx, SearchQueriesIndexName, query.QueryID)
+}
+
+func (c *ElasticClient) RecordClick(ctx context.Context, queryID string, userID, documentID int64, position int) error {
+	res, err := c.elastic.Get(SearchQueriesIndexName, queryID).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get search query %s: %w", queryID, err)
+	}
+	if !res.Found {
+		return ErrSearchQueryNotFound
+	}
+
+	var query SearchQuery
+	if err := json.Unmarshal(res.Source_, &query); err != nil {
+		return fmt.Errorf("failed to unmarshal search query %s: %w", queryID, err)
+	}
+	if query.UserID != userID {
+		return ErrClickNotAllowed
+	}
+	if slices.Contains(query.ClickedIDs, documentID) {
+		return nil
+	}
+
+	params, err := scriptParams(map[string]any{
+		"user_id":     userID,
+		"document_id": documentID,
+		"position":    position,
+		"clicked_at":  time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build click update: %w", err)
+	}
+
+	_, err = c.elastic.Update(SearchQueriesIndexName, queryID).
+		Script(&types.Script{Source: stringPtr(recordClickScript), Params: params}).
+		RetryOnConflict(upsertRetriesOnConflict).
+		Do(ctx)
+	if err != nil {
+		var esErr *types.ElasticsearchError
+		if errors.As(err, &esErr) && esErr.Status == http.StatusNotFound {
+			return ErrSearchQueryNotFound
+		}
+		return fmt.Errorf("failed to record click for %s: %w", queryID, err)
+	}
+
+	return c.mirror(ctx, SearchQueriesIndexName, queryID)
+}
+
+func (c *ElasticClient) TopQueries(ctx context.Context, r AnalyticsRange) ([]*QueryStat, error) {
+	stats, err := c.queryStats(ctx, r.filters(), r.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get top queries: %w", err)
+	}
+
+	return stats, nil
+}
+
+func (c *ElasticClient) ZeroResultQueries(ctx context.Context, r AnalyticsRange) ([]*QueryStat, error) {
+	filters := append(r.filters(), termsQuery("results", []int64{0}))
+
+	stats, err := c.queryStats(ctx, filters, r.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get zero-result queries: %w", err)
+	}
+
+	return stats, nil
+}
+
+func (c *ElasticClient) ClickThroughRate(ctx context.Context, r AnalyticsRange) (*ClickThroughRate, error) {
+	size := 0
+	res, err := c.elastic.Search().
+		Index(SearchQueriesIndexName).
+		Request(&search.Request{
+			Query:          &types.Query{Bool: &types.BoolQuery{Filter: r.filters()}},
+			Size:           &size,
+			TrackTotalHits: true,
+			Aggregations: map[string]types.Aggregations{
+				clickedAggregation: clickedSearchesAggregation(),
+			},
+		}).
+		TypedKeys(true).
+		Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get click-through rate: %w", err)
+	}
+
+	rate := &ClickThroughRate{ClickedSearches: clickedSearches(res.Aggregations)}
+	if res.Hits.Total != nil {
+		rate.Searches = res.Hits.Total.Value
+	}
+
+	return rate, nil
+}
+
+func (c *ElasticClient) queryStats(ctx context.Context, filters []types.Query, limit int) ([]*QueryStat, error) {
+	size := 0
+	field := "query"
+	res, err := c.elastic.Search().
+		Index(SearchQueriesIndexName).
+		Request(&search.Request{
+			Query: &types.Query{Bool: &types.BoolQuery{Filter: filters}},
+			Size:  &size,
+			Aggregations: map[string]types.Aggregations{
+				queriesAggregation: {
+					Terms: &types.TermsAggregation{Field: &field, Size: &limit},
+					Aggregations: map[string]types.Aggregations{
+						clickedAggregation: clickedSearchesAggregation(),
+					},
+				},
+			},
+		}).
+		TypedKeys(true).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := []*QueryStat{}
+	terms, ok := res.Aggregations[queriesAggregation].(*types.StringTermsAggregate)
+	if !ok {
+		return stats, nil
+	}
+
+	buckets, _ := terms.Buckets.([]types.StringTermsBucket)
+	for _, bucket := range buckets {
+		stats = append(stats, &QueryStat{
+			Query:           fmt.Sprintf("%v", bucket.Key),
+			Searches:        bucket.DocCount,
+			ClickedSearches: clickedSearches(bucket.Aggregations),
+		})
+	}
+
+	return stats, nil
+}
+
+func clickedSearchesAggregation() types.Aggregations {
+	return types.Aggregations{
+		Filter: &types.Query{Range: map[string]types.RangeQuery{"clicks": types.NumberRangeQuery{Gte: float64Ptr(1)}}},
+	}
+}
+
+func clickedSearches(aggregations map[string]types.Aggregate) int64 {
+	clicked, ok := aggregations[clickedAggregation].(*types.FilterAggregate)
+	if !ok {
+		return 0
+	}
+
+	return clicked.DocCount
+}
@@ -6,32 +6,90 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"time"
 
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
-	"github.com/elastic/go-elasticsearch/v8/typedapi/indices/create"
 	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/refresh"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/textquerytype"
 	"github.com/ocenb/music-go/search-service/internal/config"
+	"github.com/ocenb/music-go/search-service/migrations"
 )
 
 var (
-	ErrUserAlreadyExists  = errors.New("user already exists")
-	ErrAlbumAlreadyExists = errors.New("album already exists")
-	ErrTrackAlreadyExists = errors.New("track already exists")
-	ErrUserNotFound       = errors.New("user not found")
-	ErrAlbumNotFound      = errors.New("album not found")
-	ErrTrackNotFound      = errors.New("track not found")
+	ErrUserAlreadyExists     = errors.New("user already exists")
+	ErrAlbumAlreadyExists    = errors.New("album already exists")
+	ErrTrackAlreadyExists    = errors.New("track already exists")
+	ErrPlaylistAlreadyExists = errors.New("playlist already exists")
+	ErrUserNotFound          = errors.New("user not found")
+	ErrAlbumNotFound         = errors.New("album not found")
+	ErrTrackNotFound         = errors.New("track not found")
+	ErrPlaylistNotFound      = errors.New("playlist not found")
 )
 
 const (
-	UsersIndexName  = "users"
-	AlbumsIndexName = "albums"
-	TracksIndexName = "tracks"
+	UsersIndexName     = "users"
+	AlbumsIndexName    = "albums"
+	TracksIndexName    = "tracks"
+	PlaylistsIndexName = "playlists"
 )
 
+const (
+	DocumentTypeUser     = "user"
+	DocumentTypeAlbum    = "album"
+	DocumentTypeTrack    = "track"
+	DocumentTypePlaylist = "playlist"
+)
+
+var ErrUnknownDocumentType = errors.New("unknown document type")
+
+var documentIndices = map[string]string{
+	DocumentTypeUser:     UsersIndexName,
+	DocumentTypeTrack:    TracksIndexName,
+	DocumentTypePlaylist: PlaylistsIndexName,
+}
+
+func DocumentIndex(documentType string) (string, bool) {
+	index, ok := documentIndices[documentType]
+	return index, ok
+}
+
+type suggestTarget struct {
+	suggestionType string
+	index          string
+	field          string
+}
+
+var suggestTargets = []suggestTarget{
+	{suggestionType: DocumentTypeUser, index: UsersIndexName, field: "username"},
+	{suggestionType: DocumentTypeTrack, index: TracksIndexName, field: "title"},
+	{suggestionType: DocumentTypePlaylist, index: PlaylistsIndexName, field: "title"},
+}
+
+type DocumentSignals struct {
+	ID        int64
+	Plays     int64
+	Likes     int64
+	Followers int64
+	CreatedAt time.Time
+}
+
+type Suggestion struct {
+	Type  string
+	ID    int64
+	Text  string
+	Score float64
+}
+
+const indicesSetupTimeout = 30 * time.Second
+
 type ElasticClient struct {
-	elastic *elasticsearch.TypedClient
+	elastic    *elasticsearch.TypedClient
+	ranking    config.RankingConfig
+	migrations indexMigrations
+	rebuilds   *rebuildTargets
 }
 
 func New(cfg *config.Config, log *slog.Logger) (*ElasticClient, error) {
@@ -52,181 +110,201 @@ func New(cfg *config.Config, log *slog.Logger) (*ElasticClient, error) {
 		return nil, fmt.Errorf("failed to connect to elasticsearch: %w", err)
 	}
 
+	indexMigrations, err := loadMigrations(migrations.FS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load index migrations: %w", err)
+	}
+
 	client := &ElasticClient{
-		elastic: es,
+		elastic:    es,
+		ranking:    cfg.Ranking,
+		migrations: indexMigrations,
+		rebuilds:   newRebuildTargets(),
 	}
 
-	if err := client.createIndices(ctx); err != nil {
+	indicesCtx, indicesCancel := context.WithTimeout(context.Background(), indicesSetupTimeout)
+	defer indicesCancel()
+
+	if err := client.ensureIndices(indicesCtx, log); err != nil {
 		return nil, fmt.Errorf("failed to create indices: %w", err)
 	}
 
 	return client, nil
 }
 
-func (c *ElasticClient) createIndices(ctx context.Context) error {
-	exists, err := c.elastic.Indices.Exists(UsersIndexName).Do(ctx)
+func (c *ElasticClient) SearchUsers(ctx context.Context, query string, page SearchPage) (*SearchResult, error) {
+	result, err := c.searchDocuments(ctx, UsersIndexName, "username", query, page)
 	if err != nil {
-		return fmt.Errorf("failed to check if users index exists: %w", err)
+		return nil, fmt.Errorf("failed to search users: %w", err)
 	}
 
-	if !exists {
-		usersMapping := types.TypeMapping{
-			Properties: map[string]types.Property{
-				"id":       types.NewKeywordProperty(),
-				"username": types.NewTextProperty(),
-			},
-		}
+	return result, nil
+}
 
-		_, err = c.elastic.Indices.Create(UsersIndexName).
-			Request(&create.Request{
-				Mappings: &usersMapping,
-			}).Do(ctx)
-		if err != nil {
-			return fmt.Errorf("failed to create users index: %w", err)
-		}
+func (c *ElasticClient) SearchAlbums(ctx context.Context, query string, page SearchPage) (*SearchResult, error) {
+	result, err := c.searchDocuments(ctx, AlbumsIndexName, "title", query, page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search albums: %w", err)
 	}
 
-	exists, err = c.elastic.Indices.Exists(AlbumsIndexName).Do(ctx)
+	return result, nil
+}
+
+func (c *ElasticClient) SearchTracks(ctx context.Context, query string, page SearchPage, filters *TrackFilters) (*SearchResult, error) {
+	cursor, origin, err := page.Position()
 	if err != nil {
-		return fmt.Errorf("failed to check if albums index exists: %w", err)
+		return nil, err
 	}
+	baseFilters, genreFilter, durationFilter := filters.queries()
 
-	if !exists {
-		albumsMapping := types.TypeMapping{
-			Properties: map[string]types.Property{
-				"id":    types.NewKeywordProperty(),
-				"title": types.NewTextProperty(),
+	searchQuery := &search.Request{
+		Query: &types.Query{
+			Bool: &types.BoolQuery{
+				Must:   []types.Query{*c.rankedQuery(textQuery("title", query), origin)},
+				Filter: baseFilters,
 			},
-		}
+		},
+		PostFilter: allOf(genreFilter, durationFilter),
+		Aggregations: map[string]types.Aggregations{
+			genresFacet:    genresAggregation(durationFilter),
+			durationsFacet: durationsAggregation(genreFilter),
+		},
+	}
+	page.apply(searchQuery, "title", cursor)
 
-		_, err = c.elastic.Indices.Create(AlbumsIndexName).
-			Request(&create.Request{
-				Mappings: &albumsMapping,
-			}).Do(ctx)
-		if err != nil {
-			return fmt.Errorf("failed to create albums index: %w", err)
-		}
+	res, err := c.elastic.Search().
+		Index(TracksIndexName).
+		Request(searchQuery).
+		TypedKeys(true).
+		Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search tracks: %w", err)
 	}
 
-	exists, err = c.elastic.Indices.Exists(TracksIndexName).Do(ctx)
+	result, err := newSearchResult(res.Hits, "title", query, page.Limit, origin)
 	if err != nil {
-		return fmt.Errorf("failed to check if tracks index exists: %w", err)
+		return nil, err
 	}
 
-	if !exists {
-		tracksMapping := types.TypeMapping{
-			Properties: map[string]types.Property{
-				"id":    types.NewKeywordProperty(),
-				"title": types.NewTextProperty(),
-			},
-		}
+	result.Facets, err = parseFacets(res.Aggregations)
+	if err != nil {
+		return nil, err
+	}
 
-		_, err = c.elastic.Indices.Create(TracksIndexName).
-			Request(&create.Request{
-				Mappings: &tracksMapping,
-			}).Do(ctx)
-		if err != nil {
-			return fmt.Errorf("failed to create tracks index: %w", err)
-		}
+	return result, nil
+}
+
+func (c *ElasticClient) SearchPlaylists(ctx context.Context, query string, page SearchPage) (*SearchResult, error) {
+	result, err := c.searchDocuments(ctx, PlaylistsIndexName, "title", query, page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search playlists: %w", err)
 	}
 
-	return nil
+	return result, nil
 }
 
-func (c *ElasticClient) SearchUsers(ctx context.Context, query string) ([]int64, error) {
-	searchQuery := &search.Request{
-		Query: &types.Query{
-			MultiMatch: &types.MultiMatchQuery{
-				Query:  query,
-				Fields: []string{"username"},
+func (c *ElasticClient) Suggest(ctx context.Context, query string, limit int, timeout time.Duration) ([]*Suggestion, error) {
+	request := c.elastic.Msearch()
+	shardTimeout := timeout.String()
+	origin := RankingOrigin(time.Now())
+
+	for _, target := range suggestTargets {
+		subfield := fmt.Sprintf("%s.%s", target.field, suggestSubfield)
+		err := request.AddSearch(
+			types.MultisearchHeader{Index: []string{target.index}},
+			types.MultisearchBody{
+				Query: c.rankedQuery(&types.Query{
+					MultiMatch: &types.MultiMatchQuery{
+						Query:  query,
+						Type:   &textquerytype.Boolprefix,
+						Fields: []string{subfield, subfield + "._2gram", subfield + "._3gram"},
+					},
+				}, origin),
+				Size:    &limit,
+				Source_: &types.SourceFilter{Includes: []string{"id", target.field}},
+				Timeout: &shardTimeout,
 			},
-		},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build suggest request: %w", err)
+		}
 	}
 
-	res, err := c.elastic.Search().
-		Index(UsersIndexName).
-		Request(searchQuery).
-		Do(ctx)
+	res, err := request.Do(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to search users: %w", err)
+		return nil, fmt.Errorf("failed to suggest: %w", err)
 	}
 
-	var ids []int64
-	for _, hit := range res.Hits.Hits {
-		var user struct {
-			ID int64 `json:"id"`
+	suggestions := make([]*Suggestion, 0, limit*len(suggestTargets))
+	for i, item := range res.Responses {
+		result, ok := item.(*types.MultiSearchItem)
+		if !ok {
+			return nil, fmt.Errorf("failed to suggest %s: %v", suggestTargets[i].index, item)
 		}
-		if err := json.Unmarshal(hit.Source_, &user); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal user: %w", err)
+
+		target := suggestTargets[i]
+		for _, hit := range result.Hits.Hits {
+			suggestion, err := newSuggestion(target, hit)
+			if err != nil {
+				return nil, err
+			}
+			suggestions = append(suggestions, suggestion)
 		}
-		ids = append(ids, user.ID)
 	}
 
-	return ids, nil
+	return suggestions, nil
 }
 
-func (c *ElasticClient) SearchAlbums(ctx context.Context, query string) ([]int64, error) {
-	searchQuery := &search.Request{
-		Query: &types.Query{
-			MultiMatch: &types.MultiMatchQuery{
-				Query:  query,
-				Fields: []string{"title"},
-			},
-		},
+func newSuggestion(target suggestTarget, hit types.Hit) (*Suggestion, error) {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(hit.Source_, &doc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal hit: %w", err)
 	}
 
-	res, err := c.elastic.Search().
-		Index(AlbumsIndexName).
-		Request(searchQuery).
-		Do(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to search albums: %w", err)
+	suggestion := &Suggestion{Type: target.suggestionType}
+	if err := json.Unmarshal(doc["id"], &suggestion.ID); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal hit id: %w", err)
 	}
-
-	var ids []int64
-	for _, hit := range res.Hits.Hits {
-		var album struct {
-			ID int64 `json:"id"`
-		}
-		if err := json.Unmarshal(hit.Source_, &album); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal album: %w", err)
-		}
-		ids = append(ids, album.ID)
+	if err := json.Unmarshal(doc[target.field], &suggestion.Text); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal hit %s: %w", target.field, err)
+	}
+	if hit.Score_ != nil {
+		suggestion.Score = float64(*hit.Score_)
 	}
 
-	return ids, nil
+	return suggestion, nil
 }
 
-func (c *ElasticClient) SearchTracks(ctx context.Context, query string) ([]int64, error) {
+func (c *ElasticClient) searchDocuments(ctx context.Context, index, field, query string, page SearchPage) (*SearchResult, error) {
+	cursor, origin, err := page.Position()
+	if err != nil {
+		return nil, err
+	}
+
 	searchQuery := &search.Request{
-		Query: &types.Query{
-			MultiMatch: &types.MultiMatchQuery{
-				Query:  query,
-				Fields: []string{"title"},
-			},
-		},
+		Query: c.rankedQuery(textQuery(field, query), origin),
 	}
+	page.apply(searchQuery, field, cursor)
 
 	res, err := c.elastic.Search().
-		Index(TracksIndexName).
+		Index(index).
 		Request(searchQuery).
 		Do(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to search tracks: %w", err)
+		return nil, err
 	}
 
-	var ids []int64
-	for _, hit := range res.Hits.Hits {
-		var track struct {
-			ID int64 `json:"id"`
-		}
-		if err := json.Unmarshal(hit.Source_, &track); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal track: %w", err)
-		}
-		ids = append(ids, track.ID)
-	}
+	return newSearchResult(res.Hits, field, query, page.Limit, origin)
+}
 
-	return ids, nil
+func textQuery(field, query string) *types.Query {
+	return &types.Query{
+		MultiMatch: &types.MultiMatchQuery{
+			Query:     query,
+			Fields:    searchFields(field),
+			Fuzziness: "AUTO",
+		},
+	}
 }
 
 func (c *ElasticClient) AddUser(ctx context.Context, id int64, username string) error {
@@ -239,11 +317,13 @@ func (c *ElasticClient) AddUser(ctx context.Context, id int64, username string)
 	}
 
 	user := struct {
-		ID       int64  `json:"id"`
-		Username string `json:"username"`
+		ID        int64     `json:"id"`
+		Username  string    `json:"username"`
+		CreatedAt time.Time `json:"created_at"`
 	}{
-		ID:       id,
-		Username: username,
+		ID:        id,
+		Username:  username,
+		CreatedAt: time.Now(),
 	}
 
 	_, err = c.elastic.Index(UsersIndexName).
@@ -254,7 +334,7 @@ func (c *ElasticClient) AddUser(ctx context.Context, id int64, username string)
 		return fmt.Errorf("failed to add user: %w", err)
 	}
 
-	return nil
+	return c.mirror(ctx, UsersIndexName, fmt.Sprintf("%d", id))
 }
 
 func (c *ElasticClient) AddAlbum(ctx context.Context, id int64, title string) error {
@@ -267,11 +347,13 @@ func (c *ElasticClient) AddAlbum(ctx context.Context, id int64, title string) er
 	}
 
 	album := struct {
-		ID    int64  `json:"id"`
-		Title string `json:"title"`
+		ID        int64     `json:"id"`
+		Title     string    `json:"title"`
+		CreatedAt time.Time `json:"created_at"`
 	}{
-		ID:    id,
-		Title: title,
+		ID:        id,
+		Title:     title,
+		CreatedAt: time.Now(),
 	}
 
 	_, err = c.elastic.Index(AlbumsIndexName).
@@ -282,10 +364,10 @@ func (c *ElasticClient) AddAlbum(ctx context.Context, id int64, title string) er
 		return fmt.Errorf("failed to add album: %w", err)
 	}
 
-	return nil
+	return c.mirror(ctx, AlbumsIndexName, fmt.Sprintf("%d", id))
 }
 
-func (c *ElasticClient) AddTrack(ctx context.Context, id int64, title string) error {
+func (c *ElasticClient) AddTrack(ctx context.Context, id int64, title string, attributes *TrackAttributes) error {
 	exists, err := c.elastic.Exists(TracksIndexName, fmt.Sprintf("%d", id)).Do(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to check if track exists: %w", err)
@@ -294,13 +376,12 @@ func (c *ElasticClient) AddTrack(ctx context.Context, id int64, title string) er
 		return ErrTrackAlreadyExists
 	}
 
-	track := struct {
-		ID    int64  `json:"id"`
-		Title string `json:"title"`
-	}{
-		ID:    id,
-		Title: title,
+	track := map[string]any{
+		"id":         id,
+		"title":      title,
+		"created_at": time.Now(),
 	}
+	attributes.apply(track)
 
 	_, err = c.elastic.Index(TracksIndexName).
 		Id(fmt.Sprintf("%d", id)).
@@ -310,7 +391,37 @@ func (c *ElasticClient) AddTrack(ctx context.Context, id int64, title string) er
 		return fmt.Errorf("failed to add track: %w", err)
 	}
 
-	return nil
+	return c.mirror(ctx, TracksIndexName, fmt.Sprintf("%d", id))
+}
+
+func (c *ElasticClient) AddPlaylist(ctx context.Context, id int64, title string) error {
+	exists, err := c.elastic.Exists(PlaylistsIndexName, fmt.Sprintf("%d", id)).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check if playlist exists: %w", err)
+	}
+	if exists {
+		return ErrPlaylistAlreadyExists
+	}
+
+	playlist := struct {
+		ID        int64     `json:"id"`
+		Title     string    `json:"title"`
+		CreatedAt time.Time `json:"created_at"`
+	}{
+		ID:        id,
+		Title:     title,
+		CreatedAt: time.Now(),
+	}
+
+	_, err = c.elastic.Index(PlaylistsIndexName).
+		Id(fmt.Sprintf("%d", id)).
+		Document(playlist).
+		Do(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to add playlist: %w", err)
+	}
+
+	return c.mirror(ctx, PlaylistsIndexName, fmt.Sprintf("%d", id))
 }
 
 func (c *ElasticClient) UpdateUser(ctx context.Context, id int64, username string) error {
@@ -335,7 +446,7 @@ func (c *ElasticClient) UpdateUser(ctx context.Context, id int64, username strin
 		return fmt.Errorf("failed to update user: %w", err)
 	}
 
-	return nil
+	return c.mirror(ctx, UsersIndexName, fmt.Sprintf("%d", id))
 }
 
 func (c *ElasticClient) UpdateAlbum(ctx context.Context, id int64, title string) error {
@@ -360,23 +471,22 @@ func (c *ElasticClient) UpdateAlbum(ctx context.Context, id int64, title string)
 		return fmt.Errorf("failed to update album: %w", err)
 	}
 
-	return nil
+	return c.mirror(ctx, AlbumsIndexName, fmt.Sprintf("%d", id))
 }
 
-func (c *ElasticClient) UpdateTrack(ctx context.Context, id int64, title string) error {
+func (c *ElasticClient) UpdateTrack(ctx context.Context, id int64, title string, attributes *TrackAttributes) error {
 	exists, err := c.elastic.Exists(TracksIndexName, fmt.Sprintf("%d", id)).Do(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to check if track exists: %w", err)
 	}
 	if !exists {
-		return c.AddTrack(ctx, id, title)
+		return c.AddTrack(ctx, id, title, attributes)
 	}
 
-	track := struct {
-		Title string `json:"title"`
-	}{
-		Title: title,
+	track := map[string]any{
+		"title": title,
 	}
+	attributes.apply(track)
 
 	_, err = c.elastic.Update(TracksIndexName, fmt.Sprintf("%d", id)).
 		Doc(track).
@@ -385,7 +495,32 @@ func (c *ElasticClient) UpdateTrack(ctx context.Context, id int64, title string)
 		return fmt.Errorf("failed to update track: %w", err)
 	}
 
-	return nil
+	return c.mirror(ctx, TracksIndexName, fmt.Sprintf("%d", id))
+}
+
+func (c *ElasticClient) UpdatePlaylist(ctx context.Context, id int64, title string) error {
+	exists, err := c.elastic.Exists(PlaylistsIndexName, fmt.Sprintf("%d", id)).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check if playlist exists: %w", err)
+	}
+	if !exists {
+		return c.AddPlaylist(ctx, id, title)
+	}
+
+	playlist := struct {
+		Title string `json:"title"`
+	}{
+		Title: title,
+	}
+
+	_, err = c.elastic.Update(PlaylistsIndexName, fmt.Sprintf("%d", id)).
+		Doc(playlist).
+		Do(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to update playlist: %w", err)
+	}
+
+	return c.mirror(ctx, PlaylistsIndexName, fmt.Sprintf("%d", id))
 }
 
 func (c *ElasticClient) DeleteUser(ctx context.Context, id int64) error {
@@ -397,12 +532,12 @@ func (c *ElasticClient) DeleteUser(ctx context.Context, id int64) error {
 		return ErrUserNotFound
 	}
 
-	_, err = c.elastic.Delete(UsersIndexName, fmt.Sprintf("%d", id)).Do(ctx)
+	res, err := c.elastic.Delete(UsersIndexName, fmt.Sprintf("%d", id)).Do(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to delete user: %w", err)
 	}
 
-	return nil
+	return c.mirrorDelete(ctx, UsersIndexName, res.Index_, fmt.Sprintf("%d", id), res.SeqNo_)
 }
 
 func (c *ElasticClient) DeleteAlbum(ctx context.Context, id int64) error {
@@ -414,12 +549,12 @@ func (c *ElasticClient) DeleteAlbum(ctx context.Context, id int64) error {
 		return ErrAlbumNotFound
 	}
 
-	_, err = c.elastic.Delete(AlbumsIndexName, fmt.Sprintf("%d", id)).Do(ctx)
+	res, err := c.elastic.Delete(AlbumsIndexName, fmt.Sprintf("%d", id)).Do(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to delete album: %w", err)
 	}
 
-	return nil
+	return c.mirrorDelete(ctx, AlbumsIndexName, res.Index_, fmt.Sprintf("%d", id), res.SeqNo_)
 }
 
 func (c *ElasticClient) DeleteTrack(ctx context.Context, id int64) error {
@@ -431,10 +566,76 @@ func (c *ElasticClient) DeleteTrack(ctx context.Context, id int64) error {
 		return ErrTrackNotFound
 	}
 
-	_, err = c.elastic.Delete(TracksIndexName, fmt.Sprintf("%d", id)).Do(ctx)
+	res, err := c.elastic.Delete(TracksIndexName, fmt.Sprintf("%d", id)).Do(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to delete track: %w", err)
 	}
 
+	return c.mirrorDelete(ctx, TracksIndexName, res.Index_, fmt.Sprintf("%d", id), res.SeqNo_)
+}
+
+func (c *ElasticClient) DeletePlaylist(ctx context.Context, id int64) error {
+	exists, err := c.elastic.Exists(PlaylistsIndexName, fmt.Sprintf("%d", id)).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check if playlist exists: %w", err)
+	}
+	if !exists {
+		return ErrPlaylistNotFound
+	}
+
+	res, err := c.elastic.Delete(PlaylistsIndexName, fmt.Sprintf("%d", id)).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete playlist: %w", err)
+	}
+
+	return c.mirrorDelete(ctx, PlaylistsIndexName, res.Index_, fmt.Sprintf("%d", id), res.SeqNo_)
+}
+
+func (c *ElasticClient) UpdateSignals(ctx context.Context, documentType string, signals []*DocumentSignals) error {
+	index, ok := documentIndices[documentType]
+	if !ok {
+		return ErrUnknownDocumentType
+	}
+	if len(signals) == 0 {
+		return nil
+	}
+
+	request := c.elastic.Bulk().Index(index)
+	for _, signal := range signals {
+		id := fmt.Sprintf("%d", signal.ID)
+		doc := map[string]any{
+			"plays":     signal.Plays,
+			"likes":     signal.Likes,
+			"followers": signal.Followers,
+		}
+		if !signal.CreatedAt.IsZero() {
+			doc["created_at"] = signal.CreatedAt
+		}
+
+		if err := request.UpdateOp(types.UpdateOperation{Id_: &id}, doc, nil); err != nil {
+			return fmt.Errorf("failed to build signals update: %w", err)
+		}
+	}
+
+	// Signals are pushed in periodic batches, so waiting for the refresh costs
+	// nothing and makes the new ranking visible once the call returns.
+	res, err := request.Refresh(refresh.Waitfor).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to update signals: %w", err)
+	}
+	for _, item := range res.Items {
+		for _, result := range item {
+			if result.Error != nil && result.Status != http.StatusNotFound {
+				return fmt.Errorf("failed to update signals for %s %s: %s", documentType, *result.Id_, result.Error.Type)
+			}
+		}
+	}
+
+	for _, signal := range signals {
+		if err := c.mirror(ctx, index, fmt.Sprintf("%d", signal.ID)); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
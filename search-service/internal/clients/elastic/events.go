@@ -0,0 +1,147 @@
+package elastic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/conflicts"
+)
+
+const versionedUpsertScript = `
+if (ctx._source.event_version != null && ctx._source.event_version >= params.version) {
+	ctx.op = 'noop';
+} else {
+	ctx._source.remove('deleted');
+	ctx._source.remove('deleted_at');
+	ctx._source.putAll(params.fields);
+	ctx._source.event_version = params.version;
+	if (ctx._source.created_at == null) {
+		ctx._source.created_at = params.created_at;
+	}
+}
+`
+
+// A delete keeps a tombstone with its version instead of removing the
+// document, so an older upsert relayed after it is ignored.
+const versionedDeleteScript = `
+if (ctx._source.event_version != null && ctx._source.event_version >= params.version) {
+	ctx.op = 'noop';
+} else {
+	ctx._source.clear();
+	ctx._source.id = params.id;
+	ctx._source.deleted = true;
+	ctx._source.deleted_at = params.deleted_at;
+	ctx._source.event_version = params.version;
+}
+`
+
+const upsertRetriesOnConflict = 3
+
+func (c *ElasticClient) UpsertDocument(ctx context.Context, documentType string, id int64, name string, attributes *TrackAttributes, version int64) error {
+	index, ok := documentIndices[documentType]
+	if !ok {
+		return ErrUnknownDocumentType
+	}
+
+	fields := map[string]any{
+		"id":                 id,
+		documentField(index): name,
+	}
+	attributes.apply(fields)
+
+	params, err := scriptParams(map[string]any{
+		"fields":     fields,
+		"version":    version,
+		"created_at": time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build %s upsert: %w", documentType, err)
+	}
+
+	_, err = c.elastic.Update(index, fmt.Sprintf("%d", id)).
+		Script(&types.Script{Source: stringPtr(versionedUpsertScript), Params: params}).
+		ScriptedUpsert(true).
+		Upsert(map[string]any{}).
+		RetryOnConflict(upsertRetriesOnConflict).
+		Do(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to upsert %s %d: %w", documentType, id, err)
+	}
+
+	return c.mirror(ctx, index, fmt.Sprintf("%d", id))
+}
+
+func (c *ElasticClient) DeleteDocument(ctx context.Context, documentType string, id int64, version int64) error {
+	index, ok := documentIndices[documentType]
+	if !ok {
+		return ErrUnknownDocumentType
+	}
+
+	params, err := scriptParams(map[string]any{
+		"id":         id,
+		"version":    version,
+		"deleted_at": time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build %s delete: %w", documentType, err)
+	}
+
+	_, err = c.elastic.Update(index, fmt.Sprintf("%d", id)).
+		Script(&types.Script{Source: stringPtr(versionedDeleteScript), Params: params}).
+		ScriptedUpsert(true).
+		Upsert(map[string]any{}).
+		RetryOnConflict(upsertRetriesOnConflict).
+		Do(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete %s %d: %w", documentType, id, err)
+	}
+
+	return c.mirror(ctx, index, fmt.Sprintf("%d", id))
+}
+
+// PurgeTombstones removes the tombstones of documents deleted before the
+// given time.
+func (c *ElasticClient) PurgeTombstones(ctx context.Context, before time.Time) error {
+	for _, index := range documentIndices {
+		_, err := c.elastic.DeleteByQuery(index).
+			Query(&types.Query{Bool: &types.BoolQuery{Filter: []types.Query{
+				{Term: map[string]types.TermQuery{tombstoneField: {Value: true}}},
+				{Range: map[string]types.RangeQuery{tombstoneTimeField: types.DateRangeQuery{
+					Lt: stringPtr(before.Format(time.RFC3339)),
+				}}},
+			}}}).
+			Conflicts(conflicts.Proceed).
+			Do(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to purge tombstones from %s: %w", index, err)
+		}
+	}
+
+	return nil
+}
+
+func documentField(index string) string {
+	for _, definition := range indexDefinitions {
+		if definition.name == index {
+			return definition.field
+		}
+	}
+
+	return ""
+}
+
+func scriptParams(values map[string]any) (map[string]json.RawMessage, error) {
+	params := make(map[string]json.RawMessage, len(values))
+	for key, value := range values {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		params[key] = raw
+	}
+
+	return params, nil
+}
@@ -0,0 +1,235 @@
+package elastic
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+)
+
+const (
+	genresFacet    = "genres"
+	durationsFacet = "durations"
+	facetValues    = "values"
+	MaxGenreFacets = 20
+)
+
+type DurationBucket struct {
+	Key  string
+	From int64
+	To   int64
+}
+
+var DurationBuckets = []DurationBucket{
+	{Key: "short", From: 0, To: 120},
+	{Key: "medium", From: 120, To: 300},
+	{Key: "long", From: 300, To: 600},
+	{Key: "extended", From: 600},
+}
+
+type TrackAttributes struct {
+	Genre    string
+	Tags     []string
+	Duration int64
+	UserID   int64
+}
+
+type TrackFilters struct {
+	Genres         []string
+	Tags           []string
+	MinDuration    int64
+	MaxDuration    int64
+	UploadedAfter  time.Time
+	UploadedBefore time.Time
+	ArtistIDs      []int64
+}
+
+type FacetBucket struct {
+	Key   string
+	Count int64
+	From  int64
+	To    int64
+}
+
+type Facets struct {
+	Genres    []*FacetBucket
+	Durations []*FacetBucket
+}
+
+func (a *TrackAttributes) apply(doc map[string]any) {
+	if a == nil {
+		return
+	}
+
+	doc["genre"] = a.Genre
+	doc["tags"] = a.Tags
+	doc["duration"] = a.Duration
+	doc["user_id"] = a.UserID
+}
+
+func (f *TrackFilters) queries() ([]types.Query, *types.Query, *types.Query) {
+	if f == nil {
+		return nil, nil, nil
+	}
+
+	var filters []types.Query
+	if len(f.Tags) > 0 {
+		filters = append(filters, termsQuery("tags", f.Tags))
+	}
+	if len(f.ArtistIDs) > 0 {
+		filters = append(filters, termsQuery("user_id", f.ArtistIDs))
+	}
+	if !f.UploadedAfter.IsZero() || !f.UploadedBefore.IsZero() {
+		uploaded := types.DateRangeQuery{}
+		if !f.UploadedAfter.IsZero() {
+			uploaded.Gte = stringPtr(f.UploadedAfter.Format(time.RFC3339))
+		}
+		if !f.UploadedBefore.IsZero() {
+			uploaded.Lte = stringPtr(f.UploadedBefore.Format(time.RFC3339))
+		}
+		filters = append(filters, types.Query{Range: map[string]types.RangeQuery{"created_at": uploaded}})
+	}
+
+	var genreFilter *types.Query
+	if len(f.Genres) > 0 {
+		query := termsQuery("genre", f.Genres)
+		genreFilter = &query
+	}
+
+	var durationFilter *types.Query
+	if f.MinDuration > 0 || f.MaxDuration > 0 {
+		duration := types.NumberRangeQuery{}
+		if f.MinDuration > 0 {
+			duration.Gte = float64Ptr(float64(f.MinDuration))
+		}
+		if f.MaxDuration > 0 {
+			duration.Lte = float64Ptr(float64(f.MaxDuration))
+		}
+		durationFilter = &types.Query{Range: map[string]types.RangeQuery{"duration": duration}}
+	}
+
+	return filters, genreFilter, durationFilter
+}
+
+func termsQuery[T any](field string, values []T) types.Query {
+	fieldValues := make([]types.FieldValue, 0, len(values))
+	for _, value := range values {
+		fieldValues = append(fieldValues, value)
+	}
+
+	return types.Query{
+		Terms: &types.TermsQuery{
+			TermsQuery: map[string]types.TermsQueryField{field: fieldValues},
+		},
+	}
+}
+
+func allOf(queries ...*types.Query) *types.Query {
+	filters := make([]types.Query, 0, len(queries))
+	for _, query := range queries {
+		if query != nil {
+			filters = append(filters, *query)
+		}
+	}
+	if len(filters) == 0 {
+		return nil
+	}
+
+	return &types.Query{Bool: &types.BoolQuery{Filter: filters}}
+}
+
+func facetFilter(query *types.Query) *types.Query {
+	if query == nil {
+		return &types.Query{MatchAll: types.NewMatchAllQuery()}
+	}
+
+	return query
+}
+
+func genresAggregation(durationFilter *types.Query) types.Aggregations {
+	field := "genre"
+	size := MaxGenreFacets
+
+	return types.Aggregations{
+		Filter: facetFilter(durationFilter),
+		Aggregations: map[string]types.Aggregations{
+			facetValues: {Terms: &types.TermsAggregation{Field: &field, Size: &size}},
+		},
+	}
+}
+
+func durationsAggregation(genreFilter *types.Query) types.Aggregations {
+	field := "duration"
+	ranges := make([]types.AggregationRange, 0, len(DurationBuckets))
+	for _, bucket := range DurationBuckets {
+		aggregationRange := types.AggregationRange{
+			Key:  stringPtr(bucket.Key),
+			From: float64Ptr(float64(bucket.From)),
+		}
+		if bucket.To > 0 {
+			aggregationRange.To = float64Ptr(float64(bucket.To))
+		}
+		ranges = append(ranges, aggregationRange)
+	}
+
+	return types.Aggregations{
+		Filter: facetFilter(genreFilter),
+		Aggregations: map[string]types.Aggregations{
+			facetValues: {Range: &types.RangeAggregation{Field: &field, Ranges: ranges}},
+		},
+	}
+}
+
+func parseFacets(aggregations map[string]types.Aggregate) (*Facets, error) {
+	facets := &Facets{
+		Genres:    []*FacetBucket{},
+		Durations: []*FacetBucket{},
+	}
+
+	genres, err := facetValuesAggregate(aggregations, genresFacet)
+	if err != nil {
+		return nil, err
+	}
+	if terms, ok := genres.(*types.StringTermsAggregate); ok {
+		buckets, _ := terms.Buckets.([]types.StringTermsBucket)
+		for _, bucket := range buckets {
+			facets.Genres = append(facets.Genres, &FacetBucket{
+				Key:   fmt.Sprintf("%v", bucket.Key),
+				Count: bucket.DocCount,
+			})
+		}
+	}
+
+	durations, err := facetValuesAggregate(aggregations, durationsFacet)
+	if err != nil {
+		return nil, err
+	}
+	if ranges, ok := durations.(*types.RangeAggregate); ok {
+		buckets, _ := ranges.Buckets.([]types.RangeBucket)
+		for _, bucket := range buckets {
+			facet := &FacetBucket{Count: bucket.DocCount}
+			if bucket.Key != nil {
+				facet.Key = *bucket.Key
+			}
+			if bucket.From != nil {
+				facet.From = int64(math.Round(float64(*bucket.From)))
+			}
+			if bucket.To != nil {
+				facet.To = int64(math.Round(float64(*bucket.To)))
+			}
+			facets.Durations = append(facets.Durations, facet)
+		}
+	}
+
+	return facets, nil
+}
+
+func facetValuesAggregate(aggregations map[string]types.Aggregate, name string) (types.Aggregate, error) {
+	filter, ok := aggregations[name].(*types.FilterAggregate)
+	if !ok {
+		return nil, fmt.Errorf("failed to parse %s facet", name)
+	}
+
+	return filter.Aggregations[facetValues], nil
+}
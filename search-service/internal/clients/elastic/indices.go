@@ -0,0 +1,251 @@
+package elastic
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/core/reindex"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/conflicts"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/optype"
+)
+
+const (
+	russianSubfield  = "ru"
+	englishSubfield  = "en"
+	translitSubfield = "translit"
+	suggestSubfield  = "suggest"
+)
+
+type indexDefinition struct {
+	name  string
+	field string
+}
+
+var indexDefinitions = []indexDefinition{
+	{name: UsersIndexName, field: "username"},
+	{name: AlbumsIndexName, field: "title"},
+	{name: TracksIndexName, field: "title"},
+	{name: PlaylistsIndexName, field: "title"},
+	{name: SearchQueriesIndexName, field: "query"},
+}
+
+func VersionedIndexName(name string, version int) string {
+	return fmt.Sprintf("%s_v%d", name, version)
+}
+
+// newIndexName names a new physical index for a migration. The creation time
+// keeps it from colliding with indices left by earlier versioning schemes.
+func newIndexName(alias string, version int) string {
+	return fmt.Sprintf("%s_%d", VersionedIndexName(alias, version), time.Now().Unix())
+}
+
+func searchFields(field string) []string {
+	return []string{
+		field,
+		fmt.Sprintf("%s.%s", field, russianSubfield),
+		fmt.Sprintf("%s.%s", field, englishSubfield),
+		fmt.Sprintf("%s.%s", field, translitSubfield),
+	}
+}
+
+func (c *ElasticClient) createIndex(ctx context.Context, index string, migration *indexMigration) error {
+	_, err := c.elastic.Indices.Create(index).
+		Raw(bytes.NewReader(migration.body)).
+		Do(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create %s index: %w", index, err)
+	}
+
+	return nil
+}
+
+// ensureIndices creates the aliases that do not exist yet. Existing aliases
+// behind their latest migration are left serving as they are until the
+// migrate-indices job rebuilds them.
+func (c *ElasticClient) ensureIndices(ctx context.Context, log *slog.Logger) error {
+	for _, definition := range indexDefinitions {
+		if err := c.ensureIndex(ctx, log, definition.name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *ElasticClient) ensureIndex(ctx context.Context, log *slog.Logger, alias string) error {
+	migration, err := c.migrations.latest(alias)
+	if err != nil {
+		return err
+	}
+
+	current, err := c.previousIndices(ctx, alias)
+	if err != nil {
+		return err
+	}
+	if len(current) > 0 {
+		pending := len(current) > 1
+		if !pending {
+			applied, err := c.appliedMigration(ctx, current[0])
+			if err != nil {
+				return err
+			}
+			pending = applied < migration.version
+		}
+		if pending {
+			log.Warn("Index migration is pending, run the migrate-indices job",
+				slog.String("alias", alias),
+				slog.Any("indices", current),
+				slog.String("migration", migration.name),
+			)
+		}
+		return nil
+	}
+
+	target := newIndexName(alias, migration.version)
+	log.Info("Creating index", slog.String("alias", alias), slog.String("target", target))
+
+	if err := c.createIndex(ctx, target, migration); err != nil {
+		return err
+	}
+
+	return c.swapAlias(ctx, alias, target, nil)
+}
+
+// MigrateIndices applies pending index migrations. Each alias keeps serving
+// its current index while the documents are copied into the new one.
+func (c *ElasticClient) MigrateIndices(ctx context.Context, log *slog.Logger) error {
+	for _, definition := range indexDefinitions {
+		if err := c.migrateIndex(ctx, log, definition.name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *ElasticClient) migrateIndex(ctx context.Context, log *slog.Logger, alias string) error {
+	migration, err := c.migrations.latest(alias)
+	if err != nil {
+		return err
+	}
+
+	previous, err := c.previousIndices(ctx, alias)
+	if err != nil {
+		return err
+	}
+	if len(previous) == 1 {
+		applied, err := c.appliedMigration(ctx, previous[0])
+		if err != nil {
+			return err
+		}
+		if applied >= migration.version {
+			return nil
+		}
+	}
+
+	target, err := c.migrationTarget(ctx, alias, migration)
+	if err != nil {
+		return err
+	}
+	log.Info("Applying index migration",
+		slog.String("alias", alias),
+		slog.String("target", target),
+		slog.String("migration", migration.name),
+	)
+
+	if len(previous) > 0 {
+		if err := c.StartRebuild(ctx, alias, target); err != nil {
+			return err
+		}
+
+		// Documents mirrored by live writes are newer than the copy, so the
+		// copy only creates the ones that are still missing.
+		_, err = c.elastic.Reindex().
+			Request(&reindex.Request{
+				Conflicts: &conflicts.Proceed,
+				Source:    types.ReindexSource{Index: previous},
+				Dest:      types.ReindexDestination{Index: target, OpType: &optype.Create},
+			}).
+			WaitForCompletion(true).
+			Do(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to reindex %s into %s: %w", alias, target, err)
+		}
+	}
+
+	return c.swapAlias(ctx, alias, target, previous)
+}
+
+// migrationTarget returns the rebuild target an interrupted run left for the
+// migration, or creates a new one.
+func (c *ElasticClient) migrationTarget(ctx context.Context, alias string, migration *indexMigration) (string, error) {
+	targets, err := c.aliasedIndices(ctx, RebuildAliasName(alias))
+	if err != nil {
+		return "", err
+	}
+
+	for _, target := range targets {
+		applied, err := c.appliedMigration(ctx, target)
+		if err != nil {
+			return "", err
+		}
+		if applied == migration.version {
+			return target, nil
+		}
+	}
+
+	target := newIndexName(alias, migration.version)
+	if err := c.createIndex(ctx, target, migration); err != nil {
+		return "", err
+	}
+
+	return target, nil
+}
+
+// previousIndices returns the indices behind alias, or the legacy index that
+// was created under the alias name before indices were versioned.
+func (c *ElasticClient) previousIndices(ctx context.Context, alias string) ([]string, error) {
+	current, err := c.aliasedIndices(ctx, alias)
+	if err != nil || len(current) > 0 {
+		return current, err
+	}
+
+	legacy, err := c.IndexExists(ctx, alias)
+	if err != nil || !legacy {
+		return nil, err
+	}
+
+	return []string{alias}, nil
+}
+
+func (c *ElasticClient) aliasedIndices(ctx context.Context, alias string) ([]string, error) {
+	exists, err := c.elastic.Indices.ExistsAlias(alias).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if %s alias exists: %w", alias, err)
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	aliases, err := c.elastic.Indices.GetAlias().Name(alias).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s alias: %w", alias, err)
+	}
+
+	indices := make([]string, 0, len(aliases))
+	for index := range aliases {
+		indices = append(indices, index)
+	}
+	slices.Sort(indices)
+
+	return indices, nil
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
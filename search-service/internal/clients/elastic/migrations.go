@@ -0,0 +1,147 @@
+package elastic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	analysisFile  = "analysis.json"
+	migrationMeta = "migration"
+)
+
+var migrationFileRegexp = regexp.MustCompile(`^(\d+)_(\w+)\.json$`)
+
+var ErrNoIndexMigrations = errors.New("no index migrations")
+
+type indexMigration struct {
+	version int
+	name    string
+	body    []byte
+}
+
+type indexMigrations map[string][]*indexMigration
+
+type migrationFile struct {
+	Mappings struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+	} `json:"mappings"`
+}
+
+// loadMigrations builds the full index body of every migration: the shared
+// analysis settings, the properties added by it and all earlier migrations of
+// the alias, and the migration version in the mapping metadata.
+func loadMigrations(fsys fs.FS) (indexMigrations, error) {
+	analysis, err := fs.ReadFile(fsys, analysisFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index analysis settings: %w", err)
+	}
+
+	migrations := make(indexMigrations, len(indexDefinitions))
+
+	for _, definition := range indexDefinitions {
+		entries, err := fs.ReadDir(fsys, definition.name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s migrations: %w", definition.name, err)
+		}
+
+		files := make(map[int]migrationFile, len(entries))
+		for _, entry := range entries {
+			matches := migrationFileRegexp.FindStringSubmatch(entry.Name())
+			if entry.IsDir() || matches == nil {
+				continue
+			}
+
+			version, err := strconv.Atoi(matches[1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s migration %s: %w", definition.name, entry.Name(), err)
+			}
+			if _, ok := files[version]; ok {
+				return nil, fmt.Errorf("duplicate %s migration version %d", definition.name, version)
+			}
+
+			data, err := fs.ReadFile(fsys, path.Join(definition.name, entry.Name()))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s migration %s: %w", definition.name, entry.Name(), err)
+			}
+
+			var file migrationFile
+			if err := json.Unmarshal(data, &file); err != nil {
+				return nil, fmt.Errorf("invalid %s migration %s: %w", definition.name, entry.Name(), err)
+			}
+
+			files[version] = file
+			migrations[definition.name] = append(migrations[definition.name], &indexMigration{
+				version: version,
+				name:    strings.TrimSuffix(entry.Name(), ".json"),
+			})
+		}
+
+		slices.SortFunc(migrations[definition.name], func(a, b *indexMigration) int {
+			return a.version - b.version
+		})
+
+		properties := make(map[string]json.RawMessage)
+		for _, migration := range migrations[definition.name] {
+			maps.Copy(properties, files[migration.version].Mappings.Properties)
+
+			migration.body, err = json.Marshal(map[string]any{
+				"settings": map[string]json.RawMessage{"analysis": analysis},
+				"mappings": map[string]any{
+					"_meta":      map[string]int{migrationMeta: migration.version},
+					"properties": properties,
+				},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to build %s migration %s: %w", definition.name, migration.name, err)
+			}
+		}
+	}
+
+	return migrations, nil
+}
+
+func (m indexMigrations) latest(alias string) (*indexMigration, error) {
+	migrations := m[alias]
+	if len(migrations) == 0 {
+		return nil, fmt.Errorf("%w for %s", ErrNoIndexMigrations, alias)
+	}
+
+	return migrations[len(migrations)-1], nil
+}
+
+// appliedMigration returns the migration an index was created from. Indices
+// created before migrations were recorded in the mapping metadata report 0,
+// so they are always migrated.
+func (c *ElasticClient) appliedMigration(ctx context.Context, index string) (int, error) {
+	res, err := c.elastic.Indices.GetMapping().Index(index).Do(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get %s mapping: %w", index, err)
+	}
+
+	record, ok := res[index]
+	if !ok {
+		return 0, nil
+	}
+
+	raw, ok := record.Mappings.Meta_[migrationMeta]
+	if !ok {
+		return 0, nil
+	}
+
+	var version int
+	if err := json.Unmarshal(raw, &version); err != nil {
+		return 0, fmt.Errorf("invalid %s migration metadata: %w", index, err)
+	}
+
+	return version, nil
+}
@@ -0,0 +1,256 @@
+package elastic
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"log/slog"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/ocenb/music-go/search-service/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCluster serves the index and alias endpoints the migrations use and
+// keeps the migration version every index was created from.
+type fakeCluster struct {
+	mu      sync.Mutex
+	indices map[string]int
+	aliases map[string]map[string]bool
+	created []string
+}
+
+func newFakeCluster() *fakeCluster {
+	return &fakeCluster{
+		indices: make(map[string]int),
+		aliases: make(map[string]map[string]bool),
+	}
+}
+
+func (f *fakeCluster) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	w.Header().Set("X-Elastic-Product", "Elasticsearch")
+	w.Header().Set("Content-Type", "application/json")
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case parts[0] == "_alias" && r.Method == http.MethodHead:
+		if len(f.aliasedIndices(parts[1])) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	case parts[0] == "_alias":
+		response := make(map[string]any)
+		for _, index := range f.aliasedIndices(parts[1]) {
+			response[index] = map[string]any{"aliases": map[string]any{parts[1]: map[string]any{}}}
+		}
+		writeJSON(w, response)
+	case parts[0] == "_aliases":
+		f.updateAliases(w, r)
+	case len(parts) == 1 && r.Method == http.MethodHead:
+		if _, ok := f.indices[parts[0]]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	case len(parts) == 1 && r.Method == http.MethodPut:
+		f.createIndex(w, r, parts[0])
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		delete(f.indices, parts[0])
+		for _, indices := range f.aliases {
+			delete(indices, parts[0])
+		}
+		writeJSON(w, map[string]any{"acknowledged": true})
+	case len(parts) == 2 && parts[1] == "_mapping":
+		mappings := map[string]any{}
+		if version := f.indices[parts[0]]; version > 0 {
+			mappings["_meta"] = map[string]int{migrationMeta: version}
+		}
+		writeJSON(w, map[string]any{parts[0]: map[string]any{"mappings": mappings}})
+	case len(parts) == 2 && parts[1] == "_refresh":
+		writeJSON(w, map[string]any{"_shards": map[string]int{"total": 1, "successful": 1, "failed": 0}})
+	case len(parts) == 2 && parts[1] == "_delete_by_query":
+		writeJSON(w, map[string]any{"deleted": 0, "failures": []any{}})
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		writeJSON(w, map[string]any{"error": map[string]any{"type": "unsupported", "reason": r.Method + " " + r.URL.Path}, "status": http.StatusBadRequest})
+	}
+}
+
+func (f *fakeCluster) aliasedIndices(alias string) []string {
+	return slices.Sorted(maps.Keys(f.aliases[alias]))
+}
+
+func (f *fakeCluster) createIndex(w http.ResponseWriter, r *http.Request, index string) {
+	var body struct {
+		Mappings struct {
+			Meta map[string]int `json:"_meta"`
+		} `json:"mappings"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	f.indices[index] = body.Mappings.Meta[migrationMeta]
+	f.created = append(f.created, index)
+	writeJSON(w, map[string]any{"acknowledged": true, "shards_acknowledged": true, "index": index})
+}
+
+func (f *fakeCluster) updateAliases(w http.ResponseWriter, r *http.Request) {
+	type aliasAction struct {
+		Index string `json:"index"`
+		Alias string `json:"alias"`
+	}
+	var body struct {
+		Actions []struct {
+			Add         *aliasAction `json:"add"`
+			Remove      *aliasAction `json:"remove"`
+			RemoveIndex *aliasAction `json:"remove_index"`
+		} `json:"actions"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	for _, action := range body.Actions {
+		switch {
+		case action.Add != nil:
+			if f.aliases[action.Add.Alias] == nil {
+				f.aliases[action.Add.Alias] = make(map[string]bool)
+			}
+			f.aliases[action.Add.Alias][action.Add.Index] = true
+		case action.Remove != nil:
+			delete(f.aliases[action.Remove.Alias], action.Remove.Index)
+		case action.RemoveIndex != nil:
+			delete(f.indices, action.RemoveIndex.Index)
+			for _, indices := range f.aliases {
+				delete(indices, action.RemoveIndex.Index)
+			}
+		}
+	}
+
+	writeJSON(w, map[string]any{"acknowledged": true})
+}
+
+func writeJSON(w http.ResponseWriter, value any) {
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func newTestClient(t *testing.T, cluster *fakeCluster) *ElasticClient {
+	t.Helper()
+
+	server := httptest.NewServer(cluster)
+	t.Cleanup(server.Close)
+
+	es, err := elasticsearch.NewTypedClient(elasticsearch.Config{Addresses: []string{server.URL}})
+	require.NoError(t, err)
+
+	indexMigrations, err := loadMigrations(migrations.FS)
+	require.NoError(t, err)
+
+	return &ElasticClient{
+		elastic:    es,
+		migrations: indexMigrations,
+		rebuilds:   newRebuildTargets(),
+	}
+}
+
+func migrationsFS(files map[string]string) fstest.MapFS {
+	fsys := fstest.MapFS{analysisFile: {Data: []byte(`{}`)}}
+	for _, definition := range indexDefinitions {
+		fsys[definition.name] = &fstest.MapFile{Mode: fs.ModeDir}
+	}
+	for name, data := range files {
+		fsys[name] = &fstest.MapFile{Data: []byte(data)}
+	}
+	return fsys
+}
+
+func TestLoadMigrationsAppliesInVersionOrder(t *testing.T) {
+	fsys := migrationsFS(map[string]string{
+		"tracks/000010_add_plays.json": `{"mappings":{"properties":{"plays":{"type":"long"},"title":{"type":"keyword"}}}}`,
+		"tracks/000002_add_title.json": `{"mappings":{"properties":{"title":{"type":"text"}}}}`,
+		"tracks/000003_add_genre.json": `{"mappings":{"properties":{"genre":{"type":"keyword"}}}}`,
+		"tracks/notes.txt":             `not a migration`,
+	})
+
+	loaded, err := loadMigrations(fsys)
+	require.NoError(t, err)
+
+	tracks := loaded[TracksIndexName]
+	require.Len(t, tracks, 3)
+	assert.Equal(t, []int{2, 3, 10}, []int{tracks[0].version, tracks[1].version, tracks[2].version})
+
+	var first, last struct {
+		Mappings struct {
+			Meta       map[string]int               `json:"_meta"`
+			Properties map[string]map[string]string `json:"properties"`
+		} `json:"mappings"`
+	}
+	require.NoError(t, json.Unmarshal(tracks[0].body, &first))
+	require.NoError(t, json.Unmarshal(tracks[2].body, &last))
+
+	assert.Equal(t, 2, first.Mappings.Meta[migrationMeta])
+	assert.Equal(t, []string{"title"}, slices.Sorted(maps.Keys(first.Mappings.Properties)))
+	assert.Equal(t, 10, last.Mappings.Meta[migrationMeta])
+	assert.Equal(t, []string{"genre", "plays", "title"}, slices.Sorted(maps.Keys(last.Mappings.Properties)))
+	assert.Equal(t, "keyword", last.Mappings.Properties["title"]["type"], "later migrations override earlier properties")
+
+	latest, err := loaded.latest(TracksIndexName)
+	require.NoError(t, err)
+	assert.Equal(t, 10, latest.version)
+
+	_, err = loaded.latest(UsersIndexName)
+	assert.ErrorIs(t, err, ErrNoIndexMigrations)
+}
+
+func TestLoadMigrationsRejectsDuplicateVersions(t *testing.T) {
+	fsys := migrationsFS(map[string]string{
+		"tracks/000002_add_title.json": `{"mappings":{"properties":{"title":{"type":"text"}}}}`,
+		"tracks/000002_add_genre.json": `{"mappings":{"properties":{"genre":{"type":"keyword"}}}}`,
+	})
+
+	_, err := loadMigrations(fsys)
+	assert.ErrorContains(t, err, "duplicate tracks migration version 2")
+}
+
+func TestMigrateIndicesTwice(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cluster := newFakeCluster()
+	client := newTestClient(t, cluster)
+
+	require.NoError(t, client.MigrateIndices(ctx, log))
+
+	require.Len(t, cluster.created, len(indexDefinitions))
+	for _, definition := range indexDefinitions {
+		latest, err := client.migrations.latest(definition.name)
+		require.NoError(t, err)
+
+		indices := cluster.aliasedIndices(definition.name)
+		require.Len(t, indices, 1)
+		assert.Equal(t, latest.version, cluster.indices[indices[0]])
+	}
+	applied := make(map[string][]string, len(cluster.aliases))
+	for alias := range cluster.aliases {
+		applied[alias] = cluster.aliasedIndices(alias)
+	}
+
+	require.NoError(t, client.MigrateIndices(ctx, log))
+
+	assert.Len(t, cluster.created, len(indexDefinitions), "a second run creates no indices")
+	for alias, indices := range applied {
+		assert.Equal(t, indices, cluster.aliasedIndices(alias))
+	}
+}
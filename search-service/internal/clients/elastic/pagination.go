@@ -0,0 +1,206 @@
+package elastic
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/sortorder"
+)
+
+// MaxResultWindow mirrors Elasticsearch's index.max_result_window: from+size
+// beyond it is rejected, so deep pages have to use the cursor instead.
+const MaxResultWindow = 10000
+
+// rankingOriginPrecision rounds the recency origin, so the scores of a query
+// change at most once a day and a cursor can pin them for later pages.
+const rankingOriginPrecision = 24 * time.Hour
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+type SearchPage struct {
+	Limit          int
+	Offset         int
+	Cursor         string
+	Highlight      bool
+	AutoCorrect    bool
+	SkipCorrection bool
+	Correction     string
+}
+
+type SearchHit struct {
+	ID         int64
+	Score      float64
+	Highlights []string
+}
+
+type SearchResult struct {
+	Hits           []*SearchHit
+	Total          int64
+	NextCursor     string
+	Facets         *Facets
+	QueryID        string
+	SuggestedQuery string
+	Corrected      bool
+}
+
+// Cursor points after the last hit of a page. Origin is the recency origin
+// the page was ranked with, so the following pages are scored the same way.
+// Query is the query the page was found for, which is the corrected one after
+// an auto-correction, and Original is the query the user searched for, so the
+// cursor is only accepted for that search.
+type Cursor struct {
+	Score    float64
+	ID       int64
+	Origin   time.Time
+	Query    string
+	Original string
+}
+
+type cursorData struct {
+	Score    *float64 `json:"score"`
+	ID       *int64   `json:"id"`
+	Origin   *int64   `json:"origin"`
+	Query    *string  `json:"query"`
+	Original *string  `json:"original"`
+}
+
+func (r *SearchResult) IDs() []int64 {
+	ids := make([]int64, 0, len(r.Hits))
+	for _, hit := range r.Hits {
+		ids = append(ids, hit.ID)
+	}
+	return ids
+}
+
+// Position returns the cursor of the page, if any, and the recency origin
+// the page has to be ranked with.
+func (p SearchPage) Position() (*Cursor, time.Time, error) {
+	if p.Cursor == "" {
+		return nil, RankingOrigin(time.Now()), nil
+	}
+
+	cursor, err := DecodeCursor(p.Cursor)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	return cursor, cursor.Origin, nil
+}
+
+func RankingOrigin(now time.Time) time.Time {
+	return now.UTC().Truncate(rankingOriginPrecision)
+}
+
+func (p SearchPage) apply(request *search.Request, field string, cursor *Cursor) {
+	size := p.Limit + 1
+	request.Size = &size
+	request.TrackTotalHits = true
+	request.Sort = []types.SortCombinations{
+		types.SortOptions{Score_: &types.ScoreSort{Order: &sortorder.Desc}},
+		types.SortOptions{SortOptions: map[string]types.FieldSort{"id": {Order: &sortorder.Asc}}},
+	}
+
+	if cursor != nil {
+		request.SearchAfter = []types.FieldValue{cursor.Score, strconv.FormatInt(cursor.ID, 10)}
+	} else {
+		request.From = &p.Offset
+	}
+
+	if p.Highlight {
+		fields := make(map[string]types.HighlightField)
+		for _, searchField := range searchFields(field) {
+			fields[searchField] = types.HighlightField{}
+		}
+		request.Highlight = &types.Highlight{Fields: fields}
+	}
+}
+
+func newSearchResult(metadata types.HitsMetadata, field, query string, limit int, origin time.Time) (*SearchResult, error) {
+	hits := metadata.Hits
+	result := &SearchResult{}
+	hasMore := len(hits) > limit
+	if hasMore {
+		hits = hits[:limit]
+	}
+
+	result.Hits = make([]*SearchHit, 0, len(hits))
+	for _, hit := range hits {
+		var doc struct {
+			ID int64 `json:"id"`
+		}
+		if err := json.Unmarshal(hit.Source_, &doc); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal hit: %w", err)
+		}
+
+		searchHit := &SearchHit{ID: doc.ID}
+		if hit.Score_ != nil {
+			searchHit.Score = float64(*hit.Score_)
+		}
+		for _, searchField := range searchFields(field) {
+			for _, fragment := range hit.Highlight[searchField] {
+				if !slices.Contains(searchHit.Highlights, fragment) {
+					searchHit.Highlights = append(searchHit.Highlights, fragment)
+				}
+			}
+		}
+		result.Hits = append(result.Hits, searchHit)
+	}
+
+	if hasMore {
+		last := result.Hits[len(result.Hits)-1]
+		cursor, err := EncodeCursor(&Cursor{Score: last.Score, ID: last.ID, Origin: origin, Query: query, Original: query})
+		if err != nil {
+			return nil, err
+		}
+		result.NextCursor = cursor
+	}
+
+	if metadata.Total != nil {
+		result.Total = metadata.Total.Value
+	}
+
+	return result, nil
+}
+
+func EncodeCursor(cursor *Cursor) (string, error) {
+	origin := cursor.Origin.Unix()
+	data, err := json.Marshal(cursorData{Score: &cursor.Score, ID: &cursor.ID, Origin: &origin, Query: &cursor.Query, Original: &cursor.Original})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func DecodeCursor(cursor string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var decoded cursorData
+	if err := decoder.Decode(&decoded); err != nil || decoder.More() {
+		return nil, ErrInvalidCursor
+	}
+	if decoded.Score == nil || decoded.ID == nil || decoded.Origin == nil || *decoded.Origin <= 0 || decoded.Query == nil || decoded.Original == nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &Cursor{
+		Score:    *decoded.Score,
+		ID:       *decoded.ID,
+		Origin:   time.Unix(*decoded.Origin, 0).UTC(),
+		Query:    *decoded.Query,
+		Original: *decoded.Original,
+	}, nil
+}
@@ -0,0 +1,79 @@
+package elastic
+
+import (
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/fieldvaluefactormodifier"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/functionboostmode"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/functionscoremode"
+)
+
+func (c *ElasticClient) rankedQuery(query *types.Query, origin time.Time) *types.Query {
+	signals := []struct {
+		field  string
+		weight float64
+	}{
+		{field: "plays", weight: c.ranking.PlaysWeight},
+		{field: "likes", weight: c.ranking.LikesWeight},
+		{field: "followers", weight: c.ranking.FollowersWeight},
+	}
+
+	// Every document starts from a boost of 1, so with multiply an unknown
+	// document keeps its text score and signals can only scale it up to
+	// MaxBoost instead of drowning it.
+	functions := make([]types.FunctionScore, 0, len(signals)+2)
+	functions = append(functions, types.FunctionScore{Weight: float64Ptr(1)})
+	for _, signal := range signals {
+		if signal.weight == 0 {
+			continue
+		}
+		functions = append(functions, types.FunctionScore{
+			FieldValueFactor: &types.FieldValueFactorScoreFunction{
+				Field:    signal.field,
+				Modifier: &fieldvaluefactormodifier.Log1p,
+				Missing:  float64Ptr(0),
+			},
+			Weight: float64Ptr(signal.weight),
+		})
+	}
+
+	if c.ranking.RecencyWeight != 0 {
+		// Decay functions score documents without the field as 1, so the
+		// filter keeps them from looking brand new.
+		functions = append(functions, types.FunctionScore{
+			Filter: &types.Query{Exists: &types.ExistsQuery{Field: "created_at"}},
+			Gauss: types.DateDecayFunction{
+				DecayFunctionBaseDateMathDuration: map[string]types.DecayPlacementDateMathDuration{
+					"created_at": {
+						Origin: stringPtr(origin.Format(time.RFC3339)),
+						Scale:  c.ranking.RecencyScale,
+						Decay:  float64Ptr(0.5),
+					},
+				},
+			},
+			Weight: float64Ptr(c.ranking.RecencyWeight),
+		})
+	}
+
+	if len(functions) == 1 {
+		return query
+	}
+
+	functionScore := &types.FunctionScoreQuery{
+		Query:     query,
+		Functions: functions,
+		ScoreMode: &functionscoremode.Sum,
+		BoostMode: &functionboostmode.Multiply,
+	}
+	if c.ranking.MaxBoost > 0 {
+		functionScore.MaxBoost = float64Ptr(c.ranking.MaxBoost)
+	}
+
+	return &types.Query{FunctionScore: functionScore}
+}
+
+func float64Ptr(f float64) *types.Float64 {
+	value := types.Float64(f)
+	return &value
+}
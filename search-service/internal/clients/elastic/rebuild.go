@@ -0,0 +1,219 @@
+package elastic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/indices/updatealiases"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/conflicts"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/versiontype"
+)
+
+// While an alias is being rebuilt into a new index, the new index is kept in
+// a second "<alias>_rebuild" alias and every live write to the alias is
+// mirrored into it, so nothing written during the copy is lost at the swap.
+
+const (
+	rebuildAliasSuffix = "_rebuild"
+	rebuildTargetsTTL  = 5 * time.Second
+	tombstoneField     = "deleted"
+	tombstoneTimeField = "deleted_at"
+)
+
+// RebuildPropagationDelay is how long a rebuild waits after registering its
+// target before copying, so every running server has started mirroring
+// writes into it.
+const RebuildPropagationDelay = 3 * rebuildTargetsTTL
+
+type rebuildTargets struct {
+	mu      sync.Mutex
+	entries map[string]rebuildTargetsEntry
+}
+
+type rebuildTargetsEntry struct {
+	indices   []string
+	fetchedAt time.Time
+}
+
+func newRebuildTargets() *rebuildTargets {
+	return &rebuildTargets{entries: make(map[string]rebuildTargetsEntry)}
+}
+
+func RebuildAliasName(alias string) string {
+	return alias + rebuildAliasSuffix
+}
+
+// StartRebuild registers target as a rebuild target of alias and waits until
+// running servers mirror their writes into it.
+func (c *ElasticClient) StartRebuild(ctx context.Context, alias, target string) error {
+	rebuildAlias := RebuildAliasName(alias)
+	_, err := c.elastic.Indices.UpdateAliases().
+		Request(&updatealiases.Request{Actions: []types.IndicesAction{
+			{Add: &types.AddAction{Index: &target, Alias: &rebuildAlias}},
+		}}).
+		Do(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to register %s as a rebuild target of %s: %w", target, alias, err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(RebuildPropagationDelay):
+		return nil
+	}
+}
+
+func (c *ElasticClient) rebuildTargets(ctx context.Context, alias string) ([]string, error) {
+	c.rebuilds.mu.Lock()
+	entry, ok := c.rebuilds.entries[alias]
+	c.rebuilds.mu.Unlock()
+	if ok && time.Since(entry.fetchedAt) < rebuildTargetsTTL {
+		return entry.indices, nil
+	}
+
+	indices, err := c.aliasedIndices(ctx, RebuildAliasName(alias))
+	if err != nil {
+		return nil, err
+	}
+
+	c.rebuilds.mu.Lock()
+	c.rebuilds.entries[alias] = rebuildTargetsEntry{indices: indices, fetchedAt: time.Now()}
+	c.rebuilds.mu.Unlock()
+
+	return indices, nil
+}
+
+// mirror copies the current state of a document from the alias into its
+// rebuild targets. The alias' sequence number is used as an external version,
+// so a slower mirror never overwrites a newer one.
+func (c *ElasticClient) mirror(ctx context.Context, alias, id string) error {
+	targets, err := c.rebuildTargets(ctx, alias)
+	if err != nil || len(targets) == 0 {
+		return err
+	}
+
+	res, err := c.elastic.Get(alias, id).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get %s %s for mirroring: %w", alias, id, err)
+	}
+	if !res.Found || res.SeqNo_ == nil {
+		// The document was deleted in the meantime and that delete mirrors
+		// its own tombstone.
+		return nil
+	}
+
+	return c.mirrorSource(ctx, targets, res.Index_, id, *res.SeqNo_, res.Source_)
+}
+
+// mirrorDelete leaves a tombstone in the rebuild targets instead of deleting,
+// so the copy does not bring the document back.
+func (c *ElasticClient) mirrorDelete(ctx context.Context, alias, index, id string, seqNo *int64) error {
+	if seqNo == nil {
+		return nil
+	}
+
+	targets, err := c.rebuildTargets(ctx, alias)
+	if err != nil || len(targets) == 0 {
+		return err
+	}
+
+	tombstone, err := json.Marshal(map[string]any{tombstoneField: true})
+	if err != nil {
+		return fmt.Errorf("failed to build tombstone: %w", err)
+	}
+
+	return c.mirrorSource(ctx, targets, index, id, *seqNo, tombstone)
+}
+
+func (c *ElasticClient) mirrorSource(ctx context.Context, targets []string, source, id string, seqNo int64, document json.RawMessage) error {
+	version := strconv.FormatInt(seqNo+1, 10)
+	for _, target := range targets {
+		if target == source {
+			continue
+		}
+
+		_, err := c.elastic.Index(target).
+			Id(id).
+			Raw(bytes.NewReader(document)).
+			VersionType(versiontype.External).
+			Version(version).
+			Do(ctx)
+		if err != nil && !isVersionConflict(err) {
+			return fmt.Errorf("failed to mirror %s into %s: %w", id, target, err)
+		}
+	}
+
+	return nil
+}
+
+// swapAlias atomically points alias at target, drops target from the rebuild
+// alias and deletes the previous indices, then clears the tombstones mirrored
+// for hard deletes. Versioned tombstones stay until PurgeTombstones.
+func (c *ElasticClient) swapAlias(ctx context.Context, alias, target string, previous []string) error {
+	if err := c.refresh(ctx, target); err != nil {
+		return err
+	}
+
+	rebuildAlias := RebuildAliasName(alias)
+	isWriteIndex := true
+	mustExist := false
+	actions := []types.IndicesAction{
+		{Add: &types.AddAction{Index: &target, Alias: &alias, IsWriteIndex: &isWriteIndex}},
+		{Remove: &types.RemoveAction{Index: &target, Alias: &rebuildAlias, MustExist: &mustExist}},
+	}
+	for _, index := range previous {
+		if index == target {
+			continue
+		}
+		actions = append(actions, types.IndicesAction{RemoveIndex: &types.RemoveIndexAction{Index: &index}})
+	}
+
+	_, err := c.elastic.Indices.UpdateAliases().
+		Request(&updatealiases.Request{Actions: actions}).
+		Do(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to point %s alias to %s: %w", alias, target, err)
+	}
+
+	// Mirrors that landed after the first refresh have to be visible to the
+	// purge as well.
+	if err := c.refresh(ctx, target); err != nil {
+		return err
+	}
+
+	_, err = c.elastic.DeleteByQuery(target).
+		Query(&types.Query{Bool: &types.BoolQuery{
+			Filter:  []types.Query{{Term: map[string]types.TermQuery{tombstoneField: {Value: true}}}},
+			MustNot: []types.Query{{Exists: &types.ExistsQuery{Field: tombstoneTimeField}}},
+		}}).
+		Conflicts(conflicts.Proceed).
+		Do(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to purge tombstones from %s: %w", target, err)
+	}
+
+	return nil
+}
+
+func (c *ElasticClient) refresh(ctx context.Context, index string) error {
+	_, err := c.elastic.Indices.Refresh().Index(index).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to refresh %s index: %w", index, err)
+	}
+
+	return nil
+}
+
+func isVersionConflict(err error) bool {
+	var esErr *types.ElasticsearchError
+	return errors.As(err, &esErr) && esErr.Status == http.StatusConflict
+}
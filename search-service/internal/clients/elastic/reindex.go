@@ -0,0 +1,180 @@
+package elastic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/refresh"
+)
+
+const ReindexCheckpointsIndexName = "reindex_checkpoints"
+
+// mergeScript only fills in fields the target is missing: documents mirrored
+// by live writes are newer than the copy, and tombstones stay deleted.
+const mergeScript = `
+if (ctx._source.deleted == true) {
+	ctx.op = 'noop';
+	return;
+}
+boolean changed = false;
+for (entry in params.source.entrySet()) {
+	if (!ctx._source.containsKey(entry.getKey())) {
+		ctx._source[entry.getKey()] = entry.getValue();
+		changed = true;
+	}
+}
+if (!changed) {
+	ctx.op = 'noop';
+}
+`
+
+type ReindexCheckpoint struct {
+	Alias     string    `json:"alias"`
+	Target    string    `json:"target"`
+	LastID    int64     `json:"last_id"`
+	Indexed   int64     `json:"indexed"`
+	StartedAt time.Time `json:"started_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type Document struct {
+	ID     int64
+	Source map[string]any
+}
+
+func (c *ElasticClient) CreateRebuildIndex(ctx context.Context, alias string) (string, error) {
+	migration, err := c.migrations.latest(alias)
+	if err != nil {
+		return "", err
+	}
+
+	target := newIndexName(alias, migration.version)
+	if err := c.createIndex(ctx, target, migration); err != nil {
+		return "", err
+	}
+
+	return target, nil
+}
+
+func (c *ElasticClient) IndexExists(ctx context.Context, index string) (bool, error) {
+	exists, err := c.elastic.Indices.Exists(index).Do(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to check if %s index exists: %w", index, err)
+	}
+
+	return exists, nil
+}
+
+func (c *ElasticClient) DeleteIndex(ctx context.Context, index string) error {
+	_, err := c.elastic.Indices.Delete(index).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete %s index: %w", index, err)
+	}
+
+	return nil
+}
+
+// BulkMerge copies documents into a rebuild target without overwriting what
+// live writes have mirrored there in the meantime.
+func (c *ElasticClient) BulkMerge(ctx context.Context, index string, documents []*Document) error {
+	if len(documents) == 0 {
+		return nil
+	}
+
+	request := c.elastic.Bulk().Index(index)
+	for _, document := range documents {
+		id := fmt.Sprintf("%d", document.ID)
+		source, err := json.Marshal(document.Source)
+		if err != nil {
+			return fmt.Errorf("failed to marshal document %s: %w", id, err)
+		}
+		params, err := scriptParams(map[string]any{"source": document.Source})
+		if err != nil {
+			return fmt.Errorf("failed to build merge of document %s: %w", id, err)
+		}
+
+		update := &types.UpdateAction{
+			Script: &types.Script{Source: stringPtr(mergeScript), Params: params},
+			Upsert: source,
+		}
+		if err := request.UpdateOp(types.UpdateOperation{Id_: &id}, nil, update); err != nil {
+			return fmt.Errorf("failed to build bulk merge request: %w", err)
+		}
+	}
+
+	res, err := request.Do(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to bulk merge into %s: %w", index, err)
+	}
+	if !res.Errors {
+		return nil
+	}
+
+	for _, item := range res.Items {
+		for _, result := range item {
+			if result.Error != nil {
+				return fmt.Errorf("failed to merge document %s into %s: %s", *result.Id_, index, result.Error.Type)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *ElasticClient) SwapAlias(ctx context.Context, alias, target string) error {
+	current, err := c.aliasedIndices(ctx, alias)
+	if err != nil {
+		return err
+	}
+
+	return c.swapAlias(ctx, alias, target, current)
+}
+
+func (c *ElasticClient) GetReindexCheckpoint(ctx context.Context, alias string) (*ReindexCheckpoint, error) {
+	exists, err := c.IndexExists(ctx, ReindexCheckpointsIndexName)
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	res, err := c.elastic.Get(ReindexCheckpointsIndexName, alias).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s reindex checkpoint: %w", alias, err)
+	}
+	if !res.Found {
+		return nil, nil
+	}
+
+	var checkpoint ReindexCheckpoint
+	if err := json.Unmarshal(res.Source_, &checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s reindex checkpoint: %w", alias, err)
+	}
+
+	return &checkpoint, nil
+}
+
+func (c *ElasticClient) SaveReindexCheckpoint(ctx context.Context, checkpoint *ReindexCheckpoint) error {
+	checkpoint.UpdatedAt = time.Now()
+
+	_, err := c.elastic.Index(ReindexCheckpointsIndexName).
+		Id(checkpoint.Alias).
+		Document(checkpoint).
+		Refresh(refresh.True).
+		Do(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to save %s reindex checkpoint: %w", checkpoint.Alias, err)
+	}
+
+	return nil
+}
+
+func (c *ElasticClient) DeleteReindexCheckpoint(ctx context.Context, alias string) error {
+	_, err := c.elastic.Delete(ReindexCheckpointsIndexName, alias).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete %s reindex checkpoint: %w", alias, err)
+	}
+
+	return nil
+}
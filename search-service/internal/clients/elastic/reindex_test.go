@@ -0,0 +1,34 @@
+package elastic
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSwapAlias(t *testing.T) {
+	ctx := context.Background()
+	cluster := newFakeCluster()
+	client := newTestClient(t, cluster)
+
+	previous := VersionedIndexName(TracksIndexName, 4)
+	target := VersionedIndexName(TracksIndexName, 5)
+	cluster.indices[previous] = 4
+	cluster.indices[target] = 5
+	cluster.aliases[TracksIndexName] = map[string]bool{previous: true}
+	cluster.aliases[RebuildAliasName(TracksIndexName)] = map[string]bool{target: true}
+
+	require.NoError(t, client.SwapAlias(ctx, TracksIndexName, target))
+
+	assert.Equal(t, []string{target}, cluster.aliasedIndices(TracksIndexName))
+	assert.Empty(t, cluster.aliasedIndices(RebuildAliasName(TracksIndexName)))
+	assert.NotContains(t, cluster.indices, previous, "the previous index is deleted")
+	assert.Contains(t, cluster.indices, target)
+
+	require.NoError(t, client.SwapAlias(ctx, TracksIndexName, target))
+
+	assert.Equal(t, []string{target}, cluster.aliasedIndices(TracksIndexName), "swapping to the serving index keeps it")
+	assert.Contains(t, cluster.indices, target)
+}
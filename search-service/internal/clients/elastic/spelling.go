@@ -0,0 +1,91 @@
+package elastic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/suggestmode"
+)
+
+const (
+	correctionSuggester     = "correction"
+	correctionMaxEdits      = 2
+	correctionMinWordLength = 3
+	correctionCollateQuery  = `{"match": {"{{field_name}}": {"query": "{{suggestion}}", "operator": "and"}}}`
+)
+
+func (c *ElasticClient) CorrectQuery(ctx context.Context, index, query string) (string, error) {
+	field, ok := indexField(index)
+	if !ok {
+		return "", fmt.Errorf("unknown index %s", index)
+	}
+
+	fieldName, err := json.Marshal(field)
+	if err != nil {
+		return "", fmt.Errorf("failed to build correction request: %w", err)
+	}
+
+	size := 0
+	suggestionSize := 1
+	maxEdits := correctionMaxEdits
+	minWordLength := correctionMinWordLength
+	res, err := c.elastic.Search().
+		Index(index).
+		Request(&search.Request{
+			Size: &size,
+			Suggest: &types.Suggester{
+				Suggesters: map[string]types.FieldSuggester{
+					correctionSuggester: {
+						Text: &query,
+						Phrase: &types.PhraseSuggester{
+							Field:     field,
+							Size:      &suggestionSize,
+							MaxErrors: float64Ptr(correctionMaxEdits),
+							DirectGenerator: []types.DirectGenerator{{
+								Field:         field,
+								SuggestMode:   &suggestmode.Always,
+								MaxEdits:      &maxEdits,
+								MinWordLength: &minWordLength,
+							}},
+							Collate: &types.PhraseSuggestCollate{
+								Query:  types.PhraseSuggestCollateQuery{Source: stringPtr(correctionCollateQuery)},
+								Params: map[string]json.RawMessage{"field_name": fieldName},
+							},
+						},
+					},
+				},
+			},
+		}).
+		TypedKeys(true).
+		Do(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to correct query: %w", err)
+	}
+
+	for _, suggest := range res.Suggest[correctionSuggester] {
+		phrase, ok := suggest.(*types.PhraseSuggest)
+		if !ok {
+			continue
+		}
+		for _, option := range phrase.Options {
+			if option.CollateMatch == nil || *option.CollateMatch {
+				return option.Text, nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+func indexField(index string) (string, bool) {
+	for _, definition := range indexDefinitions {
+		if definition.name == index {
+			return definition.field, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,119 @@
+package embedded
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/ocenb/music-go/search-service/internal/clients/elastic"
+)
+
+type queryLog struct {
+	elastic.SearchQuery
+	ClickedPositions []int     `json:"clicked_positions,omitempty"`
+	LastClickedAt    time.Time `json:"last_clicked_at"`
+}
+
+func (e *Engine) LogSearchQuery(ctx context.Context, query *elastic.SearchQuery) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.queries[query.QueryID] = &queryLog{SearchQuery: *query}
+	e.dirty = true
+
+	return nil
+}
+
+func (e *Engine) RecordClick(ctx context.Context, queryID string, userID, documentID int64, position int) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	query, ok := e.queries[queryID]
+	if !ok {
+		return elastic.ErrSearchQueryNotFound
+	}
+	if query.UserID != userID {
+		return elastic.ErrClickNotAllowed
+	}
+	if slices.Contains(query.ClickedIDs, documentID) {
+		return nil
+	}
+
+	query.Clicks++
+	query.ClickedIDs = append(query.ClickedIDs, documentID)
+	query.ClickedPositions = append(query.ClickedPositions, position)
+	query.LastClickedAt = time.Now()
+	e.dirty = true
+
+	return nil
+}
+
+func (e *Engine) TopQueries(ctx context.Context, r elastic.AnalyticsRange) ([]*elastic.QueryStat, error) {
+	return e.queryStats(r, false), nil
+}
+
+func (e *Engine) ZeroResultQueries(ctx context.Context, r elastic.AnalyticsRange) ([]*elastic.QueryStat, error) {
+	return e.queryStats(r, true), nil
+}
+
+func (e *Engine) ClickThroughRate(ctx context.Context, r elastic.AnalyticsRange) (*elastic.ClickThroughRate, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	rate := &elastic.ClickThroughRate{}
+	for _, query := range e.queries {
+		if !inRange(query, r) {
+			continue
+		}
+		rate.Searches++
+		if query.Clicks > 0 {
+			rate.ClickedSearches++
+		}
+	}
+
+	return rate, nil
+}
+
+func (e *Engine) queryStats(r elastic.AnalyticsRange, zeroResults bool) []*elastic.QueryStat {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	stats := make(map[string]*elastic.QueryStat)
+	for _, query := range e.queries {
+		if !inRange(query, r) || (zeroResults && query.Results != 0) {
+			continue
+		}
+
+		stat, ok := stats[query.Query]
+		if !ok {
+			stat = &elastic.QueryStat{Query: query.Query}
+			stats[query.Query] = stat
+		}
+		stat.Searches++
+		if query.Clicks > 0 {
+			stat.ClickedSearches++
+		}
+	}
+
+	result := make([]*elastic.QueryStat, 0, len(stats))
+	for _, stat := range stats {
+		result = append(result, stat)
+	}
+	slices.SortFunc(result, func(a, b *elastic.QueryStat) int {
+		if c := cmp.Compare(b.Searches, a.Searches); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Query, b.Query)
+	})
+
+	return result[:min(len(result), r.Limit)]
+}
+
+func inRange(query *queryLog, r elastic.AnalyticsRange) bool {
+	if query.Timestamp.Before(r.From) || query.Timestamp.After(r.To) {
+		return false
+	}
+
+	return r.Type == "" || query.Type == r.Type
+}
@@ -0,0 +1,198 @@
+package embedded
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/ocenb/music-go/search-service/internal/clients/elastic"
+)
+
+func (e *Engine) AddUser(ctx context.Context, id int64, username string) error {
+	return e.add(elastic.UsersIndexName, id, username, nil, elastic.ErrUserAlreadyExists)
+}
+
+func (e *Engine) AddAlbum(ctx context.Context, id int64, title string) error {
+	return e.add(elastic.AlbumsIndexName, id, title, nil, elastic.ErrAlbumAlreadyExists)
+}
+
+func (e *Engine) AddTrack(ctx context.Context, id int64, title string, attributes *elastic.TrackAttributes) error {
+	return e.add(elastic.TracksIndexName, id, title, attributes, elastic.ErrTrackAlreadyExists)
+}
+
+func (e *Engine) AddPlaylist(ctx context.Context, id int64, title string) error {
+	return e.add(elastic.PlaylistsIndexName, id, title, nil, elastic.ErrPlaylistAlreadyExists)
+}
+
+func (e *Engine) UpdateUser(ctx context.Context, id int64, username string) error {
+	e.update(elastic.UsersIndexName, id, username, nil)
+	return nil
+}
+
+func (e *Engine) UpdateAlbum(ctx context.Context, id int64, title string) error {
+	e.update(elastic.AlbumsIndexName, id, title, nil)
+	return nil
+}
+
+func (e *Engine) UpdateTrack(ctx context.Context, id int64, title string, attributes *elastic.TrackAttributes) error {
+	e.update(elastic.TracksIndexName, id, title, attributes)
+	return nil
+}
+
+func (e *Engine) UpdatePlaylist(ctx context.Context, id int64, title string) error {
+	e.update(elastic.PlaylistsIndexName, id, title, nil)
+	return nil
+}
+
+func (e *Engine) DeleteUser(ctx context.Context, id int64) error {
+	return e.delete(elastic.UsersIndexName, id, elastic.ErrUserNotFound)
+}
+
+func (e *Engine) DeleteAlbum(ctx context.Context, id int64) error {
+	return e.delete(elastic.AlbumsIndexName, id, elastic.ErrAlbumNotFound)
+}
+
+func (e *Engine) DeleteTrack(ctx context.Context, id int64) error {
+	return e.delete(elastic.TracksIndexName, id, elastic.ErrTrackNotFound)
+}
+
+func (e *Engine) DeletePlaylist(ctx context.Context, id int64) error {
+	return e.delete(elastic.PlaylistsIndexName, id, elastic.ErrPlaylistNotFound)
+}
+
+func (e *Engine) UpdateSignals(ctx context.Context, documentType string, signals []*elastic.DocumentSignals) error {
+	name, ok := elastic.DocumentIndex(documentType)
+	if !ok {
+		return elastic.ErrUnknownDocumentType
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, signal := range signals {
+		doc, ok := e.indices[name].documents[signal.ID]
+		if !ok {
+			continue
+		}
+		doc.Plays = signal.Plays
+		doc.Likes = signal.Likes
+		doc.Followers = signal.Followers
+		if !signal.CreatedAt.IsZero() {
+			doc.CreatedAt = signal.CreatedAt
+		}
+	}
+	e.dirty = true
+
+	return nil
+}
+
+func (e *Engine) UpsertDocument(ctx context.Context, documentType string, id int64, name string, attributes *elastic.TrackAttributes, version int64) error {
+	indexName, ok := elastic.DocumentIndex(documentType)
+	if !ok {
+		return elastic.ErrUnknownDocumentType
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	idx := e.indices[indexName]
+	if idx.version(id) >= version {
+		return nil
+	}
+
+	doc := e.updated(idx.documents[id], id, name, attributes)
+	doc.EventVersion = version
+	idx.put(doc)
+	delete(idx.tombstones, id)
+	e.dirty = true
+
+	return nil
+}
+
+func (e *Engine) DeleteDocument(ctx context.Context, documentType string, id int64, version int64) error {
+	name, ok := elastic.DocumentIndex(documentType)
+	if !ok {
+		return elastic.ErrUnknownDocumentType
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	idx := e.indices[name]
+	if idx.version(id) >= version {
+		return nil
+	}
+
+	idx.remove(id)
+	idx.tombstones[id] = &tombstone{ID: id, DeletedAt: time.Now(), EventVersion: version}
+	e.dirty = true
+
+	return nil
+}
+
+func (e *Engine) PurgeTombstones(ctx context.Context, before time.Time) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, idx := range e.indices {
+		for id, t := range idx.tombstones {
+			if t.DeletedAt.Before(before) {
+				delete(idx.tombstones, id)
+				e.dirty = true
+			}
+		}
+	}
+
+	return nil
+}
+
+func (e *Engine) add(name string, id int64, text string, attributes *elastic.TrackAttributes, errExists error) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, ok := e.indices[name].documents[id]; ok {
+		return errExists
+	}
+
+	e.indices[name].put(e.updated(nil, id, text, attributes))
+	e.dirty = true
+
+	return nil
+}
+
+func (e *Engine) update(name string, id int64, text string, attributes *elastic.TrackAttributes) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.indices[name].put(e.updated(e.indices[name].documents[id], id, text, attributes))
+	e.dirty = true
+}
+
+func (e *Engine) delete(name string, id int64, errNotFound error) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.indices[name].remove(id) {
+		return errNotFound
+	}
+	e.dirty = true
+
+	return nil
+}
+
+func (e *Engine) updated(existing *document, id int64, text string, attributes *elastic.TrackAttributes) *document {
+	doc := &document{ID: id, CreatedAt: time.Now()}
+	if existing != nil {
+		copied := *existing
+		doc = &copied
+	}
+
+	doc.Text = text
+	if attributes != nil {
+		copied := *attributes
+		copied.Tags = slices.Clone(attributes.Tags)
+		doc.Attributes = &copied
+	}
+
+	return doc
+}
@@ -0,0 +1,241 @@
+package embedded
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ocenb/music-go/search-service/internal/clients/elastic"
+	"github.com/ocenb/music-go/search-service/internal/config"
+	"github.com/ocenb/music-go/search-service/internal/utils"
+)
+
+var recencyScaleRegexp = regexp.MustCompile(`^(\d+)(ms|s|m|h|d)$`)
+
+var recencyScaleUnits = map[string]time.Duration{
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+	"d":  24 * time.Hour,
+}
+
+var indexNames = []string{
+	elastic.UsersIndexName,
+	elastic.AlbumsIndexName,
+	elastic.TracksIndexName,
+	elastic.PlaylistsIndexName,
+}
+
+type snapshot struct {
+	Indices    map[string][]*document  `json:"indices"`
+	Tombstones map[string][]*tombstone `json:"tombstones"`
+	Queries    []*queryLog             `json:"queries"`
+}
+
+type Engine struct {
+	mu           sync.RWMutex
+	log          *slog.Logger
+	ranking      config.RankingConfig
+	recencyScale time.Duration
+	indices      map[string]*index
+	queries      map[string]*queryLog
+	snapshotPath string
+	dirty        bool
+	stop         chan struct{}
+	done         chan struct{}
+}
+
+func New(cfg *config.Config, log *slog.Logger) (*Engine, error) {
+	recencyScale, err := parseRecencyScale(cfg.Ranking.RecencyScale)
+	if err != nil {
+		return nil, err
+	}
+
+	engine := &Engine{
+		log:          log,
+		ranking:      cfg.Ranking,
+		recencyScale: recencyScale,
+		indices:      make(map[string]*index, len(indexNames)),
+		queries:      make(map[string]*queryLog),
+		snapshotPath: cfg.Embedded.SnapshotPath,
+		stop:         make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+	for _, name := range indexNames {
+		engine.indices[name] = newIndex()
+	}
+
+	if engine.snapshotPath == "" {
+		close(engine.done)
+		return engine, nil
+	}
+
+	if err := engine.load(); err != nil {
+		return nil, err
+	}
+
+	go engine.runSnapshots(cfg.Embedded.SnapshotInterval)
+
+	return engine, nil
+}
+
+func (e *Engine) Close() error {
+	if e.snapshotPath == "" {
+		return nil
+	}
+
+	close(e.stop)
+	<-e.done
+
+	return e.save()
+}
+
+func (e *Engine) runSnapshots(interval time.Duration) {
+	defer close(e.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-e.stop:
+			return
+		case <-ticker.C:
+			if err := e.save(); err != nil {
+				e.log.Error("Failed to save search snapshot", slog.String("path", e.snapshotPath), utils.ErrLog(err))
+			}
+		}
+	}
+}
+
+func (e *Engine) load() error {
+	data, err := os.ReadFile(e.snapshotPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("failed to read search snapshot: %w", err)
+	}
+
+	var s snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("failed to unmarshal search snapshot: %w", err)
+	}
+
+	for name, documents := range s.Indices {
+		idx, ok := e.indices[name]
+		if !ok {
+			continue
+		}
+		for _, doc := range documents {
+			idx.put(doc)
+		}
+	}
+	for name, tombstones := range s.Tombstones {
+		idx, ok := e.indices[name]
+		if !ok {
+			continue
+		}
+		for _, t := range tombstones {
+			idx.tombstones[t.ID] = t
+		}
+	}
+	for _, query := range s.Queries {
+		e.queries[query.QueryID] = query
+	}
+
+	e.log.Info("Loaded search snapshot", slog.String("path", e.snapshotPath), slog.Int("queries", len(s.Queries)))
+	return nil
+}
+
+func (e *Engine) save() error {
+	e.mu.Lock()
+	if !e.dirty {
+		e.mu.Unlock()
+		return nil
+	}
+
+	s := snapshot{
+		Indices:    make(map[string][]*document, len(e.indices)),
+		Tombstones: make(map[string][]*tombstone, len(e.indices)),
+		Queries:    make([]*queryLog, 0, len(e.queries)),
+	}
+	for name, idx := range e.indices {
+		documents := make([]*document, 0, len(idx.documents))
+		for _, doc := range idx.documents {
+			documents = append(documents, doc)
+		}
+		s.Indices[name] = documents
+
+		tombstones := make([]*tombstone, 0, len(idx.tombstones))
+		for _, t := range idx.tombstones {
+			tombstones = append(tombstones, t)
+		}
+		s.Tombstones[name] = tombstones
+	}
+	for _, query := range e.queries {
+		s.Queries = append(s.Queries, query)
+	}
+
+	data, err := json.Marshal(s)
+	e.dirty = false
+	e.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to marshal search snapshot: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(e.snapshotPath), 0o755); err != nil {
+		return fmt.Errorf("failed to create search snapshot directory: %w", err)
+	}
+
+	tmp := e.snapshotPath + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write search snapshot: %w", err)
+	}
+	if err := os.Rename(tmp, e.snapshotPath); err != nil {
+		return fmt.Errorf("failed to replace search snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// boost mirrors the elasticsearch function score: it starts from 1, adds the
+// weighted signals and is capped at MaxBoost.
+func (e *Engine) boost(doc *document, now time.Time) float64 {
+	score := 1 + e.ranking.PlaysWeight*math.Log1p(float64(doc.Plays)) +
+		e.ranking.LikesWeight*math.Log1p(float64(doc.Likes)) +
+		e.ranking.FollowersWeight*math.Log1p(float64(doc.Followers))
+
+	if e.ranking.RecencyWeight != 0 && !doc.CreatedAt.IsZero() {
+		distance := math.Abs(float64(now.Sub(doc.CreatedAt))) / float64(e.recencyScale)
+		score += e.ranking.RecencyWeight * math.Pow(0.5, distance*distance)
+	}
+
+	if e.ranking.MaxBoost > 0 {
+		score = min(score, e.ranking.MaxBoost)
+	}
+	return score
+}
+
+func parseRecencyScale(scale string) (time.Duration, error) {
+	matches := recencyScaleRegexp.FindStringSubmatch(scale)
+	if matches == nil {
+		return 0, fmt.Errorf("invalid recency scale %q", scale)
+	}
+
+	value, err := strconv.Atoi(matches[1])
+	if err != nil || value == 0 {
+		return 0, fmt.Errorf("invalid recency scale %q", scale)
+	}
+
+	return time.Duration(value) * recencyScaleUnits[matches[2]], nil
+}
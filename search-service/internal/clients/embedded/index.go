@@ -0,0 +1,202 @@
+package embedded
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/ocenb/music-go/search-service/internal/clients/elastic"
+)
+
+const termSaturation = 1.2
+
+type document struct {
+	ID           int64                    `json:"id"`
+	Text         string                   `json:"text"`
+	Attributes   *elastic.TrackAttributes `json:"attributes,omitempty"`
+	Plays        int64                    `json:"plays"`
+	Likes        int64                    `json:"likes"`
+	Followers    int64                    `json:"followers"`
+	CreatedAt    time.Time                `json:"created_at"`
+	EventVersion int64                    `json:"event_version"`
+}
+
+type tombstone struct {
+	ID           int64     `json:"id"`
+	DeletedAt    time.Time `json:"deleted_at"`
+	EventVersion int64     `json:"event_version"`
+}
+
+type match struct {
+	document *document
+	score    float64
+	terms    map[string]bool
+}
+
+type index struct {
+	documents  map[int64]*document
+	tombstones map[int64]*tombstone
+	postings   map[string]map[int64]int
+}
+
+func newIndex() *index {
+	return &index{
+		documents:  make(map[int64]*document),
+		tombstones: make(map[int64]*tombstone),
+		postings:   make(map[string]map[int64]int),
+	}
+}
+
+// version returns the event version of a document or of its tombstone.
+func (i *index) version(id int64) int64 {
+	if doc, ok := i.documents[id]; ok {
+		return doc.EventVersion
+	}
+	if t, ok := i.tombstones[id]; ok {
+		return t.EventVersion
+	}
+	return 0
+}
+
+func (i *index) put(doc *document) {
+	i.remove(doc.ID)
+	i.documents[doc.ID] = doc
+
+	for _, term := range analyze(doc.Text) {
+		if i.postings[term] == nil {
+			i.postings[term] = make(map[int64]int)
+		}
+		i.postings[term][doc.ID]++
+	}
+}
+
+func (i *index) remove(id int64) bool {
+	doc, ok := i.documents[id]
+	if !ok {
+		return false
+	}
+
+	for _, term := range analyze(doc.Text) {
+		delete(i.postings[term], id)
+		if len(i.postings[term]) == 0 {
+			delete(i.postings, term)
+		}
+	}
+	delete(i.documents, id)
+
+	return true
+}
+
+func (i *index) match(query string) map[int64]*match {
+	matches := make(map[int64]*match)
+
+	for _, t := range tokenize(query) {
+		best := make(map[int64]float64)
+		bestTerms := make(map[int64]string)
+
+		for _, variant := range termVariants(t.text) {
+			limit := maxEdits(variant)
+			runes := []rune(variant)
+
+			for term, postings := range i.postings {
+				distance := 0
+				if term != variant {
+					if limit == 0 {
+						continue
+					}
+					distance = editDistance(runes, []rune(term), limit)
+					if distance > limit {
+						continue
+					}
+				}
+
+				quality := 1 - float64(distance)/float64(len(runes)+1)
+				weight := quality * i.idf(len(postings))
+				for id, frequency := range postings {
+					score := weight * float64(frequency) * (termSaturation + 1) / (float64(frequency) + termSaturation)
+					if score > best[id] {
+						best[id] = score
+						bestTerms[id] = term
+					}
+				}
+			}
+		}
+
+		for id, score := range best {
+			m, ok := matches[id]
+			if !ok {
+				m = &match{document: i.documents[id], terms: make(map[string]bool)}
+				matches[id] = m
+			}
+			m.score += score
+			m.terms[bestTerms[id]] = true
+		}
+	}
+
+	return matches
+}
+
+func (i *index) prefixMatch(query string) map[int64]*match {
+	matches := make(map[int64]*match)
+
+	for _, t := range tokenize(query) {
+		prefix := fold(t.text)
+		matched := make(map[int64]bool)
+		for term, postings := range i.postings {
+			if !strings.HasPrefix(term, prefix) {
+				continue
+			}
+			for id := range postings {
+				matched[id] = true
+			}
+		}
+
+		for id := range matched {
+			m, ok := matches[id]
+			if !ok {
+				m = &match{document: i.documents[id]}
+				matches[id] = m
+			}
+			m.score++
+		}
+	}
+
+	return matches
+}
+
+func (i *index) idf(frequency int) float64 {
+	total := float64(len(i.documents))
+	return math.Log(1 + (total-float64(frequency)+0.5)/(float64(frequency)+0.5))
+}
+
+func highlight(text string, terms map[string]bool) []string {
+	var b strings.Builder
+	last := 0
+	highlighted := false
+
+	for _, t := range tokenize(text) {
+		matched := false
+		for _, variant := range termVariants(t.text) {
+			if terms[variant] {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+
+		b.WriteString(text[last:t.start])
+		b.WriteString("<em>")
+		b.WriteString(text[t.start:t.end])
+		b.WriteString("</em>")
+		last = t.end
+		highlighted = true
+	}
+	if !highlighted {
+		return nil
+	}
+	b.WriteString(text[last:])
+
+	return []string{b.String()}
+}
@@ -0,0 +1,243 @@
+package embedded
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/ocenb/music-go/search-service/internal/clients/elastic"
+)
+
+type suggestTarget struct {
+	suggestionType string
+	index          string
+}
+
+var suggestTargets = []suggestTarget{
+	{suggestionType: elastic.DocumentTypeUser, index: elastic.UsersIndexName},
+	{suggestionType: elastic.DocumentTypeTrack, index: elastic.TracksIndexName},
+	{suggestionType: elastic.DocumentTypePlaylist, index: elastic.PlaylistsIndexName},
+}
+
+type scoredMatch struct {
+	*match
+	total float64
+}
+
+func (e *Engine) SearchUsers(ctx context.Context, query string, page elastic.SearchPage) (*elastic.SearchResult, error) {
+	return e.search(elastic.UsersIndexName, query, page, nil)
+}
+
+func (e *Engine) SearchAlbums(ctx context.Context, query string, page elastic.SearchPage) (*elastic.SearchResult, error) {
+	return e.search(elastic.AlbumsIndexName, query, page, nil)
+}
+
+func (e *Engine) SearchTracks(ctx context.Context, query string, page elastic.SearchPage, filters *elastic.TrackFilters) (*elastic.SearchResult, error) {
+	return e.search(elastic.TracksIndexName, query, page, filters)
+}
+
+func (e *Engine) SearchPlaylists(ctx context.Context, query string, page elastic.SearchPage) (*elastic.SearchResult, error) {
+	return e.search(elastic.PlaylistsIndexName, query, page, nil)
+}
+
+func (e *Engine) Suggest(ctx context.Context, query string, limit int, timeout time.Duration) ([]*elastic.Suggestion, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	origin := elastic.RankingOrigin(time.Now())
+	suggestions := make([]*elastic.Suggestion, 0, limit*len(suggestTargets))
+	for _, target := range suggestTargets {
+		matches := e.ranked(e.indices[target.index].prefixMatch(query), origin)
+		for _, m := range matches[:min(limit, len(matches))] {
+			suggestions = append(suggestions, &elastic.Suggestion{
+				Type:  target.suggestionType,
+				ID:    m.document.ID,
+				Text:  m.document.Text,
+				Score: m.total,
+			})
+		}
+	}
+
+	return suggestions, nil
+}
+
+func (e *Engine) search(name, query string, page elastic.SearchPage, filters *elastic.TrackFilters) (*elastic.SearchResult, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	matches := e.indices[name].match(query)
+	result := &elastic.SearchResult{}
+	if name == elastic.TracksIndexName {
+		result.Facets = facets(matches, filters)
+	}
+
+	filtered := make(map[int64]*match, len(matches))
+	for id, m := range matches {
+		if matchesBase(m.document, filters) && matchesGenre(m.document, filters) && matchesDuration(m.document, filters) {
+			filtered[id] = m
+		}
+	}
+	cursor, origin, err := page.Position()
+	if err != nil {
+		return nil, err
+	}
+	ranked := e.ranked(filtered, origin)
+	result.Total = int64(len(ranked))
+
+	start := min(page.Offset, len(ranked))
+	if cursor != nil {
+		if i := slices.IndexFunc(ranked, func(m scoredMatch) bool { return m.document.ID == cursor.ID }); i >= 0 {
+			start = i + 1
+		} else {
+			start, _ = slices.BinarySearchFunc(ranked, scoredMatch{total: cursor.Score, match: &match{document: &document{ID: cursor.ID}}}, compareMatches)
+		}
+	}
+
+	window := ranked[start:min(start+page.Limit+1, len(ranked))]
+	if len(window) > page.Limit {
+		window = window[:page.Limit]
+		last := window[len(window)-1]
+		nextCursor, err := elastic.EncodeCursor(&elastic.Cursor{Score: last.total, ID: last.document.ID, Origin: origin, Query: query, Original: query})
+		if err != nil {
+			return nil, err
+		}
+		result.NextCursor = nextCursor
+	}
+
+	result.Hits = make([]*elastic.SearchHit, 0, len(window))
+	for _, m := range window {
+		hit := &elastic.SearchHit{ID: m.document.ID, Score: m.total}
+		if page.Highlight {
+			hit.Highlights = highlight(m.document.Text, m.terms)
+		}
+		result.Hits = append(result.Hits, hit)
+	}
+
+	return result, nil
+}
+
+func (e *Engine) ranked(matches map[int64]*match, now time.Time) []scoredMatch {
+	ranked := make([]scoredMatch, 0, len(matches))
+	for _, m := range matches {
+		total := float32(m.score * e.boost(m.document, now))
+		ranked = append(ranked, scoredMatch{match: m, total: float64(total)})
+	}
+	slices.SortFunc(ranked, compareMatches)
+
+	return ranked
+}
+
+func compareMatches(a, b scoredMatch) int {
+	if c := cmp.Compare(b.total, a.total); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.document.ID, b.document.ID)
+}
+
+func matchesBase(doc *document, filters *elastic.TrackFilters) bool {
+	if filters == nil {
+		return true
+	}
+
+	attributes := doc.Attributes
+	if attributes == nil {
+		attributes = &elastic.TrackAttributes{}
+	}
+
+	if len(filters.Tags) > 0 && !slices.ContainsFunc(attributes.Tags, func(tag string) bool {
+		return slices.ContainsFunc(filters.Tags, func(filter string) bool { return fold(filter) == fold(tag) })
+	}) {
+		return false
+	}
+	if len(filters.ArtistIDs) > 0 && (doc.Attributes == nil || !slices.Contains(filters.ArtistIDs, attributes.UserID)) {
+		return false
+	}
+	if !filters.UploadedAfter.IsZero() && (doc.CreatedAt.IsZero() || doc.CreatedAt.Before(filters.UploadedAfter)) {
+		return false
+	}
+	if !filters.UploadedBefore.IsZero() && (doc.CreatedAt.IsZero() || doc.CreatedAt.After(filters.UploadedBefore)) {
+		return false
+	}
+
+	return true
+}
+
+func matchesGenre(doc *document, filters *elastic.TrackFilters) bool {
+	if filters == nil || len(filters.Genres) == 0 {
+		return true
+	}
+	if doc.Attributes == nil {
+		return false
+	}
+
+	return slices.ContainsFunc(filters.Genres, func(genre string) bool {
+		return fold(genre) == fold(doc.Attributes.Genre)
+	})
+}
+
+func matchesDuration(doc *document, filters *elastic.TrackFilters) bool {
+	if filters == nil || (filters.MinDuration <= 0 && filters.MaxDuration <= 0) {
+		return true
+	}
+	if doc.Attributes == nil {
+		return false
+	}
+	if filters.MinDuration > 0 && doc.Attributes.Duration < filters.MinDuration {
+		return false
+	}
+	if filters.MaxDuration > 0 && doc.Attributes.Duration > filters.MaxDuration {
+		return false
+	}
+
+	return true
+}
+
+func facets(matches map[int64]*match, filters *elastic.TrackFilters) *elastic.Facets {
+	genreCounts := make(map[string]int64)
+	durationCounts := make([]int64, len(elastic.DurationBuckets))
+
+	for _, m := range matches {
+		doc := m.document
+		if doc.Attributes == nil || !matchesBase(doc, filters) {
+			continue
+		}
+
+		if matchesDuration(doc, filters) && doc.Attributes.Genre != "" {
+			genreCounts[fold(doc.Attributes.Genre)]++
+		}
+		if matchesGenre(doc, filters) {
+			for i, bucket := range elastic.DurationBuckets {
+				if doc.Attributes.Duration >= bucket.From && (bucket.To == 0 || doc.Attributes.Duration < bucket.To) {
+					durationCounts[i]++
+				}
+			}
+		}
+	}
+
+	result := &elastic.Facets{
+		Genres:    make([]*elastic.FacetBucket, 0, len(genreCounts)),
+		Durations: make([]*elastic.FacetBucket, 0, len(elastic.DurationBuckets)),
+	}
+	for genre, count := range genreCounts {
+		result.Genres = append(result.Genres, &elastic.FacetBucket{Key: genre, Count: count})
+	}
+	slices.SortFunc(result.Genres, func(a, b *elastic.FacetBucket) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Key, b.Key)
+	})
+	result.Genres = result.Genres[:min(len(result.Genres), elastic.MaxGenreFacets)]
+
+	for i, bucket := range elastic.DurationBuckets {
+		result.Durations = append(result.Durations, &elastic.FacetBucket{
+			Key:   bucket.Key,
+			Count: durationCounts[i],
+			From:  bucket.From,
+			To:    bucket.To,
+		})
+	}
+
+	return result
+}
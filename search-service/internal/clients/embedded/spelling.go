@@ -0,0 +1,95 @@
+package embedded
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const (
+	correctionMaxEdits      = 2
+	correctionMinWordLength = 3
+)
+
+type correction struct {
+	term      string
+	distance  int
+	frequency int
+}
+
+func (e *Engine) CorrectQuery(ctx context.Context, index, query string) (string, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	idx, ok := e.indices[index]
+	if !ok {
+		return "", fmt.Errorf("unknown index %s", index)
+	}
+
+	tokens := tokenize(query)
+	terms := make([]string, 0, len(tokens))
+	corrected := false
+	for _, t := range tokens {
+		term := idx.correct(t.text)
+		if term != fold(t.text) {
+			corrected = true
+		}
+		terms = append(terms, term)
+	}
+	if !corrected {
+		return "", nil
+	}
+
+	suggestion := strings.Join(terms, " ")
+	if len(idx.match(suggestion)) == 0 {
+		return "", nil
+	}
+
+	return suggestion, nil
+}
+
+func (i *index) correct(word string) string {
+	folded := fold(word)
+	for _, variant := range termVariants(word) {
+		if _, ok := i.postings[variant]; ok {
+			return folded
+		}
+	}
+
+	runes := []rune(folded)
+	if len(runes) < correctionMinWordLength {
+		return folded
+	}
+
+	var best *correction
+	for term, postings := range i.postings {
+		if !strings.HasPrefix(term, string(runes[0])) {
+			continue
+		}
+
+		distance := editDistance(runes, []rune(term), correctionMaxEdits)
+		if distance > correctionMaxEdits {
+			continue
+		}
+
+		candidate := &correction{term: term, distance: distance, frequency: len(postings)}
+		if best == nil || candidate.better(best) {
+			best = candidate
+		}
+	}
+	if best == nil {
+		return folded
+	}
+
+	return best.term
+}
+
+func (c *correction) better(other *correction) bool {
+	if c.distance != other.distance {
+		return c.distance < other.distance
+	}
+	if c.frequency != other.frequency {
+		return c.frequency > other.frequency
+	}
+	return c.term < other.term
+}
@@ -10,17 +10,35 @@ import (
 	"github.com/ocenb/music-go/search-service/internal/utils"
 )
 
+const (
+	SearchBackendElasticsearch = "elasticsearch"
+	SearchBackendEmbedded      = "embedded"
+)
+
 type Config struct {
-	GRPC               GRPCConfig `yaml:"grpc"`
-	LogLevel           int        `yaml:"log_level" env-default:"0"`
-	LogHandler         string     `yaml:"log_handler" env-default:"text"`
-	Environment        string     `env:"ENVIRONMENT" env-required:"true"`
-	ElasticHost        string     `env:"ELASTIC_HOST" env-required:"true"`
-	ElasticPort        string     `env:"ELASTIC_PORT" env-required:"true"`
-	ElasticUser        string     `env:"ELASTIC_USER" env-required:"true"`
-	ElasticPassword    string     `env:"ELASTIC_PASSWORD" env-required:"true"`
+	GRPC               GRPCConfig      `yaml:"grpc"`
+	LogLevel           int             `yaml:"log_level" env-default:"0"`
+	LogHandler         string          `yaml:"log_handler" env-default:"text"`
+	SuggestTimeout     time.Duration   `yaml:"suggest_timeout" env-default:"150ms"`
+	Ranking            RankingConfig   `yaml:"ranking"`
+	Reindex            ReindexConfig   `yaml:"reindex"`
+	Analytics          AnalyticsConfig `yaml:"analytics"`
+	Spelling           SpellingConfig  `yaml:"spelling"`
+	SearchBackend      string          `yaml:"search_backend" env:"SEARCH_BACKEND" env-default:"elasticsearch"`
+	Embedded           EmbeddedConfig  `yaml:"embedded"`
+	Tombstones         TombstoneConfig `yaml:"tombstones"`
+	Environment        string          `env:"ENVIRONMENT" env-required:"true"`
+	ElasticHost        string          `env:"ELASTIC_HOST"`
+	ElasticPort        string          `env:"ELASTIC_PORT"`
+	ElasticUser        string          `env:"ELASTIC_USER"`
+	ElasticPassword    string          `env:"ELASTIC_PASSWORD"`
 	ElasticUrl         string
-	UserServiceAddress string `env:"USER_SERVICE_ADDRESS" env-required:"true"`
+	UserServiceAddress string   `env:"USER_SERVICE_ADDRESS" env-required:"true"`
+	KafkaBrokers       []string `env:"KAFKA_BROKERS" env-required:"true"`
+	KafkaTopic         string   `env:"KAFKA_TOPIC" env-default:"search-index-events"`
+	KafkaGroupID       string   `env:"KAFKA_GROUP_ID" env-default:"search-service"`
+	KafkaDLQTopic      string   `env:"KAFKA_DLQ_TOPIC" env-default:"search-index-events-dlq"`
+	KafkaMaxRetries    int      `env:"KAFKA_MAX_RETRIES" env-default:"5"`
 }
 
 type GRPCConfig struct {
@@ -28,6 +46,42 @@ type GRPCConfig struct {
 	Timeout time.Duration `yaml:"timeout" env-default:"5s"`
 }
 
+type RankingConfig struct {
+	PlaysWeight     float64 `yaml:"plays_weight" env-default:"1"`
+	LikesWeight     float64 `yaml:"likes_weight" env-default:"1"`
+	FollowersWeight float64 `yaml:"followers_weight" env-default:"1"`
+	RecencyWeight   float64 `yaml:"recency_weight" env-default:"1"`
+	RecencyScale    string  `yaml:"recency_scale" env-default:"30d"`
+	MaxBoost        float64 `yaml:"max_boost" env-default:"3"`
+}
+
+type ReindexConfig struct {
+	BatchSize          int    `yaml:"batch_size" env-default:"500"`
+	UserDatabaseUrl    string `env:"REINDEX_USER_DATABASE_URL"`
+	ContentDatabaseUrl string `env:"REINDEX_CONTENT_DATABASE_URL"`
+}
+
+type AnalyticsConfig struct {
+	Enabled    bool          `yaml:"enabled" env-default:"true"`
+	LogTimeout time.Duration `yaml:"log_timeout" env-default:"300ms"`
+}
+
+type SpellingConfig struct {
+	Enabled bool          `yaml:"enabled" env-default:"true"`
+	MaxHits int64         `yaml:"max_hits" env-default:"2"`
+	Timeout time.Duration `yaml:"timeout" env-default:"200ms"`
+}
+
+type TombstoneConfig struct {
+	Retention     time.Duration `yaml:"retention" env-default:"168h"`
+	PurgeInterval time.Duration `yaml:"purge_interval" env-default:"1h"`
+}
+
+type EmbeddedConfig struct {
+	SnapshotPath     string        `yaml:"snapshot_path" env:"EMBEDDED_SNAPSHOT_PATH"`
+	SnapshotInterval time.Duration `yaml:"snapshot_interval" env-default:"1m"`
+}
+
 func MustLoad() *Config {
 	err := godotenv.Load()
 	if err != nil {
@@ -54,6 +108,16 @@ func MustLoad() *Config {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
+	switch cfg.SearchBackend {
+	case SearchBackendElasticsearch:
+		if cfg.ElasticHost == "" || cfg.ElasticPort == "" || cfg.ElasticUser == "" || cfg.ElasticPassword == "" {
+			log.Fatalf("ELASTIC_HOST, ELASTIC_PORT, ELASTIC_USER and ELASTIC_PASSWORD are required for the %s search backend", SearchBackendElasticsearch)
+		}
+	case SearchBackendEmbedded:
+	default:
+		log.Fatalf("Invalid search backend: %s", cfg.SearchBackend)
+	}
+
 	cfg.ElasticUrl = utils.GetElasticUrl(cfg.ElasticHost, cfg.ElasticPort, cfg.ElasticUser, cfg.ElasticPassword)
 
 	return &cfg
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/ocenb/music-go/search-service/internal/service"
+	"github.com/ocenb/music-go/search-service/internal/utils"
+	"github.com/ocenb/music-protos/gen/searchservice"
+)
+
+var ErrEmptyIndexEvent = errors.New("index event has no change")
+
+func IndexEventHandler(log *slog.Logger, searchService service.SearchServiceInterface) func(ctx context.Context, event *searchservice.IndexEvent, version int64) error {
+	return func(ctx context.Context, event *searchservice.IndexEvent, version int64) error {
+		var err error
+		switch change := event.Change.(type) {
+		case *searchservice.IndexEvent_Upsert:
+			err = searchService.UpsertDocument(ctx, event.Type, change.Upsert.Id, change.Upsert.Name, trackAttributes(change.Upsert.Track), version)
+		case *searchservice.IndexEvent_Delete:
+			err = searchService.DeleteDocument(ctx, event.Type, change.Delete.Id, version)
+		case *searchservice.IndexEvent_Signals:
+			err = searchService.UpdateSignals(ctx, change.Signals.Type, documentSignals(change.Signals.Signals))
+		default:
+			err = utils.InvalidArgumentError(ErrEmptyIndexEvent)
+		}
+		if err != nil {
+			log.Error("Failed to apply index event",
+				slog.String("type", event.Type),
+				slog.Int64("version", version),
+				utils.ErrLog(err))
+			return err
+		}
+		return nil
+	}
+}
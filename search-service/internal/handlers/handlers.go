@@ -3,7 +3,9 @@ package handlers
 import (
 	"context"
 	"log/slog"
+	"time"
 
+	"github.com/ocenb/music-go/search-service/internal/clients/elastic"
 	"github.com/ocenb/music-go/search-service/internal/config"
 	"github.com/ocenb/music-go/search-service/internal/service"
 	"github.com/ocenb/music-go/search-service/internal/utils"
@@ -29,43 +31,85 @@ func NewSearchServer(gRPCServer *grpc.Server, cfg *config.Config, searchService
 func (s *SearchServer) SearchUsers(ctx context.Context, req *searchservice.SearchRequest) (*searchservice.SearchResponse, error) {
 	s.log.Info("Received search users request", slog.String("query", req.Query))
 
-	ids, err := s.searchService.SearchUsers(ctx, req.Query)
+	result, err := s.searchService.SearchUsers(ctx, req.Query, searchPage(req))
 	if err != nil {
 		s.log.Error("Failed to search users", utils.ErrLog(err))
 		return nil, err
 	}
 
-	return &searchservice.SearchResponse{
-		Ids: ids,
-	}, nil
+	return searchResponse(result), nil
 }
 
 func (s *SearchServer) SearchAlbums(ctx context.Context, req *searchservice.SearchRequest) (*searchservice.SearchResponse, error) {
 	s.log.Info("Received search albums request", slog.String("query", req.Query))
 
-	ids, err := s.searchService.SearchAlbums(ctx, req.Query)
+	result, err := s.searchService.SearchAlbums(ctx, req.Query, searchPage(req))
 	if err != nil {
 		s.log.Error("Failed to search albums", utils.ErrLog(err))
 		return nil, err
 	}
 
-	return &searchservice.SearchResponse{
-		Ids: ids,
-	}, nil
+	return searchResponse(result), nil
 }
 
 func (s *SearchServer) SearchTracks(ctx context.Context, req *searchservice.SearchRequest) (*searchservice.SearchResponse, error) {
 	s.log.Info("Received search tracks request", slog.String("query", req.Query))
 
-	ids, err := s.searchService.SearchTracks(ctx, req.Query)
+	result, err := s.searchService.SearchTracks(ctx, req.Query, searchPage(req), trackFilters(req.TrackFilters))
 	if err != nil {
 		s.log.Error("Failed to search tracks", utils.ErrLog(err))
 		return nil, err
 	}
 
-	return &searchservice.SearchResponse{
-		Ids: ids,
-	}, nil
+	return searchResponse(result), nil
+}
+
+func (s *SearchServer) SearchPlaylists(ctx context.Context, req *searchservice.SearchRequest) (*searchservice.SearchResponse, error) {
+	s.log.Info("Received search playlists request", slog.String("query", req.Query))
+
+	result, err := s.searchService.SearchPlaylists(ctx, req.Query, searchPage(req))
+	if err != nil {
+		s.log.Error("Failed to search playlists", utils.ErrLog(err))
+		return nil, err
+	}
+
+	return searchResponse(result), nil
+}
+
+func (s *SearchServer) Suggest(ctx context.Context, req *searchservice.SuggestRequest) (*searchservice.SuggestResponse, error) {
+	s.log.Info("Received suggest request", slog.String("query", req.Query))
+
+	suggestions, err := s.searchService.Suggest(ctx, req.Query, int(req.Limit))
+	if err != nil {
+		s.log.Error("Failed to suggest", utils.ErrLog(err))
+		return nil, err
+	}
+
+	response := &searchservice.SuggestResponse{
+		Suggestions: make([]*searchservice.Suggestion, 0, len(suggestions)),
+	}
+	for _, suggestion := range suggestions {
+		response.Suggestions = append(response.Suggestions, &searchservice.Suggestion{
+			Type:  suggestion.Type,
+			Id:    suggestion.ID,
+			Text:  suggestion.Text,
+			Score: suggestion.Score,
+		})
+	}
+
+	return response, nil
+}
+
+func (s *SearchServer) CorrectQuery(ctx context.Context, req *searchservice.CorrectQueryRequest) (*searchservice.CorrectQueryResponse, error) {
+	s.log.Info("Received correct query request", slog.String("query", req.Query), slog.Any("types", req.Types))
+
+	suggestion, err := s.searchService.CorrectQuery(ctx, req.Query, req.Types, req.Hits)
+	if err != nil {
+		s.log.Error("Failed to correct query", utils.ErrLog(err))
+		return nil, err
+	}
+
+	return &searchservice.CorrectQueryResponse{SuggestedQuery: suggestion}, nil
 }
 
 func (s *SearchServer) AddUser(ctx context.Context, req *searchservice.AddOrUpdateRequest) (*searchservice.SuccessResponse, error) {
@@ -99,7 +143,7 @@ func (s *SearchServer) AddAlbum(ctx context.Context, req *searchservice.AddOrUpd
 func (s *SearchServer) AddTrack(ctx context.Context, req *searchservice.AddOrUpdateRequest) (*searchservice.SuccessResponse, error) {
 	s.log.Info("Received add track request", slog.Int64("track_id", req.Id), slog.String("title", req.Name))
 
-	err := s.searchService.AddTrack(ctx, req.Id, req.Name)
+	err := s.searchService.AddTrack(ctx, req.Id, req.Name, trackAttributes(req.Track))
 	if err != nil {
 		s.log.Error("Failed to add track", utils.ErrLog(err))
 		return nil, err
@@ -110,6 +154,20 @@ func (s *SearchServer) AddTrack(ctx context.Context, req *searchservice.AddOrUpd
 	}, nil
 }
 
+func (s *SearchServer) AddPlaylist(ctx context.Context, req *searchservice.AddOrUpdateRequest) (*searchservice.SuccessResponse, error) {
+	s.log.Info("Received add playlist request", slog.Int64("playlist_id", req.Id), slog.String("title", req.Name))
+
+	err := s.searchService.AddPlaylist(ctx, req.Id, req.Name)
+	if err != nil {
+		s.log.Error("Failed to add playlist", utils.ErrLog(err))
+		return nil, err
+	}
+
+	return &searchservice.SuccessResponse{
+		Success: true,
+	}, nil
+}
+
 func (s *SearchServer) UpdateUser(ctx context.Context, req *searchservice.AddOrUpdateRequest) (*searchservice.SuccessResponse, error) {
 	s.log.Info("Received update user request", slog.Int64("user_id", req.Id), slog.String("username", req.Name))
 
@@ -141,7 +199,7 @@ func (s *SearchServer) UpdateAlbum(ctx context.Context, req *searchservice.AddOr
 func (s *SearchServer) UpdateTrack(ctx context.Context, req *searchservice.AddOrUpdateRequest) (*searchservice.SuccessResponse, error) {
 	s.log.Info("Received update track request", slog.Int64("track_id", req.Id), slog.String("title", req.Name))
 
-	err := s.searchService.UpdateTrack(ctx, req.Id, req.Name)
+	err := s.searchService.UpdateTrack(ctx, req.Id, req.Name, trackAttributes(req.Track))
 	if err != nil {
 		s.log.Error("Failed to update track", utils.ErrLog(err))
 		return nil, err
@@ -152,6 +210,20 @@ func (s *SearchServer) UpdateTrack(ctx context.Context, req *searchservice.AddOr
 	}, nil
 }
 
+func (s *SearchServer) UpdatePlaylist(ctx context.Context, req *searchservice.AddOrUpdateRequest) (*searchservice.SuccessResponse, error) {
+	s.log.Info("Received update playlist request", slog.Int64("playlist_id", req.Id), slog.String("title", req.Name))
+
+	err := s.searchService.UpdatePlaylist(ctx, req.Id, req.Name)
+	if err != nil {
+		s.log.Error("Failed to update playlist", utils.ErrLog(err))
+		return nil, err
+	}
+
+	return &searchservice.SuccessResponse{
+		Success: true,
+	}, nil
+}
+
 func (s *SearchServer) DeleteUser(ctx context.Context, req *searchservice.DeleteRequest) (*searchservice.SuccessResponse, error) {
 	s.log.Info("Received delete user request", slog.Int64("user_id", req.Id))
 
@@ -193,3 +265,234 @@ func (s *SearchServer) DeleteTrack(ctx context.Context, req *searchservice.Delet
 		Success: true,
 	}, nil
 }
+
+func (s *SearchServer) DeletePlaylist(ctx context.Context, req *searchservice.DeleteRequest) (*searchservice.SuccessResponse, error) {
+	s.log.Info("Received delete playlist request", slog.Int64("playlist_id", req.Id))
+
+	err := s.searchService.DeletePlaylist(ctx, req.Id)
+	if err != nil {
+		s.log.Error("Failed to delete playlist", utils.ErrLog(err))
+		return nil, err
+	}
+
+	return &searchservice.SuccessResponse{
+		Success: true,
+	}, nil
+}
+
+func (s *SearchServer) UpdateSignals(ctx context.Context, req *searchservice.UpdateSignalsRequest) (*searchservice.SuccessResponse, error) {
+	s.log.Info("Received update signals request", slog.String("type", req.Type), slog.Int("count", len(req.Signals)))
+
+	err := s.searchService.UpdateSignals(ctx, req.Type, documentSignals(req.Signals))
+	if err != nil {
+		s.log.Error("Failed to update signals", utils.ErrLog(err))
+		return nil, err
+	}
+
+	return &searchservice.SuccessResponse{
+		Success: true,
+	}, nil
+}
+
+func (s *SearchServer) RecordClick(ctx context.Context, req *searchservice.ClickRequest) (*searchservice.SuccessResponse, error) {
+	s.log.Info("Received record click request", slog.String("query_id", req.QueryId), slog.Int64("document_id", req.DocumentId))
+
+	err := s.searchService.RecordClick(ctx, req.QueryId, req.DocumentId, int(req.Position))
+	if err != nil {
+		s.log.Error("Failed to record click", utils.ErrLog(err))
+		return nil, err
+	}
+
+	return &searchservice.SuccessResponse{
+		Success: true,
+	}, nil
+}
+
+func (s *SearchServer) GetTopQueries(ctx context.Context, req *searchservice.AnalyticsRequest) (*searchservice.QueryStatsResponse, error) {
+	s.log.Info("Received get top queries request", slog.String("type", req.Type))
+
+	stats, err := s.searchService.TopQueries(ctx, analyticsRange(req))
+	if err != nil {
+		s.log.Error("Failed to get top queries", utils.ErrLog(err))
+		return nil, err
+	}
+
+	return queryStatsResponse(stats), nil
+}
+
+func (s *SearchServer) GetZeroResultQueries(ctx context.Context, req *searchservice.AnalyticsRequest) (*searchservice.QueryStatsResponse, error) {
+	s.log.Info("Received get zero-result queries request", slog.String("type", req.Type))
+
+	stats, err := s.searchService.ZeroResultQueries(ctx, analyticsRange(req))
+	if err != nil {
+		s.log.Error("Failed to get zero-result queries", utils.ErrLog(err))
+		return nil, err
+	}
+
+	return queryStatsResponse(stats), nil
+}
+
+func (s *SearchServer) GetClickThroughRate(ctx context.Context, req *searchservice.AnalyticsRequest) (*searchservice.ClickThroughRateResponse, error) {
+	s.log.Info("Received get click-through rate request", slog.String("type", req.Type))
+
+	rate, err := s.searchService.ClickThroughRate(ctx, analyticsRange(req))
+	if err != nil {
+		s.log.Error("Failed to get click-through rate", utils.ErrLog(err))
+		return nil, err
+	}
+
+	return &searchservice.ClickThroughRateResponse{
+		Searches:         rate.Searches,
+		ClickedSearches:  rate.ClickedSearches,
+		ClickThroughRate: clickThroughRate(rate.ClickedSearches, rate.Searches),
+	}, nil
+}
+
+func documentSignals(signals []*searchservice.DocumentSignals) []*elastic.DocumentSignals {
+	result := make([]*elastic.DocumentSignals, 0, len(signals))
+	for _, signal := range signals {
+		documentSignals := &elastic.DocumentSignals{
+			ID:        signal.Id,
+			Plays:     signal.Plays,
+			Likes:     signal.Likes,
+			Followers: signal.Followers,
+		}
+		if signal.CreatedAt != 0 {
+			documentSignals.CreatedAt = time.Unix(signal.CreatedAt, 0)
+		}
+		result = append(result, documentSignals)
+	}
+
+	return result
+}
+
+func searchPage(req *searchservice.SearchRequest) elastic.SearchPage {
+	return elastic.SearchPage{
+		Limit:          int(req.Limit),
+		Offset:         int(req.Offset),
+		Cursor:         req.Cursor,
+		Highlight:      req.Highlight,
+		AutoCorrect:    req.AutoCorrect,
+		SkipCorrection: req.SkipCorrection,
+		Correction:     req.Correction,
+	}
+}
+
+func searchResponse(result *elastic.SearchResult) *searchservice.SearchResponse {
+	hits := make([]*searchservice.SearchHit, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		hits = append(hits, &searchservice.SearchHit{
+			Id:         hit.ID,
+			Score:      hit.Score,
+			Highlights: hit.Highlights,
+		})
+	}
+
+	response := &searchservice.SearchResponse{
+		Ids:            result.IDs(),
+		Total:          result.Total,
+		NextCursor:     result.NextCursor,
+		Hits:           hits,
+		QueryId:        result.QueryID,
+		SuggestedQuery: result.SuggestedQuery,
+		Corrected:      result.Corrected,
+	}
+	if result.Facets != nil {
+		response.Facets = facetsResponse(result.Facets)
+	}
+
+	return response
+}
+
+func analyticsRange(req *searchservice.AnalyticsRequest) elastic.AnalyticsRange {
+	result := elastic.AnalyticsRange{
+		Type:  req.Type,
+		Limit: int(req.Limit),
+	}
+	if req.From > 0 {
+		result.From = time.Unix(req.From, 0)
+	}
+	if req.To > 0 {
+		result.To = time.Unix(req.To, 0)
+	}
+
+	return result
+}
+
+func queryStatsResponse(stats []*elastic.QueryStat) *searchservice.QueryStatsResponse {
+	queries := make([]*searchservice.QueryStat, 0, len(stats))
+	for _, stat := range stats {
+		queries = append(queries, &searchservice.QueryStat{
+			Query:            stat.Query,
+			Searches:         stat.Searches,
+			ClickedSearches:  stat.ClickedSearches,
+			ClickThroughRate: clickThroughRate(stat.ClickedSearches, stat.Searches),
+		})
+	}
+
+	return &searchservice.QueryStatsResponse{Queries: queries}
+}
+
+func clickThroughRate(clicked, searches int64) float64 {
+	if searches == 0 {
+		return 0
+	}
+
+	return float64(clicked) / float64(searches)
+}
+
+func trackFilters(filters *searchservice.TrackFilters) *elastic.TrackFilters {
+	if filters == nil {
+		return nil
+	}
+
+	result := &elastic.TrackFilters{
+		Genres:      filters.Genres,
+		Tags:        filters.Tags,
+		MinDuration: filters.MinDuration,
+		MaxDuration: filters.MaxDuration,
+		ArtistIDs:   filters.ArtistIds,
+	}
+	if filters.UploadedAfter > 0 {
+		result.UploadedAfter = time.Unix(filters.UploadedAfter, 0)
+	}
+	if filters.UploadedBefore > 0 {
+		result.UploadedBefore = time.Unix(filters.UploadedBefore, 0)
+	}
+
+	return result
+}
+
+func trackAttributes(attributes *searchservice.TrackAttributes) *elastic.TrackAttributes {
+	if attributes == nil {
+		return nil
+	}
+
+	return &elastic.TrackAttributes{
+		Genre:    attributes.Genre,
+		Tags:     attributes.Tags,
+		Duration: attributes.Duration,
+		UserID:   attributes.UserId,
+	}
+}
+
+func facetsResponse(facets *elastic.Facets) *searchservice.Facets {
+	return &searchservice.Facets{
+		Genres:    facetBuckets(facets.Genres),
+		Durations: facetBuckets(facets.Durations),
+	}
+}
+
+func facetBuckets(buckets []*elastic.FacetBucket) []*searchservice.FacetBucket {
+	result := make([]*searchservice.FacetBucket, 0, len(buckets))
+	for _, bucket := range buckets {
+		result = append(result, &searchservice.FacetBucket{
+			Key:   bucket.Key,
+			Count: bucket.Count,
+			From:  bucket.From,
+			To:    bucket.To,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,192 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/ocenb/music-go/search-service/internal/config"
+	"github.com/ocenb/music-go/search-service/internal/utils"
+	"github.com/ocenb/music-go/shared/outbox"
+	"github.com/ocenb/music-protos/gen/searchservice"
+	"github.com/segmentio/kafka-go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	ErrorHeader     = "error"
+	minRetryBackoff = time.Second
+	maxRetryBackoff = 30 * time.Second
+)
+
+type messageReader interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, messages ...kafka.Message) error
+	Close() error
+}
+
+type messageWriter interface {
+	WriteMessages(ctx context.Context, messages ...kafka.Message) error
+	Close() error
+}
+
+type Consumer struct {
+	reader     messageReader
+	deadLetter messageWriter
+	maxRetries int
+	log        *slog.Logger
+}
+
+func NewConsumer(cfg *config.Config, log *slog.Logger) (*Consumer, error) {
+	if len(cfg.KafkaBrokers) == 0 {
+		return nil, fmt.Errorf("kafka brokers list is empty")
+	}
+
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers: cfg.KafkaBrokers,
+		Topic:   cfg.KafkaTopic,
+		GroupID: cfg.KafkaGroupID,
+	})
+
+	deadLetter := &kafka.Writer{
+		Addr:         kafka.TCP(cfg.KafkaBrokers...),
+		Topic:        cfg.KafkaDLQTopic,
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+	}
+
+	return &Consumer{
+		reader:     reader,
+		deadLetter: deadLetter,
+		maxRetries: cfg.KafkaMaxRetries,
+		log:        log,
+	}, nil
+}
+
+func (c *Consumer) Close() error {
+	return errors.Join(c.reader.Close(), c.deadLetter.Close())
+}
+
+func (c *Consumer) Consume(ctx context.Context, handler func(ctx context.Context, event *searchservice.IndexEvent, version int64) error) error {
+	backoff := minRetryBackoff
+	for {
+		message, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return nil
+			}
+			c.log.Error("Failed to fetch index event", slog.Duration("backoff", backoff), utils.ErrLog(err))
+			if err := sleep(ctx, backoff); err != nil {
+				return nil
+			}
+			backoff = min(backoff*2, maxRetryBackoff)
+			continue
+		}
+		backoff = minRetryBackoff
+
+		if err := c.handle(ctx, message, handler); err != nil {
+			if errors.Is(err, context.Canceled) {
+				return nil
+			}
+			return err
+		}
+
+		if err := c.reader.CommitMessages(ctx, message); err != nil {
+			if errors.Is(err, context.Canceled) {
+				return nil
+			}
+			c.log.Error("Failed to commit index event", slog.Int64("offset", message.Offset), utils.ErrLog(err))
+		}
+	}
+}
+
+func (c *Consumer) handle(ctx context.Context, message kafka.Message, handler func(ctx context.Context, event *searchservice.IndexEvent, version int64) error) error {
+	log := c.log.With(
+		slog.String("key", string(message.Key)),
+		slog.Int("partition", message.Partition),
+		slog.Int64("offset", message.Offset),
+	)
+
+	var event searchservice.IndexEvent
+	if err := protojson.Unmarshal(message.Value, &event); err != nil {
+		log.Error("Skipping malformed index event", utils.ErrLog(err))
+		return nil
+	}
+
+	version, err := messageVersion(message)
+	if err != nil {
+		log.Error("Skipping index event without version", utils.ErrLog(err))
+		return nil
+	}
+
+	backoff := minRetryBackoff
+	for attempt := 1; ; attempt++ {
+		err := handler(ctx, &event, version)
+		if err == nil {
+			return nil
+		}
+		if status.Code(err) == codes.InvalidArgument {
+			log.Error("Skipping invalid index event", utils.ErrLog(err))
+			return nil
+		}
+		if attempt >= c.maxRetries {
+			log.Error("Moving index event to the dead letter topic", slog.Int("attempts", attempt), utils.ErrLog(err))
+			return c.moveToDeadLetter(ctx, message, err)
+		}
+
+		log.Warn("Retrying index event", slog.Duration("backoff", backoff), utils.ErrLog(err))
+		if err := sleep(ctx, backoff); err != nil {
+			return err
+		}
+		backoff = min(backoff*2, maxRetryBackoff)
+	}
+}
+
+// moveToDeadLetter keeps retrying the write, since committing the event
+// without it would lose the event.
+func (c *Consumer) moveToDeadLetter(ctx context.Context, message kafka.Message, cause error) error {
+	headers := append(slices.Clone(message.Headers), kafka.Header{Key: ErrorHeader, Value: []byte(cause.Error())})
+
+	backoff := minRetryBackoff
+	for {
+		err := c.deadLetter.WriteMessages(ctx, kafka.Message{
+			Key:     message.Key,
+			Value:   message.Value,
+			Headers: headers,
+		})
+		if err == nil {
+			return nil
+		}
+
+		c.log.Error("Failed to write index event to the dead letter topic", slog.Duration("backoff", backoff), utils.ErrLog(err))
+		if err := sleep(ctx, backoff); err != nil {
+			return err
+		}
+		backoff = min(backoff*2, maxRetryBackoff)
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
+
+func messageVersion(message kafka.Message) (int64, error) {
+	for _, header := range message.Headers {
+		if header.Key == outbox.VersionHeader {
+			return strconv.ParseInt(string(header.Value), 10, 64)
+		}
+	}
+
+	return 0, fmt.Errorf("missing %s header", outbox.VersionHeader)
+}
@@ -0,0 +1,218 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strconv"
+	"testing"
+
+	"github.com/ocenb/music-go/search-service/internal/clients/elastic"
+	"github.com/ocenb/music-go/search-service/internal/clients/embedded"
+	"github.com/ocenb/music-go/search-service/internal/config"
+	"github.com/ocenb/music-go/search-service/internal/handlers"
+	"github.com/ocenb/music-go/search-service/internal/service"
+	"github.com/ocenb/music-go/search-service/internal/utils"
+	"github.com/ocenb/music-go/shared/outbox"
+	"github.com/ocenb/music-protos/gen/searchservice"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+type fakeReader struct {
+	messages  []kafka.Message
+	committed []kafka.Message
+	done      context.CancelFunc
+}
+
+func (r *fakeReader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	if len(r.messages) == 0 {
+		r.done()
+		<-ctx.Done()
+		return kafka.Message{}, ctx.Err()
+	}
+
+	message := r.messages[0]
+	r.messages = r.messages[1:]
+	return message, nil
+}
+
+func (r *fakeReader) CommitMessages(ctx context.Context, messages ...kafka.Message) error {
+	r.committed = append(r.committed, messages...)
+	return nil
+}
+
+func (r *fakeReader) Close() error {
+	return nil
+}
+
+type fakeWriter struct {
+	messages []kafka.Message
+}
+
+func (w *fakeWriter) WriteMessages(ctx context.Context, messages ...kafka.Message) error {
+	w.messages = append(w.messages, messages...)
+	return nil
+}
+
+func (w *fakeWriter) Close() error {
+	return nil
+}
+
+func newSearchService(t *testing.T) service.SearchServiceInterface {
+	t.Helper()
+
+	cfg := &config.Config{
+		Ranking:       config.RankingConfig{RecencyScale: "30d", MaxBoost: 1},
+		SearchBackend: config.SearchBackendEmbedded,
+	}
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	engine, err := embedded.New(cfg, log)
+	require.NoError(t, err)
+
+	return service.NewSearchService(cfg, log, engine)
+}
+
+func consume(t *testing.T, maxRetries int, messages []kafka.Message, handler func(ctx context.Context, event *searchservice.IndexEvent, version int64) error) (*fakeReader, *fakeWriter) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reader := &fakeReader{messages: messages, done: cancel}
+	deadLetter := &fakeWriter{}
+	consumer := &Consumer{
+		reader:     reader,
+		deadLetter: deadLetter,
+		maxRetries: maxRetries,
+		log:        slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+
+	require.NoError(t, consumer.Consume(ctx, handler))
+
+	return reader, deadLetter
+}
+
+func upsertMessage(t *testing.T, id int64, name string, version int64) kafka.Message {
+	t.Helper()
+
+	return eventMessage(t, &searchservice.IndexEvent{
+		Type:   elastic.DocumentTypeTrack,
+		Change: &searchservice.IndexEvent_Upsert{Upsert: &searchservice.AddOrUpdateRequest{Id: id, Name: name}},
+	}, version)
+}
+
+func deleteMessage(t *testing.T, id int64, version int64) kafka.Message {
+	t.Helper()
+
+	return eventMessage(t, &searchservice.IndexEvent{
+		Type:   elastic.DocumentTypeTrack,
+		Change: &searchservice.IndexEvent_Delete{Delete: &searchservice.DeleteRequest{Id: id}},
+	}, version)
+}
+
+func eventMessage(t *testing.T, event *searchservice.IndexEvent, version int64) kafka.Message {
+	t.Helper()
+
+	value, err := protojson.Marshal(event)
+	require.NoError(t, err)
+
+	return kafka.Message{
+		Key:     []byte("track:" + strconv.FormatInt(version, 10)),
+		Value:   value,
+		Headers: []kafka.Header{{Key: outbox.VersionHeader, Value: []byte(strconv.FormatInt(version, 10))}},
+	}
+}
+
+func searchTrack(t *testing.T, searchService service.SearchServiceInterface, query string) []int64 {
+	t.Helper()
+
+	result, err := searchService.SearchTracks(context.Background(), query, elastic.SearchPage{Limit: 10, SkipCorrection: true}, nil)
+	require.NoError(t, err)
+
+	ids := make([]int64, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		ids = append(ids, hit.ID)
+	}
+	return ids
+}
+
+func TestConsumeSkipsStaleVersions(t *testing.T) {
+	searchService := newSearchService(t)
+	handler := handlers.IndexEventHandler(slog.New(slog.NewTextHandler(io.Discard, nil)), searchService)
+
+	messages := []kafka.Message{
+		upsertMessage(t, 1, "marigold", 2),
+		upsertMessage(t, 1, "juniper", 1),
+	}
+	reader, deadLetter := consume(t, 3, messages, handler)
+
+	assert.Len(t, reader.committed, 2)
+	assert.Empty(t, deadLetter.messages)
+	assert.Equal(t, []int64{1}, searchTrack(t, searchService, "marigold"))
+	assert.Empty(t, searchTrack(t, searchService, "juniper"))
+}
+
+func TestConsumeKeepsDeleteTombstones(t *testing.T) {
+	searchService := newSearchService(t)
+	handler := handlers.IndexEventHandler(slog.New(slog.NewTextHandler(io.Discard, nil)), searchService)
+
+	messages := []kafka.Message{
+		upsertMessage(t, 1, "marigold", 1),
+		deleteMessage(t, 1, 3),
+		upsertMessage(t, 1, "marigold", 2),
+	}
+	reader, _ := consume(t, 3, messages, handler)
+
+	assert.Len(t, reader.committed, 3)
+	assert.Empty(t, searchTrack(t, searchService, "marigold"))
+
+	reader, _ = consume(t, 3, []kafka.Message{upsertMessage(t, 1, "marigold", 4)}, handler)
+
+	assert.Len(t, reader.committed, 1)
+	assert.Equal(t, []int64{1}, searchTrack(t, searchService, "marigold"))
+}
+
+func TestConsumeMovesFailedEventToDeadLetter(t *testing.T) {
+	errIndex := errors.New("index unavailable")
+	attempts := 0
+	handler := func(ctx context.Context, event *searchservice.IndexEvent, version int64) error {
+		attempts++
+		return errIndex
+	}
+
+	message := upsertMessage(t, 1, "marigold", 1)
+	reader, deadLetter := consume(t, 2, []kafka.Message{message}, handler)
+
+	assert.Equal(t, 2, attempts)
+	assert.Len(t, reader.committed, 1)
+	require.Len(t, deadLetter.messages, 1)
+	assert.Equal(t, message.Value, deadLetter.messages[0].Value)
+	assert.Contains(t, deadLetter.messages[0].Headers, kafka.Header{Key: outbox.VersionHeader, Value: []byte("1")})
+	assert.Contains(t, deadLetter.messages[0].Headers, kafka.Header{Key: ErrorHeader, Value: []byte(errIndex.Error())})
+}
+
+func TestConsumeSkipsInvalidEvents(t *testing.T) {
+	attempts := 0
+	handler := func(ctx context.Context, event *searchservice.IndexEvent, version int64) error {
+		attempts++
+		return utils.InvalidArgumentError(handlers.ErrEmptyIndexEvent)
+	}
+
+	withoutVersion := upsertMessage(t, 1, "marigold", 1)
+	withoutVersion.Headers = nil
+	messages := []kafka.Message{
+		upsertMessage(t, 1, "marigold", 1),
+		withoutVersion,
+		{Value: []byte("not json")},
+	}
+	reader, deadLetter := consume(t, 3, messages, handler)
+
+	assert.Equal(t, 1, attempts)
+	assert.Len(t, reader.committed, 3)
+	assert.Empty(t, deadLetter.messages)
+}
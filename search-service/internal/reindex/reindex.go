@@ -0,0 +1,166 @@
+package reindex
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/ocenb/music-go/search-service/internal/clients/elastic"
+)
+
+type RebuildBackend interface {
+	CreateRebuildIndex(ctx context.Context, alias string) (string, error)
+	StartRebuild(ctx context.Context, alias, target string) error
+	IndexExists(ctx context.Context, index string) (bool, error)
+	DeleteIndex(ctx context.Context, index string) error
+	BulkMerge(ctx context.Context, index string, documents []*elastic.Document) error
+	SwapAlias(ctx context.Context, alias, target string) error
+	GetReindexCheckpoint(ctx context.Context, alias string) (*elastic.ReindexCheckpoint, error)
+	SaveReindexCheckpoint(ctx context.Context, checkpoint *elastic.ReindexCheckpoint) error
+	DeleteReindexCheckpoint(ctx context.Context, alias string) error
+}
+
+type Reindexer struct {
+	log       *slog.Logger
+	elastic   RebuildBackend
+	batchSize int
+}
+
+func New(log *slog.Logger, elastic RebuildBackend, batchSize int) *Reindexer {
+	return &Reindexer{
+		log:       log,
+		elastic:   elastic,
+		batchSize: batchSize,
+	}
+}
+
+func (r *Reindexer) Run(ctx context.Context, source *Source, resume bool) error {
+	log := r.log.With(slog.String("alias", source.Alias))
+
+	checkpoint, err := r.checkpoint(ctx, source.Alias, resume)
+	if err != nil {
+		return err
+	}
+	if checkpoint.LastID > 0 {
+		log.Info("Resuming reindex",
+			slog.String("target", checkpoint.Target),
+			slog.Int64("last_id", checkpoint.LastID),
+			slog.Int64("indexed", checkpoint.Indexed),
+		)
+	} else {
+		log.Info("Starting reindex", slog.String("target", checkpoint.Target))
+	}
+
+	total, err := source.count(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to count %s rows: %w", source.Alias, err)
+	}
+
+	startedAt := time.Now()
+	startIndexed := checkpoint.Indexed
+	for {
+		documents, err := source.batch(ctx, log, checkpoint.LastID, r.batchSize)
+		if err != nil {
+			return fmt.Errorf("failed to read %s rows: %w", source.Alias, err)
+		}
+		if len(documents) == 0 {
+			break
+		}
+
+		if err := r.elastic.BulkMerge(ctx, checkpoint.Target, documents); err != nil {
+			return err
+		}
+
+		checkpoint.LastID = documents[len(documents)-1].ID
+		checkpoint.Indexed += int64(len(documents))
+		if err := r.elastic.SaveReindexCheckpoint(ctx, checkpoint); err != nil {
+			return err
+		}
+
+		log.Info("Reindex progress",
+			slog.Int64("indexed", checkpoint.Indexed),
+			slog.Int64("total", total),
+			slog.String("percent", progress(checkpoint.Indexed, total)),
+			slog.Float64("docs_per_second", rate(checkpoint.Indexed-startIndexed, time.Since(startedAt))),
+		)
+	}
+
+	if err := r.elastic.SwapAlias(ctx, source.Alias, checkpoint.Target); err != nil {
+		return err
+	}
+	if err := r.elastic.DeleteReindexCheckpoint(ctx, source.Alias); err != nil {
+		return err
+	}
+
+	log.Info("Reindex finished",
+		slog.String("target", checkpoint.Target),
+		slog.Int64("indexed", checkpoint.Indexed),
+		slog.Duration("duration", time.Since(startedAt)),
+	)
+
+	return nil
+}
+
+func (r *Reindexer) checkpoint(ctx context.Context, alias string, resume bool) (*elastic.ReindexCheckpoint, error) {
+	previous, err := r.elastic.GetReindexCheckpoint(ctx, alias)
+	if err != nil {
+		return nil, err
+	}
+	if previous != nil {
+		exists, err := r.elastic.IndexExists(ctx, previous.Target)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case !exists:
+			r.log.Warn("Reindex target is missing, starting over", slog.String("target", previous.Target))
+		case resume:
+			if err := r.elastic.StartRebuild(ctx, alias, previous.Target); err != nil {
+				return nil, err
+			}
+			return previous, nil
+		default:
+			if err := r.elastic.DeleteIndex(ctx, previous.Target); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	target, err := r.elastic.CreateRebuildIndex(ctx, alias)
+	if err != nil {
+		return nil, err
+	}
+
+	// Live writes are mirrored into the target from here on, so rows that
+	// change or disappear while the copy runs are not lost at the swap.
+	r.log.Info("Registering rebuild target", slog.String("target", target))
+	if err := r.elastic.StartRebuild(ctx, alias, target); err != nil {
+		return nil, err
+	}
+
+	checkpoint := &elastic.ReindexCheckpoint{
+		Alias:     alias,
+		Target:    target,
+		StartedAt: time.Now(),
+	}
+	if err := r.elastic.SaveReindexCheckpoint(ctx, checkpoint); err != nil {
+		return nil, err
+	}
+
+	return checkpoint, nil
+}
+
+func progress(indexed, total int64) string {
+	if total == 0 {
+		return "100.0%"
+	}
+	return fmt.Sprintf("%.1f%%", float64(indexed)*100/float64(total))
+}
+
+func rate(indexed int64, elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(indexed) / elapsed.Seconds()
+}
@@ -0,0 +1,246 @@
+package reindex
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ocenb/music-go/search-service/internal/clients/elastic"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	countQuery = "count"
+	batchQuery = "batch"
+)
+
+var errMergeFailed = errors.New("bulk merge failed")
+
+// rowsDriver is a database/sql driver that answers the count and batch
+// queries of a source from ids 1..rows.
+type rowsDriver struct {
+	rows int64
+}
+
+func (d *rowsDriver) Open(name string) (driver.Conn, error) {
+	return &rowsConn{rows: d.rows}, nil
+}
+
+func (d *rowsDriver) Connect(ctx context.Context) (driver.Conn, error) {
+	return d.Open("")
+}
+
+func (d *rowsDriver) Driver() driver.Driver {
+	return d
+}
+
+type rowsConn struct {
+	rows int64
+}
+
+func (c *rowsConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements are not supported")
+}
+
+func (c *rowsConn) Close() error {
+	return nil
+}
+
+func (c *rowsConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions are not supported")
+}
+
+func (c *rowsConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	switch query {
+	case countQuery:
+		return &rows{columns: []string{"count"}, values: [][]driver.Value{{c.rows}}}, nil
+	case batchQuery:
+		lastID, take := args[0].Value.(int64), args[1].Value.(int64)
+		result := &rows{columns: []string{"id", "title", "likes", "created_at"}}
+		for id := lastID + 1; id <= c.rows && int64(len(result.values)) < take; id++ {
+			result.values = append(result.values, []driver.Value{id, fmt.Sprintf("playlist %d", id), int64(0), time.Now()})
+		}
+		return result, nil
+	default:
+		return nil, fmt.Errorf("unexpected query %q", query)
+	}
+}
+
+type rows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (r *rows) Columns() []string {
+	return r.columns
+}
+
+func (r *rows) Close() error {
+	return nil
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+type fakeBackend struct {
+	indices     map[string]map[int64]bool
+	aliases     map[string]string
+	checkpoints map[string]elastic.ReindexCheckpoint
+	merges      [][]int64
+	failMerge   int
+	created     int
+}
+
+func newFakeBackend(alias string) *fakeBackend {
+	serving := alias + "_serving"
+	return &fakeBackend{
+		indices:     map[string]map[int64]bool{serving: {}},
+		aliases:     map[string]string{alias: serving},
+		checkpoints: make(map[string]elastic.ReindexCheckpoint),
+	}
+}
+
+func (b *fakeBackend) CreateRebuildIndex(ctx context.Context, alias string) (string, error) {
+	b.created++
+	target := elastic.VersionedIndexName(alias, b.created)
+	b.indices[target] = make(map[int64]bool)
+	return target, nil
+}
+
+func (b *fakeBackend) StartRebuild(ctx context.Context, alias, target string) error {
+	return nil
+}
+
+func (b *fakeBackend) IndexExists(ctx context.Context, index string) (bool, error) {
+	_, ok := b.indices[index]
+	return ok, nil
+}
+
+func (b *fakeBackend) DeleteIndex(ctx context.Context, index string) error {
+	delete(b.indices, index)
+	return nil
+}
+
+func (b *fakeBackend) BulkMerge(ctx context.Context, index string, documents []*elastic.Document) error {
+	ids := make([]int64, 0, len(documents))
+	for _, document := range documents {
+		ids = append(ids, document.ID)
+	}
+	b.merges = append(b.merges, ids)
+	if len(b.merges) == b.failMerge {
+		return errMergeFailed
+	}
+
+	for _, id := range ids {
+		b.indices[index][id] = true
+	}
+	return nil
+}
+
+func (b *fakeBackend) SwapAlias(ctx context.Context, alias, target string) error {
+	if previous := b.aliases[alias]; previous != target {
+		delete(b.indices, previous)
+	}
+	b.aliases[alias] = target
+	return nil
+}
+
+func (b *fakeBackend) GetReindexCheckpoint(ctx context.Context, alias string) (*elastic.ReindexCheckpoint, error) {
+	checkpoint, ok := b.checkpoints[alias]
+	if !ok {
+		return nil, nil
+	}
+	return &checkpoint, nil
+}
+
+func (b *fakeBackend) SaveReindexCheckpoint(ctx context.Context, checkpoint *elastic.ReindexCheckpoint) error {
+	b.checkpoints[checkpoint.Alias] = *checkpoint
+	return nil
+}
+
+func (b *fakeBackend) DeleteReindexCheckpoint(ctx context.Context, alias string) error {
+	delete(b.checkpoints, alias)
+	return nil
+}
+
+func newSource(t *testing.T, rows int64) *Source {
+	t.Helper()
+
+	db := sql.OpenDB(&rowsDriver{rows: rows})
+	t.Cleanup(func() {
+		db.Close()
+	})
+
+	return &Source{
+		Alias:      elastic.PlaylistsIndexName,
+		db:         db,
+		countQuery: countQuery,
+		batchQuery: batchQuery,
+		scan:       scanPlaylist,
+	}
+}
+
+func interruptedRun(t *testing.T) (*Reindexer, *fakeBackend, *Source) {
+	t.Helper()
+
+	source := newSource(t, 5)
+	backend := newFakeBackend(source.Alias)
+	backend.failMerge = 2
+	reindexer := New(slog.New(slog.NewTextHandler(io.Discard, nil)), backend, 2)
+
+	err := reindexer.Run(context.Background(), source, true)
+	require.ErrorIs(t, err, errMergeFailed)
+
+	checkpoint := backend.checkpoints[source.Alias]
+	assert.Equal(t, int64(2), checkpoint.LastID)
+	assert.Equal(t, int64(2), checkpoint.Indexed)
+	assert.Equal(t, source.Alias+"_serving", backend.aliases[source.Alias], "the alias is not swapped")
+
+	backend.failMerge = 0
+	backend.merges = nil
+
+	return reindexer, backend, source
+}
+
+func TestRunResumesFromCheckpoint(t *testing.T) {
+	reindexer, backend, source := interruptedRun(t)
+	target := backend.checkpoints[source.Alias].Target
+
+	require.NoError(t, reindexer.Run(context.Background(), source, true))
+
+	assert.Equal(t, [][]int64{{3, 4}, {5}}, backend.merges)
+	assert.Equal(t, 1, backend.created, "the interrupted target is reused")
+	assert.Equal(t, target, backend.aliases[source.Alias])
+	assert.Equal(t, []int64{1, 2, 3, 4, 5}, slices.Sorted(maps.Keys(backend.indices[target])))
+	assert.NotContains(t, backend.indices, source.Alias+"_serving", "the previous index is deleted")
+	assert.NotContains(t, backend.checkpoints, source.Alias)
+}
+
+func TestRunWithoutResumeStartsOver(t *testing.T) {
+	reindexer, backend, source := interruptedRun(t)
+	interrupted := backend.checkpoints[source.Alias].Target
+
+	require.NoError(t, reindexer.Run(context.Background(), source, false))
+
+	target := backend.aliases[source.Alias]
+	assert.Equal(t, [][]int64{{1, 2}, {3, 4}, {5}}, backend.merges)
+	assert.NotEqual(t, interrupted, target)
+	assert.NotContains(t, backend.indices, interrupted, "the interrupted target is deleted")
+	assert.Equal(t, []int64{1, 2, 3, 4, 5}, slices.Sorted(maps.Keys(backend.indices[target])))
+	assert.NotContains(t, backend.checkpoints, source.Alias)
+}
@@ -0,0 +1,164 @@
+package reindex
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/ocenb/music-go/search-service/internal/clients/elastic"
+	"github.com/ocenb/music-go/search-service/internal/utils"
+)
+
+type Source struct {
+	Alias      string
+	db         *sql.DB
+	countQuery string
+	batchQuery string
+	scan       func(rows *sql.Rows) (*elastic.Document, error)
+}
+
+func NewSources(userDB, contentDB *sql.DB) []*Source {
+	return []*Source{
+		{
+			Alias:      elastic.UsersIndexName,
+			db:         userDB,
+			countQuery: `SELECT COUNT(*) FROM users`,
+			batchQuery: `
+				SELECT id, username, followers_count, created_at
+				FROM users
+				WHERE id > $1
+				ORDER BY id
+				LIMIT $2
+			`,
+			scan: scanUser,
+		},
+		{
+			Alias:      elastic.TracksIndexName,
+			db:         contentDB,
+			countQuery: `SELECT COUNT(*) FROM tracks`,
+			batchQuery: `
+				SELECT t.id, t.title, t.genre, t.tags, t.duration, t.user_id, t.plays,
+					(SELECT COUNT(*) FROM user_liked_tracks ult WHERE ult.track_id = t.id) AS likes,
+					t.created_at
+				FROM tracks t
+				WHERE t.id > $1
+				ORDER BY t.id
+				LIMIT $2
+			`,
+			scan: scanTrack,
+		},
+		{
+			Alias:      elastic.PlaylistsIndexName,
+			db:         contentDB,
+			countQuery: `SELECT COUNT(*) FROM playlists WHERE visibility = 'public'`,
+			batchQuery: `
+				SELECT p.id, p.title,
+					(SELECT COUNT(*) FROM user_saved_playlists usp WHERE usp.playlist_id = p.id) AS likes,
+					p.created_at
+				FROM playlists p
+				WHERE p.id > $1 AND p.visibility = 'public'
+				ORDER BY p.id
+				LIMIT $2
+			`,
+			scan: scanPlaylist,
+		},
+	}
+}
+
+func (s *Source) count(ctx context.Context) (int64, error) {
+	var total int64
+	err := s.db.QueryRowContext(ctx, s.countQuery).Scan(&total)
+	return total, err
+}
+
+func (s *Source) batch(ctx context.Context, log *slog.Logger, lastID int64, take int) ([]*elastic.Document, error) {
+	rows, err := s.db.QueryContext(ctx, s.batchQuery, lastID, take)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			log.Error("Failed to close rows", utils.ErrLog(err))
+		}
+	}()
+
+	var documents []*elastic.Document
+	for rows.Next() {
+		document, err := s.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, document)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return documents, nil
+}
+
+func scanUser(rows *sql.Rows) (*elastic.Document, error) {
+	var id, followers int64
+	var username string
+	var createdAt time.Time
+	if err := rows.Scan(&id, &username, &followers, &createdAt); err != nil {
+		return nil, err
+	}
+
+	return &elastic.Document{
+		ID: id,
+		Source: map[string]any{
+			"id":         id,
+			"username":   username,
+			"followers":  followers,
+			"created_at": createdAt,
+		},
+	}, nil
+}
+
+func scanTrack(rows *sql.Rows) (*elastic.Document, error) {
+	var id, duration, userID, plays, likes int64
+	var title, genre string
+	var tags []string
+	var createdAt time.Time
+	if err := rows.Scan(&id, &title, &genre, pq.Array(&tags), &duration, &userID, &plays, &likes, &createdAt); err != nil {
+		return nil, err
+	}
+
+	return &elastic.Document{
+		ID: id,
+		Source: map[string]any{
+			"id":         id,
+			"title":      title,
+			"genre":      genre,
+			"tags":       tags,
+			"duration":   duration,
+			"user_id":    userID,
+			"plays":      plays,
+			"likes":      likes,
+			"created_at": createdAt,
+		},
+	}, nil
+}
+
+func scanPlaylist(rows *sql.Rows) (*elastic.Document, error) {
+	var id, likes int64
+	var title string
+	var createdAt time.Time
+	if err := rows.Scan(&id, &title, &likes, &createdAt); err != nil {
+		return nil, err
+	}
+
+	return &elastic.Document{
+		ID: id,
+		Source: map[string]any{
+			"id":         id,
+			"title":      title,
+			"likes":      likes,
+			"created_at": createdAt,
+		},
+	}, nil
+}
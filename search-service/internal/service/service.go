@@ -1,74 +1,234 @@
 package service
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/ocenb/music-go/search-service/internal/clients/elastic"
 	"github.com/ocenb/music-go/search-service/internal/config"
 	"github.com/ocenb/music-go/search-service/internal/utils"
 )
 
+const (
+	DefaultSearchLimit  = 10
+	MaxSearchLimit      = 100
+	DefaultSuggestLimit = 5
+	MaxSuggestLimit     = 10
+	DefaultReportLimit  = 10
+	MaxReportLimit      = 100
+	DefaultReportPeriod = 7 * 24 * time.Hour
+)
+
+var (
+	ErrInvalidDurationRange = errors.New("min duration must not exceed max duration")
+	ErrInvalidUploadRange   = errors.New("uploaded after must not exceed uploaded before")
+	ErrInvalidReportRange   = errors.New("report from must not exceed report to")
+	ErrUnknownSearchType    = errors.New("unknown search type")
+	ErrInvalidQueryID       = errors.New("invalid query id")
+	ErrInvalidClickPosition = errors.New("click position must not be negative")
+	ErrOffsetTooLarge       = errors.New("offset is beyond the result window, use the cursor instead")
+)
+
+var searchIndices = map[string]string{
+	elastic.DocumentTypeUser:     elastic.UsersIndexName,
+	elastic.DocumentTypeAlbum:    elastic.AlbumsIndexName,
+	elastic.DocumentTypeTrack:    elastic.TracksIndexName,
+	elastic.DocumentTypePlaylist: elastic.PlaylistsIndexName,
+}
+
+type searchFunc func(ctx context.Context, query string, page elastic.SearchPage) (*elastic.SearchResult, error)
+
+type SearchBackend interface {
+	SearchUsers(ctx context.Context, query string, page elastic.SearchPage) (*elastic.SearchResult, error)
+	SearchAlbums(ctx context.Context, query string, page elastic.SearchPage) (*elastic.SearchResult, error)
+	SearchTracks(ctx context.Context, query string, page elastic.SearchPage, filters *elastic.TrackFilters) (*elastic.SearchResult, error)
+	SearchPlaylists(ctx context.Context, query string, page elastic.SearchPage) (*elastic.SearchResult, error)
+	Suggest(ctx context.Context, query string, limit int, timeout time.Duration) ([]*elastic.Suggestion, error)
+	CorrectQuery(ctx context.Context, index, query string) (string, error)
+	AddUser(ctx context.Context, id int64, username string) error
+	AddAlbum(ctx context.Context, id int64, title string) error
+	AddTrack(ctx context.Context, id int64, title string, attributes *elastic.TrackAttributes) error
+	AddPlaylist(ctx context.Context, id int64, title string) error
+	UpdateUser(ctx context.Context, id int64, username string) error
+	UpdateAlbum(ctx context.Context, id int64, title string) error
+	UpdateTrack(ctx context.Context, id int64, title string, attributes *elastic.TrackAttributes) error
+	UpdatePlaylist(ctx context.Context, id int64, title string) error
+	DeleteUser(ctx context.Context, id int64) error
+	DeleteAlbum(ctx context.Context, id int64) error
+	DeleteTrack(ctx context.Context, id int64) error
+	DeletePlaylist(ctx context.Context, id int64) error
+	UpdateSignals(ctx context.Context, documentType string, signals []*elastic.DocumentSignals) error
+	UpsertDocument(ctx context.Context, documentType string, id int64, name string, attributes *elastic.TrackAttributes, version int64) error
+	DeleteDocument(ctx context.Context, documentType string, id int64, version int64) error
+	PurgeTombstones(ctx context.Context, before time.Time) error
+	LogSearchQuery(ctx context.Context, query *elastic.SearchQuery) error
+	RecordClick(ctx context.Context, queryID string, userID, documentID int64, position int) error
+	TopQueries(ctx context.Context, r elastic.AnalyticsRange) ([]*elastic.QueryStat, error)
+	ZeroResultQueries(ctx context.Context, r elastic.AnalyticsRange) ([]*elastic.QueryStat, error)
+	ClickThroughRate(ctx context.Context, r elastic.AnalyticsRange) (*elastic.ClickThroughRate, error)
+}
+
 type SearchServiceInterface interface {
-	SearchUsers(ctx context.Context, query string) ([]int64, error)
-	SearchAlbums(ctx context.Context, query string) ([]int64, error)
-	SearchTracks(ctx context.Context, query string) ([]int64, error)
+	SearchUsers(ctx context.Context, query string, page elastic.SearchPage) (*elastic.SearchResult, error)
+	SearchAlbums(ctx context.Context, query string, page elastic.SearchPage) (*elastic.SearchResult, error)
+	SearchTracks(ctx context.Context, query string, page elastic.SearchPage, filters *elastic.TrackFilters) (*elastic.SearchResult, error)
+	SearchPlaylists(ctx context.Context, query string, page elastic.SearchPage) (*elastic.SearchResult, error)
+	Suggest(ctx context.Context, query string, limit int) ([]*elastic.Suggestion, error)
+	CorrectQuery(ctx context.Context, query string, searchTypes []string, hits int64) (string, error)
 	AddUser(ctx context.Context, id int64, username string) error
 	AddAlbum(ctx context.Context, id int64, title string) error
-	AddTrack(ctx context.Context, id int64, title string) error
+	AddTrack(ctx context.Context, id int64, title string, attributes *elastic.TrackAttributes) error
+	AddPlaylist(ctx context.Context, id int64, title string) error
 	UpdateUser(ctx context.Context, id int64, username string) error
 	UpdateAlbum(ctx context.Context, id int64, title string) error
-	UpdateTrack(ctx context.Context, id int64, title string) error
+	UpdateTrack(ctx context.Context, id int64, title string, attributes *elastic.TrackAttributes) error
+	UpdatePlaylist(ctx context.Context, id int64, title string) error
 	DeleteUser(ctx context.Context, id int64) error
 	DeleteAlbum(ctx context.Context, id int64) error
 	DeleteTrack(ctx context.Context, id int64) error
+	DeletePlaylist(ctx context.Context, id int64) error
+	UpdateSignals(ctx context.Context, documentType string, signals []*elastic.DocumentSignals) error
+	UpsertDocument(ctx context.Context, documentType string, id int64, name string, attributes *elastic.TrackAttributes, version int64) error
+	DeleteDocument(ctx context.Context, documentType string, id int64, version int64) error
+	PurgeTombstones(ctx context.Context) error
+	RecordClick(ctx context.Context, queryID string, documentID int64, position int) error
+	TopQueries(ctx context.Context, r elastic.AnalyticsRange) ([]*elastic.QueryStat, error)
+	ZeroResultQueries(ctx context.Context, r elastic.AnalyticsRange) ([]*elastic.QueryStat, error)
+	ClickThroughRate(ctx context.Context, r elastic.AnalyticsRange) (*elastic.ClickThroughRate, error)
 }
 
 type SearchService struct {
 	cfg     *config.Config
 	log     *slog.Logger
-	elastic *elastic.ElasticClient
+	backend SearchBackend
 }
 
-func NewSearchService(cfg *config.Config, log *slog.Logger, elastic *elastic.ElasticClient) *SearchService {
+func NewSearchService(cfg *config.Config, log *slog.Logger, backend SearchBackend) *SearchService {
 	return &SearchService{
 		cfg:     cfg,
 		log:     log,
-		elastic: elastic,
+		backend: backend,
 	}
 }
 
-func (s *SearchService) SearchUsers(ctx context.Context, query string) ([]int64, error) {
-	s.log.Info("Searching users", slog.String("query", query))
-	ids, err := s.elastic.SearchUsers(ctx, query)
-	if err != nil {
-		return nil, utils.InternalError(err, "failed to search users")
+func (s *SearchService) SearchUsers(ctx context.Context, query string, page elastic.SearchPage) (*elastic.SearchResult, error) {
+	s.log.Info("Searching users", slog.String("query", query), slog.Int("limit", page.Limit), slog.Int("offset", page.Offset))
+	return s.search(ctx, elastic.DocumentTypeUser, elastic.UsersIndexName, query, page, "failed to search users", s.backend.SearchUsers)
+}
+
+func (s *SearchService) SearchAlbums(ctx context.Context, query string, page elastic.SearchPage) (*elastic.SearchResult, error) {
+	s.log.Info("Searching albums", slog.String("query", query), slog.Int("limit", page.Limit), slog.Int("offset", page.Offset))
+	return s.search(ctx, elastic.DocumentTypeAlbum, elastic.AlbumsIndexName, query, page, "failed to search albums", s.backend.SearchAlbums)
+}
+
+func (s *SearchService) SearchTracks(ctx context.Context, query string, page elastic.SearchPage, filters *elastic.TrackFilters) (*elastic.SearchResult, error) {
+	s.log.Info("Searching tracks", slog.String("query", query), slog.Int("limit", page.Limit), slog.Int("offset", page.Offset))
+	if filters != nil && filters.MaxDuration > 0 && filters.MinDuration > filters.MaxDuration {
+		return nil, utils.InvalidArgumentError(ErrInvalidDurationRange)
 	}
-	return ids, nil
+	if filters != nil && !filters.UploadedAfter.IsZero() && !filters.UploadedBefore.IsZero() && filters.UploadedAfter.After(filters.UploadedBefore) {
+		return nil, utils.InvalidArgumentError(ErrInvalidUploadRange)
+	}
+
+	return s.search(ctx, elastic.DocumentTypeTrack, elastic.TracksIndexName, query, page, "failed to search tracks",
+		func(ctx context.Context, query string, page elastic.SearchPage) (*elastic.SearchResult, error) {
+			return s.backend.SearchTracks(ctx, query, page, filters)
+		},
+	)
 }
 
-func (s *SearchService) SearchAlbums(ctx context.Context, query string) ([]int64, error) {
-	s.log.Info("Searching albums", slog.String("query", query))
-	ids, err := s.elastic.SearchAlbums(ctx, query)
+func (s *SearchService) SearchPlaylists(ctx context.Context, query string, page elastic.SearchPage) (*elastic.SearchResult, error) {
+	s.log.Info("Searching playlists", slog.String("query", query), slog.Int("limit", page.Limit), slog.Int("offset", page.Offset))
+	return s.search(ctx, elastic.DocumentTypePlaylist, elastic.PlaylistsIndexName, query, page, "failed to search playlists", s.backend.SearchPlaylists)
+}
+
+func (s *SearchService) Suggest(ctx context.Context, query string, limit int) ([]*elastic.Suggestion, error) {
+	s.log.Info("Suggesting", slog.String("query", query), slog.Int("limit", limit))
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return []*elastic.Suggestion{}, nil
+	}
+
+	if limit <= 0 {
+		limit = DefaultSuggestLimit
+	}
+	limit = min(limit, MaxSuggestLimit)
+
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.SuggestTimeout)
+	defer cancel()
+
+	suggestions, err := s.backend.Suggest(ctx, query, limit, s.cfg.SuggestTimeout)
 	if err != nil {
-		return nil, utils.InternalError(err, "failed to search albums")
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			s.log.Warn("Suggest exceeded latency budget", slog.String("query", query), slog.Duration("budget", s.cfg.SuggestTimeout))
+			return []*elastic.Suggestion{}, nil
+		}
+		return nil, utils.InternalError(err, "failed to suggest")
 	}
-	return ids, nil
+
+	// Backends return up to limit suggestions per index, so the lists are
+	// merged by score before cutting them down to the requested size.
+	slices.SortStableFunc(suggestions, func(a, b *elastic.Suggestion) int {
+		return cmp.Compare(b.Score, a.Score)
+	})
+	return suggestions[:min(limit, len(suggestions))], nil
 }
 
-func (s *SearchService) SearchTracks(ctx context.Context, query string) ([]int64, error) {
-	s.log.Info("Searching tracks", slog.String("query", query))
-	ids, err := s.elastic.SearchTracks(ctx, query)
-	if err != nil {
-		return nil, utils.InternalError(err, "failed to search tracks")
+// CorrectQuery corrects a query once for a search over several types, so a
+// caller searching all of them does not pay for a correction and a re-search
+// per type. hits is how many documents the query found across the types.
+func (s *SearchService) CorrectQuery(ctx context.Context, query string, searchTypes []string, hits int64) (string, error) {
+	s.log.Info("Correcting query", slog.String("query", query), slog.Any("types", searchTypes), slog.Int64("hits", hits))
+	indices := make([]string, 0, len(searchTypes))
+	for _, searchType := range searchTypes {
+		index, ok := searchIndices[searchType]
+		if !ok {
+			return "", utils.InvalidArgumentError(ErrUnknownSearchType)
+		}
+		indices = append(indices, index)
+	}
+	if !s.cfg.Spelling.Enabled || normalizeQuery(query) == "" || hits > s.cfg.Spelling.MaxHits {
+		return "", nil
 	}
-	return ids, nil
+
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Spelling.Timeout)
+	defer cancel()
+
+	suggestions := make([]string, len(indices))
+	var wg sync.WaitGroup
+	for i, index := range indices {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			suggestion, err := s.backend.CorrectQuery(ctx, index, query)
+			if err != nil {
+				s.log.Warn("Failed to correct query", slog.String("query", query), slog.String("index", index), utils.ErrLog(err))
+				return
+			}
+			suggestions[i] = suggestion
+		}()
+	}
+	wg.Wait()
+
+	// When the indices disagree, the type the caller listed first wins.
+	for _, suggestion := range suggestions {
+		if suggestion != "" && normalizeQuery(suggestion) != normalizeQuery(query) {
+			return suggestion, nil
+		}
+	}
+	return "", nil
 }
 
 func (s *SearchService) AddUser(ctx context.Context, id int64, username string) error {
 	s.log.Info("Adding user", slog.Int64("id", id), slog.String("username", username))
-	err := s.elastic.AddUser(ctx, id, username)
+	err := s.backend.AddUser(ctx, id, username)
 	if err != nil {
 		if errors.Is(err, elastic.ErrUserAlreadyExists) {
 			return utils.AlreadyExistsError(err)
@@ -80,7 +240,7 @@ func (s *SearchService) AddUser(ctx context.Context, id int64, username string)
 
 func (s *SearchService) AddAlbum(ctx context.Context, id int64, title string) error {
 	s.log.Info("Adding album", slog.Int64("id", id), slog.String("title", title))
-	err := s.elastic.AddAlbum(ctx, id, title)
+	err := s.backend.AddAlbum(ctx, id, title)
 	if err != nil {
 		if errors.Is(err, elastic.ErrAlbumAlreadyExists) {
 			return utils.AlreadyExistsError(err)
@@ -90,9 +250,9 @@ func (s *SearchService) AddAlbum(ctx context.Context, id int64, title string) er
 	return nil
 }
 
-func (s *SearchService) AddTrack(ctx context.Context, id int64, title string) error {
+func (s *SearchService) AddTrack(ctx context.Context, id int64, title string, attributes *elastic.TrackAttributes) error {
 	s.log.Info("Adding track", slog.Int64("id", id), slog.String("title", title))
-	err := s.elastic.AddTrack(ctx, id, title)
+	err := s.backend.AddTrack(ctx, id, title, attributes)
 	if err != nil {
 		if errors.Is(err, elastic.ErrTrackAlreadyExists) {
 			return utils.AlreadyExistsError(err)
@@ -102,9 +262,21 @@ func (s *SearchService) AddTrack(ctx context.Context, id int64, title string) er
 	return nil
 }
 
+func (s *SearchService) AddPlaylist(ctx context.Context, id int64, title string) error {
+	s.log.Info("Adding playlist", slog.Int64("id", id), slog.String("title", title))
+	err := s.backend.AddPlaylist(ctx, id, title)
+	if err != nil {
+		if errors.Is(err, elastic.ErrPlaylistAlreadyExists) {
+			return utils.AlreadyExistsError(err)
+		}
+		return utils.InternalError(err, "failed to add playlist")
+	}
+	return nil
+}
+
 func (s *SearchService) UpdateUser(ctx context.Context, id int64, username string) error {
 	s.log.Info("Updating user", slog.Int64("id", id), slog.String("username", username))
-	err := s.elastic.UpdateUser(ctx, id, username)
+	err := s.backend.UpdateUser(ctx, id, username)
 	if err != nil {
 		return utils.InternalError(err, "failed to update user")
 	}
@@ -113,25 +285,34 @@ func (s *SearchService) UpdateUser(ctx context.Context, id int64, username strin
 
 func (s *SearchService) UpdateAlbum(ctx context.Context, id int64, title string) error {
 	s.log.Info("Updating album", slog.Int64("id", id), slog.String("title", title))
-	err := s.elastic.UpdateAlbum(ctx, id, title)
+	err := s.backend.UpdateAlbum(ctx, id, title)
 	if err != nil {
 		return utils.InternalError(err, "failed to update album")
 	}
 	return nil
 }
 
-func (s *SearchService) UpdateTrack(ctx context.Context, id int64, title string) error {
+func (s *SearchService) UpdateTrack(ctx context.Context, id int64, title string, attributes *elastic.TrackAttributes) error {
 	s.log.Info("Updating track", slog.Int64("id", id), slog.String("title", title))
-	err := s.elastic.UpdateTrack(ctx, id, title)
+	err := s.backend.UpdateTrack(ctx, id, title, attributes)
 	if err != nil {
 		return utils.InternalError(err, "failed to update track")
 	}
 	return nil
 }
 
+func (s *SearchService) UpdatePlaylist(ctx context.Context, id int64, title string) error {
+	s.log.Info("Updating playlist", slog.Int64("id", id), slog.String("title", title))
+	err := s.backend.UpdatePlaylist(ctx, id, title)
+	if err != nil {
+		return utils.InternalError(err, "failed to update playlist")
+	}
+	return nil
+}
+
 func (s *SearchService) DeleteUser(ctx context.Context, id int64) error {
 	s.log.Info("Deleting user", slog.Int64("id", id))
-	err := s.elastic.DeleteUser(ctx, id)
+	err := s.backend.DeleteUser(ctx, id)
 	if err != nil {
 		if errors.Is(err, elastic.ErrUserNotFound) {
 			return utils.NotFoundError(err)
@@ -143,7 +324,7 @@ func (s *SearchService) DeleteUser(ctx context.Context, id int64) error {
 
 func (s *SearchService) DeleteAlbum(ctx context.Context, id int64) error {
 	s.log.Info("Deleting album", slog.Int64("id", id))
-	err := s.elastic.DeleteAlbum(ctx, id)
+	err := s.backend.DeleteAlbum(ctx, id)
 	if err != nil {
 		if errors.Is(err, elastic.ErrAlbumNotFound) {
 			return utils.NotFoundError(err)
@@ -155,7 +336,7 @@ func (s *SearchService) DeleteAlbum(ctx context.Context, id int64) error {
 
 func (s *SearchService) DeleteTrack(ctx context.Context, id int64) error {
 	s.log.Info("Deleting track", slog.Int64("id", id))
-	err := s.elastic.DeleteTrack(ctx, id)
+	err := s.backend.DeleteTrack(ctx, id)
 	if err != nil {
 		if errors.Is(err, elastic.ErrTrackNotFound) {
 			return utils.NotFoundError(err)
@@ -164,3 +345,315 @@ func (s *SearchService) DeleteTrack(ctx context.Context, id int64) error {
 	}
 	return nil
 }
+
+func (s *SearchService) DeletePlaylist(ctx context.Context, id int64) error {
+	s.log.Info("Deleting playlist", slog.Int64("id", id))
+	err := s.backend.DeletePlaylist(ctx, id)
+	if err != nil {
+		if errors.Is(err, elastic.ErrPlaylistNotFound) {
+			return utils.NotFoundError(err)
+		}
+		return utils.InternalError(err, "failed to delete playlist")
+	}
+	return nil
+}
+
+func (s *SearchService) UpdateSignals(ctx context.Context, documentType string, signals []*elastic.DocumentSignals) error {
+	s.log.Info("Updating signals", slog.String("type", documentType), slog.Int("count", len(signals)))
+	err := s.backend.UpdateSignals(ctx, documentType, signals)
+	if err != nil {
+		if errors.Is(err, elastic.ErrUnknownDocumentType) {
+			return utils.InvalidArgumentError(err)
+		}
+		return utils.InternalError(err, "failed to update signals")
+	}
+	return nil
+}
+
+func (s *SearchService) UpsertDocument(ctx context.Context, documentType string, id int64, name string, attributes *elastic.TrackAttributes, version int64) error {
+	s.log.Info("Upserting document", slog.String("type", documentType), slog.Int64("id", id), slog.Int64("version", version))
+	err := s.backend.UpsertDocument(ctx, documentType, id, name, attributes, version)
+	if err != nil {
+		if errors.Is(err, elastic.ErrUnknownDocumentType) {
+			return utils.InvalidArgumentError(err)
+		}
+		return utils.InternalError(err, "failed to upsert document")
+	}
+	return nil
+}
+
+func (s *SearchService) DeleteDocument(ctx context.Context, documentType string, id int64, version int64) error {
+	s.log.Info("Deleting document", slog.String("type", documentType), slog.Int64("id", id), slog.Int64("version", version))
+	err := s.backend.DeleteDocument(ctx, documentType, id, version)
+	if err != nil {
+		if errors.Is(err, elastic.ErrUnknownDocumentType) {
+			return utils.InvalidArgumentError(err)
+		}
+		return utils.InternalError(err, "failed to delete document")
+	}
+	return nil
+}
+
+func (s *SearchService) PurgeTombstones(ctx context.Context) error {
+	if err := s.backend.PurgeTombstones(ctx, time.Now().Add(-s.cfg.Tombstones.Retention)); err != nil {
+		return utils.InternalError(err, "failed to purge tombstones")
+	}
+	return nil
+}
+
+func (s *SearchService) RecordClick(ctx context.Context, queryID string, documentID int64, position int) error {
+	s.log.Info("Recording click", slog.String("query_id", queryID), slog.Int64("document_id", documentID), slog.Int("position", position))
+	if err := uuid.Validate(queryID); err != nil {
+		return utils.InvalidArgumentError(ErrInvalidQueryID)
+	}
+	if position < 0 {
+		return utils.InvalidArgumentError(ErrInvalidClickPosition)
+	}
+
+	err := s.backend.RecordClick(ctx, queryID, utils.GetUserIDFromContext(ctx), documentID, position)
+	if err != nil {
+		if errors.Is(err, elastic.ErrSearchQueryNotFound) {
+			return utils.NotFoundError(err)
+		}
+		if errors.Is(err, elastic.ErrClickNotAllowed) {
+			return utils.PermissionDeniedError(err)
+		}
+		return utils.InternalError(err, "failed to record click")
+	}
+	return nil
+}
+
+func (s *SearchService) TopQueries(ctx context.Context, r elastic.AnalyticsRange) ([]*elastic.QueryStat, error) {
+	s.log.Info("Getting top queries", slog.Time("from", r.From), slog.Time("to", r.To), slog.String("type", r.Type))
+	r, err := normalizeRange(r)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.backend.TopQueries(ctx, r)
+	if err != nil {
+		return nil, utils.InternalError(err, "failed to get top queries")
+	}
+	return stats, nil
+}
+
+func (s *SearchService) ZeroResultQueries(ctx context.Context, r elastic.AnalyticsRange) ([]*elastic.QueryStat, error) {
+	s.log.Info("Getting zero-result queries", slog.Time("from", r.From), slog.Time("to", r.To), slog.String("type", r.Type))
+	r, err := normalizeRange(r)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.backend.ZeroResultQueries(ctx, r)
+	if err != nil {
+		return nil, utils.InternalError(err, "failed to get zero-result queries")
+	}
+	return stats, nil
+}
+
+func (s *SearchService) ClickThroughRate(ctx context.Context, r elastic.AnalyticsRange) (*elastic.ClickThroughRate, error) {
+	s.log.Info("Getting click-through rate", slog.Time("from", r.From), slog.Time("to", r.To), slog.String("type", r.Type))
+	r, err := normalizeRange(r)
+	if err != nil {
+		return nil, err
+	}
+
+	rate, err := s.backend.ClickThroughRate(ctx, r)
+	if err != nil {
+		return nil, utils.InternalError(err, "failed to get click-through rate")
+	}
+	return rate, nil
+}
+
+func (s *SearchService) search(ctx context.Context, searchType, index, query string, page elastic.SearchPage, msg string, run searchFunc) (*elastic.SearchResult, error) {
+	start := time.Now()
+	page = normalizePage(page)
+	if page.Cursor == "" && page.Offset+page.Limit+1 > elastic.MaxResultWindow {
+		return nil, utils.InvalidArgumentError(ErrOffsetTooLarge)
+	}
+	if page.Cursor != "" {
+		return s.searchPage(ctx, query, page, msg, run)
+	}
+	if page.Correction != "" {
+		return s.searchCorrection(ctx, query, page, msg, run)
+	}
+
+	result, err := run(ctx, query, page)
+	if err != nil {
+		return nil, searchError(err, msg)
+	}
+	result = s.correct(ctx, index, query, page, result, run)
+	s.logSearch(ctx, searchType, query, page, result, time.Since(start))
+	return result, nil
+}
+
+// searchPage continues a search from a cursor. The cursor is only accepted
+// for the query it was issued for and keeps following the query its pages
+// were found for, which is the corrected one after an auto-correction.
+func (s *SearchService) searchPage(ctx context.Context, query string, page elastic.SearchPage, msg string, run searchFunc) (*elastic.SearchResult, error) {
+	cursor, err := elastic.DecodeCursor(page.Cursor)
+	if err != nil {
+		return nil, searchError(err, msg)
+	}
+	if normalizeQuery(cursor.Original) != normalizeQuery(query) {
+		return nil, utils.InvalidArgumentError(elastic.ErrInvalidCursor)
+	}
+
+	result, err := run(ctx, cursor.Query, page)
+	if err != nil {
+		return nil, searchError(err, msg)
+	}
+	if cursor.Query != cursor.Original {
+		result.SuggestedQuery = cursor.Query
+		result.Corrected = true
+	}
+	if err := bindCursor(result, cursor.Original); err != nil {
+		return nil, searchError(err, msg)
+	}
+	return result, nil
+}
+
+// searchCorrection searches a correction the caller already made for the
+// query, so a caller searching several types corrects it once for all of
+// them. The caller has logged the query, and the cursors stay bound to it.
+func (s *SearchService) searchCorrection(ctx context.Context, query string, page elastic.SearchPage, msg string, run searchFunc) (*elastic.SearchResult, error) {
+	result, err := run(ctx, page.Correction, page)
+	if err != nil {
+		return nil, searchError(err, msg)
+	}
+	result.SuggestedQuery = page.Correction
+	result.Corrected = true
+	if err := bindCursor(result, query); err != nil {
+		return nil, searchError(err, msg)
+	}
+	return result, nil
+}
+
+func (s *SearchService) correct(ctx context.Context, index, query string, page elastic.SearchPage, result *elastic.SearchResult, run searchFunc) *elastic.SearchResult {
+	if !s.cfg.Spelling.Enabled || page.SkipCorrection || normalizeQuery(query) == "" || page.Offset > 0 || page.Cursor != "" || result.Total > s.cfg.Spelling.MaxHits {
+		return result
+	}
+
+	correctCtx, cancel := context.WithTimeout(ctx, s.cfg.Spelling.Timeout)
+	defer cancel()
+
+	suggestion, err := s.backend.CorrectQuery(correctCtx, index, query)
+	if err != nil {
+		s.log.Warn("Failed to correct query", slog.String("query", query), utils.ErrLog(err))
+		return result
+	}
+	if suggestion == "" || normalizeQuery(suggestion) == normalizeQuery(query) {
+		return result
+	}
+	result.SuggestedQuery = suggestion
+
+	if !page.AutoCorrect || result.Total > 0 {
+		return result
+	}
+
+	corrected, err := run(ctx, suggestion, page)
+	if err != nil {
+		s.log.Warn("Failed to search corrected query", slog.String("query", query), slog.String("suggestion", suggestion), utils.ErrLog(err))
+		return result
+	}
+	if corrected.Total == 0 {
+		return result
+	}
+	if err := bindCursor(corrected, query); err != nil {
+		s.log.Warn("Failed to bind corrected cursor", slog.String("query", query), utils.ErrLog(err))
+		return result
+	}
+	corrected.SuggestedQuery = suggestion
+	corrected.Corrected = true
+	return corrected
+}
+
+func (s *SearchService) logSearch(ctx context.Context, searchType, query string, page elastic.SearchPage, result *elastic.SearchResult, latency time.Duration) {
+	query = normalizeQuery(query)
+	if !s.cfg.Analytics.Enabled || query == "" || page.Offset > 0 || page.Cursor != "" {
+		return
+	}
+
+	results := result.Total
+	if result.Corrected {
+		results = 0
+	}
+
+	searchQuery := &elastic.SearchQuery{
+		QueryID:   uuid.NewString(),
+		Type:      searchType,
+		Query:     query,
+		UserID:    utils.GetUserIDFromContext(ctx),
+		Results:   results,
+		LatencyMs: latency.Milliseconds(),
+		Timestamp: time.Now(),
+	}
+
+	// The log is written before the result is returned, so a click on it can
+	// never arrive first. Without a log there is nothing to click.
+	logCtx, cancel := context.WithTimeout(ctx, s.cfg.Analytics.LogTimeout)
+	defer cancel()
+
+	if err := s.backend.LogSearchQuery(logCtx, searchQuery); err != nil {
+		s.log.Warn("Failed to log search query", slog.String("query_id", searchQuery.QueryID), utils.ErrLog(err))
+		return
+	}
+	result.QueryID = searchQuery.QueryID
+}
+
+func normalizeQuery(query string) string {
+	return strings.ToLower(strings.Join(strings.Fields(query), " "))
+}
+
+func normalizeRange(r elastic.AnalyticsRange) (elastic.AnalyticsRange, error) {
+	if _, ok := searchIndices[r.Type]; r.Type != "" && !ok {
+		return r, utils.InvalidArgumentError(ErrUnknownSearchType)
+	}
+	if r.To.IsZero() {
+		r.To = time.Now()
+	}
+	if r.From.IsZero() {
+		r.From = r.To.Add(-DefaultReportPeriod)
+	}
+	if r.From.After(r.To) {
+		return r, utils.InvalidArgumentError(ErrInvalidReportRange)
+	}
+	if r.Limit <= 0 {
+		r.Limit = DefaultReportLimit
+	}
+	r.Limit = min(r.Limit, MaxReportLimit)
+	return r, nil
+}
+
+func normalizePage(page elastic.SearchPage) elastic.SearchPage {
+	if page.Limit <= 0 {
+		page.Limit = DefaultSearchLimit
+	}
+	page.Limit = min(page.Limit, MaxSearchLimit)
+	page.Offset = max(page.Offset, 0)
+	return page
+}
+
+// bindCursor binds the next cursor of a result to the query the user searched
+// for, which is not the one the result was found for after a correction.
+func bindCursor(result *elastic.SearchResult, original string) error {
+	if result.NextCursor == "" {
+		return nil
+	}
+
+	cursor, err := elastic.DecodeCursor(result.NextCursor)
+	if err != nil {
+		return err
+	}
+	cursor.Original = original
+
+	result.NextCursor, err = elastic.EncodeCursor(cursor)
+	return err
+}
+
+func searchError(err error, msg string) error {
+	if errors.Is(err, elastic.ErrInvalidCursor) {
+		return utils.InvalidArgumentError(err)
+	}
+	return utils.InternalError(err, msg)
+}
@@ -21,4 +21,12 @@ var (
 	NotFoundError = func(err error) error {
 		return status.Errorf(codes.NotFound, "%s", err.Error())
 	}
+
+	InvalidArgumentError = func(err error) error {
+		return status.Errorf(codes.InvalidArgument, "%s", err.Error())
+	}
+
+	PermissionDeniedError = func(err error) error {
+		return status.Errorf(codes.PermissionDenied, "%s", err.Error())
+	}
 )
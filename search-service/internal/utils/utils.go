@@ -1,10 +1,13 @@
 package utils
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 )
 
+type UserIDKey struct{}
+
 func ErrLog(err error) slog.Attr {
 	return slog.Attr{
 		Key:   "error",
@@ -12,6 +15,11 @@ func ErrLog(err error) slog.Attr {
 	}
 }
 
+func GetUserIDFromContext(ctx context.Context) int64 {
+	userID, _ := ctx.Value(UserIDKey{}).(int64)
+	return userID
+}
+
 func GetElasticUrl(ElasticHost, ElasticPort, ElasticUser, ElasticPassword string) string {
 	return fmt.Sprintf(
 		"http://%s:%s@%s:%s",
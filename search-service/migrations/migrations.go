@@ -0,0 +1,9 @@
+package migrations
+
+import "embed"
+
+// FS holds the analysis settings shared by every index and, per alias, the
+// mapping changes each migration adds on top of the previous ones.
+//
+//go:embed analysis.json */*.json
+var FS embed.FS
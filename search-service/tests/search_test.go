@@ -1,7 +1,9 @@
 package tests
 
 import (
+	"encoding/base64"
 	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,6 +12,8 @@ import (
 	"github.com/ocenb/music-protos/gen/searchservice"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestSearchUsers(t *testing.T) {
@@ -203,3 +207,695 @@ func TestSearchTracks(t *testing.T) {
 	found = slices.Contains(searchAfterDeleteResp.Ids, trackId)
 	assert.False(t, found, "Track should not be found after deletion")
 }
+
+func TestSearchPlaylists(t *testing.T) {
+	ctx, s := suite.New(t)
+
+	playlistTitle := gofakeit.Word()
+	updatedPlaylistTitle := gofakeit.Word()
+	playlistId := gofakeit.Int64()
+
+	addResp, err := s.SearchClient.AddPlaylist(ctx, &searchservice.AddOrUpdateRequest{
+		Id:   playlistId,
+		Name: playlistTitle,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, addResp)
+	assert.True(t, addResp.Success)
+
+	time.Sleep(1 * time.Second)
+
+	searchResp, err := s.SearchClient.SearchPlaylists(ctx, &searchservice.SearchRequest{
+		Query: playlistTitle,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, searchResp)
+
+	found := slices.Contains(searchResp.Ids, playlistId)
+	assert.True(t, found, "Playlist should be found in search results")
+
+	updateResp, err := s.SearchClient.UpdatePlaylist(ctx, &searchservice.AddOrUpdateRequest{
+		Id:   playlistId,
+		Name: updatedPlaylistTitle,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, updateResp)
+	assert.True(t, updateResp.Success)
+
+	time.Sleep(1 * time.Second)
+
+	searchUpdatedResp, err := s.SearchClient.SearchPlaylists(ctx, &searchservice.SearchRequest{
+		Query: updatedPlaylistTitle,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, searchUpdatedResp)
+
+	found = slices.Contains(searchUpdatedResp.Ids, playlistId)
+	assert.True(t, found, "Updated playlist should be found in search results")
+
+	deleteResp, err := s.SearchClient.DeletePlaylist(ctx, &searchservice.DeleteRequest{
+		Id: playlistId,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, deleteResp)
+	assert.True(t, deleteResp.Success)
+
+	time.Sleep(1 * time.Second)
+
+	searchAfterDeleteResp, err := s.SearchClient.SearchPlaylists(ctx, &searchservice.SearchRequest{
+		Query: updatedPlaylistTitle,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, searchAfterDeleteResp)
+
+	found = slices.Contains(searchAfterDeleteResp.Ids, playlistId)
+	assert.False(t, found, "Playlist should not be found after deletion")
+}
+
+func TestSearchTracksPagination(t *testing.T) {
+	ctx, s := suite.New(t)
+
+	word := gofakeit.LetterN(12)
+	trackIds := []int64{gofakeit.Int64(), gofakeit.Int64(), gofakeit.Int64()}
+
+	for _, trackId := range trackIds {
+		addResp, err := s.SearchClient.AddTrack(ctx, &searchservice.AddOrUpdateRequest{
+			Id:   trackId,
+			Name: word,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, addResp)
+		assert.True(t, addResp.Success)
+	}
+
+	time.Sleep(1 * time.Second)
+
+	firstPageResp, err := s.SearchClient.SearchTracks(ctx, &searchservice.SearchRequest{
+		Query: word,
+		Limit: 2,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, firstPageResp)
+	assert.Len(t, firstPageResp.Ids, 2)
+	assert.Equal(t, int64(3), firstPageResp.Total)
+
+	secondPageResp, err := s.SearchClient.SearchTracks(ctx, &searchservice.SearchRequest{
+		Query:  word,
+		Limit:  2,
+		Offset: 2,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, secondPageResp)
+	assert.Len(t, secondPageResp.Ids, 1)
+	assert.Equal(t, int64(3), secondPageResp.Total)
+
+	for _, trackId := range trackIds {
+		deleteResp, err := s.SearchClient.DeleteTrack(ctx, &searchservice.DeleteRequest{
+			Id: trackId,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, deleteResp)
+		assert.True(t, deleteResp.Success)
+	}
+}
+
+func TestSearchCursorPaginationAndHighlights(t *testing.T) {
+	ctx, s := suite.New(t)
+
+	word := gofakeit.LetterN(12)
+	albumIds := []int64{gofakeit.Int64(), gofakeit.Int64(), gofakeit.Int64()}
+
+	for _, albumId := range albumIds {
+		addResp, err := s.SearchClient.AddAlbum(ctx, &searchservice.AddOrUpdateRequest{
+			Id:   albumId,
+			Name: word,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, addResp)
+		assert.True(t, addResp.Success)
+	}
+
+	time.Sleep(1 * time.Second)
+
+	var seen []int64
+	cursor := ""
+	for page := 0; page < len(albumIds); page++ {
+		searchResp, err := s.SearchClient.SearchAlbums(ctx, &searchservice.SearchRequest{
+			Query:     word,
+			Limit:     2,
+			Cursor:    cursor,
+			Highlight: true,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, searchResp)
+		assert.Equal(t, int64(3), searchResp.Total)
+		require.Len(t, searchResp.Hits, len(searchResp.Ids))
+
+		for i, hit := range searchResp.Hits {
+			assert.Equal(t, searchResp.Ids[i], hit.Id)
+			assert.Positive(t, hit.Score)
+			require.NotEmpty(t, hit.Highlights)
+			assert.Contains(t, hit.Highlights[0], "<em>")
+		}
+
+		seen = append(seen, searchResp.Ids...)
+		cursor = searchResp.NextCursor
+		if cursor == "" {
+			break
+		}
+	}
+	assert.ElementsMatch(t, albumIds, seen)
+
+	for _, cursor := range []string{
+		"not-a-cursor",
+		base64.RawURLEncoding.EncodeToString([]byte(`[{"a":1},[]]`)),
+		base64.RawURLEncoding.EncodeToString([]byte(`{"score":"high","id":1,"origin":1}`)),
+		base64.RawURLEncoding.EncodeToString([]byte(`{"score":1,"id":1}`)),
+	} {
+		_, err := s.SearchClient.SearchAlbums(ctx, &searchservice.SearchRequest{
+			Query:  word,
+			Cursor: cursor,
+		})
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+
+	for _, albumId := range albumIds {
+		deleteResp, err := s.SearchClient.DeleteAlbum(ctx, &searchservice.DeleteRequest{
+			Id: albumId,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, deleteResp)
+		assert.True(t, deleteResp.Success)
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	ctx, s := suite.New(t)
+
+	trackTitle := gofakeit.LetterN(8) + " " + gofakeit.LetterN(8)
+	trackId := gofakeit.Int64()
+
+	addResp, err := s.SearchClient.AddTrack(ctx, &searchservice.AddOrUpdateRequest{
+		Id:   trackId,
+		Name: trackTitle,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, addResp)
+	assert.True(t, addResp.Success)
+
+	time.Sleep(1 * time.Second)
+
+	words := strings.Fields(trackTitle)
+	partialQuery := words[0][:3] + " " + words[1][:2]
+
+	suggestResp, err := s.SearchClient.Suggest(ctx, &searchservice.SuggestRequest{
+		Query: partialQuery,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, suggestResp)
+
+	found := slices.ContainsFunc(suggestResp.Suggestions, func(suggestion *searchservice.Suggestion) bool {
+		return suggestion.Type == "track" && suggestion.Id == trackId && suggestion.Text == trackTitle
+	})
+	assert.True(t, found, "Track should be suggested for partial input")
+
+	emptyResp, err := s.SearchClient.Suggest(ctx, &searchservice.SuggestRequest{
+		Query: " ",
+	})
+	require.NoError(t, err)
+	require.NotNil(t, emptyResp)
+	assert.Empty(t, emptyResp.Suggestions)
+
+	deleteResp, err := s.SearchClient.DeleteTrack(ctx, &searchservice.DeleteRequest{
+		Id: trackId,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, deleteResp)
+	assert.True(t, deleteResp.Success)
+}
+
+func TestSearchTransliterationAndTypos(t *testing.T) {
+	ctx, s := suite.New(t)
+
+	translit := map[rune]string{
+		'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'з': "z", 'и': "i", 'к': "k", 'л': "l",
+		'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f",
+	}
+	letters := make([]rune, 0, len(translit))
+	for letter := range translit {
+		letters = append(letters, letter)
+	}
+
+	var cyrillicTitle, latinTitle strings.Builder
+	for range 12 {
+		letter := letters[gofakeit.Number(0, len(letters)-1)]
+		cyrillicTitle.WriteRune(letter)
+		latinTitle.WriteString(translit[letter])
+	}
+
+	trackId := gofakeit.Int64()
+
+	addResp, err := s.SearchClient.AddTrack(ctx, &searchservice.AddOrUpdateRequest{
+		Id:   trackId,
+		Name: cyrillicTitle.String(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, addResp)
+	assert.True(t, addResp.Success)
+
+	time.Sleep(1 * time.Second)
+
+	latinResp, err := s.SearchClient.SearchTracks(ctx, &searchservice.SearchRequest{
+		Query: latinTitle.String(),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, latinResp)
+	assert.True(t, slices.Contains(latinResp.Ids, trackId), "Cyrillic track should be found by Latin transliteration")
+
+	typo := []rune(cyrillicTitle.String())
+	typo[len(typo)/2], typo[len(typo)/2+1] = typo[len(typo)/2+1], typo[len(typo)/2]
+
+	typoResp, err := s.SearchClient.SearchTracks(ctx, &searchservice.SearchRequest{
+		Query: string(typo),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, typoResp)
+	assert.True(t, slices.Contains(typoResp.Ids, trackId), "Track should be found despite a typo")
+
+	deleteResp, err := s.SearchClient.DeleteTrack(ctx, &searchservice.DeleteRequest{
+		Id: trackId,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, deleteResp)
+	assert.True(t, deleteResp.Success)
+}
+
+func TestSearchDidYouMean(t *testing.T) {
+	ctx, s := suite.New(t)
+
+	word := strings.ToLower(gofakeit.LetterN(5))
+	playlistId := gofakeit.Int64()
+	secondPlaylistId := gofakeit.Int64()
+
+	typo := []rune(word)
+	for _, i := range []int{2, 4} {
+		typo[i] = 'a' + (typo[i]-'a'+1)%26
+	}
+
+	addResp, err := s.SearchClient.AddPlaylist(ctx, &searchservice.AddOrUpdateRequest{
+		Id:   playlistId,
+		Name: word,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, addResp)
+	assert.True(t, addResp.Success)
+
+	addResp, err = s.SearchClient.AddPlaylist(ctx, &searchservice.AddOrUpdateRequest{
+		Id:   secondPlaylistId,
+		Name: word,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, addResp)
+	assert.True(t, addResp.Success)
+
+	time.Sleep(1 * time.Second)
+
+	suggestResp, err := s.SearchClient.SearchPlaylists(ctx, &searchservice.SearchRequest{
+		Query: string(typo),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, suggestResp)
+	assert.Empty(t, suggestResp.Ids)
+	assert.Equal(t, word, suggestResp.SuggestedQuery)
+	assert.False(t, suggestResp.Corrected)
+
+	correctedResp, err := s.SearchClient.SearchPlaylists(ctx, &searchservice.SearchRequest{
+		Query:       string(typo),
+		AutoCorrect: true,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, correctedResp)
+	assert.True(t, slices.Contains(correctedResp.Ids, playlistId), "Playlist should be found by the corrected query")
+	assert.Equal(t, word, correctedResp.SuggestedQuery)
+	assert.True(t, correctedResp.Corrected)
+
+	firstPage, err := s.SearchClient.SearchPlaylists(ctx, &searchservice.SearchRequest{
+		Query:       string(typo),
+		Limit:       1,
+		AutoCorrect: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, firstPage.Ids, 1)
+	require.NotEmpty(t, firstPage.NextCursor)
+
+	secondPage, err := s.SearchClient.SearchPlaylists(ctx, &searchservice.SearchRequest{
+		Query:       string(typo),
+		Limit:       1,
+		Cursor:      firstPage.NextCursor,
+		AutoCorrect: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, secondPage.Ids, 1, "The next page should follow the corrected query")
+	assert.ElementsMatch(t, []int64{playlistId, secondPlaylistId}, append(firstPage.Ids, secondPage.Ids...))
+	assert.Equal(t, word, secondPage.SuggestedQuery)
+	assert.True(t, secondPage.Corrected)
+
+	_, err = s.SearchClient.SearchPlaylists(ctx, &searchservice.SearchRequest{
+		Query:  gofakeit.LetterN(8),
+		Limit:  1,
+		Cursor: firstPage.NextCursor,
+	})
+	require.Error(t, err, "A cursor should only continue the search it was issued for")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	correctionPage, err := s.SearchClient.SearchPlaylists(ctx, &searchservice.SearchRequest{
+		Query:      string(typo),
+		Limit:      1,
+		Correction: word,
+	})
+	require.NoError(t, err)
+	require.Len(t, correctionPage.Ids, 1)
+	require.NotEmpty(t, correctionPage.NextCursor)
+	assert.Equal(t, word, correctionPage.SuggestedQuery)
+	assert.True(t, correctionPage.Corrected)
+
+	correctionNextPage, err := s.SearchClient.SearchPlaylists(ctx, &searchservice.SearchRequest{
+		Query:  string(typo),
+		Limit:  1,
+		Cursor: correctionPage.NextCursor,
+	})
+	require.NoError(t, err)
+	require.Len(t, correctionNextPage.Ids, 1, "The cursor of a caller's correction should stay bound to the query")
+	assert.True(t, correctionNextPage.Corrected)
+
+	exactResp, err := s.SearchClient.SearchPlaylists(ctx, &searchservice.SearchRequest{
+		Query:       word,
+		AutoCorrect: true,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, exactResp)
+	assert.True(t, slices.Contains(exactResp.Ids, playlistId))
+	assert.Empty(t, exactResp.SuggestedQuery)
+	assert.False(t, exactResp.Corrected)
+
+	skippedResp, err := s.SearchClient.SearchPlaylists(ctx, &searchservice.SearchRequest{
+		Query:          string(typo),
+		AutoCorrect:    true,
+		SkipCorrection: true,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, skippedResp)
+	assert.Empty(t, skippedResp.Ids)
+	assert.Empty(t, skippedResp.SuggestedQuery)
+	assert.False(t, skippedResp.Corrected)
+
+	correctResp, err := s.SearchClient.CorrectQuery(ctx, &searchservice.CorrectQueryRequest{
+		Query: string(typo),
+		Types: []string{"user", "playlist"},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, correctResp)
+	assert.Equal(t, word, correctResp.SuggestedQuery)
+
+	correctResp, err = s.SearchClient.CorrectQuery(ctx, &searchservice.CorrectQueryRequest{
+		Query: string(typo),
+		Types: []string{"playlist"},
+		Hits:  100,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, correctResp)
+	assert.Empty(t, correctResp.SuggestedQuery, "Queries with enough hits should not be corrected")
+
+	_, err = s.SearchClient.CorrectQuery(ctx, &searchservice.CorrectQueryRequest{
+		Query: string(typo),
+		Types: []string{"unknown"},
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	for _, id := range []int64{playlistId, secondPlaylistId} {
+		deleteResp, err := s.SearchClient.DeletePlaylist(ctx, &searchservice.DeleteRequest{
+			Id: id,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, deleteResp)
+		assert.True(t, deleteResp.Success)
+	}
+}
+
+func TestSearchRankingSignals(t *testing.T) {
+	ctx, s := suite.New(t)
+
+	word := gofakeit.LetterN(12)
+	popularTrackId := gofakeit.Int64()
+	quietTrackId := gofakeit.Int64()
+
+	for _, trackId := range []int64{quietTrackId, popularTrackId} {
+		addResp, err := s.SearchClient.AddTrack(ctx, &searchservice.AddOrUpdateRequest{
+			Id:   trackId,
+			Name: word,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, addResp)
+		assert.True(t, addResp.Success)
+	}
+
+	createdAt := time.Now().Unix()
+	signalsResp, err := s.SearchClient.UpdateSignals(ctx, &searchservice.UpdateSignalsRequest{
+		Type: "track",
+		Signals: []*searchservice.DocumentSignals{
+			{Id: popularTrackId, Plays: 1000000, Likes: 10000, CreatedAt: createdAt},
+			{Id: quietTrackId, CreatedAt: createdAt},
+			{Id: gofakeit.Int64(), Plays: 1},
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, signalsResp)
+	assert.True(t, signalsResp.Success)
+
+	searchResp, err := s.SearchClient.SearchTracks(ctx, &searchservice.SearchRequest{
+		Query: word,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, searchResp)
+	require.Len(t, searchResp.Ids, 2)
+	assert.Equal(t, popularTrackId, searchResp.Ids[0], "Popular track should rank first")
+
+	_, err = s.SearchClient.UpdateSignals(ctx, &searchservice.UpdateSignalsRequest{
+		Type: "unknown",
+	})
+	require.Error(t, err)
+
+	for _, trackId := range []int64{quietTrackId, popularTrackId} {
+		deleteResp, err := s.SearchClient.DeleteTrack(ctx, &searchservice.DeleteRequest{
+			Id: trackId,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, deleteResp)
+		assert.True(t, deleteResp.Success)
+	}
+}
+
+func TestSearchTracksFiltersAndFacets(t *testing.T) {
+	ctx, s := suite.New(t)
+
+	word := gofakeit.LetterN(12)
+	artistId := gofakeit.Int64()
+	shortRockTrackId := gofakeit.Int64()
+	longRockTrackId := gofakeit.Int64()
+	jazzTrackId := gofakeit.Int64()
+
+	tracks := []*searchservice.AddOrUpdateRequest{
+		{Id: shortRockTrackId, Name: word, Track: &searchservice.TrackAttributes{Genre: "Rock", Tags: []string{"live"}, Duration: 90, UserId: artistId}},
+		{Id: longRockTrackId, Name: word, Track: &searchservice.TrackAttributes{Genre: "Rock", Tags: []string{"studio"}, Duration: 400, UserId: gofakeit.Int64()}},
+		{Id: jazzTrackId, Name: word, Track: &searchservice.TrackAttributes{Genre: "Jazz", Tags: []string{"live"}, Duration: 200, UserId: artistId}},
+	}
+	for _, track := range tracks {
+		addResp, err := s.SearchClient.AddTrack(ctx, track)
+		require.NoError(t, err)
+		require.NotNil(t, addResp)
+		assert.True(t, addResp.Success)
+	}
+
+	time.Sleep(1 * time.Second)
+
+	genreResp, err := s.SearchClient.SearchTracks(ctx, &searchservice.SearchRequest{
+		Query:        word,
+		TrackFilters: &searchservice.TrackFilters{Genres: []string{"rock"}},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, genreResp)
+	assert.ElementsMatch(t, []int64{shortRockTrackId, longRockTrackId}, genreResp.Ids)
+	assert.Equal(t, int64(2), genreResp.Total)
+	require.NotNil(t, genreResp.Facets)
+
+	genreCounts := make(map[string]int64)
+	for _, bucket := range genreResp.Facets.Genres {
+		genreCounts[bucket.Key] = bucket.Count
+	}
+	assert.Equal(t, map[string]int64{"rock": 2, "jazz": 1}, genreCounts, "Genre facet should ignore the genre filter")
+
+	durationCounts := make(map[string]int64)
+	for _, bucket := range genreResp.Facets.Durations {
+		durationCounts[bucket.Key] = bucket.Count
+	}
+	assert.Equal(t, int64(1), durationCounts["short"])
+	assert.Equal(t, int64(0), durationCounts["medium"])
+	assert.Equal(t, int64(1), durationCounts["long"])
+
+	filteredResp, err := s.SearchClient.SearchTracks(ctx, &searchservice.SearchRequest{
+		Query: word,
+		TrackFilters: &searchservice.TrackFilters{
+			Tags:        []string{"live"},
+			ArtistIds:   []int64{artistId},
+			MinDuration: 120,
+			MaxDuration: 300,
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, filteredResp)
+	assert.Equal(t, []int64{jazzTrackId}, filteredResp.Ids)
+
+	futureResp, err := s.SearchClient.SearchTracks(ctx, &searchservice.SearchRequest{
+		Query:        word,
+		TrackFilters: &searchservice.TrackFilters{UploadedAfter: time.Now().Add(time.Hour).Unix()},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, futureResp)
+	assert.Empty(t, futureResp.Ids)
+
+	_, err = s.SearchClient.SearchTracks(ctx, &searchservice.SearchRequest{
+		Query:        word,
+		TrackFilters: &searchservice.TrackFilters{MinDuration: 300, MaxDuration: 100},
+	})
+	require.Error(t, err)
+
+	for _, track := range tracks {
+		deleteResp, err := s.SearchClient.DeleteTrack(ctx, &searchservice.DeleteRequest{
+			Id: track.Id,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, deleteResp)
+		assert.True(t, deleteResp.Success)
+	}
+}
+
+func TestSearchAnalytics(t *testing.T) {
+	ctx, s := suite.New(t)
+
+	word := gofakeit.LetterN(12)
+	missingWord := gofakeit.LetterN(16)
+	trackId := gofakeit.Int64()
+	from := time.Now().Add(-time.Minute).Unix()
+
+	addResp, err := s.SearchClient.AddTrack(ctx, &searchservice.AddOrUpdateRequest{
+		Id:   trackId,
+		Name: word,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, addResp)
+	assert.True(t, addResp.Success)
+
+	time.Sleep(1 * time.Second)
+
+	searchResp, err := s.SearchClient.SearchTracks(ctx, &searchservice.SearchRequest{
+		Query: word,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, searchResp)
+	require.Contains(t, searchResp.Ids, trackId)
+	require.NotEmpty(t, searchResp.QueryId, "Search should return a query id for click tracking")
+
+	missingResp, err := s.SearchClient.SearchTracks(ctx, &searchservice.SearchRequest{
+		Query: missingWord,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, missingResp)
+	assert.Empty(t, missingResp.Ids)
+
+	clickResp, err := s.SearchClient.RecordClick(ctx, &searchservice.ClickRequest{
+		QueryId:    searchResp.QueryId,
+		DocumentId: trackId,
+		Position:   0,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, clickResp)
+	assert.True(t, clickResp.Success)
+
+	repeatedResp, err := s.SearchClient.RecordClick(ctx, &searchservice.ClickRequest{
+		QueryId:    searchResp.QueryId,
+		DocumentId: trackId,
+		Position:   0,
+	})
+	require.NoError(t, err, "A repeated click on the same document should be ignored")
+	require.NotNil(t, repeatedResp)
+	assert.True(t, repeatedResp.Success)
+
+	_, err = s.SearchClient.RecordClick(ctx, &searchservice.ClickRequest{
+		QueryId:    gofakeit.UUID(),
+		DocumentId: trackId,
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = s.SearchClient.RecordClick(ctx, &searchservice.ClickRequest{
+		QueryId:    "not-a-query-id",
+		DocumentId: trackId,
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	time.Sleep(1 * time.Second)
+
+	analyticsRequest := &searchservice.AnalyticsRequest{
+		From:  from,
+		To:    time.Now().Unix(),
+		Type:  "track",
+		Limit: 100,
+	}
+
+	topResp, err := s.SearchClient.GetTopQueries(ctx, analyticsRequest)
+	require.NoError(t, err)
+	require.NotNil(t, topResp)
+	var topStat *searchservice.QueryStat
+	for _, stat := range topResp.Queries {
+		if stat.Query == strings.ToLower(word) {
+			topStat = stat
+		}
+	}
+	require.NotNil(t, topStat, "Query should be reported in top queries")
+	assert.Equal(t, int64(1), topStat.Searches)
+	assert.Equal(t, int64(1), topStat.ClickedSearches)
+	assert.Equal(t, 1.0, topStat.ClickThroughRate)
+
+	zeroResp, err := s.SearchClient.GetZeroResultQueries(ctx, analyticsRequest)
+	require.NoError(t, err)
+	require.NotNil(t, zeroResp)
+	zeroQueries := make([]string, 0, len(zeroResp.Queries))
+	for _, stat := range zeroResp.Queries {
+		zeroQueries = append(zeroQueries, stat.Query)
+	}
+	assert.Contains(t, zeroQueries, strings.ToLower(missingWord))
+	assert.NotContains(t, zeroQueries, strings.ToLower(word))
+
+	ctrResp, err := s.SearchClient.GetClickThroughRate(ctx, analyticsRequest)
+	require.NoError(t, err)
+	require.NotNil(t, ctrResp)
+	assert.GreaterOrEqual(t, ctrResp.Searches, int64(2))
+	assert.GreaterOrEqual(t, ctrResp.ClickedSearches, int64(1))
+
+	_, err = s.SearchClient.GetTopQueries(ctx, &searchservice.AnalyticsRequest{
+		From: time.Now().Unix(),
+		To:   from,
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	deleteResp, err := s.SearchClient.DeleteTrack(ctx, &searchservice.DeleteRequest{
+		Id: trackId,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, deleteResp)
+	assert.True(t, deleteResp.Success)
+}
@@ -3,19 +3,36 @@ package suite
 import (
 	"context"
 	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/ocenb/music-go/search-service/internal/app"
+	"github.com/ocenb/music-go/search-service/internal/clients/embedded"
+	"github.com/ocenb/music-go/search-service/internal/config"
+	"github.com/ocenb/music-go/search-service/internal/service"
 	"github.com/ocenb/music-protos/gen/searchservice"
 	"github.com/ocenb/music-protos/gen/userservice"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 var (
 	AdminEmail    = "admin@example.com"
 	AdminPassword = "Password123!"
+	TestUserID    = int64(1)
+)
+
+var (
+	embeddedOnce sync.Once
+	embeddedAddr string
+	embeddedErr  error
 )
 
 type Suite struct {
@@ -23,6 +40,14 @@ type Suite struct {
 	SearchClient searchservice.SearchServiceClient
 }
 
+type fakeUserServiceClient struct {
+	userservice.UserServiceClient
+}
+
+func (c *fakeUserServiceClient) CheckAuth(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*userservice.CheckAuthResponse, error) {
+	return &userservice.CheckAuthResponse{User: &userservice.UserPrivateModel{Id: TestUserID}}, nil
+}
+
 func New(t *testing.T) (context.Context, *Suite) {
 	t.Helper()
 	t.Parallel()
@@ -34,6 +59,82 @@ func New(t *testing.T) (context.Context, *Suite) {
 		cancel()
 	})
 
+	if os.Getenv("SEARCH_TEST_BACKEND") == config.SearchBackendElasticsearch {
+		return newLive(ctx, t)
+	}
+
+	embeddedOnce.Do(func() {
+		embeddedAddr, embeddedErr = startEmbedded()
+	})
+	if embeddedErr != nil {
+		t.Fatalf("embedded search server error: %v", embeddedErr)
+	}
+
+	searchServiceConn, err := grpc.NewClient(
+		embeddedAddr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("grpc server connection error: %v", err)
+	}
+	t.Cleanup(func() {
+		searchServiceConn.Close()
+	})
+
+	outMD := metadata.New(map[string]string{
+		"authorization": "Bearer test",
+	})
+
+	return metadata.NewOutgoingContext(ctx, outMD), &Suite{
+		T:            t,
+		SearchClient: searchservice.NewSearchServiceClient(searchServiceConn),
+	}
+}
+
+func startEmbedded() (string, error) {
+	cfg := &config.Config{
+		GRPC:           config.GRPCConfig{Timeout: 5 * time.Second},
+		SuggestTimeout: 150 * time.Millisecond,
+		Ranking: config.RankingConfig{
+			PlaysWeight:     1,
+			LikesWeight:     1,
+			FollowersWeight: 1,
+			RecencyWeight:   1,
+			RecencyScale:    "30d",
+			MaxBoost:        3,
+		},
+		Analytics: config.AnalyticsConfig{
+			Enabled:    true,
+			LogTimeout: 2 * time.Second,
+		},
+		Spelling: config.SpellingConfig{
+			Enabled: true,
+			MaxHits: 2,
+			Timeout: 200 * time.Millisecond,
+		},
+		SearchBackend: config.SearchBackendEmbedded,
+	}
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	engine, err := embedded.New(cfg, log)
+	if err != nil {
+		return "", err
+	}
+	searchService := service.NewSearchService(cfg, log, engine)
+	grpcApp := app.New(searchService, &fakeUserServiceClient{}, cfg, log)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	go grpcApp.Serve(l)
+
+	return l.Addr().String(), nil
+}
+
+func newLive(ctx context.Context, t *testing.T) (context.Context, *Suite) {
+	t.Helper()
+
 	userServiceConn, err := grpc.NewClient(
 		"localhost:9090",
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
@@ -0,0 +1,8 @@
+package outbox
+
+type Message struct {
+	ID      int64
+	Topic   string
+	Key     string
+	Payload []byte
+}
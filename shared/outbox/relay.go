@@ -0,0 +1,190 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	VersionHeader   = "version"
+	relayTimeout    = 30 * time.Second
+	cleanupInterval = 24 * time.Hour
+	cleanupTimeout  = time.Minute
+)
+
+type Config struct {
+	Brokers       []string
+	BatchSize     int
+	RelayInterval time.Duration
+	Retention     time.Duration
+}
+
+type RelayInterface interface {
+	Relay(ctx context.Context) (int, error)
+	CleanupPublished(ctx context.Context) error
+	RunRelay(ctx context.Context)
+	RunCleanup(ctx context.Context)
+	Close() error
+}
+
+type messageWriter interface {
+	WriteMessages(ctx context.Context, messages ...kafka.Message) error
+	Close() error
+}
+
+type Relay struct {
+	cfg    Config
+	log    *slog.Logger
+	repo   RepoInterface
+	writer messageWriter
+}
+
+func NewRelay(cfg Config, log *slog.Logger, repo RepoInterface) RelayInterface {
+	return &Relay{
+		cfg:  cfg,
+		log:  log,
+		repo: repo,
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(cfg.Brokers...),
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+			Async:        false,
+		},
+	}
+}
+
+// Relay publishes unpublished messages in batches. The outbox id is sent as
+// the version header, so consumers can drop events that arrive out of order.
+func (r *Relay) Relay(ctx context.Context) (int, error) {
+	relayed := 0
+	for {
+		count, err := r.relayBatch(ctx)
+		if err != nil {
+			return relayed, err
+		}
+
+		relayed += count
+		if count < r.cfg.BatchSize {
+			return relayed, nil
+		}
+	}
+}
+
+func (r *Relay) relayBatch(ctx context.Context) (int, error) {
+	tx, err := r.repo.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin outbox transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			r.log.Error("Failed to roll back outbox transaction", slog.String("error", err.Error()))
+		}
+	}()
+
+	messages, err := r.repo.GetUnpublished(ctx, tx, r.cfg.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get unpublished outbox messages: %w", err)
+	}
+	if len(messages) == 0 {
+		return 0, nil
+	}
+
+	if err := r.writer.WriteMessages(ctx, kafkaMessages(messages)...); err != nil {
+		return 0, fmt.Errorf("failed to publish outbox messages: %w", err)
+	}
+
+	ids := make([]int64, 0, len(messages))
+	for _, message := range messages {
+		ids = append(ids, message.ID)
+	}
+	if err := r.repo.MarkPublished(ctx, tx, ids); err != nil {
+		return 0, fmt.Errorf("failed to mark outbox messages as published: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit outbox transaction: %w", err)
+	}
+
+	return len(messages), nil
+}
+
+func (r *Relay) CleanupPublished(ctx context.Context) error {
+	deleted, err := r.repo.DeletePublished(ctx, time.Now().Add(-r.cfg.Retention))
+	if err != nil {
+		return fmt.Errorf("failed to delete published outbox messages: %w", err)
+	}
+
+	r.log.Info("Cleaned up published outbox messages", slog.Int64("deleted", deleted))
+	return nil
+}
+
+// RunRelay relays the outbox on every tick until ctx is cancelled.
+func (r *Relay) RunRelay(ctx context.Context) {
+	r.log.Info("Outbox relay scheduled", slog.Duration("interval", r.cfg.RelayInterval))
+	ticker := time.NewTicker(r.cfg.RelayInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			relayCtx, cancel := context.WithTimeout(ctx, relayTimeout)
+			relayed, err := r.Relay(relayCtx)
+			if err != nil {
+				r.log.Error("Failed to relay outbox messages", slog.String("error", err.Error()))
+			} else if relayed > 0 {
+				r.log.Debug("Relayed outbox messages", slog.Int("count", relayed))
+			}
+			cancel()
+		}
+	}
+}
+
+// RunCleanup deletes old published messages once a day until ctx is
+// cancelled.
+func (r *Relay) RunCleanup(ctx context.Context) {
+	r.log.Info("Outbox cleanup scheduled", slog.Duration("interval", cleanupInterval))
+	ticker := time.NewTicker(cleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			cleanupCtx, cancel := context.WithTimeout(ctx, cleanupTimeout)
+			if err := r.CleanupPublished(cleanupCtx); err != nil {
+				r.log.Error("Failed to clean up outbox", slog.String("error", err.Error()))
+			}
+			cancel()
+		}
+	}
+}
+
+func (r *Relay) Close() error {
+	return r.writer.Close()
+}
+
+func kafkaMessages(messages []*Message) []kafka.Message {
+	result := make([]kafka.Message, 0, len(messages))
+	for _, message := range messages {
+		result = append(result, kafka.Message{
+			Topic: message.Topic,
+			Key:   []byte(message.Key),
+			Value: message.Payload,
+			Headers: []kafka.Header{
+				{Key: VersionHeader, Value: []byte(strconv.FormatInt(message.ID, 10))},
+			},
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,198 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"log/slog"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// txDriver is a database/sql driver that only supports transactions, so the
+// relay can begin, commit and roll back without a database.
+type txDriver struct {
+	mu        sync.Mutex
+	commits   int
+	rollbacks int
+}
+
+func (d *txDriver) Open(name string) (driver.Conn, error) {
+	return &txConn{driver: d}, nil
+}
+
+type txConn struct {
+	driver *txDriver
+}
+
+func (c *txConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("queries are not supported")
+}
+
+func (c *txConn) Close() error {
+	return nil
+}
+
+func (c *txConn) Begin() (driver.Tx, error) {
+	return c, nil
+}
+
+func (c *txConn) Commit() error {
+	c.driver.mu.Lock()
+	defer c.driver.mu.Unlock()
+	c.driver.commits++
+	return nil
+}
+
+func (c *txConn) Rollback() error {
+	c.driver.mu.Lock()
+	defer c.driver.mu.Unlock()
+	c.driver.rollbacks++
+	return nil
+}
+
+type fakeRepo struct {
+	postgres  *sql.DB
+	messages  []*Message
+	published map[int64]bool
+}
+
+func (r *fakeRepo) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	return r.postgres.BeginTx(ctx, opts)
+}
+
+func (r *fakeRepo) Add(ctx context.Context, topic, key string, payload []byte) error {
+	r.messages = append(r.messages, &Message{ID: int64(len(r.messages) + 1), Topic: topic, Key: key, Payload: payload})
+	return nil
+}
+
+func (r *fakeRepo) GetUnpublished(ctx context.Context, tx *sql.Tx, take int) ([]*Message, error) {
+	var messages []*Message
+	for _, message := range r.messages {
+		if len(messages) == take {
+			break
+		}
+		if !r.published[message.ID] {
+			messages = append(messages, message)
+		}
+	}
+	return messages, nil
+}
+
+func (r *fakeRepo) MarkPublished(ctx context.Context, tx *sql.Tx, ids []int64) error {
+	for _, id := range ids {
+		r.published[id] = true
+	}
+	return nil
+}
+
+func (r *fakeRepo) DeletePublished(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+type fakeWriter struct {
+	messages []kafka.Message
+	err      error
+}
+
+func (w *fakeWriter) WriteMessages(ctx context.Context, messages ...kafka.Message) error {
+	if w.err != nil {
+		return w.err
+	}
+	w.messages = append(w.messages, messages...)
+	return nil
+}
+
+func (w *fakeWriter) Close() error {
+	return nil
+}
+
+func newRelay(t *testing.T, batchSize int, writer *fakeWriter) (*Relay, *fakeRepo, *txDriver) {
+	t.Helper()
+
+	txs := &txDriver{}
+	postgres := sql.OpenDB(connector{driver: txs})
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	repo := &fakeRepo{postgres: postgres, published: make(map[int64]bool)}
+	relay := &Relay{
+		cfg:    Config{BatchSize: batchSize},
+		log:    slog.New(slog.NewTextHandler(io.Discard, nil)),
+		repo:   repo,
+		writer: writer,
+	}
+
+	return relay, repo, txs
+}
+
+type connector struct {
+	driver *txDriver
+}
+
+func (c connector) Connect(ctx context.Context) (driver.Conn, error) {
+	return c.driver.Open("")
+}
+
+func (c connector) Driver() driver.Driver {
+	return c.driver
+}
+
+func TestRelayPublishesInBatches(t *testing.T) {
+	ctx := context.Background()
+	writer := &fakeWriter{}
+	relay, repo, txs := newRelay(t, 2, writer)
+
+	for i := range 5 {
+		require.NoError(t, repo.Add(ctx, "search-index-events", "track:"+strconv.Itoa(i), []byte("{}")))
+	}
+
+	relayed, err := relay.Relay(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, 5, relayed)
+	assert.Equal(t, 3, txs.commits)
+	require.Len(t, writer.messages, 5)
+	for i, message := range writer.messages {
+		id := int64(i + 1)
+		assert.True(t, repo.published[id])
+		assert.Equal(t, "search-index-events", message.Topic)
+		assert.Equal(t, []byte("track:"+strconv.Itoa(i)), message.Key)
+		assert.Equal(t, []kafka.Header{{Key: VersionHeader, Value: []byte(strconv.FormatInt(id, 10))}}, message.Headers)
+	}
+
+	relayed, err = relay.Relay(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, relayed)
+}
+
+func TestRelayKeepsMessagesWhenPublishFails(t *testing.T) {
+	ctx := context.Background()
+	writer := &fakeWriter{err: errors.New("broker unavailable")}
+	relay, repo, txs := newRelay(t, 2, writer)
+
+	require.NoError(t, repo.Add(ctx, "search-index-events", "track:1", []byte("{}")))
+
+	_, err := relay.Relay(ctx)
+	require.Error(t, err)
+
+	assert.Empty(t, repo.published)
+	assert.Zero(t, txs.commits)
+	assert.Equal(t, 1, txs.rollbacks)
+
+	writer.err = nil
+	relayed, err := relay.Relay(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, relayed)
+	assert.True(t, repo.published[1])
+}
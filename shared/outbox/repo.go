@@ -0,0 +1,112 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// TxFromContext returns the transaction the calling service stored in the
+// context, so messages are added in the same transaction as the change.
+type TxFromContext func(ctx context.Context) (*sql.Tx, bool)
+
+type RepoInterface interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+	Add(ctx context.Context, topic, key string, payload []byte) error
+	GetUnpublished(ctx context.Context, tx *sql.Tx, take int) ([]*Message, error)
+	MarkPublished(ctx context.Context, tx *sql.Tx, ids []int64) error
+	DeletePublished(ctx context.Context, before time.Time) (int64, error)
+}
+
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+type Repo struct {
+	postgres      *sql.DB
+	txFromContext TxFromContext
+}
+
+func NewRepo(postgres *sql.DB, txFromContext TxFromContext) RepoInterface {
+	return &Repo{postgres: postgres, txFromContext: txFromContext}
+}
+
+func (r *Repo) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	return r.postgres.BeginTx(ctx, opts)
+}
+
+func (r *Repo) db(ctx context.Context) querier {
+	if tx, hasTx := r.txFromContext(ctx); hasTx {
+		return tx
+	}
+	return r.postgres
+}
+
+func (r *Repo) Add(ctx context.Context, topic, key string, payload []byte) error {
+	query := `
+		INSERT INTO outbox (topic, key, payload)
+		VALUES ($1, $2, $3)
+	`
+
+	_, err := r.db(ctx).ExecContext(ctx, query, topic, key, payload)
+	return err
+}
+
+func (r *Repo) GetUnpublished(ctx context.Context, tx *sql.Tx, take int) ([]*Message, error) {
+	query := `
+		SELECT id, topic, key, payload
+		FROM outbox
+		WHERE published_at IS NULL
+		ORDER BY id
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	`
+
+	rows, err := tx.QueryContext(ctx, query, take)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []*Message
+	for rows.Next() {
+		var message Message
+		if err := rows.Scan(&message.ID, &message.Topic, &message.Key, &message.Payload); err != nil {
+			return nil, err
+		}
+		messages = append(messages, &message)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+func (r *Repo) MarkPublished(ctx context.Context, tx *sql.Tx, ids []int64) error {
+	query := `
+		UPDATE outbox
+		SET published_at = NOW()
+		WHERE id = ANY($1)
+	`
+
+	_, err := tx.ExecContext(ctx, query, pq.Array(ids))
+	return err
+}
+
+func (r *Repo) DeletePublished(ctx context.Context, before time.Time) (int64, error) {
+	query := `
+		DELETE FROM outbox
+		WHERE published_at IS NOT NULL AND published_at < $1
+	`
+
+	result, err := r.postgres.ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
@@ -0,0 +1,64 @@
+package searchindex
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ocenb/music-go/shared/outbox"
+	"github.com/ocenb/music-protos/gen/searchservice"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	searchIndexTopic = "search-index-events"
+)
+
+type SearchIndexClientInterface interface {
+	Upsert(ctx context.Context, documentType string, document *searchservice.AddOrUpdateRequest) error
+	Delete(ctx context.Context, documentType string, id int64) error
+	UpdateSignals(ctx context.Context, signals *searchservice.UpdateSignalsRequest) error
+}
+
+type SearchIndexClient struct {
+	outboxRepo outbox.RepoInterface
+}
+
+func NewSearchIndexClient(outboxRepo outbox.RepoInterface) SearchIndexClientInterface {
+	return &SearchIndexClient{
+		outboxRepo: outboxRepo,
+	}
+}
+
+func (c *SearchIndexClient) Upsert(ctx context.Context, documentType string, document *searchservice.AddOrUpdateRequest) error {
+	return c.enqueue(ctx, fmt.Sprintf("%s:%d", documentType, document.Id), &searchservice.IndexEvent{
+		Type:   documentType,
+		Change: &searchservice.IndexEvent_Upsert{Upsert: document},
+	})
+}
+
+func (c *SearchIndexClient) Delete(ctx context.Context, documentType string, id int64) error {
+	return c.enqueue(ctx, fmt.Sprintf("%s:%d", documentType, id), &searchservice.IndexEvent{
+		Type:   documentType,
+		Change: &searchservice.IndexEvent_Delete{Delete: &searchservice.DeleteRequest{Id: id}},
+	})
+}
+
+func (c *SearchIndexClient) UpdateSignals(ctx context.Context, signals *searchservice.UpdateSignalsRequest) error {
+	return c.enqueue(ctx, fmt.Sprintf("%s:signals", signals.Type), &searchservice.IndexEvent{
+		Type:   signals.Type,
+		Change: &searchservice.IndexEvent_Signals{Signals: signals},
+	})
+}
+
+func (c *SearchIndexClient) enqueue(ctx context.Context, key string, event *searchservice.IndexEvent) error {
+	payload, err := protojson.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal index event: %w", err)
+	}
+
+	if err := c.outboxRepo.Add(ctx, searchIndexTopic, key, payload); err != nil {
+		return fmt.Errorf("failed to add index event to outbox: %w", err)
+	}
+
+	return nil
+}
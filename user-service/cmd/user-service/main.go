@@ -1,16 +1,18 @@
 package main
 
 import (
+	"context"
 	"log/slog"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/ocenb/music-go/shared/outbox"
+	"github.com/ocenb/music-go/shared/searchindex"
 	"github.com/ocenb/music-go/user-service/internal/app"
 	"github.com/ocenb/music-go/user-service/internal/clients/contentservice"
 	"github.com/ocenb/music-go/user-service/internal/clients/notificationclient"
-	"github.com/ocenb/music-go/user-service/internal/clients/searchservice"
 	"github.com/ocenb/music-go/user-service/internal/config"
 	"github.com/ocenb/music-go/user-service/internal/logger"
 	authrepo "github.com/ocenb/music-go/user-service/internal/repos/auth"
@@ -46,43 +48,41 @@ func main() {
 		}
 	}()
 
-	searchServiceClient, err := searchservice.New(cfg)
-	if err != nil {
-		log.Error("Failed to connect to search service", utils.ErrLog(err))
-		os.Exit(1)
-	}
-	defer func() {
-		log.Info("Closing search service connection")
-		err := searchServiceClient.Conn.Close()
-		if err != nil {
-			log.Error("Failed to close search service connection", utils.ErrLog(err))
-		}
-	}()
-
-	notificationClient, err := notificationclient.NewNotificationClient(cfg.KafkaBrokers)
-	if err != nil {
-		log.Error("Failed to create notification client", utils.ErrLog(err))
-		os.Exit(1)
-	}
-	defer func() {
-		log.Info("Closing notification client")
-		err := notificationClient.Close()
-		if err != nil {
-			log.Error("Failed to close notification client", utils.ErrLog(err))
-		}
-	}()
-
 	contentServiceClient := contentservice.New(cfg, log)
 
 	tokenRepo := tokenrepo.NewTokenRepo(postgres)
 	userRepo := userrepo.NewUserRepo(postgres)
 	authRepo := authrepo.NewAuthRepo(postgres)
+	outboxRepo := outbox.NewRepo(postgres, utils.GetTxFromContext)
+
+	searchIndexClient := searchindex.NewSearchIndexClient(outboxRepo)
+	notificationClient := notificationclient.NewNotificationClient(outboxRepo)
+
+	outboxRelay := outbox.NewRelay(outbox.Config{
+		Brokers:       cfg.KafkaBrokers,
+		BatchSize:     cfg.OutboxBatchSize,
+		RelayInterval: cfg.OutboxRelayInterval,
+		Retention:     cfg.OutboxRetention,
+	}, log, outboxRepo)
+	defer func() {
+		log.Info("Closing outbox kafka writer")
+		err := outboxRelay.Close()
+		if err != nil {
+			log.Error("Failed to close outbox kafka writer", utils.ErrLog(err))
+		}
+	}()
 
 	tokenService := token.NewTokenService(cfg, log, tokenRepo)
-	userService := user.NewUserService(cfg, log, userRepo, searchServiceClient, contentServiceClient)
+	userService := user.NewUserService(cfg, log, userRepo, searchIndexClient, contentServiceClient)
 	authService := auth.NewAuthService(cfg, log, userService, tokenService, authRepo, notificationClient)
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
 	go runTokenCleanup(tokenService, log)
+	go runSearchSignalsPush(ctx, userService, cfg.SearchSignalsInterval, log)
+	go outboxRelay.RunRelay(ctx)
+	go outboxRelay.RunCleanup(ctx)
 
 	log.Info("Initializing gRPC server", slog.Int("port", cfg.GRPC.Port))
 	grpcApp := app.New(authService, userService, cfg, log)
@@ -97,6 +97,7 @@ func main() {
 
 	shutdownStart := time.Now()
 
+	cancel()
 	grpcApp.Stop()
 
 	log.Info("Service shutdown complete",
@@ -114,3 +115,24 @@ func runTokenCleanup(tokenService token.TokenServiceInterface, log *slog.Logger)
 		tokenService.CleanupExpiredTokens(log)
 	}
 }
+
+func runSearchSignalsPush(ctx context.Context, userService user.UserServiceInterface, interval time.Duration, log *slog.Logger) {
+	log.Info("Search signals push scheduled", slog.Duration("interval", interval))
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			pushCtx, cancel := context.WithTimeout(ctx, interval)
+			if err := userService.PushSearchSignals(pushCtx); err != nil {
+				log.Error("Failed to push search signals", utils.ErrLog(err))
+			} else {
+				log.Info("Successfully pushed search signals")
+			}
+			cancel()
+		}
+	}
+}
@@ -11,27 +11,31 @@ import (
 )
 
 type Config struct {
-	GRPC                 GRPCConfig    `yaml:"grpc"`
-	LogLevel             int           `yaml:"log_level" env-default:"0"`
-	LogHandler           string        `yaml:"log_handler" env-default:"text"`
-	AccessTokenLiveTime  time.Duration `yaml:"access_token_live_time" env-default:"1h"`
-	RefreshTokenLiveTime time.Duration `yaml:"refresh_token_live_time" env-default:"720h"`
-	DBMaxOpenConns       int           `yaml:"db_max_open_conns" env-default:"10"`
-	DBMaxIdleConns       int           `yaml:"db_max_idle_conns" env-default:"5"`
-	DBConnMaxLifetime    time.Duration `yaml:"db_conn_max_lifetime" env-default:"1h"`
-	Environment          string        `env:"ENVIRONMENT" env-required:"true"`
-	JWTSecret            string        `env:"JWT_SECRET" env-required:"true"`
-	BCryptCost           int           `env:"BCRYPT_COST" env-default:"12"`
-	DBHost               string        `env:"POSTGRES_HOST" env-required:"true"`
-	DBPort               string        `env:"POSTGRES_PORT" env-required:"true"`
-	DBUser               string        `env:"POSTGRES_USER" env-required:"true"`
-	DBPassword           string        `env:"POSTGRES_PASSWORD" env-required:"true"`
-	DBName               string        `env:"POSTGRES_DB" env-required:"true"`
-	DBSSLMode            string        `env:"POSTGRES_SSL_MODE" env-default:"disable"`
-	DatabaseUrl          string
-	SearchServiceAddress string   `env:"SEARCH_SERVICE_ADDRESS" env-required:"true"`
-	ContentServiceURL    string   `env:"CONTENT_SERVICE_URL" env-required:"true"`
-	KafkaBrokers         []string `env:"KAFKA_BROKERS" env-required:"true"`
+	GRPC                   GRPCConfig    `yaml:"grpc"`
+	LogLevel               int           `yaml:"log_level" env-default:"0"`
+	LogHandler             string        `yaml:"log_handler" env-default:"text"`
+	AccessTokenLiveTime    time.Duration `yaml:"access_token_live_time" env-default:"1h"`
+	RefreshTokenLiveTime   time.Duration `yaml:"refresh_token_live_time" env-default:"720h"`
+	DBMaxOpenConns         int           `yaml:"db_max_open_conns" env-default:"10"`
+	DBMaxIdleConns         int           `yaml:"db_max_idle_conns" env-default:"5"`
+	DBConnMaxLifetime      time.Duration `yaml:"db_conn_max_lifetime" env-default:"1h"`
+	SearchSignalsInterval  time.Duration `yaml:"search_signals_interval" env-default:"15m"`
+	SearchSignalsBatchSize int           `yaml:"search_signals_batch_size" env-default:"500"`
+	OutboxRelayInterval    time.Duration `yaml:"outbox_relay_interval" env-default:"1s"`
+	OutboxBatchSize        int           `yaml:"outbox_batch_size" env-default:"100"`
+	OutboxRetention        time.Duration `yaml:"outbox_retention" env-default:"168h"`
+	Environment            string        `env:"ENVIRONMENT" env-required:"true"`
+	JWTSecret              string        `env:"JWT_SECRET" env-required:"true"`
+	BCryptCost             int           `env:"BCRYPT_COST" env-default:"12"`
+	DBHost                 string        `env:"POSTGRES_HOST" env-required:"true"`
+	DBPort                 string        `env:"POSTGRES_PORT" env-required:"true"`
+	DBUser                 string        `env:"POSTGRES_USER" env-required:"true"`
+	DBPassword             string        `env:"POSTGRES_PASSWORD" env-required:"true"`
+	DBName                 string        `env:"POSTGRES_DB" env-required:"true"`
+	DBSSLMode              string        `env:"POSTGRES_SSL_MODE" env-default:"disable"`
+	DatabaseUrl            string
+	ContentServiceURL      string   `env:"CONTENT_SERVICE_URL" env-required:"true"`
+	KafkaBrokers           []string `env:"KAFKA_BROKERS" env-required:"true"`
 }
 
 type GRPCConfig struct {
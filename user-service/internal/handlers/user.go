@@ -104,3 +104,33 @@ func (s *UserServer) Unfollow(ctx context.Context, req *userservice.UnfollowRequ
 	res := &userservice.UnfollowResponse{Success: true}
 	return res, nil
 }
+
+func (s *UserServer) GetFollowing(ctx context.Context, req *userservice.GetFollowingRequest) (*userservice.GetFollowingResponse, error) {
+	users, err := s.userService.GetFollowing(ctx, req.UserId, req.Take, req.LastId)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &userservice.GetFollowingResponse{Users: users}
+	return res, nil
+}
+
+func (s *UserServer) GetFollowers(ctx context.Context, req *userservice.GetFollowersRequest) (*userservice.GetFollowersResponse, error) {
+	followerIDs, followersCount, err := s.userService.GetFollowers(ctx, req.UserId, req.Take, req.LastId)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &userservice.GetFollowersResponse{UserIds: followerIDs, FollowersCount: followersCount}
+	return res, nil
+}
+
+func (s *UserServer) GetUsersByIds(ctx context.Context, req *userservice.GetUsersByIdsRequest) (*userservice.GetUsersByIdsResponse, error) {
+	users, err := s.userService.GetManyByIds(ctx, req.UserIds)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &userservice.GetUsersByIdsResponse{Users: users}
+	return res, nil
+}
@@ -1,6 +1,8 @@
 package notificationmocks
 
 import (
+	"context"
+
 	"github.com/stretchr/testify/mock"
 )
 
@@ -8,12 +10,7 @@ type MockNotificationClient struct {
 	mock.Mock
 }
 
-func (m *MockNotificationClient) SendEmailNotification(email, msg string) error {
-	args := m.Called(email, msg)
-	return args.Error(0)
-}
-
-func (m *MockNotificationClient) Close() error {
-	args := m.Called()
+func (m *MockNotificationClient) SendEmailNotification(ctx context.Context, email, msg string) error {
+	args := m.Called(ctx, email, msg)
 	return args.Error(0)
 }
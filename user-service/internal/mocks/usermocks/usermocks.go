@@ -111,3 +111,32 @@ func (m *MockUserService) Unfollow(ctx context.Context, userID int64, targetUser
 	args := m.Called(ctx, userID, targetUserID)
 	return args.Error(0)
 }
+
+func (m *MockUserService) GetFollowing(ctx context.Context, userID int64, take int64, lastID int64) ([]*userservice.UserPublicModel, error) {
+	args := m.Called(ctx, userID, take, lastID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*userservice.UserPublicModel), args.Error(1)
+}
+
+func (m *MockUserService) GetFollowers(ctx context.Context, userID int64, take int64, lastID int64) ([]int64, int64, error) {
+	args := m.Called(ctx, userID, take, lastID)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]int64), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockUserService) GetManyByIds(ctx context.Context, userIDs []int64) ([]*userservice.UserPublicModel, error) {
+	args := m.Called(ctx, userIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*userservice.UserPublicModel), args.Error(1)
+}
+
+func (m *MockUserService) PushSearchSignals(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 type UserFullModel struct {
 	ID                         int64
 	Username                   string
@@ -12,6 +14,12 @@ type UserFullModel struct {
 	CreatedAt                  string
 }
 
+type UserSignalsModel struct {
+	ID             int64
+	FollowersCount int64
+	CreatedAt      time.Time
+}
+
 type TokenModel struct {
 	ID           string
 	CreatedAt    string
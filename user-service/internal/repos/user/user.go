@@ -9,7 +9,7 @@ import (
 	"github.com/ocenb/music-go/user-service/internal/utils"
 	"github.com/ocenb/music-protos/gen/userservice"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 type UserRepoInterface interface {
@@ -28,6 +28,10 @@ type UserRepoInterface interface {
 	CheckFollow(ctx context.Context, userID int64, targetUserID int64) (bool, error)
 	Follow(ctx context.Context, userID int64, targetUserID int64) error
 	Unfollow(ctx context.Context, userID int64, targetUserID int64) error
+	GetFollowing(ctx context.Context, userID int64, take int64, lastID int64) ([]*userservice.UserPublicModel, error)
+	GetFollowers(ctx context.Context, userID int64, take int64, lastID int64) ([]int64, error)
+	GetManyByIds(ctx context.Context, userIDs []int64) ([]*userservice.UserPublicModel, error)
+	GetSignals(ctx context.Context, lastID int64, take int) ([]*models.UserSignalsModel, error)
 }
 
 type UserRepo struct {
@@ -108,16 +112,17 @@ func (r *UserRepo) UpdateVerificationToken(ctx context.Context, userID int64, ne
 	var user userservice.UserPrivateModel
 
 	tx, hasTx := utils.GetTxFromContext(ctx)
+
+	var row *sql.Row
 	if hasTx {
-		_, err := tx.ExecContext(ctx, query, userID, newVerificationToken, expiresAt)
-		if err != nil {
-			return nil, err
-		}
+		row = tx.QueryRowContext(ctx, query, userID, newVerificationToken, expiresAt)
 	} else {
-		err := r.postgres.QueryRowContext(ctx, query, userID, newVerificationToken, expiresAt).Scan(&user.Id, &user.Username, &user.Email, &user.CreatedAt)
-		if err != nil {
-			return nil, err
-		}
+		row = r.postgres.QueryRowContext(ctx, query, userID, newVerificationToken, expiresAt)
+	}
+
+	err := row.Scan(&user.Id, &user.Username, &user.Email, &user.CreatedAt)
+	if err != nil {
+		return nil, err
 	}
 
 	return &user, nil
@@ -283,3 +288,118 @@ func (r *UserRepo) Unfollow(ctx context.Context, userID int64, targetUserID int6
 	_, err := r.postgres.ExecContext(ctx, query, targetUserID, userID)
 	return err
 }
+
+func (r *UserRepo) GetFollowing(ctx context.Context, userID int64, take int64, lastID int64) ([]*userservice.UserPublicModel, error) {
+	query := `
+		SELECT u.id, u.username, u.followers_count
+		FROM user_followers uf
+		JOIN users u ON u.id = uf.user_id
+		WHERE uf.follower_id = $1 AND ($2 = 0 OR u.id > $2)
+		ORDER BY u.id ASC
+		LIMIT $3
+	`
+	rows, err := r.postgres.QueryContext(ctx, query, userID, lastID, take)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*userservice.UserPublicModel
+	for rows.Next() {
+		var user userservice.UserPublicModel
+		if err := rows.Scan(&user.Id, &user.Username, &user.FollowersCount); err != nil {
+			return nil, err
+		}
+		users = append(users, &user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (r *UserRepo) GetFollowers(ctx context.Context, userID int64, take int64, lastID int64) ([]int64, error) {
+	query := `
+		SELECT follower_id
+		FROM user_followers
+		WHERE user_id = $1 AND ($2 = 0 OR follower_id > $2)
+		ORDER BY follower_id ASC
+		LIMIT $3
+	`
+	rows, err := r.postgres.QueryContext(ctx, query, userID, lastID, take)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var followerIDs []int64
+	for rows.Next() {
+		var followerID int64
+		if err := rows.Scan(&followerID); err != nil {
+			return nil, err
+		}
+		followerIDs = append(followerIDs, followerID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return followerIDs, nil
+}
+
+func (r *UserRepo) GetManyByIds(ctx context.Context, userIDs []int64) ([]*userservice.UserPublicModel, error) {
+	query := `
+		SELECT id, username, followers_count
+		FROM users
+		WHERE id = ANY($1)
+	`
+	rows, err := r.postgres.QueryContext(ctx, query, pq.Array(userIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*userservice.UserPublicModel
+	for rows.Next() {
+		var user userservice.UserPublicModel
+		if err := rows.Scan(&user.Id, &user.Username, &user.FollowersCount); err != nil {
+			return nil, err
+		}
+		users = append(users, &user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (r *UserRepo) GetSignals(ctx context.Context, lastID int64, take int) ([]*models.UserSignalsModel, error) {
+	query := `
+		SELECT id, followers_count, created_at
+		FROM users
+		WHERE id > $1
+		ORDER BY id
+		LIMIT $2
+	`
+	rows, err := r.postgres.QueryContext(ctx, query, lastID, take)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var signals []*models.UserSignalsModel
+	for rows.Next() {
+		var signal models.UserSignalsModel
+		if err := rows.Scan(&signal.ID, &signal.FollowersCount, &signal.CreatedAt); err != nil {
+			return nil, err
+		}
+		signals = append(signals, &signal)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return signals, nil
+}
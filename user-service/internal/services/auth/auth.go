@@ -83,20 +83,29 @@ func (s *AuthService) Register(ctx context.Context, username, email, password st
 
 	verificationToken := s.tokenService.GenerateVerificationToken()
 
-	user, err := s.userService.Create(ctx, username, email, string(hashedPassword), verificationToken)
-	if err != nil {
-		s.log.Error("Registration failed: error creating user", slog.String("username", username), slog.String("email", email), utils.ErrLog(err))
-		return nil, utils.InternalError(err, "failed to create user")
-	}
+	var user *userservice.UserPrivateModel
+	err = storage.WithTransaction(ctx, s.authRepo, func(txCtx context.Context) error {
+		var err error
+		user, err = s.userService.Create(txCtx, username, email, string(hashedPassword), verificationToken)
+		if err != nil {
+			s.log.Error("Registration failed: error creating user", slog.String("username", username), slog.String("email", email), utils.ErrLog(err))
+			return utils.InternalError(err, "failed to create user")
+		}
 
-	s.log.Info("User registered successfully", slog.String("username", username), slog.String("email", email), slog.Int64("user_id", user.Id))
+		err = s.notificationClient.SendEmailNotification(txCtx, user.Email, verificationToken)
+		if err != nil {
+			s.log.Error("Registration failed: error sending verification email", slog.String("email", email), utils.ErrLog(err))
+			return utils.InternalError(err, "failed to send verification email")
+		}
 
-	err = s.notificationClient.SendEmailNotification(user.Email, verificationToken)
+		return nil
+	})
 	if err != nil {
-		s.log.Error("Registration failed: error sending verification email", slog.String("email", email), utils.ErrLog(err))
-		return nil, utils.InternalError(err, "failed to send verification email")
+		return nil, err
 	}
 
+	s.log.Info("User registered successfully", slog.String("username", username), slog.String("email", email), slog.Int64("user_id", user.Id))
+
 	return user, nil
 }
 
@@ -232,15 +241,24 @@ func (s *AuthService) NewVerification(ctx context.Context, email, password strin
 	}
 
 	newVerificationToken := s.tokenService.GenerateVerificationToken()
-	updatedUser, err := s.userService.UpdateVerificationToken(ctx, user.ID, newVerificationToken)
-	if err != nil {
-		return nil, utils.InternalError(err, "failed to update verification token")
-	}
+	var updatedUser *userservice.UserPrivateModel
+	err = storage.WithTransaction(ctx, s.authRepo, func(txCtx context.Context) error {
+		var err error
+		updatedUser, err = s.userService.UpdateVerificationToken(txCtx, user.ID, newVerificationToken)
+		if err != nil {
+			return utils.InternalError(err, "failed to update verification token")
+		}
+
+		err = s.notificationClient.SendEmailNotification(txCtx, user.Email, newVerificationToken)
+		if err != nil {
+			s.log.Error("New verification failed: error sending verification email", slog.String("email", user.Email), utils.ErrLog(err))
+			return utils.InternalError(err, "failed to send verification email")
+		}
 
-	err = s.notificationClient.SendEmailNotification(user.Email, newVerificationToken)
+		return nil
+	})
 	if err != nil {
-		s.log.Error("New verification failed: error sending verification email", slog.String("email", user.Email), utils.ErrLog(err))
-		return nil, utils.InternalError(err, "failed to send verification email")
+		return nil, err
 	}
 
 	return updatedUser, nil
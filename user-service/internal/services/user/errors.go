@@ -9,4 +9,5 @@ var (
 	ErrUserEmailExists          = utils.AlreadyExistsError("user with the same email already exists")
 	ErrUserUsernameExists       = utils.AlreadyExistsError("user with the same username already exists")
 	ErrInvalidVerificationToken = utils.InvalidArgumentError("invalid verification token")
+	ErrTooManyUserIDs           = utils.InvalidArgumentError("too many user ids")
 )
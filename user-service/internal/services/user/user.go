@@ -7,8 +7,8 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/ocenb/music-go/shared/searchindex"
 	"github.com/ocenb/music-go/user-service/internal/clients/contentservice"
-	searchclient "github.com/ocenb/music-go/user-service/internal/clients/searchservice"
 	"github.com/ocenb/music-go/user-service/internal/config"
 	"github.com/ocenb/music-go/user-service/internal/models"
 	userrepo "github.com/ocenb/music-go/user-service/internal/repos/user"
@@ -18,6 +18,11 @@ import (
 	"github.com/ocenb/music-protos/gen/userservice"
 )
 
+const searchDocumentType = "user"
+
+// MaxUsersByIDs matches the max_items rule on GetUsersByIdsRequest.
+const MaxUsersByIDs = 100
+
 type UserServiceInterface interface {
 	GetByUsername(ctx context.Context, username string) (*userservice.UserPublicModel, error)
 	GetById(ctx context.Context, id int64) (*models.UserFullModel, error)
@@ -33,22 +38,26 @@ type UserServiceInterface interface {
 	CheckFollow(ctx context.Context, userID int64, targetUserID int64) (bool, error)
 	Follow(ctx context.Context, userID int64, targetUserID int64) error
 	Unfollow(ctx context.Context, userID int64, targetUserID int64) error
+	GetFollowing(ctx context.Context, userID int64, take int64, lastID int64) ([]*userservice.UserPublicModel, error)
+	GetFollowers(ctx context.Context, userID int64, take int64, lastID int64) ([]int64, int64, error)
+	GetManyByIds(ctx context.Context, userIDs []int64) ([]*userservice.UserPublicModel, error)
+	PushSearchSignals(ctx context.Context) error
 }
 
 type UserService struct {
 	cfg                  *config.Config
 	userRepo             userrepo.UserRepoInterface
 	log                  *slog.Logger
-	searchServiceClient  *searchclient.SearchServiceClient
+	searchIndexClient    searchindex.SearchIndexClientInterface
 	contentServiceClient *contentservice.ContentServiceClient
 }
 
-func NewUserService(cfg *config.Config, log *slog.Logger, userRepo userrepo.UserRepoInterface, searchServiceClient *searchclient.SearchServiceClient, contentServiceClient *contentservice.ContentServiceClient) UserServiceInterface {
+func NewUserService(cfg *config.Config, log *slog.Logger, userRepo userrepo.UserRepoInterface, searchIndexClient searchindex.SearchIndexClientInterface, contentServiceClient *contentservice.ContentServiceClient) UserServiceInterface {
 	return &UserService{
 		cfg:                  cfg,
 		userRepo:             userRepo,
 		log:                  log,
-		searchServiceClient:  searchServiceClient,
+		searchIndexClient:    searchIndexClient,
 		contentServiceClient: contentServiceClient,
 	}
 }
@@ -130,22 +139,31 @@ func (s *UserService) Create(ctx context.Context, username, email, password, ver
 	s.log.Info("Creating new user", slog.String("username", username), slog.String("email", email))
 	verificationTokenExpiresAt := time.Now().Add(24 * time.Hour)
 
-	user, err := s.userRepo.Create(ctx, username, email, password, verificationToken, verificationTokenExpiresAt)
-	if err != nil {
-		s.log.Error("Failed to create user", slog.String("username", username), slog.String("email", email), utils.ErrLog(err))
-		return nil, utils.InternalError(err, "failed to create user")
-	}
+	var user *userservice.UserPrivateModel
+	err := storage.WithTransaction(ctx, s.userRepo, func(txCtx context.Context) error {
+		var err error
+		user, err = s.userRepo.Create(txCtx, username, email, password, verificationToken, verificationTokenExpiresAt)
+		if err != nil {
+			s.log.Error("Failed to create user", slog.String("username", username), slog.String("email", email), utils.ErrLog(err))
+			return utils.InternalError(err, "failed to create user")
+		}
 
-	s.log.Info("User created successfully", slog.String("username", username), slog.String("email", email), slog.Int64("user_id", user.Id))
+		err = s.searchIndexClient.Upsert(txCtx, searchDocumentType, &searchservice.AddOrUpdateRequest{
+			Id:   user.Id,
+			Name: username,
+		})
+		if err != nil {
+			return utils.InternalError(err, "failed to queue user for search indexing")
+		}
 
-	addResp, err := s.searchServiceClient.Client.AddUser(ctx, &searchservice.AddOrUpdateRequest{
-		Id:   user.Id,
-		Name: username,
+		return nil
 	})
-	if err != nil || !addResp.Success {
-		return nil, utils.InternalError(err, "failed to add user to search service")
+	if err != nil {
+		return nil, err
 	}
 
+	s.log.Info("User created successfully", slog.String("username", username), slog.String("email", email), slog.Int64("user_id", user.Id))
+
 	return user, nil
 }
 
@@ -163,12 +181,12 @@ func (s *UserService) ChangeUsername(ctx context.Context, userID int64, username
 			return utils.InternalError(err, "failed to change username")
 		}
 
-		updateResp, err := s.searchServiceClient.Client.UpdateUser(ctx, &searchservice.AddOrUpdateRequest{
+		err = s.searchIndexClient.Upsert(txCtx, searchDocumentType, &searchservice.AddOrUpdateRequest{
 			Id:   userID,
 			Name: username,
 		})
-		if err != nil || !updateResp.Success {
-			return utils.InternalError(err, "failed to update user in search service")
+		if err != nil {
+			return utils.InternalError(err, "failed to queue user for search indexing")
 		}
 
 		return nil
@@ -222,11 +240,8 @@ func (s *UserService) Delete(ctx context.Context, userID int64) error {
 			return utils.InternalError(err, "failed to delete user")
 		}
 
-		deleteResp, err := s.searchServiceClient.Client.DeleteUser(ctx, &searchservice.DeleteRequest{
-			Id: userID,
-		})
-		if err != nil || !deleteResp.Success {
-			return utils.InternalError(err, "failed to delete user in search service")
+		if err := s.searchIndexClient.Delete(txCtx, searchDocumentType, userID); err != nil {
+			return utils.InternalError(err, "failed to queue user for search removal")
 		}
 
 		return nil
@@ -277,3 +292,78 @@ func (s *UserService) Unfollow(ctx context.Context, userID int64, targetUserID i
 	}
 	return nil
 }
+
+func (s *UserService) GetFollowing(ctx context.Context, userID int64, take int64, lastID int64) ([]*userservice.UserPublicModel, error) {
+	users, err := s.userRepo.GetFollowing(ctx, userID, take, lastID)
+	if err != nil {
+		return nil, utils.InternalError(err, "failed to get followed users")
+	}
+	return users, nil
+}
+
+func (s *UserService) GetFollowers(ctx context.Context, userID int64, take int64, lastID int64) ([]int64, int64, error) {
+	user, err := s.userRepo.GetById(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, 0, utils.NotFoundError("user not found")
+		}
+		return nil, 0, utils.InternalError(err, "failed to get user")
+	}
+
+	if take == 0 {
+		return nil, user.FollowersCount, nil
+	}
+
+	followerIDs, err := s.userRepo.GetFollowers(ctx, userID, take, lastID)
+	if err != nil {
+		return nil, 0, utils.InternalError(err, "failed to get followers")
+	}
+	return followerIDs, user.FollowersCount, nil
+}
+
+func (s *UserService) GetManyByIds(ctx context.Context, userIDs []int64) ([]*userservice.UserPublicModel, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+	if len(userIDs) > MaxUsersByIDs {
+		return nil, ErrTooManyUserIDs
+	}
+
+	users, err := s.userRepo.GetManyByIds(ctx, userIDs)
+	if err != nil {
+		return nil, utils.InternalError(err, "failed to get users")
+	}
+	return users, nil
+}
+
+func (s *UserService) PushSearchSignals(ctx context.Context) error {
+	var lastID int64
+	for {
+		users, err := s.userRepo.GetSignals(ctx, lastID, s.cfg.SearchSignalsBatchSize)
+		if err != nil {
+			return utils.InternalError(err, "failed to get user signals")
+		}
+		if len(users) == 0 {
+			return nil
+		}
+
+		signals := make([]*searchservice.DocumentSignals, 0, len(users))
+		for _, user := range users {
+			signals = append(signals, &searchservice.DocumentSignals{
+				Id:        user.ID,
+				Followers: user.FollowersCount,
+				CreatedAt: user.CreatedAt.Unix(),
+			})
+		}
+
+		err = s.searchIndexClient.UpdateSignals(ctx, &searchservice.UpdateSignalsRequest{
+			Type:    searchDocumentType,
+			Signals: signals,
+		})
+		if err != nil {
+			return utils.InternalError(err, "failed to queue user signals for search indexing")
+		}
+
+		lastID = users[len(users)-1].ID
+	}
+}
@@ -154,6 +154,34 @@ func TestFollowUnfollow(t *testing.T) {
 	require.NotNil(t, checkRespAfterFollow)
 	assert.True(t, checkRespAfterFollow.IsFollowed)
 
+	followingResp, err := s.UserClient.GetFollowing(authCtx1, &userservice.GetFollowingRequest{
+		UserId: login1Resp.User.Id,
+		Take:   10,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, followingResp)
+	require.Len(t, followingResp.Users, 1)
+	assert.Equal(t, login2Resp.User.Id, followingResp.Users[0].Id)
+	assert.Equal(t, int64(1), followingResp.Users[0].FollowersCount)
+
+	followersResp, err := s.UserClient.GetFollowers(authCtx1, &userservice.GetFollowersRequest{
+		UserId: login2Resp.User.Id,
+		Take:   10,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, followersResp)
+	assert.Equal(t, []int64{login1Resp.User.Id}, followersResp.UserIds)
+	assert.Equal(t, int64(1), followersResp.FollowersCount)
+
+	usersResp, err := s.UserClient.GetUsersByIds(authCtx1, &userservice.GetUsersByIdsRequest{
+		UserIds: []int64{login2Resp.User.Id},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, usersResp)
+	require.Len(t, usersResp.Users, 1)
+	assert.Equal(t, login2Resp.User.Id, usersResp.Users[0].Id)
+	assert.Equal(t, int64(1), usersResp.Users[0].FollowersCount)
+
 	unfollowReq := &userservice.UnfollowRequest{
 		UserId: login2Resp.User.Id,
 	}